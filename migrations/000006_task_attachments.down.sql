@@ -0,0 +1 @@
+DROP TABLE IF EXISTS task_service.task_attachments;
@@ -0,0 +1,13 @@
+CREATE TABLE task_service.task_attachments (
+    id SERIAL PRIMARY KEY,
+    task_id INTEGER NOT NULL REFERENCES task_service.tasks(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL,
+    file_name VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size_bytes BIGINT NOT NULL CHECK (size_bytes > 0),
+    storage_key VARCHAR(512) UNIQUE NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_task_attachments_task_id ON task_service.task_attachments(task_id);
+CREATE INDEX idx_task_attachments_user_id ON task_service.task_attachments(user_id);
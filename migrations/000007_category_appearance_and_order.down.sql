@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS task_service.idx_tasks_category_id;
+DROP INDEX IF EXISTS task_service.idx_categories_user_position;
+
+ALTER TABLE task_service.categories
+    DROP COLUMN IF EXISTS updated_at,
+    DROP COLUMN IF EXISTS position,
+    DROP COLUMN IF EXISTS icon,
+    ALTER COLUMN color DROP NOT NULL;
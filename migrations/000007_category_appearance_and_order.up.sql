@@ -0,0 +1,19 @@
+UPDATE task_service.categories SET color = '#3498db' WHERE color IS NULL;
+
+ALTER TABLE task_service.categories
+    ALTER COLUMN color SET NOT NULL,
+    ADD COLUMN icon VARCHAR(50) NOT NULL DEFAULT '',
+    ADD COLUMN position INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP;
+
+UPDATE task_service.categories c
+SET position = ranked.position,
+    updated_at = c.created_at
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY user_id ORDER BY name) AS position
+    FROM task_service.categories
+) ranked
+WHERE ranked.id = c.id;
+
+CREATE INDEX idx_categories_user_position ON task_service.categories(user_id, position);
+CREATE INDEX idx_tasks_category_id ON task_service.tasks(category_id);
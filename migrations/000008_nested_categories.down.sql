@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS task_service.idx_categories_parent_id;
+DROP INDEX IF EXISTS task_service.idx_categories_user_parent_name;
+
+ALTER TABLE task_service.categories
+    DROP COLUMN IF EXISTS parent_id,
+    ADD CONSTRAINT categories_name_user_id_key UNIQUE (name, user_id);
@@ -0,0 +1,9 @@
+ALTER TABLE task_service.categories
+    ADD COLUMN parent_id INTEGER REFERENCES task_service.categories(id),
+    DROP CONSTRAINT IF EXISTS categories_name_user_id_key;
+
+-- Sibling names stay unique; the same name may repeat under different parents.
+CREATE UNIQUE INDEX idx_categories_user_parent_name
+    ON task_service.categories(user_id, parent_id, name) NULLS NOT DISTINCT;
+
+CREATE INDEX idx_categories_parent_id ON task_service.categories(parent_id);
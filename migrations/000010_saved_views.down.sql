@@ -0,0 +1 @@
+DROP TABLE IF EXISTS task_service.saved_views;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS task_service.idx_tasks_user_status_position;
+
+ALTER TABLE task_service.tasks
+    DROP COLUMN IF EXISTS position;
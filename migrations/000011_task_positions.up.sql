@@ -0,0 +1,12 @@
+ALTER TABLE task_service.tasks
+    ADD COLUMN position DOUBLE PRECISION NOT NULL DEFAULT 0;
+
+UPDATE task_service.tasks t
+SET position = ranked.position
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY user_id, status ORDER BY created_at, id) * 1024 AS position
+    FROM task_service.tasks
+) ranked
+WHERE ranked.id = t.id;
+
+CREATE INDEX idx_tasks_user_status_position ON task_service.tasks(user_id, status, position);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS task_service.time_entries;
+
+ALTER TABLE task_service.tasks
+    DROP COLUMN IF EXISTS estimate_minutes;
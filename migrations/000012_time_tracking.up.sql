@@ -0,0 +1,18 @@
+ALTER TABLE task_service.tasks
+    ADD COLUMN estimate_minutes INTEGER CHECK (estimate_minutes > 0);
+
+CREATE TABLE task_service.time_entries (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    task_id INTEGER NOT NULL REFERENCES task_service.tasks(id) ON DELETE CASCADE,
+    started_at TIMESTAMP NOT NULL,
+    ended_at TIMESTAMP,
+    duration_seconds BIGINT NOT NULL DEFAULT 0 CHECK (duration_seconds >= 0),
+    note VARCHAR(500) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- At most one running timer per user.
+CREATE UNIQUE INDEX idx_time_entries_running ON task_service.time_entries(user_id) WHERE ended_at IS NULL;
+CREATE INDEX idx_time_entries_task_id ON task_service.time_entries(task_id);
+CREATE INDEX idx_time_entries_user_started ON task_service.time_entries(user_id, started_at);
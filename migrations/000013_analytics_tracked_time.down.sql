@@ -0,0 +1,2 @@
+ALTER TABLE analytics_service.task_metrics
+    DROP COLUMN IF EXISTS tracked_seconds;
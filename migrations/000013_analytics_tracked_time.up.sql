@@ -0,0 +1,2 @@
+ALTER TABLE analytics_service.task_metrics
+    ADD COLUMN tracked_seconds BIGINT NOT NULL DEFAULT 0;
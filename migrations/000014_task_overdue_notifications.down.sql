@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS task_service.idx_tasks_open_due_date;
+
+DROP TABLE IF EXISTS task_service.task_overdue_notifications;
@@ -0,0 +1,20 @@
+-- One row per task and due date that task.overdue was published for. Changing
+-- the due date makes the task eligible again.
+CREATE TABLE task_service.task_overdue_notifications (
+    task_id INTEGER NOT NULL REFERENCES task_service.tasks(id) ON DELETE CASCADE,
+    due_date TIMESTAMP NOT NULL,
+    notified_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (task_id, due_date)
+);
+
+-- Tasks that are already overdue are not announced when the scanner first runs.
+INSERT INTO task_service.task_overdue_notifications (task_id, due_date)
+SELECT id, due_date
+FROM task_service.tasks
+WHERE due_date IS NOT NULL
+  AND due_date < CURRENT_TIMESTAMP
+  AND status IN ('pending', 'in_progress')
+  AND deleted_at IS NULL;
+
+CREATE INDEX idx_tasks_open_due_date ON task_service.tasks(due_date)
+    WHERE deleted_at IS NULL AND status IN ('pending', 'in_progress');
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS task_service.event_outbox;
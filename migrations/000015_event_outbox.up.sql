@@ -0,0 +1,18 @@
+-- Task events are written here in the same transaction as the task change and
+-- relayed to RabbitMQ afterwards.
+CREATE TABLE task_service.event_outbox (
+    id BIGSERIAL PRIMARY KEY,
+    event_id VARCHAR(36) NOT NULL UNIQUE,
+    event_type VARCHAR(50) NOT NULL,
+    task_id INTEGER NOT NULL,
+    payload JSONB NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_error TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    sent_at TIMESTAMP
+);
+
+CREATE INDEX idx_event_outbox_pending ON task_service.event_outbox(next_attempt_at) WHERE sent_at IS NULL;
+CREATE INDEX idx_event_outbox_task_pending ON task_service.event_outbox(task_id, id) WHERE sent_at IS NULL;
+CREATE INDEX idx_event_outbox_sent_at ON task_service.event_outbox(sent_at) WHERE sent_at IS NOT NULL;
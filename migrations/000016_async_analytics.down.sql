@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS analytics_service.processed_events;
+DROP TABLE IF EXISTS task_service.analytics_outbox;
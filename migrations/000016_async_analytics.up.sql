@@ -0,0 +1,21 @@
+-- Analytics events are written here in the same transaction as the task change
+-- and sent to analytics-service in batches; rows are deleted once accepted.
+CREATE TABLE task_service.analytics_outbox (
+    id BIGSERIAL PRIMARY KEY,
+    event_id VARCHAR(36) NOT NULL UNIQUE,
+    payload JSONB NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_error TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_analytics_outbox_next_attempt ON task_service.analytics_outbox(next_attempt_at);
+
+-- Event IDs applied to task_metrics, so redelivered events are counted once.
+CREATE TABLE analytics_service.processed_events (
+    event_id VARCHAR(36) PRIMARY KEY,
+    processed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_processed_events_processed_at ON analytics_service.processed_events(processed_at);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS task_service.webhook_deliveries;
+DROP TABLE IF EXISTS task_service.webhooks;
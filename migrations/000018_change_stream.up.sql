@@ -0,0 +1,12 @@
+-- Change events for the real-time stream. Rows only need to outlive the
+-- NOTIFY that announces them and a listener reconnect, so they are purged
+-- after an hour.
+CREATE TABLE task_service.change_events (
+    id BIGSERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    type VARCHAR(50) NOT NULL,
+    payload JSONB NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_change_events_created_at ON task_service.change_events(created_at);
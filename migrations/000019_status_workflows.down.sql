@@ -0,0 +1,19 @@
+DROP TABLE IF EXISTS task_service.workflow_transitions;
+DROP TABLE IF EXISTS task_service.workflow_statuses;
+DROP TABLE IF EXISTS task_service.workflows;
+
+DROP INDEX IF EXISTS task_service.idx_tasks_open_due_date;
+CREATE INDEX idx_tasks_open_due_date ON task_service.tasks(due_date)
+    WHERE deleted_at IS NULL AND status IN ('pending', 'in_progress');
+
+ALTER TABLE task_service.tasks DROP CONSTRAINT IF EXISTS tasks_status_fkey;
+
+UPDATE task_service.tasks
+SET status = CASE WHEN completed_at IS NOT NULL THEN 'completed' ELSE 'pending' END
+WHERE status NOT IN ('pending', 'in_progress', 'completed', 'archived');
+
+ALTER TABLE task_service.tasks ALTER COLUMN status TYPE VARCHAR(20);
+ALTER TABLE task_service.tasks
+    ADD CONSTRAINT tasks_status_check CHECK (status IN ('pending', 'in_progress', 'completed', 'archived'));
+
+DROP TABLE IF EXISTS task_service.task_statuses;
@@ -0,0 +1,56 @@
+-- Task statuses are no longer a fixed list. Every status used by a workflow is
+-- registered here and tasks reference it instead of a CHECK constraint.
+CREATE TABLE task_service.task_statuses (
+    key VARCHAR(50) PRIMARY KEY
+);
+
+INSERT INTO task_service.task_statuses (key)
+VALUES ('pending'), ('in_progress'), ('completed'), ('archived');
+
+ALTER TABLE task_service.tasks DROP CONSTRAINT tasks_status_check;
+ALTER TABLE task_service.tasks ALTER COLUMN status TYPE VARCHAR(50);
+ALTER TABLE task_service.tasks
+    ADD CONSTRAINT tasks_status_fkey FOREIGN KEY (status) REFERENCES task_service.task_statuses(key);
+
+-- completed_at now marks tasks in a completed status of their workflow.
+UPDATE task_service.tasks
+SET completed_at = updated_at
+WHERE status = 'completed'
+  AND completed_at IS NULL;
+
+DROP INDEX task_service.idx_tasks_open_due_date;
+CREATE INDEX idx_tasks_open_due_date ON task_service.tasks(due_date)
+    WHERE deleted_at IS NULL AND completed_at IS NULL AND status <> 'archived';
+
+-- A workflow without a category is the user's default; one with a category
+-- applies to that category and its subcategories.
+CREATE TABLE task_service.workflows (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    category_id INTEGER REFERENCES task_service.categories(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, name)
+);
+
+CREATE UNIQUE INDEX idx_workflows_user_default ON task_service.workflows(user_id) WHERE category_id IS NULL;
+CREATE UNIQUE INDEX idx_workflows_category ON task_service.workflows(category_id) WHERE category_id IS NOT NULL;
+
+CREATE TABLE task_service.workflow_statuses (
+    workflow_id INTEGER NOT NULL REFERENCES task_service.workflows(id) ON DELETE CASCADE,
+    status VARCHAR(50) NOT NULL REFERENCES task_service.task_statuses(key),
+    name VARCHAR(100) NOT NULL,
+    position INTEGER NOT NULL,
+    is_completed BOOLEAN NOT NULL DEFAULT FALSE,
+    PRIMARY KEY (workflow_id, status)
+);
+
+CREATE TABLE task_service.workflow_transitions (
+    workflow_id INTEGER NOT NULL,
+    from_status VARCHAR(50) NOT NULL,
+    to_status VARCHAR(50) NOT NULL,
+    PRIMARY KEY (workflow_id, from_status, to_status),
+    FOREIGN KEY (workflow_id, from_status) REFERENCES task_service.workflow_statuses(workflow_id, status) ON DELETE CASCADE,
+    FOREIGN KEY (workflow_id, to_status) REFERENCES task_service.workflow_statuses(workflow_id, status) ON DELETE CASCADE
+);
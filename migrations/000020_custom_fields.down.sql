@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS task_service.idx_tasks_custom_fields;
+ALTER TABLE task_service.tasks DROP COLUMN IF EXISTS custom_fields;
+
+DROP TABLE IF EXISTS task_service.custom_fields;
@@ -0,0 +1,18 @@
+-- Custom field definitions. Values live on the task as JSONB keyed by the
+-- definition's key, so renaming a field does not touch its tasks.
+CREATE TABLE task_service.custom_fields (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    key VARCHAR(50) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('text', 'number', 'date', 'select', 'multi_select', 'checkbox', 'url')),
+    options TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, key)
+);
+
+ALTER TABLE task_service.tasks ADD COLUMN custom_fields JSONB NOT NULL DEFAULT '{}'::jsonb;
+
+-- jsonb_path_ops serves the @> containment filters of ListTasks.
+CREATE INDEX idx_tasks_custom_fields ON task_service.tasks USING GIN (custom_fields jsonb_path_ops);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS user_service.revoked_tokens;
+
+ALTER TABLE user_service.users DROP COLUMN IF EXISTS tokens_valid_after;
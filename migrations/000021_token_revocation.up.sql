@@ -0,0 +1,14 @@
+-- Access tokens issued at or before tokens_valid_after are rejected, which
+-- ends every session of a deactivated user at once.
+ALTER TABLE user_service.users ADD COLUMN tokens_valid_after TIMESTAMP;
+
+-- Access tokens revoked one by one, e.g. on logout. Rows are only needed
+-- until the token would have expired anyway.
+CREATE TABLE user_service.revoked_tokens (
+    token_id VARCHAR(64) PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES user_service.users(id) ON DELETE CASCADE,
+    expires_at TIMESTAMP NOT NULL,
+    revoked_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_revoked_tokens_expires_at ON user_service.revoked_tokens(expires_at);
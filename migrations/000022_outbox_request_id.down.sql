@@ -0,0 +1 @@
+ALTER TABLE task_service.event_outbox DROP COLUMN IF EXISTS request_id;
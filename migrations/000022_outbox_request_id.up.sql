@@ -0,0 +1,3 @@
+-- The ID of the request that produced an event, so the relay can pass it on
+-- to RabbitMQ and consumers can log it.
+ALTER TABLE task_service.event_outbox ADD COLUMN request_id VARCHAR(128);
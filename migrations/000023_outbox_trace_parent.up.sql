@@ -0,0 +1,3 @@
+-- The W3C traceparent of the request that produced an event, so the relay
+-- publishes it as part of the same trace.
+ALTER TABLE task_service.event_outbox ADD COLUMN trace_parent VARCHAR(55);
@@ -0,0 +1,36 @@
+// Package migrations holds the database schema shared by the services. The
+// migrate service in docker-compose applies the SQL files; the services embed
+// them only to know which schema version they were built against.
+package migrations
+
+import (
+	"embed"
+	"strconv"
+	"strings"
+)
+
+//go:embed *.up.sql
+var files embed.FS
+
+// Version returns the version of the latest migration, the schema a service
+// built from this tree expects the database to be at.
+func Version() uint {
+	entries, err := files.ReadDir(".")
+	if err != nil {
+		return 0
+	}
+
+	var latest uint
+	for _, entry := range entries {
+		prefix, _, ok := strings.Cut(entry.Name(), "_")
+		if !ok {
+			continue
+		}
+		version, err := strconv.ParseUint(prefix, 10, 64)
+		if err != nil {
+			continue
+		}
+		latest = max(latest, uint(version))
+	}
+	return latest
+}
@@ -0,0 +1,24 @@
+package migrations
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+)
+
+func TestVersionIsLatestMigration(t *testing.T) {
+	version := Version()
+
+	for name, want := range map[string]int{
+		fmt.Sprintf("%06d_*.up.sql", version):   1,
+		fmt.Sprintf("%06d_*.up.sql", version+1): 0,
+	} {
+		matches, err := filepath.Glob(name)
+		if err != nil {
+			t.Fatalf("glob %s: %v", name, err)
+		}
+		if len(matches) != want {
+			t.Fatalf("expected %d migrations matching %s for version %d, got %v", want, name, version, matches)
+		}
+	}
+}
@@ -33,4 +33,16 @@ var (
 	ErrCommentNotFound   = New(CodeCommentNotFound, "comment not found")
 	ErrInvalidTaskStatus = New(CodeInvalidTaskStatus, "invalid task status")
 	ErrInvalidPriority   = New(CodeInvalidPriority, "invalid priority")
+	ErrTemplateNotFound  = New(CodeTemplateNotFound, "template not found")
+	ErrViewNotFound      = New(CodeViewNotFound, "view not found")
+	ErrTimeEntryNotFound = New(CodeTimeEntryNotFound, "time entry not found")
+	ErrWebhookNotFound   = New(CodeWebhookNotFound, "webhook not found")
+	ErrWorkflowNotFound  = New(CodeWorkflowNotFound, "workflow not found")
+
+	ErrCustomFieldNotFound = New(CodeCustomFieldNotFound, "custom field not found")
+
+	ErrAttachmentNotFound   = New(CodeAttachmentNotFound, "attachment not found")
+	ErrFileTooLarge         = New(CodeFileTooLarge, "file is too large")
+	ErrUnsupportedMediaType = New(CodeUnsupportedMediaType, "unsupported media type")
+	ErrStorageQuotaExceeded = New(CodeStorageQuotaExceeded, "storage quota exceeded")
 )
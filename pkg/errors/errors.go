@@ -38,6 +38,18 @@ const (
 	CodeCommentNotFound   ErrorCode = "COMMENT_NOT_FOUND"
 	CodeInvalidTaskStatus ErrorCode = "INVALID_TASK_STATUS"
 	CodeInvalidPriority   ErrorCode = "INVALID_PRIORITY"
+	CodeTemplateNotFound  ErrorCode = "TEMPLATE_NOT_FOUND"
+	CodeViewNotFound      ErrorCode = "VIEW_NOT_FOUND"
+	CodeTimeEntryNotFound ErrorCode = "TIME_ENTRY_NOT_FOUND"
+	CodeWebhookNotFound   ErrorCode = "WEBHOOK_NOT_FOUND"
+	CodeWorkflowNotFound  ErrorCode = "WORKFLOW_NOT_FOUND"
+
+	CodeCustomFieldNotFound ErrorCode = "CUSTOM_FIELD_NOT_FOUND"
+
+	CodeAttachmentNotFound   ErrorCode = "ATTACHMENT_NOT_FOUND"
+	CodeFileTooLarge         ErrorCode = "FILE_TOO_LARGE"
+	CodeUnsupportedMediaType ErrorCode = "UNSUPPORTED_MEDIA_TYPE"
+	CodeStorageQuotaExceeded ErrorCode = "STORAGE_QUOTA_EXCEEDED"
 
 	CodeInvalidArgument ErrorCode = "INVALID_ARGUMENT"
 )
@@ -150,12 +162,20 @@ func CodeToHTTPStatus(code ErrorCode) int {
 	case CodeForbidden, CodeInsufficientPrivileges, CodeUserLocked, CodeUserInactive:
 		return http.StatusForbidden
 
-	case CodeNotFound, CodeUserNotFound, CodeTaskNotFound, CodeCategoryNotFound, CodeCommentNotFound:
+	case CodeNotFound, CodeUserNotFound, CodeTaskNotFound, CodeCategoryNotFound, CodeCommentNotFound,
+		CodeAttachmentNotFound, CodeTemplateNotFound, CodeViewNotFound, CodeTimeEntryNotFound,
+		CodeWebhookNotFound, CodeWorkflowNotFound, CodeCustomFieldNotFound:
 		return http.StatusNotFound
 
 	case CodeAlreadyExists, CodeUserAlreadyExists, CodeConflict:
 		return http.StatusConflict
 
+	case CodeFileTooLarge, CodeStorageQuotaExceeded:
+		return http.StatusRequestEntityTooLarge
+
+	case CodeUnsupportedMediaType:
+		return http.StatusUnsupportedMediaType
+
 	case CodeTooManyRequests:
 		return http.StatusTooManyRequests
 
@@ -170,7 +190,8 @@ func CodeToHTTPStatus(code ErrorCode) int {
 func CodeToGRPCCode(code ErrorCode) codes.Code {
 	switch code {
 	case CodeValidation, CodeBadRequest, CodeInvalidArgument,
-		CodeInvalidTaskStatus, CodeInvalidPriority, CodePasswordTooWeak:
+		CodeInvalidTaskStatus, CodeInvalidPriority, CodePasswordTooWeak,
+		CodeFileTooLarge, CodeUnsupportedMediaType:
 		return codes.InvalidArgument
 
 	case CodeUnauthorized, CodeInvalidCredentials, CodeTokenRevoked,
@@ -180,13 +201,15 @@ func CodeToGRPCCode(code ErrorCode) codes.Code {
 	case CodeForbidden, CodeInsufficientPrivileges, CodeUserLocked, CodeUserInactive:
 		return codes.PermissionDenied
 
-	case CodeNotFound, CodeUserNotFound, CodeTaskNotFound, CodeCategoryNotFound, CodeCommentNotFound:
+	case CodeNotFound, CodeUserNotFound, CodeTaskNotFound, CodeCategoryNotFound, CodeCommentNotFound,
+		CodeAttachmentNotFound, CodeTemplateNotFound, CodeViewNotFound, CodeTimeEntryNotFound,
+		CodeWebhookNotFound, CodeWorkflowNotFound, CodeCustomFieldNotFound:
 		return codes.NotFound
 
 	case CodeAlreadyExists, CodeUserAlreadyExists, CodeConflict:
 		return codes.AlreadyExists
 
-	case CodeTooManyRequests:
+	case CodeTooManyRequests, CodeStorageQuotaExceeded:
 		return codes.ResourceExhausted
 
 	case CodeServiceUnavailable:
@@ -99,6 +99,16 @@ func TestCodeToHTTPStatus(t *testing.T) {
 		{CodeUserNotFound, http.StatusNotFound},
 		{CodeTaskNotFound, http.StatusNotFound},
 		{CodeAlreadyExists, http.StatusConflict},
+		{CodeAttachmentNotFound, http.StatusNotFound},
+		{CodeTemplateNotFound, http.StatusNotFound},
+		{CodeViewNotFound, http.StatusNotFound},
+		{CodeTimeEntryNotFound, http.StatusNotFound},
+		{CodeWebhookNotFound, http.StatusNotFound},
+		{CodeWorkflowNotFound, http.StatusNotFound},
+		{CodeCustomFieldNotFound, http.StatusNotFound},
+		{CodeFileTooLarge, http.StatusRequestEntityTooLarge},
+		{CodeStorageQuotaExceeded, http.StatusRequestEntityTooLarge},
+		{CodeUnsupportedMediaType, http.StatusUnsupportedMediaType},
 		{CodeTooManyRequests, http.StatusTooManyRequests},
 		{CodeServiceUnavailable, http.StatusServiceUnavailable},
 		{CodeInternal, http.StatusInternalServerError},
@@ -124,6 +134,7 @@ func TestCodeToGRPCCode(t *testing.T) {
 		{CodeForbidden, codes.PermissionDenied},
 		{CodeNotFound, codes.NotFound},
 		{CodeAlreadyExists, codes.AlreadyExists},
+		{CodeStorageQuotaExceeded, codes.ResourceExhausted},
 		{CodeInternal, codes.Internal},
 	}
 
@@ -71,7 +71,14 @@ func IsNotFound(err error) bool {
 		IsCode(err, CodeUserNotFound) ||
 		IsCode(err, CodeTaskNotFound) ||
 		IsCode(err, CodeCategoryNotFound) ||
-		IsCode(err, CodeCommentNotFound)
+		IsCode(err, CodeCommentNotFound) ||
+		IsCode(err, CodeAttachmentNotFound) ||
+		IsCode(err, CodeTemplateNotFound) ||
+		IsCode(err, CodeViewNotFound) ||
+		IsCode(err, CodeTimeEntryNotFound) ||
+		IsCode(err, CodeWebhookNotFound) ||
+		IsCode(err, CodeWorkflowNotFound) ||
+		IsCode(err, CodeCustomFieldNotFound)
 }
 
 func IsUnauthorized(err error) bool {
@@ -6,8 +6,11 @@ type TaskEventType string
 
 const (
 	TaskEventCreated   TaskEventType = "task.created"
+	TaskEventUpdated   TaskEventType = "task.updated"
 	TaskEventCompleted TaskEventType = "task.completed"
 	TaskEventDeleted   TaskEventType = "task.deleted"
+	TaskEventCommented TaskEventType = "task.commented"
+	TaskEventOverdue   TaskEventType = "task.overdue"
 )
 
 type TaskEvent struct {
@@ -22,6 +25,13 @@ type TaskEvent struct {
 	DueDate     *time.Time    `json:"dueDate,omitempty"`
 	UserEmail   string        `json:"userEmail"`
 	CreatedAt   time.Time     `json:"createdAt"`
+	// Comment is set on task.commented events.
+	Comment *TaskEventComment `json:"comment,omitempty"`
+}
+
+type TaskEventComment struct {
+	ID      int64  `json:"id"`
+	Content string `json:"content"`
 }
 
 func (e TaskEvent) IsZero() bool {
@@ -0,0 +1,31 @@
+package events
+
+import "time"
+
+// UserEventsExchange is the fanout exchange user-service publishes user
+// events to. Every consumer binds its own queue, so each service instance
+// receives every event.
+const UserEventsExchange = "user.events"
+
+type UserEventType string
+
+const (
+	UserEventUpdated       UserEventType = "user.updated"
+	UserEventRoleChanged   UserEventType = "user.role_changed"
+	UserEventStatusChanged UserEventType = "user.status_changed"
+	UserEventTokensRevoked UserEventType = "user.tokens_revoked"
+)
+
+// UserEvent announces that a user's profile, preferences, role or status
+// changed, or that some of their access tokens were revoked. It carries no
+// user data: consumers reload what they need.
+type UserEvent struct {
+	ID         string        `json:"id"`
+	Type       UserEventType `json:"type"`
+	UserID     int64         `json:"userId"`
+	OccurredAt time.Time     `json:"occurredAt"`
+}
+
+func (e UserEvent) IsZero() bool {
+	return e.ID == "" || e.Type == "" || e.UserID == 0
+}
@@ -0,0 +1,23 @@
+package events
+
+import "testing"
+
+func TestUserEvent_IsZero(t *testing.T) {
+	tests := []struct {
+		name   string
+		event  UserEvent
+		expect bool
+	}{
+		{name: "empty", event: UserEvent{}, expect: true},
+		{name: "missing user", event: UserEvent{ID: "id", Type: UserEventUpdated}, expect: true},
+		{name: "valid", event: UserEvent{ID: "id", Type: UserEventStatusChanged, UserID: 7}, expect: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.event.IsZero(); got != tt.expect {
+				t.Fatalf("expected %v, got %v", tt.expect, got)
+			}
+		})
+	}
+}
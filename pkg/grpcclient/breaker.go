@@ -0,0 +1,165 @@
+package grpcclient
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultFailureThreshold = 5
+	defaultOpenTimeout      = 10 * time.Second
+)
+
+// ErrBreakerOpen is returned instead of calling a peer whose breaker is
+// open. It carries codes.Unavailable, so callers treat it like any other
+// unreachable peer.
+var ErrBreakerOpen = status.Error(codes.Unavailable, "circuit breaker is open")
+
+type BreakerState int
+
+const (
+	BreakerClosed BreakerState = iota
+	BreakerOpen
+	BreakerHalfOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerOpen:
+		return "open"
+	case BreakerHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+type BreakerConfig struct {
+	// FailureThreshold is the number of consecutive failed calls that opens
+	// the breaker.
+	FailureThreshold int
+	// OpenTimeout is how long calls are refused before a single probe is let
+	// through. The probe's result closes the breaker or opens it again.
+	OpenTimeout time.Duration
+}
+
+// Breaker stops calls to a peer that keeps failing. Only failures that point
+// at the peer count: unavailable and deadline exceeded. Any answer from the
+// peer, including an error, shows it is up.
+type Breaker struct {
+	name        string
+	threshold   int
+	openTimeout time.Duration
+	logger      *slog.Logger
+	now         func() time.Time
+
+	mu       sync.Mutex
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+func NewBreaker(name string, cfg BreakerConfig, logger *slog.Logger) *Breaker {
+	if cfg.FailureThreshold <= 0 {
+		cfg.FailureThreshold = defaultFailureThreshold
+	}
+	if cfg.OpenTimeout <= 0 {
+		cfg.OpenTimeout = defaultOpenTimeout
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &Breaker{
+		name:        name,
+		threshold:   cfg.FailureThreshold,
+		openTimeout: cfg.OpenTimeout,
+		logger:      logger,
+		now:         time.Now,
+	}
+}
+
+// State reports the current state. An open breaker whose timeout has passed
+// reports half-open, as the next call will probe the peer.
+func (b *Breaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == BreakerOpen && !b.now().Before(b.openedAt.Add(b.openTimeout)) {
+		return BreakerHalfOpen
+	}
+	return b.state
+}
+
+func (b *Breaker) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if !b.allow() {
+			return ErrBreakerOpen
+		}
+
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		b.record(err)
+		return err
+	}
+}
+
+func (b *Breaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerOpen:
+		if b.now().Before(b.openedAt.Add(b.openTimeout)) {
+			return false
+		}
+		b.setState(BreakerHalfOpen)
+		b.probing = true
+		return true
+	case BreakerHalfOpen:
+		if b.probing {
+			return false
+		}
+		b.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+func (b *Breaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		b.failures++
+		if b.state == BreakerHalfOpen || b.failures >= b.threshold {
+			b.openedAt = b.now()
+			b.probing = false
+			b.setState(BreakerOpen)
+		}
+	case codes.Canceled:
+		// The caller gave up; that says nothing about the peer, but a
+		// cancelled probe must not keep the breaker half-open forever.
+		b.probing = false
+	default:
+		b.failures = 0
+		b.probing = false
+		b.setState(BreakerClosed)
+	}
+}
+
+func (b *Breaker) setState(state BreakerState) {
+	if b.state == state {
+		return
+	}
+	b.logger.Warn("circuit breaker state changed", "peer", b.name, "from", b.state.String(), "to", state.String())
+	b.state = state
+}
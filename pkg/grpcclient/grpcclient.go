@@ -0,0 +1,76 @@
+// Package grpcclient builds gRPC client connections that tolerate an
+// unavailable peer: they connect lazily, retry idempotent calls and stop
+// calling a peer that keeps failing until it has had time to recover.
+package grpcclient
+
+import (
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"todoapp/pkg/metrics"
+	"todoapp/pkg/requestid"
+)
+
+type options struct {
+	retry   RetryPolicy
+	breaker *Breaker
+	dial    []grpc.DialOption
+}
+
+type Option func(*options)
+
+// WithRetry retries the policy's methods when the peer cannot be reached.
+func WithRetry(policy RetryPolicy) Option {
+	return func(o *options) {
+		o.retry = policy
+	}
+}
+
+// WithBreaker guards every call with breaker. A breaker can be shared by
+// several connections to the same peer.
+func WithBreaker(breaker *Breaker) Option {
+	return func(o *options) {
+		o.breaker = breaker
+	}
+}
+
+// WithDialOptions appends raw dial options, e.g. to replace the default
+// insecure transport credentials.
+func WithDialOptions(opts ...grpc.DialOption) Option {
+	return func(o *options) {
+		o.dial = append(o.dial, opts...)
+	}
+}
+
+// DialOptions returns the dial options for a resilient connection that
+// forwards the request ID of each call. Retries wrap the breaker, so every
+// attempt is checked against it and an open breaker ends the retries.
+func DialOptions(opts ...Option) []grpc.DialOption {
+	o := options{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	interceptors := []grpc.UnaryClientInterceptor{requestid.UnaryClientInterceptor(), metrics.UnaryClientInterceptor()}
+	if o.retry.MaxAttempts > 1 && len(o.retry.Methods) > 0 {
+		interceptors = append(interceptors, o.retry.UnaryClientInterceptor())
+	}
+	if o.breaker != nil {
+		interceptors = append(interceptors, o.breaker.UnaryClientInterceptor())
+	}
+
+	dial := []grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(otelgrpc.NewClientHandler()),
+	}
+	dial = append(dial, grpc.WithChainUnaryInterceptor(interceptors...))
+	return append(dial, o.dial...)
+}
+
+// New creates a connection to target without waiting for it to be
+// reachable; the first call connects. It only fails for invalid targets or
+// options.
+func New(target string, opts ...Option) (*grpc.ClientConn, error) {
+	return grpc.NewClient(target, DialOptions(opts...)...)
+}
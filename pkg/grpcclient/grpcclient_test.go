@@ -0,0 +1,184 @@
+package grpcclient
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const testMethod = "/test.v1.Service/Get"
+
+type invokerStub struct {
+	errs  []error
+	calls int
+}
+
+func (s *invokerStub) invoke(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+	s.calls++
+	if len(s.errs) == 0 {
+		return nil
+	}
+	err := s.errs[0]
+	s.errs = s.errs[1:]
+	return err
+}
+
+func noSleep(ctx context.Context, d time.Duration) error { return nil }
+
+func unavailable() error { return status.Error(codes.Unavailable, "connection refused") }
+
+func TestRetryRepeatsUnavailableCalls(t *testing.T) {
+	stub := &invokerStub{errs: []error{unavailable(), unavailable()}}
+	retry := RetryPolicy{MaxAttempts: 3, Methods: []string{testMethod}, sleep: noSleep}.UnaryClientInterceptor()
+
+	if err := retry(context.Background(), testMethod, nil, nil, nil, stub.invoke); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stub.calls != 3 {
+		t.Fatalf("expected 3 attempts, got %d", stub.calls)
+	}
+}
+
+func TestRetryStopsAfterMaxAttempts(t *testing.T) {
+	stub := &invokerStub{errs: []error{unavailable(), unavailable(), unavailable()}}
+	retry := RetryPolicy{MaxAttempts: 2, Methods: []string{testMethod}, sleep: noSleep}.UnaryClientInterceptor()
+
+	if err := retry(context.Background(), testMethod, nil, nil, nil, stub.invoke); status.Code(err) != codes.Unavailable {
+		t.Fatalf("expected unavailable, got %v", err)
+	}
+	if stub.calls != 2 {
+		t.Fatalf("expected 2 attempts, got %d", stub.calls)
+	}
+}
+
+func TestRetrySkipsOtherMethodsAndErrors(t *testing.T) {
+	retry := RetryPolicy{MaxAttempts: 3, Methods: []string{testMethod}, sleep: noSleep}.UnaryClientInterceptor()
+
+	stub := &invokerStub{errs: []error{unavailable()}}
+	_ = retry(context.Background(), "/test.v1.Service/Create", nil, nil, nil, stub.invoke)
+	if stub.calls != 1 {
+		t.Fatalf("expected non-idempotent method not to be retried, got %d attempts", stub.calls)
+	}
+
+	stub = &invokerStub{errs: []error{status.Error(codes.NotFound, "missing")}}
+	_ = retry(context.Background(), testMethod, nil, nil, nil, stub.invoke)
+	if stub.calls != 1 {
+		t.Fatalf("expected not found not to be retried, got %d attempts", stub.calls)
+	}
+
+	stub = &invokerStub{errs: []error{ErrBreakerOpen}}
+	_ = retry(context.Background(), testMethod, nil, nil, nil, stub.invoke)
+	if stub.calls != 1 {
+		t.Fatalf("expected open breaker not to be retried, got %d attempts", stub.calls)
+	}
+}
+
+func TestRetryRespectsDeadline(t *testing.T) {
+	stub := &invokerStub{errs: []error{unavailable(), unavailable()}}
+	retry := RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Second,
+		Methods:        []string{testMethod},
+		sleep:          noSleep,
+	}.UnaryClientInterceptor()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	if err := retry(ctx, testMethod, nil, nil, nil, stub.invoke); status.Code(err) != codes.Unavailable {
+		t.Fatalf("expected unavailable, got %v", err)
+	}
+	if stub.calls != 1 {
+		t.Fatalf("expected no retry past the deadline, got %d attempts", stub.calls)
+	}
+}
+
+func newTestBreaker(cfg BreakerConfig) (*Breaker, *time.Time) {
+	now := time.Date(2024, 12, 10, 9, 0, 0, 0, time.UTC)
+	b := NewBreaker("test", cfg, slog.New(slog.DiscardHandler))
+	b.now = func() time.Time { return now }
+	return b, &now
+}
+
+func TestBreakerOpensAfterConsecutiveFailures(t *testing.T) {
+	b, _ := newTestBreaker(BreakerConfig{FailureThreshold: 2, OpenTimeout: time.Minute})
+	call := b.UnaryClientInterceptor()
+	stub := &invokerStub{errs: []error{unavailable(), status.Error(codes.NotFound, "missing"), unavailable(), unavailable()}}
+
+	for range 4 {
+		_ = call(context.Background(), testMethod, nil, nil, nil, stub.invoke)
+	}
+	if b.State() != BreakerOpen {
+		t.Fatalf("expected open breaker, got %s", b.State())
+	}
+
+	if err := call(context.Background(), testMethod, nil, nil, nil, stub.invoke); !errors.Is(err, ErrBreakerOpen) {
+		t.Fatalf("expected breaker open error, got %v", err)
+	}
+	if stub.calls != 4 {
+		t.Fatalf("expected the peer not to be called while open, got %d calls", stub.calls)
+	}
+}
+
+func TestBreakerHalfOpenProbe(t *testing.T) {
+	b, now := newTestBreaker(BreakerConfig{FailureThreshold: 1, OpenTimeout: time.Minute})
+	call := b.UnaryClientInterceptor()
+
+	_ = call(context.Background(), testMethod, nil, nil, nil, (&invokerStub{errs: []error{unavailable()}}).invoke)
+	*now = now.Add(time.Minute)
+	if b.State() != BreakerHalfOpen {
+		t.Fatalf("expected half-open breaker, got %s", b.State())
+	}
+
+	// A failed probe opens the breaker again.
+	_ = call(context.Background(), testMethod, nil, nil, nil, (&invokerStub{errs: []error{unavailable()}}).invoke)
+	if b.State() != BreakerOpen {
+		t.Fatalf("expected failed probe to reopen the breaker, got %s", b.State())
+	}
+
+	*now = now.Add(time.Minute)
+	if err := call(context.Background(), testMethod, nil, nil, nil, (&invokerStub{}).invoke); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if b.State() != BreakerClosed {
+		t.Fatalf("expected successful probe to close the breaker, got %s", b.State())
+	}
+}
+
+func TestBreakerAllowsSingleProbe(t *testing.T) {
+	b, now := newTestBreaker(BreakerConfig{FailureThreshold: 1, OpenTimeout: time.Minute})
+	call := b.UnaryClientInterceptor()
+
+	_ = call(context.Background(), testMethod, nil, nil, nil, (&invokerStub{errs: []error{unavailable()}}).invoke)
+	*now = now.Add(time.Minute)
+
+	probe := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		if err := call(ctx, testMethod, nil, nil, nil, (&invokerStub{}).invoke); !errors.Is(err, ErrBreakerOpen) {
+			t.Errorf("expected concurrent call to be refused during the probe, got %v", err)
+		}
+		return nil
+	}
+	if err := call(context.Background(), testMethod, nil, nil, nil, probe); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if b.State() != BreakerClosed {
+		t.Fatalf("expected closed breaker, got %s", b.State())
+	}
+}
+
+func TestNewConnectsLazily(t *testing.T) {
+	conn, err := New("127.0.0.1:1",
+		WithRetry(RetryPolicy{MaxAttempts: 2, Methods: []string{testMethod}}),
+		WithBreaker(NewBreaker("test", BreakerConfig{}, nil)),
+	)
+	if err != nil {
+		t.Fatalf("expected lazy connection to an unreachable peer, got %v", err)
+	}
+	defer conn.Close()
+}
@@ -0,0 +1,94 @@
+package grpcclient
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultInitialBackoff = 50 * time.Millisecond
+	defaultMaxBackoff     = time.Second
+)
+
+// RetryPolicy repeats calls that failed with codes.Unavailable, i.e. calls
+// the peer most likely never processed. Retries stop early when the caller's
+// deadline would pass before the next attempt.
+type RetryPolicy struct {
+	// MaxAttempts includes the first call; values below 2 disable retries.
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// Methods lists the full names of the methods that are safe to repeat,
+	// e.g. "/user.v1.UserService/GetUser". Other methods are never retried.
+	Methods []string
+
+	sleep func(ctx context.Context, d time.Duration) error
+}
+
+func (p RetryPolicy) UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	if p.InitialBackoff <= 0 {
+		p.InitialBackoff = defaultInitialBackoff
+	}
+	if p.MaxBackoff < p.InitialBackoff {
+		p.MaxBackoff = max(defaultMaxBackoff, p.InitialBackoff)
+	}
+	if p.sleep == nil {
+		p.sleep = sleep
+	}
+
+	methods := make(map[string]struct{}, len(p.Methods))
+	for _, method := range p.Methods {
+		methods[method] = struct{}{}
+	}
+
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if _, ok := methods[method]; !ok {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		backoff := p.InitialBackoff
+		for attempt := 1; ; attempt++ {
+			err := invoker(ctx, method, req, reply, cc, opts...)
+			if err == nil || attempt >= p.MaxAttempts || !retryable(err) {
+				return err
+			}
+
+			// Equal jitter, half the backoff plus a random share of the
+			// other half, keeps clients that failed together from retrying
+			// together.
+			delay := backoff/2 + rand.N(backoff/2+1)
+			if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) <= delay {
+				return err
+			}
+			if p.sleep(ctx, delay) != nil {
+				return err
+			}
+			backoff = min(2*backoff, p.MaxBackoff)
+		}
+	}
+}
+
+func retryable(err error) bool {
+	if errors.Is(err, ErrBreakerOpen) {
+		return false
+	}
+	return status.Code(err) == codes.Unavailable
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,90 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+
+	"github.com/jackc/pgx/v5"
+	amqp "github.com/rabbitmq/amqp091-go"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Database is the part of a pgx pool the Postgres check uses.
+type Database interface {
+	Ping(ctx context.Context) error
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// Postgres checks the database answers and its schema, as recorded by
+// golang-migrate, is at least at version minVersion with no migration left
+// half applied.
+func Postgres(db Database, minVersion uint) CheckFunc {
+	return func(ctx context.Context) error {
+		if err := db.Ping(ctx); err != nil {
+			return err
+		}
+
+		var (
+			version int64
+			dirty   bool
+		)
+		err := db.QueryRow(ctx, `SELECT version, dirty FROM schema_migrations LIMIT 1`).Scan(&version, &dirty)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errors.New("no migrations applied")
+		}
+		if err != nil {
+			return err
+		}
+
+		if dirty {
+			return fmt.Errorf("migration %d is dirty", version)
+		}
+		if version < int64(minVersion) {
+			return fmt.Errorf("schema version %d is behind %d", version, minVersion)
+		}
+		return nil
+	}
+}
+
+// GRPC checks service is SERVING over the standard gRPC health protocol.
+func GRPC(conn grpc.ClientConnInterface, service string) CheckFunc {
+	client := healthpb.NewHealthClient(conn)
+	return func(ctx context.Context) error {
+		resp, err := client.Check(ctx, &healthpb.HealthCheckRequest{Service: service})
+		if err != nil {
+			return err
+		}
+		if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+			return fmt.Errorf("%s is %s", service, resp.GetStatus())
+		}
+		return nil
+	}
+}
+
+// RabbitMQ checks the broker at url accepts a connection.
+func RabbitMQ(url string) CheckFunc {
+	return func(ctx context.Context) error {
+		conn, err := amqp.DialConfig(url, amqp.Config{
+			Locale: "en_US",
+			Dial: func(network, addr string) (net.Conn, error) {
+				var dialer net.Dialer
+				conn, err := dialer.DialContext(ctx, network, addr)
+				if err != nil {
+					return nil, err
+				}
+				// Bounds the handshake; the client clears it once connected.
+				if deadline, ok := ctx.Deadline(); ok {
+					_ = conn.SetDeadline(deadline)
+				}
+				return conn, nil
+			},
+		})
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}
+}
@@ -0,0 +1,193 @@
+// Package health answers the probes of the services. Liveness only says the
+// process is up; readiness checks the dependencies a service needs to serve
+// requests. Readiness reports are cached for a short while, so that probes from
+// the orchestrator, the load balancer and other services do not each cause a
+// round of checks.
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+type Status string
+
+const (
+	StatusOK Status = "ok"
+	// StatusDegraded means an optional dependency is down: the service is
+	// ready, but parts of it are slower or unavailable.
+	StatusDegraded    Status = "degraded"
+	StatusUnavailable Status = "unavailable"
+)
+
+const (
+	defaultTTL     = 5 * time.Second
+	defaultTimeout = 2 * time.Second
+)
+
+// CheckFunc reports whether a dependency can be used, within the deadline of
+// ctx.
+type CheckFunc func(ctx context.Context) error
+
+type Result struct {
+	Status    Status  `json:"status"`
+	LatencyMS float64 `json:"latency_ms"`
+	Optional  bool    `json:"optional,omitempty"`
+	Error     string  `json:"error,omitempty"`
+}
+
+type Report struct {
+	Status    Status            `json:"status"`
+	Service   string            `json:"service"`
+	CheckedAt time.Time         `json:"checked_at"`
+	Checks    map[string]Result `json:"checks"`
+}
+
+// Ready reports whether every required dependency is up.
+func (r Report) Ready() bool {
+	return r.Status != StatusUnavailable
+}
+
+type check struct {
+	name     string
+	run      CheckFunc
+	optional bool
+}
+
+// Checker runs the readiness checks of a service. Checks are registered with
+// Add and AddOptional before the checker is used.
+type Checker struct {
+	service string
+	ttl     time.Duration
+	timeout time.Duration
+	now     func() time.Time
+
+	checks []check
+	group  singleflight.Group
+
+	mu      sync.Mutex
+	report  Report
+	expires time.Time
+}
+
+type Option func(*Checker)
+
+// WithTTL sets how long a report is served before the checks run again.
+func WithTTL(ttl time.Duration) Option {
+	return func(c *Checker) {
+		if ttl > 0 {
+			c.ttl = ttl
+		}
+	}
+}
+
+// WithTimeout bounds a round of checks; a check still running by then is
+// reported down.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Checker) {
+		if timeout > 0 {
+			c.timeout = timeout
+		}
+	}
+}
+
+func NewChecker(service string, opts ...Option) *Checker {
+	c := &Checker{
+		service: service,
+		ttl:     defaultTTL,
+		timeout: defaultTimeout,
+		now:     time.Now,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Add registers a dependency the service cannot serve requests without.
+func (c *Checker) Add(name string, run CheckFunc) {
+	c.checks = append(c.checks, check{name: name, run: run})
+}
+
+// AddOptional registers a dependency the service degrades without: it being
+// down is reported, but leaves the service ready.
+func (c *Checker) AddOptional(name string, run CheckFunc) {
+	c.checks = append(c.checks, check{name: name, run: run, optional: true})
+}
+
+// Ready returns the latest report, running the checks when it is older than
+// the TTL. Concurrent callers share a single round of checks.
+func (c *Checker) Ready(ctx context.Context) Report {
+	c.mu.Lock()
+	if c.now().Before(c.expires) {
+		report := c.report
+		c.mu.Unlock()
+		return report
+	}
+	c.mu.Unlock()
+
+	// The round is shared, so it must not end with the caller that started it.
+	ctx = context.WithoutCancel(ctx)
+	report, _, _ := c.group.Do("ready", func() (any, error) {
+		report := c.run(ctx)
+
+		c.mu.Lock()
+		c.report = report
+		c.expires = report.CheckedAt.Add(c.ttl)
+		c.mu.Unlock()
+
+		return report, nil
+	})
+	return report.(Report)
+}
+
+func (c *Checker) run(ctx context.Context) Report {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	results := make([]Result, len(c.checks))
+	var wg sync.WaitGroup
+	for i, check := range c.checks {
+		wg.Go(func() {
+			results[i] = runCheck(ctx, check)
+		})
+	}
+	wg.Wait()
+
+	report := Report{
+		Status:    StatusOK,
+		Service:   c.service,
+		CheckedAt: c.now(),
+		Checks:    make(map[string]Result, len(c.checks)),
+	}
+	for i, check := range c.checks {
+		result := results[i]
+		report.Checks[check.name] = result
+		switch {
+		case result.Status == StatusOK:
+		case !check.optional:
+			report.Status = StatusUnavailable
+		case report.Status == StatusOK:
+			report.Status = StatusDegraded
+		}
+	}
+	return report
+}
+
+func runCheck(ctx context.Context, check check) Result {
+	start := time.Now()
+	err := check.run(ctx)
+	result := Result{
+		Status:    StatusOK,
+		LatencyMS: float64(time.Since(start).Microseconds()) / 1000,
+		Optional:  check.optional,
+	}
+	if err != nil {
+		result.Status = StatusUnavailable
+		result.Error = err.Error()
+	}
+	return result
+}
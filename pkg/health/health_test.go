@@ -0,0 +1,242 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestCheckerReportsRequiredAndOptional(t *testing.T) {
+	cases := []struct {
+		name     string
+		optional error
+		required error
+		want     Status
+	}{
+		{name: "all up", want: StatusOK},
+		{name: "optional down", optional: errors.New("refused"), want: StatusDegraded},
+		{name: "required down", required: errors.New("refused"), want: StatusUnavailable},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			checker := NewChecker("task-service")
+			checker.Add("postgres", func(context.Context) error { return tc.required })
+			checker.AddOptional("rabbitmq", func(context.Context) error { return tc.optional })
+
+			report := checker.Ready(context.Background())
+			if report.Status != tc.want {
+				t.Fatalf("expected %s, got %s", tc.want, report.Status)
+			}
+			if report.Service != "task-service" || len(report.Checks) != 2 {
+				t.Fatalf("unexpected report %+v", report)
+			}
+			if tc.optional != nil && report.Checks["rabbitmq"].Error != tc.optional.Error() {
+				t.Fatalf("expected the check error to be reported, got %+v", report.Checks["rabbitmq"])
+			}
+		})
+	}
+}
+
+func TestCheckerCachesReport(t *testing.T) {
+	var calls atomic.Int32
+	now := time.Now()
+	checker := NewChecker("test", WithTTL(time.Minute))
+	checker.now = func() time.Time { return now }
+	checker.Add("postgres", func(context.Context) error {
+		calls.Add(1)
+		time.Sleep(10 * time.Millisecond)
+		return nil
+	})
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Go(func() { checker.Ready(context.Background()) })
+	}
+	wg.Wait()
+	checker.Ready(context.Background())
+
+	if got := calls.Load(); got != 1 {
+		t.Fatalf("expected the checks to run once, got %d", got)
+	}
+
+	now = now.Add(time.Minute)
+	checker.Ready(context.Background())
+	if got := calls.Load(); got != 2 {
+		t.Fatalf("expected the checks to run again after the TTL, got %d", got)
+	}
+}
+
+func TestCheckerTimesOutChecks(t *testing.T) {
+	checker := NewChecker("test", WithTimeout(10*time.Millisecond))
+	checker.Add("smtp", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	report := checker.Ready(context.Background())
+	if report.Ready() || report.Checks["smtp"].Error != context.DeadlineExceeded.Error() {
+		t.Fatalf("expected the hanging check to be reported down, got %+v", report)
+	}
+}
+
+func TestCheckerIgnoresCallerCancellation(t *testing.T) {
+	checker := NewChecker("test")
+	checker.Add("postgres", func(ctx context.Context) error { return ctx.Err() })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if report := checker.Ready(ctx); !report.Ready() {
+		t.Fatalf("expected a gone caller not to fail the shared checks, got %+v", report)
+	}
+}
+
+func TestReadyHandler(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	checker := NewChecker("user-service")
+	checker.Add("postgres", func(context.Context) error { return errors.New("connection refused") })
+
+	router := gin.New()
+	router.GET(LivePath, Live("user-service"))
+	router.GET(ReadyPath, Ready(checker))
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, LivePath, nil))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected live to answer 200, got %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, ReadyPath, nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected ready to answer 503, got %d", rec.Code)
+	}
+
+	var report Report
+	if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if report.Status != StatusUnavailable || report.Checks["postgres"].Status != StatusUnavailable {
+		t.Fatalf("unexpected report %+v", report)
+	}
+}
+
+type databaseStub struct {
+	pingErr error
+	version int64
+	dirty   bool
+	rowErr  error
+}
+
+func (d databaseStub) Ping(context.Context) error { return d.pingErr }
+
+func (d databaseStub) QueryRow(context.Context, string, ...any) pgx.Row {
+	return rowStub(d)
+}
+
+type rowStub databaseStub
+
+func (r rowStub) Scan(dest ...any) error {
+	if r.rowErr != nil {
+		return r.rowErr
+	}
+	*dest[0].(*int64) = r.version
+	*dest[1].(*bool) = r.dirty
+	return nil
+}
+
+func TestPostgresCheck(t *testing.T) {
+	cases := []struct {
+		name    string
+		db      databaseStub
+		wantErr bool
+	}{
+		{name: "migrated", db: databaseStub{version: 23}},
+		{name: "ahead", db: databaseStub{version: 24}},
+		{name: "behind", db: databaseStub{version: 22}, wantErr: true},
+		{name: "dirty", db: databaseStub{version: 23, dirty: true}, wantErr: true},
+		{name: "not migrated", db: databaseStub{rowErr: pgx.ErrNoRows}, wantErr: true},
+		{name: "unreachable", db: databaseStub{pingErr: errors.New("refused")}, wantErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := Postgres(tc.db, 23)(context.Background())
+			if (err != nil) != tc.wantErr {
+				t.Fatalf("expected error %v, got %v", tc.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestGRPCCheckAndPublish(t *testing.T) {
+	listener := bufconn.Listen(1 << 20)
+	server := grpc.NewServer()
+	healthServer := grpchealth.NewServer()
+	healthpb.RegisterHealthServer(server, healthServer)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	var down atomic.Bool
+	checker := NewChecker("user-service", WithTTL(time.Millisecond))
+	checker.Add("postgres", func(context.Context) error {
+		if down.Load() {
+			return errors.New("refused")
+		}
+		return nil
+	})
+
+	check := GRPC(conn, "user.v1.UserService")
+	if err := check(context.Background()); err == nil {
+		t.Fatalf("expected an unknown service to fail the check")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go checker.Publish(ctx, healthServer, time.Millisecond, "user.v1.UserService")
+
+	waitFor := func(wantErr bool) {
+		t.Helper()
+		deadline := time.Now().Add(time.Second)
+		for {
+			err := check(context.Background())
+			if (err != nil) == wantErr {
+				return
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("expected error %v, got %v", wantErr, err)
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	waitFor(false)
+	down.Store(true)
+	waitFor(true)
+}
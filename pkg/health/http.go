@@ -0,0 +1,63 @@
+package health
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	LivePath  = "/health/live"
+	ReadyPath = "/health/ready"
+)
+
+// Live answers the liveness probe: the process is up and serving HTTP,
+// whatever the state of its dependencies.
+func Live(service string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if ctx.Request.Method == http.MethodHead {
+			ctx.Status(http.StatusOK)
+			return
+		}
+		ctx.JSON(http.StatusOK, gin.H{
+			"status":  StatusOK,
+			"service": service,
+		})
+	}
+}
+
+// Ready answers the readiness probe with the report of checker, with status
+// 503 while a required dependency is down.
+func Ready(checker *Checker) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		report := checker.Ready(ctx.Request.Context())
+
+		code := http.StatusOK
+		if !report.Ready() {
+			code = http.StatusServiceUnavailable
+		}
+		if ctx.Request.Method == http.MethodHead {
+			ctx.Status(code)
+			return
+		}
+		ctx.JSON(code, report)
+	}
+}
+
+// RegisterRoutes serves the probes of service. /health, which predates the
+// split, keeps answering as the liveness probe. A nil checker has no checks,
+// so the service is always ready.
+func RegisterRoutes(router *gin.Engine, service string, checker *Checker) {
+	if checker == nil {
+		checker = NewChecker(service)
+	}
+
+	live := Live(service)
+	ready := Ready(checker)
+	for _, path := range []string{"/health", LivePath} {
+		router.GET(path, live)
+		router.HEAD(path, live)
+	}
+	router.GET(ReadyPath, ready)
+	router.HEAD(ReadyPath, ready)
+}
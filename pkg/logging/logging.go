@@ -0,0 +1,110 @@
+// Package logging sets up the structured JSON logs shared by all services.
+// Records logged with a context carry the request ID and the user of that
+// context, so callers only add what the context does not know, such as the
+// task ID.
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/trace"
+
+	"todoapp/pkg/requestid"
+)
+
+type userIDKey struct{}
+
+// New returns a logger writing JSON to stdout with every record tagged with
+// service.
+func New(service string) *slog.Logger {
+	return NewWithWriter(os.Stdout, service)
+}
+
+func NewWithWriter(w io.Writer, service string) *slog.Logger {
+	handler := slog.NewJSONHandler(w, &slog.HandlerOptions{Level: slog.LevelInfo})
+	return slog.New(NewContextHandler(handler)).With("service", service)
+}
+
+// Discard returns a logger that drops every record, for use as a default.
+func Discard() *slog.Logger {
+	return slog.New(slog.DiscardHandler)
+}
+
+// Fatal logs err and exits, for failures during startup.
+func Fatal(logger *slog.Logger, msg string, err error) {
+	logger.Error(msg, "error", err)
+	os.Exit(1)
+}
+
+// WithUserID records the authenticated user of a request in ctx.
+func WithUserID(ctx context.Context, userID int64) context.Context {
+	return context.WithValue(ctx, userIDKey{}, userID)
+}
+
+func UserID(ctx context.Context) (int64, bool) {
+	userID, ok := ctx.Value(userIDKey{}).(int64)
+	return userID, ok
+}
+
+// ContextHandler adds request_id, user_id and trace_id from the context of a
+// record.
+type ContextHandler struct {
+	slog.Handler
+}
+
+func NewContextHandler(next slog.Handler) *ContextHandler {
+	return &ContextHandler{Handler: next}
+}
+
+func (h *ContextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if ctx != nil {
+		if id := requestid.FromContext(ctx); id != "" {
+			record.AddAttrs(slog.String("request_id", id))
+		}
+		if userID, ok := UserID(ctx); ok {
+			record.AddAttrs(slog.Int64("user_id", userID))
+		}
+		if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+			record.AddAttrs(slog.String("trace_id", span.TraceID().String()))
+		}
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h *ContextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &ContextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *ContextHandler) WithGroup(name string) slog.Handler {
+	return &ContextHandler{Handler: h.Handler.WithGroup(name)}
+}
+
+// Middleware logs every request once it has been handled. It runs after
+// requestid.Middleware, and sees the user set by authentication middleware
+// further down the chain.
+func Middleware(logger *slog.Logger) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+		ctx.Next()
+
+		status := ctx.Writer.Status()
+		level := slog.LevelInfo
+		if status >= 500 {
+			level = slog.LevelError
+		}
+
+		logger.Log(ctx.Request.Context(), level, "http request",
+			"method", ctx.Request.Method,
+			"route", ctx.FullPath(),
+			"path", ctx.Request.URL.Path,
+			"status", status,
+			"duration_ms", time.Since(start).Milliseconds(),
+			"client_ip", ctx.ClientIP(),
+		)
+	}
+}
@@ -0,0 +1,63 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"todoapp/pkg/requestid"
+)
+
+func decode(t *testing.T, buf *bytes.Buffer) map[string]any {
+	t.Helper()
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("log is not JSON: %v: %s", err, buf.String())
+	}
+	return record
+}
+
+func TestContextAttributes(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewWithWriter(&buf, "task-service")
+
+	ctx := WithUserID(requestid.WithContext(context.Background(), "abc-123"), 7)
+	logger.InfoContext(ctx, "task created", "task_id", int64(42))
+
+	record := decode(t, &buf)
+	if record["service"] != "task-service" || record["request_id"] != "abc-123" {
+		t.Fatalf("unexpected record: %v", record)
+	}
+	if record["user_id"] != float64(7) || record["task_id"] != float64(42) {
+		t.Fatalf("unexpected record: %v", record)
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var buf bytes.Buffer
+
+	router := gin.New()
+	router.Use(requestid.Middleware(), Middleware(NewWithWriter(&buf, "task-service")))
+	router.GET("/tasks/:id", func(c *gin.Context) {
+		c.Request = c.Request.WithContext(WithUserID(c.Request.Context(), 7))
+		c.Status(http.StatusNoContent)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/tasks/1", nil)
+	req.Header.Set(requestid.Header, "abc-123")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	record := decode(t, &buf)
+	if record["route"] != "/tasks/:id" || record["status"] != float64(http.StatusNoContent) {
+		t.Fatalf("unexpected record: %v", record)
+	}
+	if record["request_id"] != "abc-123" || record["user_id"] != float64(7) {
+		t.Fatalf("unexpected record: %v", record)
+	}
+}
@@ -0,0 +1,28 @@
+// Package metrics holds the Prometheus metrics shared by the services: HTTP
+// and gRPC traffic, Postgres pools and RabbitMQ messages. Metrics specific to
+// one service are declared next to the code updating them. Everything is
+// registered with the default registry, which Handler serves.
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Path is where the services serve their metrics.
+const Path = "/metrics"
+
+// Handler serves the metrics in the Prometheus text format.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// Result is the value of the result label of operations that either succeed
+// or fail.
+func Result(err error) string {
+	if err != nil {
+		return "failure"
+	}
+	return "success"
+}
@@ -0,0 +1,83 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func sampleCount(t *testing.T, vec *prometheus.HistogramVec, labels ...string) uint64 {
+	t.Helper()
+	var metric dto.Metric
+	if err := vec.WithLabelValues(labels...).(prometheus.Metric).Write(&metric); err != nil {
+		t.Fatalf("write metric: %v", err)
+	}
+	return metric.GetHistogram().GetSampleCount()
+}
+
+func TestMiddlewareObservesRoute(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(Middleware())
+	router.GET("/tasks/:id", func(ctx *gin.Context) { ctx.Status(http.StatusNoContent) })
+
+	for _, path := range []string{"/tasks/1", "/tasks/2", "/nowhere"} {
+		router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	if got := sampleCount(t, httpRequestDuration, "GET", "/tasks/:id", "204"); got != 2 {
+		t.Fatalf("expected 2 requests for the route, got %d", got)
+	}
+	if got := sampleCount(t, httpRequestDuration, "GET", "unmatched", "404"); got != 1 {
+		t.Fatalf("expected 1 unmatched request, got %d", got)
+	}
+}
+
+func TestUnaryServerInterceptorObservesCode(t *testing.T) {
+	interceptor := UnaryServerInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.v1.Test/Server"}
+
+	_, _ = interceptor(context.Background(), nil, info, func(ctx context.Context, req any) (any, error) {
+		return nil, status.Error(codes.NotFound, "missing")
+	})
+
+	if got := sampleCount(t, grpcServerDuration, "/test.v1.Test/Server", "NotFound"); got != 1 {
+		t.Fatalf("expected 1 observation, got %d", got)
+	}
+}
+
+func TestUnaryClientInterceptorObservesCode(t *testing.T) {
+	interceptor := UnaryClientInterceptor()
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		return nil
+	}
+
+	_ = interceptor(context.Background(), "/test.v1.Test/Client", nil, nil, nil, invoker)
+
+	if got := sampleCount(t, grpcClientDuration, "/test.v1.Test/Client", "OK"); got != 1 {
+		t.Fatalf("expected 1 observation, got %d", got)
+	}
+}
+
+func TestMessagePublishedByResult(t *testing.T) {
+	MessagePublished("test_queue", nil)
+	MessagePublished("test_queue", errors.New("closed"))
+	MessagePublished("test_queue", nil)
+
+	if got := testutil.ToFloat64(messagesPublished.WithLabelValues("test_queue", "success")); got != 2 {
+		t.Fatalf("expected 2 successful publishes, got %v", got)
+	}
+	if got := testutil.ToFloat64(messagesPublished.WithLabelValues("test_queue", "failure")); got != 1 {
+		t.Fatalf("expected 1 failed publish, got %v", got)
+	}
+}
@@ -0,0 +1,59 @@
+package metrics
+
+import (
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	poolAcquiredConns = prometheus.NewDesc("pgxpool_acquired_conns",
+		"Connections currently in use.", []string{"pool"}, nil)
+	poolIdleConns = prometheus.NewDesc("pgxpool_idle_conns",
+		"Connections currently idle.", []string{"pool"}, nil)
+	poolTotalConns = prometheus.NewDesc("pgxpool_total_conns",
+		"Connections currently open, including those being established.", []string{"pool"}, nil)
+	poolMaxConns = prometheus.NewDesc("pgxpool_max_conns",
+		"Maximum size of the pool.", []string{"pool"}, nil)
+	poolAcquireCount = prometheus.NewDesc("pgxpool_acquire_total",
+		"Connections acquired from the pool.", []string{"pool"}, nil)
+	poolEmptyAcquireCount = prometheus.NewDesc("pgxpool_empty_acquire_total",
+		"Acquires that had to wait because the pool had no idle connection.", []string{"pool"}, nil)
+	poolAcquireDuration = prometheus.NewDesc("pgxpool_acquire_duration_seconds_total",
+		"Time spent acquiring connections.", []string{"pool"}, nil)
+)
+
+// PoolCollector exports the statistics of a pgx pool, read at scrape time.
+type PoolCollector struct {
+	pool *pgxpool.Pool
+	name string
+}
+
+var _ prometheus.Collector = (*PoolCollector)(nil)
+
+// RegisterPool exports the statistics of pool under the given pool label,
+// such as "primary" or "replica".
+func RegisterPool(pool *pgxpool.Pool, name string) error {
+	return prometheus.Register(&PoolCollector{pool: pool, name: name})
+}
+
+func (c *PoolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- poolAcquiredConns
+	ch <- poolIdleConns
+	ch <- poolTotalConns
+	ch <- poolMaxConns
+	ch <- poolAcquireCount
+	ch <- poolEmptyAcquireCount
+	ch <- poolAcquireDuration
+}
+
+func (c *PoolCollector) Collect(ch chan<- prometheus.Metric) {
+	stat := c.pool.Stat()
+
+	ch <- prometheus.MustNewConstMetric(poolAcquiredConns, prometheus.GaugeValue, float64(stat.AcquiredConns()), c.name)
+	ch <- prometheus.MustNewConstMetric(poolIdleConns, prometheus.GaugeValue, float64(stat.IdleConns()), c.name)
+	ch <- prometheus.MustNewConstMetric(poolTotalConns, prometheus.GaugeValue, float64(stat.TotalConns()), c.name)
+	ch <- prometheus.MustNewConstMetric(poolMaxConns, prometheus.GaugeValue, float64(stat.MaxConns()), c.name)
+	ch <- prometheus.MustNewConstMetric(poolAcquireCount, prometheus.CounterValue, float64(stat.AcquireCount()), c.name)
+	ch <- prometheus.MustNewConstMetric(poolEmptyAcquireCount, prometheus.CounterValue, float64(stat.EmptyAcquireCount()), c.name)
+	ch <- prometheus.MustNewConstMetric(poolAcquireDuration, prometheus.CounterValue, stat.AcquireDuration().Seconds(), c.name)
+}
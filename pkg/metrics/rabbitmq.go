@@ -0,0 +1,46 @@
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	messagesPublished = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "rabbitmq_messages_published_total",
+		Help: "Messages published to RabbitMQ, by destination and result.",
+	}, []string{"destination", "result"})
+
+	messagesConsumed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "rabbitmq_messages_consumed_total",
+		Help: "Messages received from RabbitMQ, by source.",
+	}, []string{"source"})
+
+	messagesAcked = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "rabbitmq_messages_acked_total",
+		Help: "Received messages acknowledged, by source.",
+	}, []string{"source"})
+
+	messagesNacked = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "rabbitmq_messages_nacked_total",
+		Help: "Received messages rejected, by source.",
+	}, []string{"source"})
+)
+
+// MessagePublished counts a publish to destination, a queue or an exchange,
+// that failed when err is not nil.
+func MessagePublished(destination string, err error) {
+	messagesPublished.WithLabelValues(destination, Result(err)).Inc()
+}
+
+func MessageConsumed(source string) {
+	messagesConsumed.WithLabelValues(source).Inc()
+}
+
+func MessageAcked(source string) {
+	messagesAcked.WithLabelValues(source).Inc()
+}
+
+func MessageNacked(source string) {
+	messagesNacked.WithLabelValues(source).Inc()
+}
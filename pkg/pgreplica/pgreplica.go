@@ -0,0 +1,248 @@
+// Package pgreplica sends read-only queries to a Postgres streaming replica
+// while it keeps up with the primary, and to the primary otherwise.
+package pgreplica
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"todoapp/pkg/tracing"
+)
+
+const (
+	defaultReplicaMaxLag        = 5 * time.Second
+	defaultReplicaCheckInterval = 2 * time.Second
+)
+
+// replicaLagQuery reports whether the server is a standby and how far it is
+// behind, in seconds. A standby that has replayed everything it received
+// counts as current even when the primary has been idle.
+const replicaLagQuery = `
+SELECT pg_is_in_recovery(),
+    CASE
+        WHEN NOT pg_is_in_recovery() THEN 0
+        WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+        ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0)
+    END
+`
+
+// Config holds the replica connection and pool settings. Zero values leave
+// the pgx defaults, and MaxLag and CheckInterval fall back to 5s and 2s.
+type Config struct {
+	URL             string
+	MaxConns        int32
+	MinConns        int32
+	MaxConnLifetime time.Duration
+	MaxLag          time.Duration
+	CheckInterval   time.Duration
+}
+
+// Pool runs read-only queries on a streaming replica while it is reachable
+// and no more than MaxLag behind, and on the primary otherwise.
+type Pool struct {
+	primary  *pgxpool.Pool
+	replica  *pgxpool.Pool
+	maxLag   time.Duration
+	interval time.Duration
+	logger   *slog.Logger
+	healthy  atomic.Bool
+	// warnedNotStandby is only used by check, which never runs concurrently.
+	warnedNotStandby bool
+}
+
+// New connects to the replica at cfg.URL and checks it once, so queries go
+// to it right away when it is in sync.
+func New(ctx context.Context, cfg Config, primary *pgxpool.Pool, logger *slog.Logger) (*Pool, error) {
+	replica, err := connect(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Pool{
+		primary:  primary,
+		replica:  replica,
+		maxLag:   cfg.MaxLag,
+		interval: cfg.CheckInterval,
+		logger:   logger,
+	}
+	if p.maxLag <= 0 {
+		p.maxLag = defaultReplicaMaxLag
+	}
+	if p.interval <= 0 {
+		p.interval = defaultReplicaCheckInterval
+	}
+	if p.logger == nil {
+		p.logger = slog.Default()
+	}
+
+	p.check(ctx)
+
+	return p, nil
+}
+
+func connect(ctx context.Context, cfg Config) (*pgxpool.Pool, error) {
+	poolCfg, err := pgxpool.ParseConfig(cfg.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.MaxConns > 0 {
+		poolCfg.MaxConns = cfg.MaxConns
+	}
+
+	if cfg.MinConns > 0 {
+		poolCfg.MinConns = cfg.MinConns
+	}
+
+	if cfg.MaxConnLifetime > 0 {
+		poolCfg.MaxConnLifetime = cfg.MaxConnLifetime
+	}
+
+	poolCfg.ConnConfig.Tracer = tracing.QueryTracer{}
+
+	return pgxpool.NewWithConfig(ctx, poolCfg)
+}
+
+// Run rechecks the replica every interval until ctx is done, so it is used
+// again once it catches up.
+func (p *Pool) Run(ctx context.Context) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.check(ctx)
+		}
+	}
+}
+
+func (p *Pool) Close() {
+	p.replica.Close()
+}
+
+// Replica returns the pool connected to the replica, for its statistics.
+func (p *Pool) Replica() *pgxpool.Pool {
+	return p.replica
+}
+
+func (p *Pool) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	if p.healthy.Load() {
+		rows, err := p.replica.Query(ctx, sql, args...)
+		if err == nil || ctx.Err() != nil || !isConnectionError(err) {
+			return rows, err
+		}
+		p.fail(err)
+	}
+
+	return p.primary.Query(ctx, sql, args...)
+}
+
+func (p *Pool) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	if !p.healthy.Load() {
+		return p.primary.QueryRow(ctx, sql, args...)
+	}
+
+	return &fallbackRow{
+		ctx:  ctx,
+		pool: p,
+		row:  p.replica.QueryRow(ctx, sql, args...),
+		sql:  sql,
+		args: args,
+	}
+}
+
+func (p *Pool) check(ctx context.Context) {
+	checkCtx, cancel := context.WithTimeout(ctx, p.interval)
+	defer cancel()
+
+	var (
+		standby    bool
+		lagSeconds float64
+	)
+	if err := p.replica.QueryRow(checkCtx, replicaLagQuery).Scan(&standby, &lagSeconds); err != nil {
+		if ctx.Err() == nil {
+			p.fail(err)
+		}
+		return
+	}
+
+	// A server that is not a standby does not receive the primary's writes,
+	// so reading from it would return unrelated data.
+	if !standby {
+		if p.healthy.Swap(false) || !p.warnedNotStandby {
+			p.logger.Warn("postgres replica is not a standby, reading from primary")
+			p.warnedNotStandby = true
+		}
+		return
+	}
+
+	lag := time.Duration(lagSeconds * float64(time.Second))
+	if lag > p.maxLag {
+		if p.healthy.Swap(false) {
+			p.logger.Warn("postgres replica is behind, reading from primary", "lag", lag.Round(time.Millisecond).String())
+		}
+		return
+	}
+
+	if !p.healthy.Swap(true) {
+		p.logger.Info("postgres replica is in sync, reading from replica")
+	}
+}
+
+// fail stops reads from the replica until the next successful check.
+func (p *Pool) fail(err error) {
+	if p.healthy.Swap(false) {
+		p.logger.Warn("postgres replica failed, reading from primary", "error", err)
+	}
+}
+
+// fallbackRow repeats the query on the primary when the replica fails. Errors
+// of the query itself, such as no rows, are returned as they are.
+type fallbackRow struct {
+	ctx  context.Context
+	pool *Pool
+	row  pgx.Row
+	sql  string
+	args []any
+}
+
+func (r *fallbackRow) Scan(dest ...any) error {
+	err := r.row.Scan(dest...)
+	if err == nil || errors.Is(err, pgx.ErrNoRows) || r.ctx.Err() != nil || !isConnectionError(err) {
+		return err
+	}
+
+	r.pool.fail(err)
+	return r.pool.primary.QueryRow(r.ctx, r.sql, r.args...).Scan(dest...)
+}
+
+// isConnectionError reports errors that say nothing about the query, for
+// which the primary may succeed where the replica did not.
+func isConnectionError(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return true
+	}
+
+	// Class 08 is connection exceptions, 57P01-57P03 are shutdowns and 40001
+	// is raised when replay cancels a query on a hot standby.
+	switch {
+	case strings.HasPrefix(pgErr.Code, "08"),
+		pgErr.Code == "57P01", pgErr.Code == "57P02", pgErr.Code == "57P03",
+		pgErr.Code == "40001":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,32 @@
+package pgreplica
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func TestIsConnectionError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "network", err: errors.New("connection refused"), want: true},
+		{name: "connection exception", err: &pgconn.PgError{Code: "08006"}, want: true},
+		{name: "admin shutdown", err: &pgconn.PgError{Code: "57P01"}, want: true},
+		{name: "recovery conflict", err: fmt.Errorf("query: %w", &pgconn.PgError{Code: "40001"}), want: true},
+		{name: "undefined column", err: &pgconn.PgError{Code: "42703"}, want: false},
+		{name: "unique violation", err: &pgconn.PgError{Code: "23505"}, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isConnectionError(tt.err); got != tt.want {
+				t.Fatalf("isConnectionError(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
@@ -27,6 +27,8 @@ const (
 	TaskEventType_TASK_EVENT_TYPE_CREATED     TaskEventType = 1
 	TaskEventType_TASK_EVENT_TYPE_COMPLETED   TaskEventType = 2
 	TaskEventType_TASK_EVENT_TYPE_DELETED     TaskEventType = 3
+	// Time spent on a task; duration_seconds carries the amount.
+	TaskEventType_TASK_EVENT_TYPE_TIME_TRACKED TaskEventType = 4
 )
 
 // Enum value maps for TaskEventType.
@@ -36,12 +38,14 @@ var (
 		1: "TASK_EVENT_TYPE_CREATED",
 		2: "TASK_EVENT_TYPE_COMPLETED",
 		3: "TASK_EVENT_TYPE_DELETED",
+		4: "TASK_EVENT_TYPE_TIME_TRACKED",
 	}
 	TaskEventType_value = map[string]int32{
-		"TASK_EVENT_TYPE_UNSPECIFIED": 0,
-		"TASK_EVENT_TYPE_CREATED":     1,
-		"TASK_EVENT_TYPE_COMPLETED":   2,
-		"TASK_EVENT_TYPE_DELETED":     3,
+		"TASK_EVENT_TYPE_UNSPECIFIED":  0,
+		"TASK_EVENT_TYPE_CREATED":      1,
+		"TASK_EVENT_TYPE_COMPLETED":    2,
+		"TASK_EVENT_TYPE_DELETED":      3,
+		"TASK_EVENT_TYPE_TIME_TRACKED": 4,
 	}
 )
 
@@ -77,12 +81,15 @@ type TrackTaskEventRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Type       TaskEventType `protobuf:"varint,1,opt,name=type,proto3,enum=analytics.v1.TaskEventType" json:"type,omitempty"`
-	UserId     int64         `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	TaskId     int64         `protobuf:"varint,3,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
-	Status     string        `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
-	Priority   string        `protobuf:"bytes,5,opt,name=priority,proto3" json:"priority,omitempty"`
-	OccurredAt int64         `protobuf:"varint,6,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	Type            TaskEventType `protobuf:"varint,1,opt,name=type,proto3,enum=analytics.v1.TaskEventType" json:"type,omitempty"`
+	UserId          int64         `protobuf:"varint,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	TaskId          int64         `protobuf:"varint,3,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Status          string        `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	Priority        string        `protobuf:"bytes,5,opt,name=priority,proto3" json:"priority,omitempty"`
+	OccurredAt      int64         `protobuf:"varint,6,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	DurationSeconds int64         `protobuf:"varint,7,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"`
+	// Idempotency key; an event with an ID that was already applied is ignored.
+	EventId string `protobuf:"bytes,8,opt,name=event_id,json=eventId,proto3" json:"event_id,omitempty"`
 }
 
 func (x *TrackTaskEventRequest) Reset() {
@@ -159,6 +166,20 @@ func (x *TrackTaskEventRequest) GetOccurredAt() int64 {
 	return 0
 }
 
+func (x *TrackTaskEventRequest) GetDurationSeconds() int64 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
+func (x *TrackTaskEventRequest) GetEventId() string {
+	if x != nil {
+		return x.EventId
+	}
+	return ""
+}
+
 type TrackTaskEventResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -197,6 +218,118 @@ func (*TrackTaskEventResponse) Descriptor() ([]byte, []int) {
 	return file_proto_analytics_v1_analytics_proto_rawDescGZIP(), []int{1}
 }
 
+type BatchTrackTaskEventsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Events []*TrackTaskEventRequest `protobuf:"bytes,1,rep,name=events,proto3" json:"events,omitempty"`
+}
+
+func (x *BatchTrackTaskEventsRequest) Reset() {
+	*x = BatchTrackTaskEventsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_analytics_v1_analytics_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchTrackTaskEventsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchTrackTaskEventsRequest) ProtoMessage() {}
+
+func (x *BatchTrackTaskEventsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_analytics_v1_analytics_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchTrackTaskEventsRequest.ProtoReflect.Descriptor instead.
+func (*BatchTrackTaskEventsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_analytics_v1_analytics_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BatchTrackTaskEventsRequest) GetEvents() []*TrackTaskEventRequest {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+type BatchTrackTaskEventsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Applied int32 `protobuf:"varint,1,opt,name=applied,proto3" json:"applied,omitempty"`
+	// Events already applied under the same event_id.
+	Duplicates int32 `protobuf:"varint,2,opt,name=duplicates,proto3" json:"duplicates,omitempty"`
+	// Invalid events; they are skipped so the rest of the batch still applies.
+	Rejected int32 `protobuf:"varint,3,opt,name=rejected,proto3" json:"rejected,omitempty"`
+}
+
+func (x *BatchTrackTaskEventsResponse) Reset() {
+	*x = BatchTrackTaskEventsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_analytics_v1_analytics_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BatchTrackTaskEventsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchTrackTaskEventsResponse) ProtoMessage() {}
+
+func (x *BatchTrackTaskEventsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_analytics_v1_analytics_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchTrackTaskEventsResponse.ProtoReflect.Descriptor instead.
+func (*BatchTrackTaskEventsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_analytics_v1_analytics_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *BatchTrackTaskEventsResponse) GetApplied() int32 {
+	if x != nil {
+		return x.Applied
+	}
+	return 0
+}
+
+func (x *BatchTrackTaskEventsResponse) GetDuplicates() int32 {
+	if x != nil {
+		return x.Duplicates
+	}
+	return 0
+}
+
+func (x *BatchTrackTaskEventsResponse) GetRejected() int32 {
+	if x != nil {
+		return x.Rejected
+	}
+	return 0
+}
+
 type DailyTaskMetrics struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -207,12 +340,13 @@ type DailyTaskMetrics struct {
 	CreatedTasks   int32  `protobuf:"varint,3,opt,name=created_tasks,json=createdTasks,proto3" json:"created_tasks,omitempty"`
 	CompletedTasks int32  `protobuf:"varint,4,opt,name=completed_tasks,json=completedTasks,proto3" json:"completed_tasks,omitempty"`
 	TotalTasks     int32  `protobuf:"varint,5,opt,name=total_tasks,json=totalTasks,proto3" json:"total_tasks,omitempty"`
+	TrackedSeconds int64  `protobuf:"varint,6,opt,name=tracked_seconds,json=trackedSeconds,proto3" json:"tracked_seconds,omitempty"`
 }
 
 func (x *DailyTaskMetrics) Reset() {
 	*x = DailyTaskMetrics{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_analytics_v1_analytics_proto_msgTypes[2]
+		mi := &file_proto_analytics_v1_analytics_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -225,7 +359,7 @@ func (x *DailyTaskMetrics) String() string {
 func (*DailyTaskMetrics) ProtoMessage() {}
 
 func (x *DailyTaskMetrics) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_analytics_v1_analytics_proto_msgTypes[2]
+	mi := &file_proto_analytics_v1_analytics_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -238,7 +372,7 @@ func (x *DailyTaskMetrics) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DailyTaskMetrics.ProtoReflect.Descriptor instead.
 func (*DailyTaskMetrics) Descriptor() ([]byte, []int) {
-	return file_proto_analytics_v1_analytics_proto_rawDescGZIP(), []int{2}
+	return file_proto_analytics_v1_analytics_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *DailyTaskMetrics) GetUserId() int64 {
@@ -276,6 +410,13 @@ func (x *DailyTaskMetrics) GetTotalTasks() int32 {
 	return 0
 }
 
+func (x *DailyTaskMetrics) GetTrackedSeconds() int64 {
+	if x != nil {
+		return x.TrackedSeconds
+	}
+	return 0
+}
+
 type GetDailyMetricsRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -288,7 +429,7 @@ type GetDailyMetricsRequest struct {
 func (x *GetDailyMetricsRequest) Reset() {
 	*x = GetDailyMetricsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_analytics_v1_analytics_proto_msgTypes[3]
+		mi := &file_proto_analytics_v1_analytics_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -301,7 +442,7 @@ func (x *GetDailyMetricsRequest) String() string {
 func (*GetDailyMetricsRequest) ProtoMessage() {}
 
 func (x *GetDailyMetricsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_analytics_v1_analytics_proto_msgTypes[3]
+	mi := &file_proto_analytics_v1_analytics_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -314,7 +455,7 @@ func (x *GetDailyMetricsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetDailyMetricsRequest.ProtoReflect.Descriptor instead.
 func (*GetDailyMetricsRequest) Descriptor() ([]byte, []int) {
-	return file_proto_analytics_v1_analytics_proto_rawDescGZIP(), []int{3}
+	return file_proto_analytics_v1_analytics_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *GetDailyMetricsRequest) GetUserId() int64 {
@@ -342,7 +483,7 @@ type GetDailyMetricsResponse struct {
 func (x *GetDailyMetricsResponse) Reset() {
 	*x = GetDailyMetricsResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_proto_analytics_v1_analytics_proto_msgTypes[4]
+		mi := &file_proto_analytics_v1_analytics_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -355,7 +496,7 @@ func (x *GetDailyMetricsResponse) String() string {
 func (*GetDailyMetricsResponse) ProtoMessage() {}
 
 func (x *GetDailyMetricsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_analytics_v1_analytics_proto_msgTypes[4]
+	mi := &file_proto_analytics_v1_analytics_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -368,7 +509,7 @@ func (x *GetDailyMetricsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetDailyMetricsResponse.ProtoReflect.Descriptor instead.
 func (*GetDailyMetricsResponse) Descriptor() ([]byte, []int) {
-	return file_proto_analytics_v1_analytics_proto_rawDescGZIP(), []int{4}
+	return file_proto_analytics_v1_analytics_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *GetDailyMetricsResponse) GetMetrics() *DailyTaskMetrics {
@@ -384,7 +525,7 @@ var file_proto_analytics_v1_analytics_proto_rawDesc = []byte{
 	0x0a, 0x22, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x61, 0x6e, 0x61, 0x6c, 0x79, 0x74, 0x69, 0x63,
 	0x73, 0x2f, 0x76, 0x31, 0x2f, 0x61, 0x6e, 0x61, 0x6c, 0x79, 0x74, 0x69, 0x63, 0x73, 0x2e, 0x70,
 	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0c, 0x61, 0x6e, 0x61, 0x6c, 0x79, 0x74, 0x69, 0x63, 0x73, 0x2e,
-	0x76, 0x31, 0x22, 0xcf, 0x01, 0x0a, 0x15, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x54, 0x61, 0x73, 0x6b,
+	0x76, 0x31, 0x22, 0x95, 0x02, 0x0a, 0x15, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x54, 0x61, 0x73, 0x6b,
 	0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x2f, 0x0a, 0x04,
 	0x74, 0x79, 0x70, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1b, 0x2e, 0x61, 0x6e, 0x61,
 	0x6c, 0x79, 0x74, 0x69, 0x63, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x61, 0x73, 0x6b, 0x45, 0x76,
@@ -397,54 +538,84 @@ var file_proto_analytics_v1_analytics_proto_rawDesc = []byte{
 	0x69, 0x74, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72,
 	0x69, 0x74, 0x79, 0x12, 0x1f, 0x0a, 0x0b, 0x6f, 0x63, 0x63, 0x75, 0x72, 0x72, 0x65, 0x64, 0x5f,
 	0x61, 0x74, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x6f, 0x63, 0x63, 0x75, 0x72, 0x72,
-	0x65, 0x64, 0x41, 0x74, 0x22, 0x18, 0x0a, 0x16, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x54, 0x61, 0x73,
-	0x6b, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x22, 0xae,
-	0x01, 0x0a, 0x10, 0x44, 0x61, 0x69, 0x6c, 0x79, 0x54, 0x61, 0x73, 0x6b, 0x4d, 0x65, 0x74, 0x72,
-	0x69, 0x63, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04,
-	0x64, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74, 0x65,
-	0x12, 0x23, 0x0a, 0x0d, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x74, 0x61, 0x73, 0x6b,
-	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0c, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64,
-	0x54, 0x61, 0x73, 0x6b, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74,
-	0x65, 0x64, 0x5f, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e,
-	0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x54, 0x61, 0x73, 0x6b, 0x73, 0x12, 0x1f,
-	0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x18, 0x05, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x0a, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x54, 0x61, 0x73, 0x6b, 0x73, 0x22,
-	0x45, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x44, 0x61, 0x69, 0x6c, 0x79, 0x4d, 0x65, 0x74, 0x72, 0x69,
-	0x63, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73, 0x65,
-	0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65, 0x72,
-	0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x04, 0x64, 0x61, 0x74, 0x65, 0x22, 0x53, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x44, 0x61, 0x69,
-	0x6c, 0x79, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x38, 0x0a, 0x07, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x61, 0x6e, 0x61, 0x6c, 0x79, 0x74, 0x69, 0x63, 0x73, 0x2e, 0x76,
-	0x31, 0x2e, 0x44, 0x61, 0x69, 0x6c, 0x79, 0x54, 0x61, 0x73, 0x6b, 0x4d, 0x65, 0x74, 0x72, 0x69,
-	0x63, 0x73, 0x52, 0x07, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x2a, 0x89, 0x01, 0x0a, 0x0d,
-	0x54, 0x61, 0x73, 0x6b, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1f, 0x0a,
-	0x1b, 0x54, 0x41, 0x53, 0x4b, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45,
-	0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x1b,
-	0x0a, 0x17, 0x54, 0x41, 0x53, 0x4b, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50,
-	0x45, 0x5f, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45, 0x44, 0x10, 0x01, 0x12, 0x1d, 0x0a, 0x19, 0x54,
-	0x41, 0x53, 0x4b, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x43,
-	0x4f, 0x4d, 0x50, 0x4c, 0x45, 0x54, 0x45, 0x44, 0x10, 0x02, 0x12, 0x1b, 0x0a, 0x17, 0x54, 0x41,
-	0x53, 0x4b, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x44, 0x45,
-	0x4c, 0x45, 0x54, 0x45, 0x44, 0x10, 0x03, 0x32, 0xcf, 0x01, 0x0a, 0x10, 0x41, 0x6e, 0x61, 0x6c,
-	0x79, 0x74, 0x69, 0x63, 0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x5b, 0x0a, 0x0e,
-	0x54, 0x72, 0x61, 0x63, 0x6b, 0x54, 0x61, 0x73, 0x6b, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x12, 0x23,
-	0x2e, 0x61, 0x6e, 0x61, 0x6c, 0x79, 0x74, 0x69, 0x63, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x72,
-	0x61, 0x63, 0x6b, 0x54, 0x61, 0x73, 0x6b, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x61, 0x6e, 0x61, 0x6c, 0x79, 0x74, 0x69, 0x63, 0x73, 0x2e,
+	0x65, 0x64, 0x41, 0x74, 0x12, 0x29, 0x0a, 0x10, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f,
+	0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x12,
+	0x19, 0x0a, 0x08, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x08, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x07, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x22, 0x18, 0x0a, 0x16, 0x54, 0x72,
+	0x61, 0x63, 0x6b, 0x54, 0x61, 0x73, 0x6b, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x22, 0x5a, 0x0a, 0x1b, 0x42, 0x61, 0x74, 0x63, 0x68, 0x54, 0x72, 0x61,
+	0x63, 0x6b, 0x54, 0x61, 0x73, 0x6b, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x12, 0x3b, 0x0a, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x23, 0x2e, 0x61, 0x6e, 0x61, 0x6c, 0x79, 0x74, 0x69, 0x63, 0x73, 0x2e,
 	0x76, 0x31, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x54, 0x61, 0x73, 0x6b, 0x45, 0x76, 0x65, 0x6e,
-	0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5e, 0x0a, 0x0f, 0x47, 0x65, 0x74,
-	0x44, 0x61, 0x69, 0x6c, 0x79, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x12, 0x24, 0x2e, 0x61,
-	0x6e, 0x61, 0x6c, 0x79, 0x74, 0x69, 0x63, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x44,
-	0x61, 0x69, 0x6c, 0x79, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
-	0x73, 0x74, 0x1a, 0x25, 0x2e, 0x61, 0x6e, 0x61, 0x6c, 0x79, 0x74, 0x69, 0x63, 0x73, 0x2e, 0x76,
-	0x31, 0x2e, 0x47, 0x65, 0x74, 0x44, 0x61, 0x69, 0x6c, 0x79, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63,
-	0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x2c, 0x5a, 0x2a, 0x74, 0x6f, 0x64,
-	0x6f, 0x61, 0x70, 0x70, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x61,
-	0x6e, 0x61, 0x6c, 0x79, 0x74, 0x69, 0x63, 0x73, 0x2f, 0x76, 0x31, 0x3b, 0x61, 0x6e, 0x61, 0x6c,
-	0x79, 0x74, 0x69, 0x63, 0x73, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x06, 0x65, 0x76, 0x65, 0x6e, 0x74, 0x73,
+	0x22, 0x74, 0x0a, 0x1c, 0x42, 0x61, 0x74, 0x63, 0x68, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x54, 0x61,
+	0x73, 0x6b, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x18, 0x0a, 0x07, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x07, 0x61, 0x70, 0x70, 0x6c, 0x69, 0x65, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x64, 0x75,
+	0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a,
+	0x64, 0x75, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x74, 0x65, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x72, 0x65,
+	0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x08, 0x72, 0x65,
+	0x6a, 0x65, 0x63, 0x74, 0x65, 0x64, 0x22, 0xd7, 0x01, 0x0a, 0x10, 0x44, 0x61, 0x69, 0x6c, 0x79,
+	0x54, 0x61, 0x73, 0x6b, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x75,
+	0x73, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73,
+	0x65, 0x72, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x64, 0x61, 0x74, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x63, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x64, 0x5f, 0x74, 0x61, 0x73, 0x6b, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x0c, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x54, 0x61, 0x73, 0x6b, 0x73, 0x12, 0x27, 0x0a,
+	0x0f, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x5f, 0x74, 0x61, 0x73, 0x6b, 0x73,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0e, 0x63, 0x6f, 0x6d, 0x70, 0x6c, 0x65, 0x74, 0x65,
+	0x64, 0x54, 0x61, 0x73, 0x6b, 0x73, 0x12, 0x1f, 0x0a, 0x0b, 0x74, 0x6f, 0x74, 0x61, 0x6c, 0x5f,
+	0x74, 0x61, 0x73, 0x6b, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52, 0x0a, 0x74, 0x6f, 0x74,
+	0x61, 0x6c, 0x54, 0x61, 0x73, 0x6b, 0x73, 0x12, 0x27, 0x0a, 0x0f, 0x74, 0x72, 0x61, 0x63, 0x6b,
+	0x65, 0x64, 0x5f, 0x73, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x0e, 0x74, 0x72, 0x61, 0x63, 0x6b, 0x65, 0x64, 0x53, 0x65, 0x63, 0x6f, 0x6e, 0x64, 0x73,
+	0x22, 0x45, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x44, 0x61, 0x69, 0x6c, 0x79, 0x4d, 0x65, 0x74, 0x72,
+	0x69, 0x63, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x17, 0x0a, 0x07, 0x75, 0x73,
+	0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x75, 0x73, 0x65,
+	0x72, 0x49, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x64, 0x61, 0x74, 0x65, 0x22, 0x53, 0x0a, 0x17, 0x47, 0x65, 0x74, 0x44, 0x61,
+	0x69, 0x6c, 0x79, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x38, 0x0a, 0x07, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x61, 0x6e, 0x61, 0x6c, 0x79, 0x74, 0x69, 0x63, 0x73, 0x2e,
+	0x76, 0x31, 0x2e, 0x44, 0x61, 0x69, 0x6c, 0x79, 0x54, 0x61, 0x73, 0x6b, 0x4d, 0x65, 0x74, 0x72,
+	0x69, 0x63, 0x73, 0x52, 0x07, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x2a, 0xab, 0x01, 0x0a,
+	0x0d, 0x54, 0x61, 0x73, 0x6b, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x54, 0x79, 0x70, 0x65, 0x12, 0x1f,
+	0x0a, 0x1b, 0x54, 0x41, 0x53, 0x4b, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50,
+	0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12,
+	0x1b, 0x0a, 0x17, 0x54, 0x41, 0x53, 0x4b, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59,
+	0x50, 0x45, 0x5f, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45, 0x44, 0x10, 0x01, 0x12, 0x1d, 0x0a, 0x19,
+	0x54, 0x41, 0x53, 0x4b, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f,
+	0x43, 0x4f, 0x4d, 0x50, 0x4c, 0x45, 0x54, 0x45, 0x44, 0x10, 0x02, 0x12, 0x1b, 0x0a, 0x17, 0x54,
+	0x41, 0x53, 0x4b, 0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x44,
+	0x45, 0x4c, 0x45, 0x54, 0x45, 0x44, 0x10, 0x03, 0x12, 0x20, 0x0a, 0x1c, 0x54, 0x41, 0x53, 0x4b,
+	0x5f, 0x45, 0x56, 0x45, 0x4e, 0x54, 0x5f, 0x54, 0x59, 0x50, 0x45, 0x5f, 0x54, 0x49, 0x4d, 0x45,
+	0x5f, 0x54, 0x52, 0x41, 0x43, 0x4b, 0x45, 0x44, 0x10, 0x04, 0x32, 0xbe, 0x02, 0x0a, 0x10, 0x41,
+	0x6e, 0x61, 0x6c, 0x79, 0x74, 0x69, 0x63, 0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12,
+	0x5b, 0x0a, 0x0e, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x54, 0x61, 0x73, 0x6b, 0x45, 0x76, 0x65, 0x6e,
+	0x74, 0x12, 0x23, 0x2e, 0x61, 0x6e, 0x61, 0x6c, 0x79, 0x74, 0x69, 0x63, 0x73, 0x2e, 0x76, 0x31,
+	0x2e, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x54, 0x61, 0x73, 0x6b, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x61, 0x6e, 0x61, 0x6c, 0x79, 0x74, 0x69,
+	0x63, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x54, 0x61, 0x73, 0x6b, 0x45,
+	0x76, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x6d, 0x0a, 0x14,
+	0x42, 0x61, 0x74, 0x63, 0x68, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x54, 0x61, 0x73, 0x6b, 0x45, 0x76,
+	0x65, 0x6e, 0x74, 0x73, 0x12, 0x29, 0x2e, 0x61, 0x6e, 0x61, 0x6c, 0x79, 0x74, 0x69, 0x63, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x42, 0x61, 0x74, 0x63, 0x68, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x54, 0x61,
+	0x73, 0x6b, 0x45, 0x76, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a,
+	0x2a, 0x2e, 0x61, 0x6e, 0x61, 0x6c, 0x79, 0x74, 0x69, 0x63, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x42,
+	0x61, 0x74, 0x63, 0x68, 0x54, 0x72, 0x61, 0x63, 0x6b, 0x54, 0x61, 0x73, 0x6b, 0x45, 0x76, 0x65,
+	0x6e, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x5e, 0x0a, 0x0f, 0x47,
+	0x65, 0x74, 0x44, 0x61, 0x69, 0x6c, 0x79, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x12, 0x24,
+	0x2e, 0x61, 0x6e, 0x61, 0x6c, 0x79, 0x74, 0x69, 0x63, 0x73, 0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65,
+	0x74, 0x44, 0x61, 0x69, 0x6c, 0x79, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x25, 0x2e, 0x61, 0x6e, 0x61, 0x6c, 0x79, 0x74, 0x69, 0x63, 0x73,
+	0x2e, 0x76, 0x31, 0x2e, 0x47, 0x65, 0x74, 0x44, 0x61, 0x69, 0x6c, 0x79, 0x4d, 0x65, 0x74, 0x72,
+	0x69, 0x63, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x2c, 0x5a, 0x2a, 0x74,
+	0x6f, 0x64, 0x6f, 0x61, 0x70, 0x70, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2f, 0x61, 0x6e, 0x61, 0x6c, 0x79, 0x74, 0x69, 0x63, 0x73, 0x2f, 0x76, 0x31, 0x3b, 0x61, 0x6e,
+	0x61, 0x6c, 0x79, 0x74, 0x69, 0x63, 0x73, 0x76, 0x31, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
 }
 
 var (
@@ -460,27 +631,32 @@ func file_proto_analytics_v1_analytics_proto_rawDescGZIP() []byte {
 }
 
 var file_proto_analytics_v1_analytics_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_proto_analytics_v1_analytics_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_proto_analytics_v1_analytics_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
 var file_proto_analytics_v1_analytics_proto_goTypes = []any{
-	(TaskEventType)(0),              // 0: analytics.v1.TaskEventType
-	(*TrackTaskEventRequest)(nil),   // 1: analytics.v1.TrackTaskEventRequest
-	(*TrackTaskEventResponse)(nil),  // 2: analytics.v1.TrackTaskEventResponse
-	(*DailyTaskMetrics)(nil),        // 3: analytics.v1.DailyTaskMetrics
-	(*GetDailyMetricsRequest)(nil),  // 4: analytics.v1.GetDailyMetricsRequest
-	(*GetDailyMetricsResponse)(nil), // 5: analytics.v1.GetDailyMetricsResponse
+	(TaskEventType)(0),                   // 0: analytics.v1.TaskEventType
+	(*TrackTaskEventRequest)(nil),        // 1: analytics.v1.TrackTaskEventRequest
+	(*TrackTaskEventResponse)(nil),       // 2: analytics.v1.TrackTaskEventResponse
+	(*BatchTrackTaskEventsRequest)(nil),  // 3: analytics.v1.BatchTrackTaskEventsRequest
+	(*BatchTrackTaskEventsResponse)(nil), // 4: analytics.v1.BatchTrackTaskEventsResponse
+	(*DailyTaskMetrics)(nil),             // 5: analytics.v1.DailyTaskMetrics
+	(*GetDailyMetricsRequest)(nil),       // 6: analytics.v1.GetDailyMetricsRequest
+	(*GetDailyMetricsResponse)(nil),      // 7: analytics.v1.GetDailyMetricsResponse
 }
 var file_proto_analytics_v1_analytics_proto_depIdxs = []int32{
 	0, // 0: analytics.v1.TrackTaskEventRequest.type:type_name -> analytics.v1.TaskEventType
-	3, // 1: analytics.v1.GetDailyMetricsResponse.metrics:type_name -> analytics.v1.DailyTaskMetrics
-	1, // 2: analytics.v1.AnalyticsService.TrackTaskEvent:input_type -> analytics.v1.TrackTaskEventRequest
-	4, // 3: analytics.v1.AnalyticsService.GetDailyMetrics:input_type -> analytics.v1.GetDailyMetricsRequest
-	2, // 4: analytics.v1.AnalyticsService.TrackTaskEvent:output_type -> analytics.v1.TrackTaskEventResponse
-	5, // 5: analytics.v1.AnalyticsService.GetDailyMetrics:output_type -> analytics.v1.GetDailyMetricsResponse
-	4, // [4:6] is the sub-list for method output_type
-	2, // [2:4] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	1, // 1: analytics.v1.BatchTrackTaskEventsRequest.events:type_name -> analytics.v1.TrackTaskEventRequest
+	5, // 2: analytics.v1.GetDailyMetricsResponse.metrics:type_name -> analytics.v1.DailyTaskMetrics
+	1, // 3: analytics.v1.AnalyticsService.TrackTaskEvent:input_type -> analytics.v1.TrackTaskEventRequest
+	3, // 4: analytics.v1.AnalyticsService.BatchTrackTaskEvents:input_type -> analytics.v1.BatchTrackTaskEventsRequest
+	6, // 5: analytics.v1.AnalyticsService.GetDailyMetrics:input_type -> analytics.v1.GetDailyMetricsRequest
+	2, // 6: analytics.v1.AnalyticsService.TrackTaskEvent:output_type -> analytics.v1.TrackTaskEventResponse
+	4, // 7: analytics.v1.AnalyticsService.BatchTrackTaskEvents:output_type -> analytics.v1.BatchTrackTaskEventsResponse
+	7, // 8: analytics.v1.AnalyticsService.GetDailyMetrics:output_type -> analytics.v1.GetDailyMetricsResponse
+	6, // [6:9] is the sub-list for method output_type
+	3, // [3:6] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
 }
 
 func init() { file_proto_analytics_v1_analytics_proto_init() }
@@ -514,7 +690,7 @@ func file_proto_analytics_v1_analytics_proto_init() {
 			}
 		}
 		file_proto_analytics_v1_analytics_proto_msgTypes[2].Exporter = func(v any, i int) any {
-			switch v := v.(*DailyTaskMetrics); i {
+			switch v := v.(*BatchTrackTaskEventsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -526,7 +702,7 @@ func file_proto_analytics_v1_analytics_proto_init() {
 			}
 		}
 		file_proto_analytics_v1_analytics_proto_msgTypes[3].Exporter = func(v any, i int) any {
-			switch v := v.(*GetDailyMetricsRequest); i {
+			switch v := v.(*BatchTrackTaskEventsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -538,6 +714,30 @@ func file_proto_analytics_v1_analytics_proto_init() {
 			}
 		}
 		file_proto_analytics_v1_analytics_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*DailyTaskMetrics); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_analytics_v1_analytics_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*GetDailyMetricsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_analytics_v1_analytics_proto_msgTypes[6].Exporter = func(v any, i int) any {
 			switch v := v.(*GetDailyMetricsResponse); i {
 			case 0:
 				return &v.state
@@ -556,7 +756,7 @@ func file_proto_analytics_v1_analytics_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_proto_analytics_v1_analytics_proto_rawDesc,
 			NumEnums:      1,
-			NumMessages:   5,
+			NumMessages:   7,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,8 +19,9 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	AnalyticsService_TrackTaskEvent_FullMethodName  = "/analytics.v1.AnalyticsService/TrackTaskEvent"
-	AnalyticsService_GetDailyMetrics_FullMethodName = "/analytics.v1.AnalyticsService/GetDailyMetrics"
+	AnalyticsService_TrackTaskEvent_FullMethodName       = "/analytics.v1.AnalyticsService/TrackTaskEvent"
+	AnalyticsService_BatchTrackTaskEvents_FullMethodName = "/analytics.v1.AnalyticsService/BatchTrackTaskEvents"
+	AnalyticsService_GetDailyMetrics_FullMethodName      = "/analytics.v1.AnalyticsService/GetDailyMetrics"
 )
 
 // AnalyticsServiceClient is the client API for AnalyticsService service.
@@ -28,6 +29,9 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type AnalyticsServiceClient interface {
 	TrackTaskEvent(ctx context.Context, in *TrackTaskEventRequest, opts ...grpc.CallOption) (*TrackTaskEventResponse, error)
+	// BatchTrackTaskEvents applies events atomically: either every valid event
+	// of the batch is counted or none is.
+	BatchTrackTaskEvents(ctx context.Context, in *BatchTrackTaskEventsRequest, opts ...grpc.CallOption) (*BatchTrackTaskEventsResponse, error)
 	GetDailyMetrics(ctx context.Context, in *GetDailyMetricsRequest, opts ...grpc.CallOption) (*GetDailyMetricsResponse, error)
 }
 
@@ -49,6 +53,16 @@ func (c *analyticsServiceClient) TrackTaskEvent(ctx context.Context, in *TrackTa
 	return out, nil
 }
 
+func (c *analyticsServiceClient) BatchTrackTaskEvents(ctx context.Context, in *BatchTrackTaskEventsRequest, opts ...grpc.CallOption) (*BatchTrackTaskEventsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchTrackTaskEventsResponse)
+	err := c.cc.Invoke(ctx, AnalyticsService_BatchTrackTaskEvents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *analyticsServiceClient) GetDailyMetrics(ctx context.Context, in *GetDailyMetricsRequest, opts ...grpc.CallOption) (*GetDailyMetricsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(GetDailyMetricsResponse)
@@ -64,6 +78,9 @@ func (c *analyticsServiceClient) GetDailyMetrics(ctx context.Context, in *GetDai
 // for forward compatibility.
 type AnalyticsServiceServer interface {
 	TrackTaskEvent(context.Context, *TrackTaskEventRequest) (*TrackTaskEventResponse, error)
+	// BatchTrackTaskEvents applies events atomically: either every valid event
+	// of the batch is counted or none is.
+	BatchTrackTaskEvents(context.Context, *BatchTrackTaskEventsRequest) (*BatchTrackTaskEventsResponse, error)
 	GetDailyMetrics(context.Context, *GetDailyMetricsRequest) (*GetDailyMetricsResponse, error)
 	mustEmbedUnimplementedAnalyticsServiceServer()
 }
@@ -78,6 +95,9 @@ type UnimplementedAnalyticsServiceServer struct{}
 func (UnimplementedAnalyticsServiceServer) TrackTaskEvent(context.Context, *TrackTaskEventRequest) (*TrackTaskEventResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method TrackTaskEvent not implemented")
 }
+func (UnimplementedAnalyticsServiceServer) BatchTrackTaskEvents(context.Context, *BatchTrackTaskEventsRequest) (*BatchTrackTaskEventsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchTrackTaskEvents not implemented")
+}
 func (UnimplementedAnalyticsServiceServer) GetDailyMetrics(context.Context, *GetDailyMetricsRequest) (*GetDailyMetricsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetDailyMetrics not implemented")
 }
@@ -120,6 +140,24 @@ func _AnalyticsService_TrackTaskEvent_Handler(srv interface{}, ctx context.Conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AnalyticsService_BatchTrackTaskEvents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchTrackTaskEventsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AnalyticsServiceServer).BatchTrackTaskEvents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AnalyticsService_BatchTrackTaskEvents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AnalyticsServiceServer).BatchTrackTaskEvents(ctx, req.(*BatchTrackTaskEventsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _AnalyticsService_GetDailyMetrics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetDailyMetricsRequest)
 	if err := dec(in); err != nil {
@@ -149,6 +187,10 @@ var AnalyticsService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "TrackTaskEvent",
 			Handler:    _AnalyticsService_TrackTaskEvent_Handler,
 		},
+		{
+			MethodName: "BatchTrackTaskEvents",
+			Handler:    _AnalyticsService_BatchTrackTaskEvents_Handler,
+		},
 		{
 			MethodName: "GetDailyMetrics",
 			Handler:    _AnalyticsService_GetDailyMetrics_Handler,
@@ -0,0 +1,93 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// MemoryLimiter is a token bucket per key: a bucket holds up to Limit tokens
+// and refills at Limit per Window, so short bursts are allowed as long as the
+// average rate stays within the rule. Limits apply to this process only.
+type MemoryLimiter struct {
+	rule    Rule
+	now     func() time.Time
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	swept   time.Time
+}
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+}
+
+func NewMemoryLimiter(rule Rule, opts ...Option) *MemoryLimiter {
+	o := applyOptions(opts)
+
+	return &MemoryLimiter{
+		rule:    rule,
+		now:     o.now,
+		buckets: make(map[string]*bucket),
+		swept:   o.now(),
+	}
+}
+
+func (l *MemoryLimiter) Rule() Rule {
+	return l.rule
+}
+
+func (l *MemoryLimiter) Allow(_ context.Context, key string) (Result, error) {
+	if !l.rule.valid() {
+		return Result{Allowed: true}, nil
+	}
+
+	now := l.now()
+	limit := float64(l.rule.Limit)
+	perToken := l.rule.Window / time.Duration(l.rule.Limit)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.sweep(now)
+
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: limit, updated: now}
+		l.buckets[key] = b
+	}
+
+	if elapsed := now.Sub(b.updated); elapsed > 0 {
+		b.tokens = math.Min(limit, b.tokens+float64(elapsed)/float64(perToken))
+		b.updated = now
+	}
+
+	result := Result{Limit: l.rule.Limit}
+	if b.tokens >= 1 {
+		b.tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = time.Duration((1 - b.tokens) * float64(perToken))
+	}
+
+	result.Remaining = int(b.tokens)
+	result.ResetAfter = time.Duration((limit - b.tokens) * float64(perToken))
+
+	return result, nil
+}
+
+// sweep drops buckets that have refilled completely, since they are
+// indistinguishable from new ones. It runs at most once per window.
+func (l *MemoryLimiter) sweep(now time.Time) {
+	if now.Sub(l.swept) < l.rule.Window {
+		return
+	}
+	l.swept = now
+
+	for key, b := range l.buckets {
+		if now.Sub(b.updated) >= l.rule.Window {
+			delete(l.buckets, key)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package ratelimit
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"todoapp/pkg/errors"
+)
+
+// KeyFunc returns the key a request is counted against. An empty key skips
+// the limit for that request.
+type KeyFunc func(ctx *gin.Context) string
+
+// ClientIP counts requests per client address.
+func ClientIP(ctx *gin.Context) string {
+	return "ip:" + ctx.ClientIP()
+}
+
+// UserKey is the key for requests made by an authenticated user.
+func UserKey(userID int64) string {
+	return "user:" + strconv.FormatInt(userID, 10)
+}
+
+// Middleware rejects requests over the limit with 429 TOO_MANY_REQUESTS and
+// reports the state of the limit in RateLimit-* headers. If the limiter
+// fails, the request is let through.
+func Middleware(limiter Limiter, key KeyFunc) gin.HandlerFunc {
+	if limiter == nil || !limiter.Rule().valid() {
+		return func(ctx *gin.Context) {
+			ctx.Next()
+		}
+	}
+
+	policy := strconv.Itoa(limiter.Rule().Limit) + ";w=" + strconv.Itoa(seconds(limiter.Rule().Window))
+
+	return func(ctx *gin.Context) {
+		k := key(ctx)
+		if k == "" {
+			ctx.Next()
+			return
+		}
+
+		result, err := limiter.Allow(ctx.Request.Context(), k)
+		if err != nil {
+			ctx.Next()
+			return
+		}
+
+		header := ctx.Writer.Header()
+		header.Set("RateLimit-Policy", policy)
+		header.Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+		header.Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		header.Set("RateLimit-Reset", strconv.Itoa(seconds(result.ResetAfter)))
+
+		if !result.Allowed {
+			header.Set("Retry-After", strconv.Itoa(max(seconds(result.RetryAfter), 1)))
+			ctx.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+				"error":   errors.CodeTooManyRequests,
+				"message": errors.ErrTooManyRequests.Error(),
+			})
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+func seconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,126 @@
+// Package ratelimit limits how often a key, such as a user or a client IP,
+// may hit the API. Limits are shared between instances through Redis; without
+// Redis, or while it is unreachable, each instance enforces them on its own.
+package ratelimit
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Rule allows Limit requests per Window.
+type Rule struct {
+	Limit  int
+	Window time.Duration
+}
+
+func (r Rule) valid() bool {
+	return r.Limit > 0 && r.Window > 0
+}
+
+// Result describes the state of a key after a request was counted.
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// ResetAfter is the time until the key has its full limit again.
+	ResetAfter time.Duration
+	// RetryAfter is the time until the next request would be allowed. It is
+	// zero when Allowed is true.
+	RetryAfter time.Duration
+}
+
+type Limiter interface {
+	Allow(ctx context.Context, key string) (Result, error)
+	Rule() Rule
+}
+
+type Option func(*options)
+
+type options struct {
+	prefix string
+	logger *slog.Logger
+	now    func() time.Time
+}
+
+// WithPrefix namespaces the keys, so several rules can share one Redis.
+func WithPrefix(prefix string) Option {
+	return func(o *options) {
+		if prefix != "" {
+			o.prefix = prefix
+		}
+	}
+}
+
+func WithLogger(logger *slog.Logger) Option {
+	return func(o *options) {
+		if logger != nil {
+			o.logger = logger
+		}
+	}
+}
+
+func withClock(now func() time.Time) Option {
+	return func(o *options) {
+		o.now = now
+	}
+}
+
+// New returns a Redis limiter that falls back to an in-process limiter when
+// Redis fails, or only the in-process limiter when client is nil.
+func New(client redis.Scripter, rule Rule, opts ...Option) Limiter {
+	memory := NewMemoryLimiter(rule, opts...)
+	if client == nil {
+		return memory
+	}
+
+	return &fallbackLimiter{
+		primary:   NewRedisLimiter(client, rule, opts...),
+		secondary: memory,
+		logger:    applyOptions(opts).logger,
+	}
+}
+
+func applyOptions(opts []Option) options {
+	o := options{
+		prefix: "ratelimit",
+		logger: slog.Default(),
+		now:    time.Now,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// fallbackLogInterval keeps a Redis outage from logging on every request.
+const fallbackLogInterval = time.Minute
+
+type fallbackLimiter struct {
+	primary   Limiter
+	secondary Limiter
+	logger    *slog.Logger
+	lastLog   atomic.Int64
+}
+
+func (l *fallbackLimiter) Allow(ctx context.Context, key string) (Result, error) {
+	result, err := l.primary.Allow(ctx, key)
+	if err == nil {
+		return result, nil
+	}
+
+	now := time.Now().UnixNano()
+	if last := l.lastLog.Load(); now-last >= int64(fallbackLogInterval) && l.lastLog.CompareAndSwap(last, now) {
+		l.logger.WarnContext(ctx, "ratelimit: redis unavailable, using in-process limits", "error", err)
+	}
+
+	return l.secondary.Allow(ctx, key)
+}
+
+func (l *fallbackLimiter) Rule() Rule {
+	return l.primary.Rule()
+}
@@ -0,0 +1,193 @@
+package ratelimit
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/gin-gonic/gin"
+	"github.com/redis/go-redis/v9"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2024, 12, 10, 9, 0, 0, 0, time.UTC)}
+}
+
+func allowN(t *testing.T, limiter Limiter, key string, n int) Result {
+	t.Helper()
+
+	var result Result
+	for i := range n {
+		var err error
+		result, err = limiter.Allow(context.Background(), key)
+		if err != nil {
+			t.Fatalf("request %d: unexpected error: %v", i+1, err)
+		}
+		if !result.Allowed {
+			t.Fatalf("request %d: expected to be allowed", i+1)
+		}
+	}
+
+	return result
+}
+
+func TestMemoryLimiter(t *testing.T) {
+	clock := newFakeClock()
+	limiter := NewMemoryLimiter(Rule{Limit: 3, Window: time.Minute}, withClock(clock.Now))
+
+	result := allowN(t, limiter, "user:1", 3)
+	if result.Remaining != 0 {
+		t.Fatalf("expected no remaining requests, got %d", result.Remaining)
+	}
+
+	result, _ = limiter.Allow(context.Background(), "user:1")
+	if result.Allowed || result.RetryAfter != 20*time.Second {
+		t.Fatalf("expected denial with 20s retry, got %+v", result)
+	}
+
+	allowN(t, limiter, "user:2", 1)
+
+	clock.Advance(20 * time.Second)
+	allowN(t, limiter, "user:1", 1)
+
+	result, _ = limiter.Allow(context.Background(), "user:1")
+	if result.Allowed {
+		t.Fatalf("expected a single token after 20s")
+	}
+}
+
+func TestMemoryLimiterSweepsIdleBuckets(t *testing.T) {
+	clock := newFakeClock()
+	limiter := NewMemoryLimiter(Rule{Limit: 1, Window: time.Second}, withClock(clock.Now))
+
+	allowN(t, limiter, "user:1", 1)
+	clock.Advance(2 * time.Second)
+	allowN(t, limiter, "user:2", 1)
+
+	if _, ok := limiter.buckets["user:1"]; ok {
+		t.Fatalf("expected idle bucket to be removed")
+	}
+}
+
+func TestRedisLimiter(t *testing.T) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	clock := newFakeClock()
+	limiter := NewRedisLimiter(client, Rule{Limit: 2, Window: time.Minute}, WithPrefix("test"), withClock(clock.Now))
+
+	allowN(t, limiter, "user:1", 1)
+	clock.Advance(30 * time.Second)
+	result := allowN(t, limiter, "user:1", 1)
+	if result.Remaining != 0 || result.ResetAfter != 30*time.Second {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+
+	result, err := limiter.Allow(context.Background(), "user:1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Allowed || result.RetryAfter != 30*time.Second {
+		t.Fatalf("expected denial with 30s retry, got %+v", result)
+	}
+	if !server.Exists("test:user:1") {
+		t.Fatalf("expected prefixed key in redis")
+	}
+
+	clock.Advance(30 * time.Second)
+	allowN(t, limiter, "user:1", 1)
+}
+
+func TestNewFallsBackToMemory(t *testing.T) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr(), MaxRetries: -1})
+	t.Cleanup(func() { _ = client.Close() })
+
+	limiter := New(client, Rule{Limit: 1, Window: time.Minute}, WithLogger(slog.New(slog.DiscardHandler)))
+	allowN(t, limiter, "user:1", 1)
+
+	server.Close()
+
+	result, err := limiter.Allow(context.Background(), "user:2")
+	if err != nil || !result.Allowed {
+		t.Fatalf("expected in-process limiter to allow, got %+v, %v", result, err)
+	}
+	result, _ = limiter.Allow(context.Background(), "user:2")
+	if result.Allowed {
+		t.Fatalf("expected in-process limiter to enforce the rule")
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	limiter := NewMemoryLimiter(Rule{Limit: 1, Window: time.Minute})
+	router := gin.New()
+	router.Use(Middleware(limiter, ClientIP))
+	router.GET("/ping", func(ctx *gin.Context) {
+		ctx.Status(http.StatusNoContent)
+	})
+
+	request := func() *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/ping", nil)
+		req.RemoteAddr = "10.0.0.1:1234"
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := request()
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d", rec.Code)
+	}
+	if rec.Header().Get("RateLimit-Limit") != "1" || rec.Header().Get("RateLimit-Remaining") != "0" {
+		t.Fatalf("unexpected headers: %v", rec.Header())
+	}
+	if rec.Header().Get("RateLimit-Policy") != "1;w=60" {
+		t.Fatalf("unexpected policy: %q", rec.Header().Get("RateLimit-Policy"))
+	}
+
+	rec = request()
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected 429, got %d", rec.Code)
+	}
+	if rec.Header().Get("Retry-After") != "60" || rec.Header().Get("RateLimit-Reset") != "60" {
+		t.Fatalf("unexpected headers: %v", rec.Header())
+	}
+}
+
+func TestMiddlewareSkipsEmptyKey(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	limiter := NewMemoryLimiter(Rule{Limit: 1, Window: time.Minute})
+	router := gin.New()
+	router.Use(Middleware(limiter, func(*gin.Context) string { return "" }))
+	router.GET("/ping", func(ctx *gin.Context) {
+		ctx.Status(http.StatusNoContent)
+	})
+
+	for range 3 {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/ping", nil))
+		if rec.Code != http.StatusNoContent {
+			t.Fatalf("expected 204, got %d", rec.Code)
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// slidingWindowScript keeps the timestamps of the requests in the last window
+// in a sorted set. It returns whether the request was allowed, the number of
+// requests in the window and the milliseconds until the oldest one expires.
+var slidingWindowScript = redis.NewScript(`
+local key = KEYS[1]
+local now = tonumber(ARGV[1])
+local window = tonumber(ARGV[2])
+local limit = tonumber(ARGV[3])
+
+redis.call('ZREMRANGEBYSCORE', key, '-inf', now - window)
+
+local count = redis.call('ZCARD', key)
+local allowed = 0
+if count < limit then
+	redis.call('ZADD', key, now, ARGV[4])
+	count = count + 1
+	allowed = 1
+end
+redis.call('PEXPIRE', key, window)
+
+local reset = window
+local oldest = redis.call('ZRANGE', key, 0, 0, 'WITHSCORES')
+if oldest[2] then
+	reset = tonumber(oldest[2]) + window - now
+end
+
+return {allowed, count, reset}
+`)
+
+// RedisLimiter is a sliding window log shared by every instance using the
+// same Redis: a key may make Limit requests in any Window-long interval.
+type RedisLimiter struct {
+	client redis.Scripter
+	rule   Rule
+	prefix string
+	now    func() time.Time
+}
+
+func NewRedisLimiter(client redis.Scripter, rule Rule, opts ...Option) *RedisLimiter {
+	o := applyOptions(opts)
+
+	return &RedisLimiter{
+		client: client,
+		rule:   rule,
+		prefix: o.prefix,
+		now:    o.now,
+	}
+}
+
+func (l *RedisLimiter) Rule() Rule {
+	return l.rule
+}
+
+func (l *RedisLimiter) Allow(ctx context.Context, key string) (Result, error) {
+	if !l.rule.valid() {
+		return Result{Allowed: true}, nil
+	}
+
+	now := l.now().UnixMilli()
+	window := max(l.rule.Window.Milliseconds(), 1)
+
+	values, err := slidingWindowScript.Run(ctx, l.client,
+		[]string{l.prefix + ":" + key},
+		now, window, l.rule.Limit, fmt.Sprintf("%d-%s", now, uuid.NewString()),
+	).Int64Slice()
+	if err != nil {
+		return Result{}, fmt.Errorf("ratelimit: run script: %w", err)
+	}
+	if len(values) != 3 {
+		return Result{}, fmt.Errorf("ratelimit: unexpected script result %v", values)
+	}
+
+	result := Result{
+		Allowed:    values[0] == 1,
+		Limit:      l.rule.Limit,
+		Remaining:  max(l.rule.Limit-int(values[1]), 0),
+		ResetAfter: time.Duration(values[2]) * time.Millisecond,
+	}
+	if !result.Allowed {
+		result.RetryAfter = result.ResetAfter
+	}
+
+	return result, nil
+}
@@ -0,0 +1,119 @@
+// Package requestid carries the ID of the request that started some work
+// across HTTP, gRPC and RabbitMQ, so the logs of every service involved can be
+// tied back to it.
+package requestid
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Header is the HTTP header and AMQP message header holding the ID.
+const Header = "X-Request-ID"
+
+// metadataKey is Header as gRPC metadata, whose keys are lowercase.
+const metadataKey = "x-request-id"
+
+// maxLength bounds IDs accepted from clients, which end up in every log line.
+const maxLength = 128
+
+type contextKey struct{}
+
+func New() string {
+	return uuid.NewString()
+}
+
+func WithContext(ctx context.Context, id string) context.Context {
+	if id == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext returns the request ID of ctx, or "" when there is none.
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
+
+// Valid reports whether an ID received from elsewhere can be used as is.
+func Valid(id string) bool {
+	if id == "" || len(id) > maxLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if c := id[i]; c <= ' ' || c > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// Headers returns the message headers carrying the request ID of ctx, for
+// AMQP publishings, or nil when there is none.
+func Headers(ctx context.Context) map[string]any {
+	id := FromContext(ctx)
+	if id == "" {
+		return nil
+	}
+	return map[string]any{Header: id}
+}
+
+// FromHeaders returns the request ID in AMQP message headers, or "" when it
+// is missing or invalid.
+func FromHeaders(headers map[string]any) string {
+	id, _ := headers[Header].(string)
+	if !Valid(id) {
+		return ""
+	}
+	return id
+}
+
+// Middleware keeps a valid X-Request-ID sent by the client and generates one
+// otherwise. The ID is stored in the request context and echoed in the
+// response.
+func Middleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id := ctx.GetHeader(Header)
+		if !Valid(id) {
+			id = New()
+		}
+
+		ctx.Header(Header, id)
+		ctx.Request = ctx.Request.WithContext(WithContext(ctx.Request.Context(), id))
+		ctx.Next()
+	}
+}
+
+// UnaryClientInterceptor sends the request ID of the call context as gRPC
+// metadata.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if id := FromContext(ctx); id != "" {
+			ctx = metadata.AppendToOutgoingContext(ctx, metadataKey, id)
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// UnaryServerInterceptor stores the request ID sent by the caller in the
+// handler context, generating one when the caller sent none.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		var id string
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(metadataKey); len(values) > 0 {
+				id = values[0]
+			}
+		}
+		if !Valid(id) {
+			id = New()
+		}
+
+		return handler(WithContext(ctx, id), req)
+	}
+}
@@ -0,0 +1,99 @@
+package requestid
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestMiddlewareKeepsValidID(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name   string
+		header string
+		keep   bool
+	}{
+		{name: "valid", header: "abc-123", keep: true},
+		{name: "missing", header: ""},
+		{name: "spaces", header: "a b"},
+		{name: "too long", header: strings.Repeat("a", maxLength+1)},
+	}
+
+	for _, tt := range tests {
+		var got string
+		router := gin.New()
+		router.Use(Middleware())
+		router.GET("/", func(c *gin.Context) {
+			got = FromContext(c.Request.Context())
+			c.Status(http.StatusOK)
+		})
+
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if tt.header != "" {
+			req.Header.Set(Header, tt.header)
+		}
+		router.ServeHTTP(rec, req)
+
+		if got == "" || rec.Header().Get(Header) != got {
+			t.Fatalf("%s: expected the ID in context and response, got %q and %q", tt.name, got, rec.Header().Get(Header))
+		}
+		if (got == tt.header) != tt.keep {
+			t.Fatalf("%s: unexpected ID %q", tt.name, got)
+		}
+	}
+}
+
+func TestGRPCPropagation(t *testing.T) {
+	var sent metadata.MD
+	invoker := func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		sent, _ = metadata.FromOutgoingContext(ctx)
+		return nil
+	}
+
+	ctx := WithContext(context.Background(), "abc-123")
+	if err := UnaryClientInterceptor()(ctx, "/svc/Method", nil, nil, nil, invoker); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var received string
+	handler := func(ctx context.Context, req any) (any, error) {
+		received = FromContext(ctx)
+		return nil, nil
+	}
+	incoming := metadata.NewIncomingContext(context.Background(), sent)
+	if _, err := UnaryServerInterceptor()(incoming, nil, &grpc.UnaryServerInfo{}, handler); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if received != "abc-123" {
+		t.Fatalf("expected propagated ID, got %q", received)
+	}
+
+	if _, err := UnaryServerInterceptor()(context.Background(), nil, &grpc.UnaryServerInfo{}, handler); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if received == "" {
+		t.Fatalf("expected an ID to be generated")
+	}
+}
+
+func TestHeaders(t *testing.T) {
+	if Headers(context.Background()) != nil {
+		t.Fatalf("expected no headers without an ID")
+	}
+
+	headers := Headers(WithContext(context.Background(), "abc-123"))
+	if got := FromHeaders(headers); got != "abc-123" {
+		t.Fatalf("expected ID to round-trip, got %q", got)
+	}
+	if got := FromHeaders(map[string]any{Header: 42}); got != "" {
+		t.Fatalf("expected non-string header to be ignored, got %q", got)
+	}
+}
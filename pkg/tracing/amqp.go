@@ -0,0 +1,87 @@
+package tracing
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// HeaderCarrier adapts AMQP message headers to the propagation API.
+type HeaderCarrier map[string]any
+
+func (c HeaderCarrier) Get(key string) string {
+	value, _ := c[key].(string)
+	return value
+}
+
+func (c HeaderCarrier) Set(key, value string) {
+	c[key] = value
+}
+
+func (c HeaderCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// InjectHeaders adds the trace context of ctx to headers, allocating them when
+// nil, and returns them.
+func InjectHeaders(ctx context.Context, headers map[string]any) map[string]any {
+	if headers == nil {
+		headers = make(map[string]any)
+	}
+	otel.GetTextMapPropagator().Inject(ctx, HeaderCarrier(headers))
+	return headers
+}
+
+// ExtractHeaders returns ctx joined to the trace context found in headers.
+func ExtractHeaders(ctx context.Context, headers map[string]any) context.Context {
+	if headers == nil {
+		return ctx
+	}
+	return otel.GetTextMapPropagator().Extract(ctx, HeaderCarrier(headers))
+}
+
+// StartPublish starts the producer span of a message sent to destination, a
+// queue or an exchange. The returned context should be passed to
+// InjectHeaders.
+func StartPublish(ctx context.Context, destination string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	attrs = append(attrs,
+		semconv.MessagingSystemRabbitMQ,
+		semconv.MessagingOperationTypeSend,
+		semconv.MessagingDestinationName(destination),
+	)
+	return tracer().Start(ctx, "send "+destination,
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(attrs...),
+	)
+}
+
+// StartConsume starts the consumer span of a message received from
+// destination, as a child of the span that published it.
+func StartConsume(ctx context.Context, destination string, headers map[string]any, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	attrs = append(attrs,
+		semconv.MessagingSystemRabbitMQ,
+		semconv.MessagingOperationTypeProcess,
+		semconv.MessagingDestinationName(destination),
+	)
+	return tracer().Start(ExtractHeaders(ctx, headers), "process "+destination,
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(attrs...),
+	)
+}
+
+// End records err on span, if any, and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,18 @@
+package tracing
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+)
+
+// Middleware starts a server span per request, continuing the trace of the
+// caller. Health checks and metric scrapes are not traced, as they would drown
+// everything else.
+func Middleware(service string) gin.HandlerFunc {
+	return otelgin.Middleware(service, otelgin.WithFilter(func(r *http.Request) bool {
+		return !strings.HasPrefix(r.URL.Path, "/health") && r.URL.Path != "/metrics"
+	}))
+}
@@ -0,0 +1,167 @@
+// Package tracing sets up OpenTelemetry for the services and holds the
+// instrumentation the contrib packages do not cover: RabbitMQ messages and
+// pgx queries. Trace context crosses process boundaries in W3C format, as
+// HTTP headers, gRPC metadata and AMQP message headers.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	ExporterNone   = "none"
+	ExporterOTLP   = "otlp"
+	ExporterStdout = "stdout"
+	ExporterFile   = "file"
+)
+
+// instrumentationName names the tracer of the spans started by this package.
+const instrumentationName = "todoapp/pkg/tracing"
+
+type Config struct {
+	// Exporter is one of the Exporter constants; empty means ExporterNone.
+	Exporter string
+	// Endpoint is the host:port of the OTLP gRPC collector.
+	Endpoint string
+	Insecure bool
+	// FilePath is where ExporterFile writes spans, one JSON document each.
+	FilePath string
+	// SampleRatio is the share of new traces recorded, 1 when zero. Traces
+	// started upstream follow the caller's decision.
+	SampleRatio float64
+}
+
+// Setup installs the global tracer provider and W3C propagators, and returns
+// the function flushing pending spans on shutdown. With ExporterNone spans
+// are not recorded, but trace context is still propagated.
+func Setup(ctx context.Context, service string, cfg Config) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	exporter, closer, err := newExporter(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if exporter == nil {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(service),
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	ratio := cfg.SampleRatio
+	if ratio <= 0 {
+		ratio = 1
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(ratio))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return func(ctx context.Context) error {
+		err := provider.Shutdown(ctx)
+		if closer != nil {
+			if closeErr := closer.Close(); err == nil {
+				err = closeErr
+			}
+		}
+		return err
+	}, nil
+}
+
+func newExporter(ctx context.Context, cfg Config) (sdktrace.SpanExporter, io.Closer, error) {
+	switch cfg.Exporter {
+	case "", ExporterNone:
+		return nil, nil, nil
+	case ExporterOTLP:
+		opts := []otlptracegrpc.Option{}
+		if cfg.Endpoint != "" {
+			opts = append(opts, otlptracegrpc.WithEndpoint(cfg.Endpoint))
+		}
+		if cfg.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		exporter, err := otlptracegrpc.New(ctx, opts...)
+		return exporter, nil, err
+	case ExporterStdout:
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+		return exporter, nil, err
+	case ExporterFile:
+		if cfg.FilePath == "" {
+			return nil, nil, fmt.Errorf("tracing: file path is required for the %s exporter", ExporterFile)
+		}
+		file, err := os.OpenFile(cfg.FilePath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return nil, nil, err
+		}
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(file))
+		if err != nil {
+			file.Close()
+			return nil, nil, err
+		}
+		return exporter, file, nil
+	default:
+		return nil, nil, fmt.Errorf("tracing: unknown exporter %q", cfg.Exporter)
+	}
+}
+
+func tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+func UserID(userID int64) attribute.KeyValue {
+	return attribute.Int64("user.id", userID)
+}
+
+func TaskID(taskID int64) attribute.KeyValue {
+	return attribute.Int64("task.id", taskID)
+}
+
+// SetUserID tags the current span with the user the work is done for.
+func SetUserID(ctx context.Context, userID int64) {
+	trace.SpanFromContext(ctx).SetAttributes(UserID(userID))
+}
+
+// SetTaskID tags the current span with the task the work is about.
+func SetTaskID(ctx context.Context, taskID int64) {
+	trace.SpanFromContext(ctx).SetAttributes(TaskID(taskID))
+}
+
+// TraceParent returns the W3C traceparent of the span in ctx, or "" when
+// there is none, for work stored now and carried out later.
+func TraceParent(ctx context.Context) string {
+	carrier := propagation.MapCarrier{}
+	propagation.TraceContext{}.Inject(ctx, carrier)
+	return carrier.Get("traceparent")
+}
+
+// WithTraceParent returns ctx continuing the trace of a TraceParent value.
+func WithTraceParent(ctx context.Context, traceParent string) context.Context {
+	if traceParent == "" {
+		return ctx
+	}
+	return propagation.TraceContext{}.Extract(ctx, propagation.MapCarrier{"traceparent": traceParent})
+}
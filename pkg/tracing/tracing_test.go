@@ -0,0 +1,112 @@
+package tracing
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func useRecorder(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	if _, err := Setup(context.Background(), "test", Config{}); err != nil {
+		t.Fatalf("setup: %v", err)
+	}
+
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+	return recorder
+}
+
+func TestMessageSpansShareTrace(t *testing.T) {
+	recorder := useRecorder(t)
+
+	ctx, publish := StartPublish(context.Background(), "task_events")
+	headers := InjectHeaders(ctx, map[string]any{"X-Request-ID": "req-1"})
+	publish.End()
+
+	if headers["X-Request-ID"] != "req-1" {
+		t.Fatalf("expected existing headers to be kept, got %v", headers)
+	}
+	if _, ok := headers["traceparent"].(string); !ok {
+		t.Fatalf("expected traceparent header, got %v", headers)
+	}
+
+	_, consume := StartConsume(context.Background(), "task_events", headers)
+	consume.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+	if spans[1].SpanKind() != trace.SpanKindConsumer {
+		t.Fatalf("expected consumer span, got %v", spans[1].SpanKind())
+	}
+	if spans[1].Parent().SpanID() != spans[0].SpanContext().SpanID() {
+		t.Fatalf("expected consumer span to be a child of the publish span")
+	}
+}
+
+func TestExtractHeadersWithoutContext(t *testing.T) {
+	useRecorder(t)
+
+	ctx := ExtractHeaders(context.Background(), nil)
+	if trace.SpanContextFromContext(ctx).IsValid() {
+		t.Fatalf("expected no span context")
+	}
+}
+
+func TestQueryOperation(t *testing.T) {
+	cases := map[string]string{
+		"select id from tasks":             "SELECT",
+		"\n\t INSERT INTO tasks VALUES ()": "INSERT",
+		"":                                 "query",
+	}
+	for sql, want := range cases {
+		if got := queryOperation(sql); got != want {
+			t.Errorf("queryOperation(%q) = %q, want %q", sql, got, want)
+		}
+	}
+}
+
+func TestSetupFileExporter(t *testing.T) {
+	previous := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	path := filepath.Join(t.TempDir(), "spans.json")
+	shutdown, err := Setup(context.Background(), "task-service", Config{Exporter: ExporterFile, FilePath: path})
+	if err != nil {
+		t.Fatalf("setup: %v", err)
+	}
+
+	_, span := otel.Tracer("test").Start(context.Background(), "work")
+	span.End()
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatalf("shutdown: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if !strings.Contains(string(data), `"Name":"work"`) || !strings.Contains(string(data), "task-service") {
+		t.Fatalf("expected exported span, got %s", data)
+	}
+}
+
+func TestSetupRejectsUnknownExporter(t *testing.T) {
+	if _, err := Setup(context.Background(), "test", Config{Exporter: "zipkin"}); err == nil {
+		t.Fatalf("expected error")
+	}
+	if _, err := Setup(context.Background(), "test", Config{Exporter: ExporterFile}); err == nil {
+		t.Fatalf("expected error without file path")
+	}
+}
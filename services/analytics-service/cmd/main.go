@@ -3,7 +3,7 @@ package main
 import (
 	"context"
 	"errors"
-	"log"
+	"log/slog"
 	"net"
 	"net/http"
 	"os"
@@ -12,7 +12,13 @@ import (
 	"syscall"
 	"time"
 
+	"todoapp/migrations"
+	"todoapp/pkg/health"
+	"todoapp/pkg/logging"
+	"todoapp/pkg/metrics"
 	analyticsv1 "todoapp/pkg/proto/analytics/v1"
+	"todoapp/pkg/requestid"
+	"todoapp/pkg/tracing"
 	dbadapter "todoapp/services/analytics-service/internal/adapters/database"
 	grpcadapter "todoapp/services/analytics-service/internal/adapters/grpc"
 	"todoapp/services/analytics-service/internal/infrastructure/app"
@@ -20,46 +26,112 @@ import (
 	"todoapp/services/analytics-service/internal/infrastructure/postgres"
 	"todoapp/services/analytics-service/internal/service"
 
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
 func main() {
+	logger := logging.New("analytics-service")
+	slog.SetDefault(logger)
+
 	cfg, err := config.Load()
 	if err != nil {
-		log.Fatalf("failed to load config: %v", err)
+		logging.Fatal(logger, "failed to load config", err)
 	}
 
-	logger := log.New(os.Stdout, "analytics-service ", log.LstdFlags|log.Lshortfile)
-
 	ctx := context.Background()
+
+	shutdownTracing, err := tracing.Setup(ctx, "analytics-service", tracing.Config{
+		Exporter:    cfg.Tracing.Exporter,
+		Endpoint:    cfg.Tracing.Endpoint,
+		Insecure:    cfg.Tracing.Insecure,
+		FilePath:    cfg.Tracing.FilePath,
+		SampleRatio: cfg.Tracing.SampleRatio,
+	})
+	if err != nil {
+		logging.Fatal(logger, "failed to set up tracing", err)
+	}
+	defer func() {
+		flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(flushCtx); err != nil {
+			logger.Error("failed to flush traces", "error", err)
+		}
+	}()
+
 	pool, err := postgres.NewPool(ctx, cfg)
 	if err != nil {
-		logger.Fatalf("failed to connect to postgres: %v", err)
+		logging.Fatal(logger, "failed to connect to postgres", err)
 	}
 	defer pool.Close()
+	if err := metrics.RegisterPool(pool, "primary"); err != nil {
+		logging.Fatal(logger, "failed to register pool metrics", err)
+	}
 
-	repo := dbadapter.NewPostgresRepository(pool)
+	var repoOpts []dbadapter.RepositoryOption
+	replicaPool, err := postgres.NewReplicaPool(ctx, cfg, pool, logger)
+	if err != nil {
+		logging.Fatal(logger, "failed to connect to postgres replica", err)
+	}
+	if replicaPool != nil {
+		defer replicaPool.Close()
+		if err := metrics.RegisterPool(replicaPool.Replica(), "replica"); err != nil {
+			logging.Fatal(logger, "failed to register pool metrics", err)
+		}
+		repoOpts = append(repoOpts, dbadapter.WithReadReplica(replicaPool))
+	}
+
+	repo := dbadapter.NewPostgresRepository(pool, repoOpts...)
 	analyticsService := service.New(repo)
 
-	router, err := app.NewRouter(app.HTTPDeps{ServiceName: cfg.ServiceName, Analytics: analyticsService})
+	readiness := health.NewChecker("analytics-service")
+	readiness.Add("postgres", health.Postgres(pool, migrations.Version()))
+	if replicaPool != nil {
+		readiness.AddOptional("postgres-replica", health.Postgres(replicaPool.Replica(), migrations.Version()))
+	}
+
+	router, err := app.NewRouter(app.HTTPDeps{
+		ServiceName:    cfg.ServiceName,
+		Analytics:      analyticsService,
+		TrustedProxies: cfg.TrustedProxies,
+		Readiness:      readiness,
+		Logger:         logger,
+	})
 	if err != nil {
-		logger.Fatalf("failed to initialize http router: %v", err)
+		logging.Fatal(logger, "failed to initialize http router", err)
 	}
 
 	httpServer := &http.Server{Addr: cfg.HTTPAddr, Handler: router}
 
 	lis, err := net.Listen("tcp", cfg.GRPCAddr)
 	if err != nil {
-		logger.Fatalf("failed to listen on %s: %v", cfg.GRPCAddr, err)
+		logging.Fatal(logger, "failed to listen on "+cfg.GRPCAddr, err)
 	}
 	defer lis.Close()
 
-	grpcServer := grpc.NewServer()
+	grpcServer := grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(requestid.UnaryServerInterceptor(), metrics.UnaryServerInterceptor()),
+	)
 	analyticsv1.RegisterAnalyticsServiceServer(grpcServer, grpcadapter.NewServer(analyticsService))
+	healthServer := grpchealth.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
 
 	var wg sync.WaitGroup
 	errCh := make(chan error, 2)
 
+	replicaCtx, stopReplicaChecks := context.WithCancel(ctx)
+	defer stopReplicaChecks()
+	if replicaPool != nil {
+		go replicaPool.Run(replicaCtx)
+	}
+
+	healthCtx, stopHealth := context.WithCancel(ctx)
+	defer stopHealth()
+	go readiness.Publish(healthCtx, healthServer, 10*time.Second, analyticsv1.AnalyticsService_ServiceDesc.ServiceName)
+
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
@@ -84,9 +156,11 @@ func main() {
 	defer cancel()
 
 	if err := httpServer.Shutdown(shutdownCtx); err != nil {
-		logger.Printf("http shutdown error: %v", err)
+		logger.Error("http shutdown failed", "error", err)
 	}
 
+	stopHealth()
+	healthServer.Shutdown()
 	grpcServer.GracefulStop()
 
 	done := make(chan struct{})
@@ -97,7 +171,7 @@ func main() {
 
 	select {
 	case err := <-errCh:
-		logger.Printf("server stopped with error: %v", err)
+		logger.Error("server stopped with error", "error", err)
 	case <-done:
 	}
 }
@@ -12,55 +12,115 @@ import (
 )
 
 type PostgresRepository struct {
-	pool pgxPool
+	pool    pgxPool
+	replica ReadPool
+}
+
+// ReadPool serves queries that may see data slightly older than the primary,
+// such as a streaming replica.
+type ReadPool interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+type RepositoryOption func(*PostgresRepository)
+
+// WithReadReplica sends metric reads to replica. Metrics are updated
+// asynchronously anyway, so a little replication lag is not noticeable.
+func WithReadReplica(replica ReadPool) RepositoryOption {
+	return func(r *PostgresRepository) {
+		r.replica = replica
+	}
 }
 
 type pgxPool interface {
-	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	execer
 	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
 	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+	Begin(ctx context.Context) (pgx.Tx, error)
 }
 
-func NewPostgresRepository(pool pgxPool) *PostgresRepository {
-	return &PostgresRepository{pool: pool}
+type execer interface {
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+}
+
+// processedEventRetention bounds how long event IDs are remembered. Senders
+// retry within minutes, so older duplicates are not expected.
+const processedEventRetention = 7 * 24 * time.Hour
+
+func NewPostgresRepository(pool pgxPool, opts ...RepositoryOption) *PostgresRepository {
+	r := &PostgresRepository{pool: pool}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
 }
 
 func (r *PostgresRepository) UpdateTaskMetrics(ctx context.Context, userID int64, date time.Time, delta ports.MetricsDelta) error {
-	normalized := normalizeDate(date)
+	return updateTaskMetrics(ctx, r.pool, userID, date, delta)
+}
 
-	const query = `
-INSERT INTO analytics_service.task_metrics (user_id, date, created_tasks, completed_tasks, total_tasks)
-VALUES ($1, $2, $3, $4, $5)
-ON CONFLICT (user_id, date) DO UPDATE
-SET created_tasks = analytics_service.task_metrics.created_tasks + EXCLUDED.created_tasks,
-    completed_tasks = analytics_service.task_metrics.completed_tasks + EXCLUDED.completed_tasks,
-    total_tasks = GREATEST(0, analytics_service.task_metrics.total_tasks + EXCLUDED.total_tasks),
-    updated_at = NOW()
+func (r *PostgresRepository) ApplyTaskEvents(ctx context.Context, events []ports.TrackedEvent) (applied int, err error) {
+	const markProcessed = `
+INSERT INTO analytics_service.processed_events (event_id)
+VALUES ($1)
+ON CONFLICT (event_id) DO NOTHING
+`
+	const purgeProcessed = `
+DELETE FROM analytics_service.processed_events
+WHERE processed_at < $1
 `
 
-	_, err := r.pool.Exec(ctx, query,
-		userID,
-		normalized,
-		delta.Created,
-		delta.Completed,
-		delta.Total,
-	)
-	return err
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback(ctx)
+		}
+	}()
+
+	for _, event := range events {
+		if event.EventID != "" {
+			tag, err := tx.Exec(ctx, markProcessed, event.EventID)
+			if err != nil {
+				return 0, err
+			}
+			if tag.RowsAffected() == 0 {
+				continue
+			}
+		}
+
+		if err := updateTaskMetrics(ctx, tx, event.UserID, event.Date, event.Delta); err != nil {
+			return 0, err
+		}
+		applied++
+	}
+
+	if _, err := tx.Exec(ctx, purgeProcessed, time.Now().UTC().Add(-processedEventRetention)); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, err
+	}
+
+	return applied, nil
 }
 
 func (r *PostgresRepository) GetDailyMetrics(ctx context.Context, userID int64, date time.Time) (*entities.DailyTaskMetrics, error) {
 	normalized := normalizeDate(date)
 
 	const query = `
-SELECT user_id, date, created_tasks, completed_tasks, total_tasks, updated_at
+SELECT user_id, date, created_tasks, completed_tasks, total_tasks, tracked_seconds, updated_at
 FROM analytics_service.task_metrics
 WHERE user_id = $1 AND date = $2
 `
 
-	row := r.pool.QueryRow(ctx, query, userID, normalized)
+	row := r.reader().QueryRow(ctx, query, userID, normalized)
 
 	var metrics entities.DailyTaskMetrics
-	if err := row.Scan(&metrics.UserID, &metrics.Date, &metrics.CreatedTasks, &metrics.CompletedTasks, &metrics.TotalTasks, &metrics.UpdatedAt); err != nil {
+	if err := row.Scan(&metrics.UserID, &metrics.Date, &metrics.CreatedTasks, &metrics.CompletedTasks, &metrics.TotalTasks, &metrics.TrackedSeconds, &metrics.UpdatedAt); err != nil {
 		if err == pgx.ErrNoRows {
 			return &entities.DailyTaskMetrics{UserID: userID, Date: normalized}, nil
 		}
@@ -70,6 +130,38 @@ WHERE user_id = $1 AND date = $2
 	return &metrics, nil
 }
 
+func (r *PostgresRepository) reader() ReadPool {
+	if r.replica != nil {
+		return r.replica
+	}
+	return r.pool
+}
+
+func updateTaskMetrics(ctx context.Context, q execer, userID int64, date time.Time, delta ports.MetricsDelta) error {
+	normalized := normalizeDate(date)
+
+	const query = `
+INSERT INTO analytics_service.task_metrics (user_id, date, created_tasks, completed_tasks, total_tasks, tracked_seconds)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (user_id, date) DO UPDATE
+SET created_tasks = analytics_service.task_metrics.created_tasks + EXCLUDED.created_tasks,
+    completed_tasks = analytics_service.task_metrics.completed_tasks + EXCLUDED.completed_tasks,
+    total_tasks = GREATEST(0, analytics_service.task_metrics.total_tasks + EXCLUDED.total_tasks),
+    tracked_seconds = analytics_service.task_metrics.tracked_seconds + EXCLUDED.tracked_seconds,
+    updated_at = NOW()
+`
+
+	_, err := q.Exec(ctx, query,
+		userID,
+		normalized,
+		delta.Created,
+		delta.Completed,
+		delta.Total,
+		delta.TrackedSeconds,
+	)
+	return err
+}
+
 func normalizeDate(value time.Time) time.Time {
 	if value.IsZero() {
 		value = time.Now()
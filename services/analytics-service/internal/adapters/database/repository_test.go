@@ -2,6 +2,7 @@ package database
 
 import (
 	"context"
+	"errors"
 	"regexp"
 	"testing"
 	"time"
@@ -25,15 +26,16 @@ func TestUpdateTaskMetrics(t *testing.T) {
 	normalized := normalizeDate(now)
 
 	mock.ExpectExec(regexp.QuoteMeta(`
-INSERT INTO analytics_service.task_metrics (user_id, date, created_tasks, completed_tasks, total_tasks)
-VALUES ($1, $2, $3, $4, $5)
+INSERT INTO analytics_service.task_metrics (user_id, date, created_tasks, completed_tasks, total_tasks, tracked_seconds)
+VALUES ($1, $2, $3, $4, $5, $6)
 ON CONFLICT (user_id, date) DO UPDATE
 SET created_tasks = analytics_service.task_metrics.created_tasks + EXCLUDED.created_tasks,
     completed_tasks = analytics_service.task_metrics.completed_tasks + EXCLUDED.completed_tasks,
     total_tasks = GREATEST(0, analytics_service.task_metrics.total_tasks + EXCLUDED.total_tasks),
+    tracked_seconds = analytics_service.task_metrics.tracked_seconds + EXCLUDED.tracked_seconds,
     updated_at = NOW()
 `)).
-		WithArgs(int64(7), normalized, int32(1), int32(0), int32(2)).
+		WithArgs(int64(7), normalized, int32(1), int32(0), int32(2), int64(0)).
 		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
 
 	if err := repo.UpdateTaskMetrics(context.Background(), 7, now, ports.MetricsDelta{Created: 1, Total: 2}); err != nil {
@@ -45,6 +47,68 @@ SET created_tasks = analytics_service.task_metrics.created_tasks + EXCLUDED.crea
 	}
 }
 
+func TestApplyTaskEventsSkipsProcessed(t *testing.T) {
+	mock, err := pgxmock.NewPool()
+	if err != nil {
+		t.Fatalf("failed to create mock pool: %v", err)
+	}
+	defer mock.Close()
+
+	repo := NewPostgresRepository(mock)
+	date := time.Date(2024, 10, 1, 12, 30, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO analytics_service.processed_events")).
+		WithArgs("e1").
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO analytics_service.task_metrics")).
+		WithArgs(int64(7), normalizeDate(date), int32(1), int32(0), int32(1), int64(0)).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO analytics_service.processed_events")).
+		WithArgs("e2").
+		WillReturnResult(pgxmock.NewResult("INSERT", 0))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM analytics_service.processed_events")).
+		WithArgs(pgxmock.AnyArg()).
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+	mock.ExpectCommit()
+
+	applied, err := repo.ApplyTaskEvents(context.Background(), []ports.TrackedEvent{
+		{EventID: "e1", UserID: 7, Date: date, Delta: ports.MetricsDelta{Created: 1, Total: 1}},
+		{EventID: "e2", UserID: 7, Date: date, Delta: ports.MetricsDelta{Completed: 1}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if applied != 1 {
+		t.Fatalf("expected 1 applied event, got %d", applied)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
+func TestApplyTaskEventsRollsBackOnError(t *testing.T) {
+	mock, _ := pgxmock.NewPool()
+	defer mock.Close()
+
+	repo := NewPostgresRepository(mock)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO analytics_service.task_metrics")).
+		WithArgs(pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg(), pgxmock.AnyArg()).
+		WillReturnError(errors.New("boom"))
+	mock.ExpectRollback()
+
+	if _, err := repo.ApplyTaskEvents(context.Background(), []ports.TrackedEvent{{UserID: 7, Delta: ports.MetricsDelta{Created: 1}}}); err == nil {
+		t.Fatalf("expected error")
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet expectations: %v", err)
+	}
+}
+
 func TestGetDailyMetricsFound(t *testing.T) {
 	mock, _ := pgxmock.NewPool()
 	defer mock.Close()
@@ -53,10 +117,10 @@ func TestGetDailyMetricsFound(t *testing.T) {
 	date := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
 	normalized := normalizeDate(date)
 
-	rows := pgxmock.NewRows([]string{"user_id", "date", "created_tasks", "completed_tasks", "total_tasks", "updated_at"}).
-		AddRow(int64(5), normalized, int32(2), int32(1), int32(3), normalized.Add(time.Hour))
+	rows := pgxmock.NewRows([]string{"user_id", "date", "created_tasks", "completed_tasks", "total_tasks", "tracked_seconds", "updated_at"}).
+		AddRow(int64(5), normalized, int32(2), int32(1), int32(3), int64(5400), normalized.Add(time.Hour))
 	mock.ExpectQuery(regexp.QuoteMeta(`
-SELECT user_id, date, created_tasks, completed_tasks, total_tasks, updated_at
+SELECT user_id, date, created_tasks, completed_tasks, total_tasks, tracked_seconds, updated_at
 FROM analytics_service.task_metrics
 WHERE user_id = $1 AND date = $2
 `)).
@@ -73,6 +137,7 @@ WHERE user_id = $1 AND date = $2
 		CreatedTasks:   2,
 		CompletedTasks: 1,
 		TotalTasks:     3,
+		TrackedSeconds: 5400,
 		UpdatedAt:      normalized.Add(time.Hour),
 	}
 	if *metrics != *want {
@@ -90,7 +155,7 @@ func TestGetDailyMetricsNoRows(t *testing.T) {
 
 	repo := NewPostgresRepository(mock)
 	mock.ExpectQuery(regexp.QuoteMeta(`
-SELECT user_id, date, created_tasks, completed_tasks, total_tasks, updated_at
+SELECT user_id, date, created_tasks, completed_tasks, total_tasks, tracked_seconds, updated_at
 FROM analytics_service.task_metrics
 WHERE user_id = $1 AND date = $2
 `)).
@@ -109,6 +174,31 @@ WHERE user_id = $1 AND date = $2
 	}
 }
 
+func TestGetDailyMetricsReadsFromReplica(t *testing.T) {
+	primary, _ := pgxmock.NewPool()
+	defer primary.Close()
+	replica, _ := pgxmock.NewPool()
+	defer replica.Close()
+
+	repo := NewPostgresRepository(primary, WithReadReplica(replica))
+	date := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	replica.ExpectQuery(regexp.QuoteMeta(`FROM analytics_service.task_metrics`)).
+		WithArgs(int64(5), normalizeDate(date)).
+		WillReturnError(pgx.ErrNoRows)
+
+	if _, err := repo.GetDailyMetrics(context.Background(), 5, date); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := replica.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unmet replica expectations: %v", err)
+	}
+	if err := primary.ExpectationsWereMet(); err != nil {
+		t.Fatalf("unexpected primary queries: %v", err)
+	}
+}
+
 func TestNormalizeDate(t *testing.T) {
 	zero := normalizeDate(time.Time{})
 	if zero.Hour() != 0 || zero.Minute() != 0 || zero.Second() != 0 || zero.Location() != time.UTC {
@@ -9,6 +9,7 @@ import (
 	"google.golang.org/grpc/status"
 
 	analyticsv1 "todoapp/pkg/proto/analytics/v1"
+	"todoapp/pkg/tracing"
 	"todoapp/services/analytics-service/internal/domain"
 	"todoapp/services/analytics-service/internal/ports"
 )
@@ -24,20 +25,31 @@ func NewServer(service ports.AnalyticsService) *Server {
 }
 
 func (s *Server) TrackTaskEvent(ctx context.Context, req *analyticsv1.TrackTaskEventRequest) (*analyticsv1.TrackTaskEventResponse, error) {
-	input := ports.TrackEventInput{
-		Type:       req.GetType(),
-		UserID:     req.GetUserId(),
-		TaskID:     req.GetTaskId(),
-		Status:     req.GetStatus(),
-		Priority:   req.GetPriority(),
-		OccurredAt: time.Unix(req.GetOccurredAt(), 0),
+	tracing.SetUserID(ctx, req.GetUserId())
+	tracing.SetTaskID(ctx, req.GetTaskId())
+	if err := s.service.TrackTaskEvent(ctx, trackEventInput(req)); err != nil {
+		return nil, mapError(err)
+	}
+
+	return &analyticsv1.TrackTaskEventResponse{}, nil
+}
+
+func (s *Server) BatchTrackTaskEvents(ctx context.Context, req *analyticsv1.BatchTrackTaskEventsRequest) (*analyticsv1.BatchTrackTaskEventsResponse, error) {
+	inputs := make([]ports.TrackEventInput, 0, len(req.GetEvents()))
+	for _, event := range req.GetEvents() {
+		inputs = append(inputs, trackEventInput(event))
 	}
 
-	if err := s.service.TrackTaskEvent(ctx, input); err != nil {
+	result, err := s.service.BatchTrackTaskEvents(ctx, inputs)
+	if err != nil {
 		return nil, mapError(err)
 	}
 
-	return &analyticsv1.TrackTaskEventResponse{}, nil
+	return &analyticsv1.BatchTrackTaskEventsResponse{
+		Applied:    int32(result.Applied),
+		Duplicates: int32(result.Duplicates),
+		Rejected:   int32(result.Rejected),
+	}, nil
 }
 
 func (s *Server) GetDailyMetrics(ctx context.Context, req *analyticsv1.GetDailyMetricsRequest) (*analyticsv1.GetDailyMetricsResponse, error) {
@@ -62,10 +74,27 @@ func (s *Server) GetDailyMetrics(ctx context.Context, req *analyticsv1.GetDailyM
 			CreatedTasks:   metrics.CreatedTasks,
 			CompletedTasks: metrics.CompletedTasks,
 			TotalTasks:     metrics.TotalTasks,
+			TrackedSeconds: metrics.TrackedSeconds,
 		},
 	}, nil
 }
 
+func trackEventInput(req *analyticsv1.TrackTaskEventRequest) ports.TrackEventInput {
+	input := ports.TrackEventInput{
+		Type:     req.GetType(),
+		UserID:   req.GetUserId(),
+		TaskID:   req.GetTaskId(),
+		Status:   req.GetStatus(),
+		Priority: req.GetPriority(),
+		Duration: time.Duration(req.GetDurationSeconds()) * time.Second,
+		EventID:  req.GetEventId(),
+	}
+	if req.GetOccurredAt() != 0 {
+		input.OccurredAt = time.Unix(req.GetOccurredAt(), 0)
+	}
+	return input
+}
+
 func mapError(err error) error {
 	switch {
 	case errors.Is(err, domain.ErrInvalidArgument):
@@ -7,6 +7,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	"todoapp/pkg/tracing"
 	"todoapp/services/analytics-service/internal/ports"
 )
 
@@ -28,6 +29,7 @@ func (h *Handler) GetDailyMetrics(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": "INVALID_USER_ID"})
 		return
 	}
+	tracing.SetUserID(ctx.Request.Context(), userID)
 
 	var date time.Time
 	if raw := ctx.Query("date"); raw != "" {
@@ -51,6 +53,7 @@ func (h *Handler) GetDailyMetrics(ctx *gin.Context) {
 		"createdTasks":   metrics.CreatedTasks,
 		"completedTasks": metrics.CompletedTasks,
 		"totalTasks":     metrics.TotalTasks,
+		"trackedSeconds": metrics.TrackedSeconds,
 		"updatedAt":      metrics.UpdatedAt,
 	})
 }
@@ -24,6 +24,10 @@ func (s *analyticsStub) TrackTaskEvent(ctx context.Context, input ports.TrackEve
 	return nil
 }
 
+func (s *analyticsStub) BatchTrackTaskEvents(ctx context.Context, inputs []ports.TrackEventInput) (*ports.BatchTrackResult, error) {
+	return &ports.BatchTrackResult{}, nil
+}
+
 func (s *analyticsStub) GetDailyMetrics(ctx context.Context, req ports.DailyMetricsRequest) (*entities.DailyTaskMetrics, error) {
 	s.lastReq = req
 	return s.metrics, s.err
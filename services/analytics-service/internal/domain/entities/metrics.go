@@ -8,5 +8,6 @@ type DailyTaskMetrics struct {
 	CreatedTasks   int32
 	CompletedTasks int32
 	TotalTasks     int32
+	TrackedSeconds int64
 	UpdatedAt      time.Time
 }
@@ -2,10 +2,16 @@ package app
 
 import (
 	"errors"
-	"net/http"
+	"fmt"
+	"log/slog"
 
 	"github.com/gin-gonic/gin"
 
+	"todoapp/pkg/health"
+	"todoapp/pkg/logging"
+	"todoapp/pkg/metrics"
+	"todoapp/pkg/requestid"
+	"todoapp/pkg/tracing"
 	metricshttp "todoapp/services/analytics-service/internal/adapters/http/metrics"
 	"todoapp/services/analytics-service/internal/ports"
 )
@@ -13,6 +19,13 @@ import (
 type HTTPDeps struct {
 	ServiceName string
 	Analytics   ports.AnalyticsService
+	// TrustedProxies are the addresses and CIDRs allowed to report the client
+	// address in X-Forwarded-For; the peer address is used when empty.
+	TrustedProxies []string
+	// Readiness runs the checks behind /health/ready; none when nil.
+	Readiness *health.Checker
+	// Logger receives the request log; slog.Default() when nil.
+	Logger *slog.Logger
 }
 
 func NewRouter(deps HTTPDeps) (*gin.Engine, error) {
@@ -20,31 +33,26 @@ func NewRouter(deps HTTPDeps) (*gin.Engine, error) {
 		return nil, errors.New("analytics service is required")
 	}
 
+	logger := deps.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+
 	router := gin.New()
-	router.Use(gin.Logger(), gin.Recovery())
+	if err := router.SetTrustedProxies(deps.TrustedProxies); err != nil {
+		return nil, fmt.Errorf("trusted proxies: %w", err)
+	}
+	router.Use(tracing.Middleware(deps.ServiceName), requestid.Middleware(), logging.Middleware(logger), metrics.Middleware(), gin.Recovery())
 
-	router.GET("/health", healthHandler(deps.ServiceName))
-	router.HEAD("/health", healthHandler(deps.ServiceName))
+	serviceName := deps.ServiceName
+	if serviceName == "" {
+		serviceName = "analytics-service"
+	}
+	health.RegisterRoutes(router, serviceName, deps.Readiness)
+	router.GET(metrics.Path, gin.WrapH(metrics.Handler()))
 
 	handler := metricshttp.New(deps.Analytics)
 	handler.RegisterRoutes(router)
 
 	return router, nil
 }
-
-func healthHandler(serviceName string) gin.HandlerFunc {
-	if serviceName == "" {
-		serviceName = "analytics-service"
-	}
-
-	return func(ctx *gin.Context) {
-		if ctx.Request.Method == http.MethodHead {
-			ctx.Status(http.StatusOK)
-			return
-		}
-		ctx.JSON(http.StatusOK, gin.H{
-			"status":  "ok",
-			"service": serviceName,
-		})
-	}
-}
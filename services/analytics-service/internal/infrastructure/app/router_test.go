@@ -15,6 +15,9 @@ import (
 type analyticsMock struct{}
 
 func (analyticsMock) TrackTaskEvent(ctx context.Context, input ports.TrackEventInput) error { return nil }
+func (analyticsMock) BatchTrackTaskEvents(ctx context.Context, inputs []ports.TrackEventInput) (*ports.BatchTrackResult, error) {
+	return &ports.BatchTrackResult{}, nil
+}
 func (analyticsMock) GetDailyMetrics(ctx context.Context, req ports.DailyMetricsRequest) (*entities.DailyTaskMetrics, error) {
 	return &entities.DailyTaskMetrics{UserID: req.UserID, Date: req.Date}, nil
 }
@@ -5,11 +5,16 @@ import (
 
 	"github.com/jackc/pgx/v5/pgxpool"
 
+	"todoapp/pkg/tracing"
 	"todoapp/services/analytics-service/internal/infrastructure/config"
 )
 
 func NewPool(ctx context.Context, cfg config.Config) (*pgxpool.Pool, error) {
-	poolCfg, err := pgxpool.ParseConfig(cfg.PostgresURL())
+	return newPool(ctx, cfg.PostgresURL(), cfg)
+}
+
+func newPool(ctx context.Context, url string, cfg config.Config) (*pgxpool.Pool, error) {
+	poolCfg, err := pgxpool.ParseConfig(url)
 	if err != nil {
 		return nil, err
 	}
@@ -24,5 +29,7 @@ func NewPool(ctx context.Context, cfg config.Config) (*pgxpool.Pool, error) {
 		poolCfg.MaxConnLifetime = cfg.Postgres.MaxLifetime
 	}
 
+	poolCfg.ConnConfig.Tracer = tracing.QueryTracer{}
+
 	return pgxpool.NewWithConfig(ctx, poolCfg)
 }
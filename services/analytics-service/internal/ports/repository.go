@@ -11,9 +11,23 @@ type MetricsDelta struct {
 	Created   int32
 	Completed int32
 	Total     int32
+	// TrackedSeconds is time spent on tasks.
+	TrackedSeconds int64
+}
+
+// TrackedEvent is a metrics change identified by the event that caused it.
+type TrackedEvent struct {
+	// EventID deduplicates redelivered events; empty IDs are always applied.
+	EventID string
+	UserID  int64
+	Date    time.Time
+	Delta   MetricsDelta
 }
 
 type AnalyticsRepository interface {
 	UpdateTaskMetrics(ctx context.Context, userID int64, date time.Time, delta MetricsDelta) error
+	// ApplyTaskEvents applies the events in one transaction, skipping events
+	// whose ID was applied before, and returns how many were applied.
+	ApplyTaskEvents(ctx context.Context, events []TrackedEvent) (int, error)
 	GetDailyMetrics(ctx context.Context, userID int64, date time.Time) (*entities.DailyTaskMetrics, error)
 }
@@ -15,6 +15,16 @@ type TrackEventInput struct {
 	Status     string
 	Priority   string
 	OccurredAt time.Time
+	// Duration is set for TASK_EVENT_TYPE_TIME_TRACKED events.
+	Duration time.Duration
+	// EventID makes tracking idempotent when set.
+	EventID string
+}
+
+type BatchTrackResult struct {
+	Applied    int
+	Duplicates int
+	Rejected   int
 }
 
 type DailyMetricsRequest struct {
@@ -24,5 +34,6 @@ type DailyMetricsRequest struct {
 
 type AnalyticsService interface {
 	TrackTaskEvent(ctx context.Context, input TrackEventInput) error
+	BatchTrackTaskEvents(ctx context.Context, inputs []TrackEventInput) (*BatchTrackResult, error)
 	GetDailyMetrics(ctx context.Context, req DailyMetricsRequest) (*entities.DailyTaskMetrics, error)
 }
@@ -21,21 +21,56 @@ func New(repo ports.AnalyticsRepository) *AnalyticsService {
 	return &AnalyticsService{repo: repo}
 }
 
+// MaxBatchSize limits the number of events in one BatchTrackTaskEvents call.
+const MaxBatchSize = 500
+
 func (s *AnalyticsService) TrackTaskEvent(ctx context.Context, input ports.TrackEventInput) error {
-	if input.UserID == 0 {
-		return fmt.Errorf("%w: user id is required", domain.ErrInvalidArgument)
+	event, err := trackedEvent(input)
+	if err != nil {
+		return err
 	}
 
-	if input.OccurredAt.IsZero() {
-		input.OccurredAt = time.Now()
+	if event.EventID == "" {
+		return s.repo.UpdateTaskMetrics(ctx, event.UserID, event.Date, event.Delta)
+	}
+
+	_, err = s.repo.ApplyTaskEvents(ctx, []ports.TrackedEvent{event})
+	return err
+}
+
+// BatchTrackTaskEvents applies a batch of events at once. Invalid events are
+// counted as rejected instead of failing the batch, so a sender retrying on
+// errors is not stuck on them.
+func (s *AnalyticsService) BatchTrackTaskEvents(ctx context.Context, inputs []ports.TrackEventInput) (*ports.BatchTrackResult, error) {
+	if len(inputs) > MaxBatchSize {
+		return nil, fmt.Errorf("%w: at most %d events per batch", domain.ErrInvalidArgument, MaxBatchSize)
+	}
+
+	result := &ports.BatchTrackResult{}
+	tracked := make([]ports.TrackedEvent, 0, len(inputs))
+
+	for _, input := range inputs {
+		event, err := trackedEvent(input)
+		if err != nil {
+			result.Rejected++
+			continue
+		}
+		tracked = append(tracked, event)
+	}
+
+	if len(tracked) == 0 {
+		return result, nil
 	}
 
-	delta, err := buildDelta(input.Type)
+	applied, err := s.repo.ApplyTaskEvents(ctx, tracked)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return s.repo.UpdateTaskMetrics(ctx, input.UserID, input.OccurredAt, delta)
+	result.Applied = applied
+	result.Duplicates = len(tracked) - applied
+
+	return result, nil
 }
 
 func (s *AnalyticsService) GetDailyMetrics(ctx context.Context, req ports.DailyMetricsRequest) (*entities.DailyTaskMetrics, error) {
@@ -48,17 +83,44 @@ func (s *AnalyticsService) GetDailyMetrics(ctx context.Context, req ports.DailyM
 	return s.repo.GetDailyMetrics(ctx, req.UserID, req.Date)
 }
 
-func buildDelta(eventType analyticsv1.TaskEventType) (ports.MetricsDelta, error) {
-	switch eventType {
+func trackedEvent(input ports.TrackEventInput) (ports.TrackedEvent, error) {
+	if input.UserID == 0 {
+		return ports.TrackedEvent{}, fmt.Errorf("%w: user id is required", domain.ErrInvalidArgument)
+	}
+
+	if input.OccurredAt.IsZero() {
+		input.OccurredAt = time.Now()
+	}
+
+	delta, err := buildDelta(input)
+	if err != nil {
+		return ports.TrackedEvent{}, err
+	}
+
+	return ports.TrackedEvent{
+		EventID: input.EventID,
+		UserID:  input.UserID,
+		Date:    input.OccurredAt,
+		Delta:   delta,
+	}, nil
+}
+
+func buildDelta(input ports.TrackEventInput) (ports.MetricsDelta, error) {
+	switch input.Type {
 	case analyticsv1.TaskEventType_TASK_EVENT_TYPE_CREATED:
 		return ports.MetricsDelta{Created: 1, Total: 1}, nil
 	case analyticsv1.TaskEventType_TASK_EVENT_TYPE_COMPLETED:
 		return ports.MetricsDelta{Completed: 1}, nil
 	case analyticsv1.TaskEventType_TASK_EVENT_TYPE_DELETED:
 		return ports.MetricsDelta{Total: -1}, nil
+	case analyticsv1.TaskEventType_TASK_EVENT_TYPE_TIME_TRACKED:
+		if input.Duration <= 0 {
+			return ports.MetricsDelta{}, fmt.Errorf("%w: tracked duration must be positive", domain.ErrInvalidArgument)
+		}
+		return ports.MetricsDelta{TrackedSeconds: int64(input.Duration / time.Second)}, nil
 	case analyticsv1.TaskEventType_TASK_EVENT_TYPE_UNSPECIFIED:
 		fallthrough
 	default:
-		return ports.MetricsDelta{}, fmt.Errorf("unsupported event type: %s", eventType.String())
+		return ports.MetricsDelta{}, fmt.Errorf("unsupported event type: %s", input.Type.String())
 	}
 }
@@ -20,6 +20,25 @@ type stubRepo struct {
 
 	metrics    *entities.DailyTaskMetrics
 	metricsErr error
+
+	applied []ports.TrackedEvent
+	seen    map[string]bool
+}
+
+func (r *stubRepo) ApplyTaskEvents(ctx context.Context, events []ports.TrackedEvent) (int, error) {
+	if r.seen == nil {
+		r.seen = map[string]bool{}
+	}
+	count := 0
+	for _, event := range events {
+		if event.EventID != "" && r.seen[event.EventID] {
+			continue
+		}
+		r.seen[event.EventID] = true
+		r.applied = append(r.applied, event)
+		count++
+	}
+	return count, r.updateErr
 }
 
 func (r *stubRepo) UpdateTaskMetrics(ctx context.Context, userID int64, date time.Time, delta ports.MetricsDelta) error {
@@ -82,6 +101,43 @@ func TestTrackTaskEventInvalidType(t *testing.T) {
 	}
 }
 
+func TestBatchTrackTaskEvents(t *testing.T) {
+	repo := &stubRepo{}
+	svc := New(repo)
+
+	created := ports.TrackEventInput{Type: analyticsv1.TaskEventType_TASK_EVENT_TYPE_CREATED, UserID: 1, EventID: "a"}
+	result, err := svc.BatchTrackTaskEvents(context.Background(), []ports.TrackEventInput{
+		created,
+		{Type: analyticsv1.TaskEventType_TASK_EVENT_TYPE_COMPLETED, UserID: 1, EventID: "b"},
+		{Type: analyticsv1.TaskEventType_TASK_EVENT_TYPE_UNSPECIFIED, UserID: 1, EventID: "c"},
+		created,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if *result != (ports.BatchTrackResult{Applied: 2, Duplicates: 1, Rejected: 1}) {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+
+	// A redelivered batch changes nothing.
+	result, err = svc.BatchTrackTaskEvents(context.Background(), []ports.TrackEventInput{created})
+	if err != nil || result.Applied != 0 || result.Duplicates != 1 {
+		t.Fatalf("expected duplicate, got %+v, %v", result, err)
+	}
+	if len(repo.applied) != 2 {
+		t.Fatalf("expected 2 applied events, got %d", len(repo.applied))
+	}
+}
+
+func TestBatchTrackTaskEventsTooLarge(t *testing.T) {
+	svc := New(&stubRepo{})
+
+	_, err := svc.BatchTrackTaskEvents(context.Background(), make([]ports.TrackEventInput, MaxBatchSize+1))
+	if !errors.Is(err, domain.ErrInvalidArgument) {
+		t.Fatalf("expected invalid argument error, got %v", err)
+	}
+}
+
 func TestGetDailyMetricsValidation(t *testing.T) {
 	svc := New(&stubRepo{})
 
@@ -114,18 +170,21 @@ func TestBuildDelta(t *testing.T) {
 	tests := []struct {
 		name    string
 		typ     analyticsv1.TaskEventType
+		dur     time.Duration
 		expect  ports.MetricsDelta
 		wantErr bool
 	}{
 		{name: "created", typ: analyticsv1.TaskEventType_TASK_EVENT_TYPE_CREATED, expect: ports.MetricsDelta{Created: 1, Total: 1}},
 		{name: "completed", typ: analyticsv1.TaskEventType_TASK_EVENT_TYPE_COMPLETED, expect: ports.MetricsDelta{Completed: 1}},
 		{name: "deleted", typ: analyticsv1.TaskEventType_TASK_EVENT_TYPE_DELETED, expect: ports.MetricsDelta{Total: -1}},
+		{name: "time tracked", typ: analyticsv1.TaskEventType_TASK_EVENT_TYPE_TIME_TRACKED, dur: 90 * time.Minute, expect: ports.MetricsDelta{TrackedSeconds: 5400}},
+		{name: "time tracked without duration", typ: analyticsv1.TaskEventType_TASK_EVENT_TYPE_TIME_TRACKED, wantErr: true},
 		{name: "unsupported", typ: analyticsv1.TaskEventType_TASK_EVENT_TYPE_UNSPECIFIED, wantErr: true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			delta, err := buildDelta(tt.typ)
+			delta, err := buildDelta(ports.TrackEventInput{Type: tt.typ, Duration: tt.dur})
 			if tt.wantErr {
 				if err == nil {
 					t.Fatalf("expected error")
@@ -2,29 +2,53 @@ package main
 
 import (
 	"context"
-	"log"
+	"log/slog"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	rabbitmq "todoapp/services/notification-service/internal/adapters/rabbitmq"
 	smtpadapter "todoapp/services/notification-service/internal/adapters/smtp"
 	"todoapp/services/notification-service/internal/adapters/templates"
+	"todoapp/services/notification-service/internal/infrastructure/app"
 	"todoapp/services/notification-service/internal/infrastructure/config"
+	"todoapp/services/notification-service/internal/infrastructure/health"
+	"todoapp/services/notification-service/internal/infrastructure/logging"
+	"todoapp/services/notification-service/internal/infrastructure/tracing"
 	"todoapp/services/notification-service/internal/service"
 )
 
 func main() {
+	logger := logging.New("notification-service")
+	slog.SetDefault(logger)
+
 	cfg, err := config.Load()
 	if err != nil {
-		log.Fatalf("config error: %v", err)
+		logging.Fatal(logger, "config error", err)
 	}
 
-	logger := log.New(os.Stdout, "notification-service ", log.LstdFlags|log.Lshortfile)
+	shutdownTracing, err := tracing.Setup(context.Background(), "notification-service", tracing.Config{
+		Exporter:    cfg.Tracing.Exporter,
+		Endpoint:    cfg.Tracing.Endpoint,
+		Insecure:    cfg.Tracing.Insecure,
+		FilePath:    cfg.Tracing.FilePath,
+		SampleRatio: cfg.Tracing.SampleRatio,
+	})
+	if err != nil {
+		logging.Fatal(logger, "tracing error", err)
+	}
+	defer func() {
+		flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(flushCtx); err != nil {
+			logger.Error("failed to flush traces", "error", err)
+		}
+	}()
 
 	templateEngine, err := templates.NewEngine()
 	if err != nil {
-		log.Fatalf("template error: %v", err)
+		logging.Fatal(logger, "template error", err)
 	}
 
 	mailer := smtpadapter.NewMailer(cfg.SMTP.Host, cfg.SMTP.Port, cfg.SMTP.Username, cfg.SMTP.Password, cfg.SMTP.From)
@@ -38,11 +62,22 @@ func main() {
 		sigCh := make(chan os.Signal, 1)
 		signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
 		<-sigCh
-		logger.Println("shutdown signal received")
+		logger.Info("shutdown signal received")
 		cancel()
 	}()
 
+	readiness := health.NewChecker("notification-service")
+	readiness.Add("rabbitmq", health.RabbitMQ(cfg.Rabbit.URL))
+	readiness.Add("smtp", mailer.Ping)
+
+	server := app.NewServer(cfg.HTTPAddr, readiness)
+	go func() {
+		if err := server.Run(ctx); err != nil {
+			logger.Error("http server stopped", "error", err)
+		}
+	}()
+
 	if err := consumer.Consume(ctx, notificationService); err != nil {
-		logger.Fatalf("consumer stopped: %v", err)
+		logging.Fatal(logger, "consumer stopped", err)
 	}
 }
@@ -4,23 +4,30 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
-	"log"
+	"log/slog"
 	"time"
 
 	amqp "github.com/rabbitmq/amqp091-go"
 
 	"todoapp/services/notification-service/internal/domain/events"
+	"todoapp/services/notification-service/internal/infrastructure/logging"
+	"todoapp/services/notification-service/internal/infrastructure/metrics"
+	"todoapp/services/notification-service/internal/infrastructure/tracing"
 	"todoapp/services/notification-service/internal/ports"
 )
 
 type Consumer struct {
 	url      string
 	queue    string
-	logger   *log.Logger
+	logger   *slog.Logger
 	prefetch int
 }
 
-func NewConsumer(url, queue string, logger *log.Logger) *Consumer {
+func NewConsumer(url, queue string, logger *slog.Logger) *Consumer {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
 	return &Consumer{
 		url:      url,
 		queue:    queue,
@@ -63,24 +70,45 @@ func (c *Consumer) Consume(ctx context.Context, handler ports.EventHandler) erro
 			if !ok {
 				return errors.New("rabbitmq: deliveries channel closed")
 			}
+			metrics.MessageConsumed(c.queue)
 			if err := c.handleMessage(ctx, handler, &msg); err != nil {
-				c.logger.Printf("failed to process message: %v", err)
 				_ = msg.Nack(false, false)
+				metrics.MessageNacked(c.queue)
 				continue
 			}
 			_ = msg.Ack(false)
+			metrics.MessageAcked(c.queue)
 		}
 	}
 }
 
-func (c *Consumer) handleMessage(ctx context.Context, handler ports.EventHandler, msg *amqp.Delivery) error {
+// handleMessage logs failures with the request ID the message was published
+// with, so they can be traced back to the request that caused them. Its span
+// continues the trace of that request.
+func (c *Consumer) handleMessage(ctx context.Context, handler ports.EventHandler, msg *amqp.Delivery) (err error) {
+	ctx = logging.WithMessageHeaders(ctx, msg.Headers)
+	ctx, span := tracing.StartConsume(ctx, c.queue, msg.Headers)
+	defer func() { tracing.End(span, err) }()
+
 	var event events.TaskEvent
 	if err := json.Unmarshal(msg.Body, &event); err != nil {
+		c.logger.ErrorContext(ctx, "failed to decode message", "message_id", msg.MessageId, "error", err)
 		return err
 	}
+	ctx = logging.WithUserID(ctx, event.UserID)
+	span.SetAttributes(tracing.UserID(event.UserID), tracing.TaskID(event.TaskID))
 
 	ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
 	defer cancel()
 
-	return handler.Handle(ctx, event)
+	if err := handler.Handle(ctx, event); err != nil {
+		c.logger.ErrorContext(ctx, "failed to process message",
+			"event_id", event.ID,
+			"event_type", event.Type,
+			"task_id", event.TaskID,
+			"error", err,
+		)
+		return err
+	}
+	return nil
 }
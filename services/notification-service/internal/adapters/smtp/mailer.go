@@ -3,10 +3,16 @@ package smtp
 import (
 	"context"
 	"fmt"
+	"net"
 	stdsmtp "net/smtp"
 	"strings"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"todoapp/services/notification-service/internal/infrastructure/metrics"
+	"todoapp/services/notification-service/internal/infrastructure/tracing"
 	"todoapp/services/notification-service/internal/ports"
 )
 
@@ -33,7 +39,17 @@ func NewMailer(host string, port int, username, password, from string) *Mailer {
 
 var _ ports.Mailer = (*Mailer)(nil)
 
-func (m *Mailer) Send(ctx context.Context, req ports.MailRequest) error {
+func (m *Mailer) Send(ctx context.Context, req ports.MailRequest) (err error) {
+	ctx, span := tracing.Tracer().Start(ctx, "smtp send",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("server.address", m.addr)),
+	)
+	start := time.Now()
+	defer func() {
+		metrics.EmailSent(time.Since(start), err)
+		tracing.End(span, err)
+	}()
+
 	payload := buildMessage(m.from, req)
 
 	done := make(chan error, 1)
@@ -49,6 +65,32 @@ func (m *Mailer) Send(ctx context.Context, req ports.MailRequest) error {
 	}
 }
 
+// Ping checks the SMTP server greets and answers a NOOP, without sending
+// anything.
+func (m *Mailer) Ping(ctx context.Context) error {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", m.addr)
+	if err != nil {
+		return err
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	host, _, _ := net.SplitHostPort(m.addr)
+	client, err := stdsmtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if err := client.Noop(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
 func buildMessage(from string, req ports.MailRequest) string {
 	headers := map[string]string{
 		"From":         from,
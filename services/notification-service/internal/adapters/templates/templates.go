@@ -13,6 +13,7 @@ type Engine struct {
 	created   *template.Template
 	completed *template.Template
 	deleted   *template.Template
+	overdue   *template.Template
 	timeFmt   string
 }
 
@@ -29,11 +30,16 @@ func NewEngine() (*Engine, error) {
 	if err != nil {
 		return nil, err
 	}
+	overdue, err := template.New("task_overdue").Parse(taskOverdueTemplate)
+	if err != nil {
+		return nil, err
+	}
 
 	return &Engine{
 		created:   created,
 		completed: completed,
 		deleted:   deleted,
+		overdue:   overdue,
 		timeFmt:   "02 Jan 2006 15:04",
 	}, nil
 }
@@ -50,6 +56,10 @@ func (e *Engine) RenderTaskDeleted(event events.TaskEvent) (svc.TemplateResult,
 	return e.render(e.deleted, "Задача удалена", event)
 }
 
+func (e *Engine) RenderTaskOverdue(event events.TaskEvent) (svc.TemplateResult, error) {
+	return e.render(e.overdue, "Задача просрочена", event)
+}
+
 func (e *Engine) render(tpl *template.Template, subject string, event events.TaskEvent) (svc.TemplateResult, error) {
 	var buf bytes.Buffer
 	data := map[string]any{
@@ -99,3 +109,14 @@ const taskDeletedTemplate = `<!DOCTYPE html>
   <p>{{.Description}}</p>
 </body>
 </html>`
+
+const taskOverdueTemplate = `<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><style>body{font-family:Arial,sans-serif;}h1{color:#e67e22;}</style></head>
+<body>
+  <h1>Задача просрочена</h1>
+  <p>Срок выполнения задачи <strong>{{.Title}}</strong> истёк.</p>
+  <p><strong>Дедлайн:</strong> {{.DueDate}}</p>
+  <p>{{.Description}}</p>
+</body>
+</html>`
@@ -0,0 +1,54 @@
+// Package app holds the HTTP listener of notification-service, which
+// otherwise only consumes messages. It serves operational endpoints only.
+package app
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"todoapp/services/notification-service/internal/infrastructure/health"
+	"todoapp/services/notification-service/internal/infrastructure/metrics"
+)
+
+type Server struct {
+	server *http.Server
+}
+
+func NewServer(addr string, readiness *health.Checker) *Server {
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", metrics.Handler())
+	mux.Handle("GET "+health.LivePath, health.LiveHandler("notification-service"))
+	mux.Handle("GET "+health.ReadyPath, health.ReadyHandler(readiness))
+
+	return &Server{server: &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}}
+}
+
+// Run serves until ctx is done, then shuts the listener down.
+func (s *Server) Run(ctx context.Context) error {
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- s.server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 5*time.Second)
+	defer cancel()
+	if err := s.server.Shutdown(shutdownCtx); err != nil {
+		return err
+	}
+	if err := <-errCh; !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
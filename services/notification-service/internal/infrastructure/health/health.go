@@ -0,0 +1,184 @@
+// Package health answers the probes of notification-service, with the report
+// format of todoapp/pkg/health, which this module cannot import. Readiness
+// reports are cached for a short while, so that frequent probes do not each
+// cause a round of checks.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+type Status string
+
+const (
+	StatusOK          Status = "ok"
+	StatusUnavailable Status = "unavailable"
+)
+
+const (
+	LivePath  = "/health/live"
+	ReadyPath = "/health/ready"
+)
+
+const (
+	defaultTTL     = 5 * time.Second
+	defaultTimeout = 2 * time.Second
+)
+
+// CheckFunc reports whether a dependency can be used, within the deadline of
+// ctx.
+type CheckFunc func(ctx context.Context) error
+
+type Result struct {
+	Status    Status  `json:"status"`
+	LatencyMS float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+type Report struct {
+	Status    Status            `json:"status"`
+	Service   string            `json:"service"`
+	CheckedAt time.Time         `json:"checked_at"`
+	Checks    map[string]Result `json:"checks"`
+}
+
+type check struct {
+	name string
+	run  CheckFunc
+}
+
+// Checker runs the readiness checks of the service. Checks are registered
+// with Add before the checker is used.
+type Checker struct {
+	service string
+	checks  []check
+
+	// mu is held while the checks run, so concurrent callers wait for the
+	// round in progress instead of starting their own.
+	mu      sync.Mutex
+	report  Report
+	expires time.Time
+}
+
+func NewChecker(service string) *Checker {
+	return &Checker{service: service}
+}
+
+// Add registers a dependency the service cannot work without.
+func (c *Checker) Add(name string, run CheckFunc) {
+	c.checks = append(c.checks, check{name: name, run: run})
+}
+
+// Ready returns the latest report, running the checks when it is older than
+// the TTL.
+func (c *Checker) Ready(ctx context.Context) Report {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if time.Now().Before(c.expires) {
+		return c.report
+	}
+
+	c.report = c.run(context.WithoutCancel(ctx))
+	c.expires = c.report.CheckedAt.Add(defaultTTL)
+	return c.report
+}
+
+func (c *Checker) run(ctx context.Context) Report {
+	ctx, cancel := context.WithTimeout(ctx, defaultTimeout)
+	defer cancel()
+
+	results := make([]Result, len(c.checks))
+	var wg sync.WaitGroup
+	for i, check := range c.checks {
+		wg.Go(func() {
+			start := time.Now()
+			err := check.run(ctx)
+			results[i] = Result{
+				Status:    StatusOK,
+				LatencyMS: float64(time.Since(start).Microseconds()) / 1000,
+			}
+			if err != nil {
+				results[i].Status = StatusUnavailable
+				results[i].Error = err.Error()
+			}
+		})
+	}
+	wg.Wait()
+
+	report := Report{
+		Status:    StatusOK,
+		Service:   c.service,
+		CheckedAt: time.Now(),
+		Checks:    make(map[string]Result, len(c.checks)),
+	}
+	for i, check := range c.checks {
+		report.Checks[check.name] = results[i]
+		if results[i].Status != StatusOK {
+			report.Status = StatusUnavailable
+		}
+	}
+	return report
+}
+
+// LiveHandler answers the liveness probe: the process is up.
+func LiveHandler(service string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]any{
+			"status":  StatusOK,
+			"service": service,
+		})
+	})
+}
+
+// ReadyHandler answers the readiness probe with the report of checker, with
+// status 503 while a dependency is down.
+func ReadyHandler(checker *Checker) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		report := checker.Ready(r.Context())
+
+		code := http.StatusOK
+		if report.Status != StatusOK {
+			code = http.StatusServiceUnavailable
+		}
+		writeJSON(w, code, report)
+	})
+}
+
+func writeJSON(w http.ResponseWriter, code int, body any) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+// RabbitMQ checks the broker at url accepts a connection.
+func RabbitMQ(url string) CheckFunc {
+	return func(ctx context.Context) error {
+		conn, err := amqp.DialConfig(url, amqp.Config{
+			Locale: "en_US",
+			Dial: func(network, addr string) (net.Conn, error) {
+				var dialer net.Dialer
+				conn, err := dialer.DialContext(ctx, network, addr)
+				if err != nil {
+					return nil, err
+				}
+				// Bounds the handshake; the client clears it once connected.
+				if deadline, ok := ctx.Deadline(); ok {
+					_ = conn.SetDeadline(deadline)
+				}
+				return conn, nil
+			},
+		})
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	}
+}
@@ -0,0 +1,75 @@
+// Package logging sets up the structured JSON logs of notification-service.
+// It mirrors todoapp/pkg/logging, which this module cannot import, so the
+// records of every service share the same request_id, user_id and trace_id
+// fields.
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"os"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// RequestIDHeader is the AMQP message header holding the ID of the request
+// that produced the message.
+const RequestIDHeader = "X-Request-ID"
+
+type (
+	requestIDKey struct{}
+	userIDKey    struct{}
+)
+
+// New returns a logger writing JSON to stdout with every record tagged with
+// service.
+func New(service string) *slog.Logger {
+	handler := slog.NewJSONHandler(os.Stdout, &slog.HandlerOptions{Level: slog.LevelInfo})
+	return slog.New(&contextHandler{Handler: handler}).With("service", service)
+}
+
+// Fatal logs err and exits, for failures during startup.
+func Fatal(logger *slog.Logger, msg string, err error) {
+	logger.Error(msg, "error", err)
+	os.Exit(1)
+}
+
+// WithMessageHeaders carries the request ID found in AMQP message headers.
+func WithMessageHeaders(ctx context.Context, headers map[string]any) context.Context {
+	id, _ := headers[RequestIDHeader].(string)
+	if id == "" || len(id) > 128 {
+		return ctx
+	}
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+func WithUserID(ctx context.Context, userID int64) context.Context {
+	return context.WithValue(ctx, userIDKey{}, userID)
+}
+
+type contextHandler struct {
+	slog.Handler
+}
+
+func (h *contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if ctx != nil {
+		if id, ok := ctx.Value(requestIDKey{}).(string); ok {
+			record.AddAttrs(slog.String("request_id", id))
+		}
+		if userID, ok := ctx.Value(userIDKey{}).(int64); ok {
+			record.AddAttrs(slog.Int64("user_id", userID))
+		}
+		if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+			record.AddAttrs(slog.String("trace_id", span.TraceID().String()))
+		}
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h *contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *contextHandler) WithGroup(name string) slog.Handler {
+	return &contextHandler{Handler: h.Handler.WithGroup(name)}
+}
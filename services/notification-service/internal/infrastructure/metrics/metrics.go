@@ -0,0 +1,68 @@
+// Package metrics holds the Prometheus metrics of notification-service.
+// Shared metrics have the names used by todoapp/pkg/metrics, which this module
+// cannot import.
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+var (
+	messagesConsumed = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "rabbitmq_messages_consumed_total",
+		Help: "Messages received from RabbitMQ, by source.",
+	}, []string{"source"})
+
+	messagesAcked = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "rabbitmq_messages_acked_total",
+		Help: "Received messages acknowledged, by source.",
+	}, []string{"source"})
+
+	messagesNacked = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "rabbitmq_messages_nacked_total",
+		Help: "Received messages rejected, by source.",
+	}, []string{"source"})
+
+	emailsSent = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "emails_sent_total",
+		Help: "Emails handed to the SMTP server, by result.",
+	}, []string{"result"})
+
+	emailSendDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "email_send_duration_seconds",
+		Help:    "Duration of SMTP sends.",
+		Buckets: prometheus.DefBuckets,
+	})
+)
+
+func MessageConsumed(source string) {
+	messagesConsumed.WithLabelValues(source).Inc()
+}
+
+func MessageAcked(source string) {
+	messagesAcked.WithLabelValues(source).Inc()
+}
+
+func MessageNacked(source string) {
+	messagesNacked.WithLabelValues(source).Inc()
+}
+
+// EmailSent counts an email that failed to send when err is not nil.
+func EmailSent(duration time.Duration, err error) {
+	result := "success"
+	if err != nil {
+		result = "failure"
+	}
+	emailsSent.WithLabelValues(result).Inc()
+	emailSendDuration.Observe(duration.Seconds())
+}
+
+// Handler serves the metrics in the Prometheus text format.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
@@ -13,6 +13,7 @@ type TemplateRenderer interface {
 	RenderTaskCreated(event events.TaskEvent) (TemplateResult, error)
 	RenderTaskCompleted(event events.TaskEvent) (TemplateResult, error)
 	RenderTaskDeleted(event events.TaskEvent) (TemplateResult, error)
+	RenderTaskOverdue(event events.TaskEvent) (TemplateResult, error)
 }
 
 type TemplateResult struct {
@@ -65,6 +66,8 @@ func (s *NotificationService) render(event events.TaskEvent) (TemplateResult, er
 		return s.templates.RenderTaskCompleted(event)
 	case events.TaskDeleted:
 		return s.templates.RenderTaskDeleted(event)
+	case events.TaskOverdue:
+		return s.templates.RenderTaskOverdue(event)
 	default:
 		return TemplateResult{}, fmt.Errorf("%w: %s", errUnknownType, event.Type)
 	}
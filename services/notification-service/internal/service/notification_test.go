@@ -36,6 +36,10 @@ func (t templatesStub) RenderTaskDeleted(event events.TaskEvent) (TemplateResult
 	return t.result, t.err
 }
 
+func (t templatesStub) RenderTaskOverdue(event events.TaskEvent) (TemplateResult, error) {
+	return TemplateResult{Subject: "overdue", Body: t.result.Body}, t.err
+}
+
 func TestHandleSendsMail(t *testing.T) {
 	mailer := &mailerStub{}
 	templates := templatesStub{result: TemplateResult{Subject: "s", Body: "b"}}
@@ -78,6 +82,29 @@ func TestHandleDeletedEvent(t *testing.T) {
 	}
 }
 
+func TestHandleOverdueEvent(t *testing.T) {
+	mailer := &mailerStub{}
+	templates := templatesStub{result: TemplateResult{Subject: "s", Body: "body"}}
+	svc := NewNotificationService(mailer, templates)
+
+	due := time.Now().Add(-time.Hour)
+	event := events.TaskEvent{
+		ID:        "id",
+		Type:      events.TaskOverdue,
+		TaskID:    4,
+		DueDate:   &due,
+		UserEmail: "user@example.com",
+		CreatedAt: time.Now(),
+	}
+
+	if err := svc.Handle(context.Background(), event); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mailer.last.Subject != "overdue" {
+		t.Fatalf("expected overdue template to be used, got %q", mailer.last.Subject)
+	}
+}
+
 func TestHandleUnknownTypeIsIgnored(t *testing.T) {
 	mailer := &mailerStub{}
 	templates := templatesStub{}
@@ -3,88 +3,357 @@ package main
 import (
 	"context"
 	"errors"
-	"log"
+	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
 	"time"
 
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+
+	"todoapp/migrations"
+
+	"todoapp/pkg/grpcclient"
+	"todoapp/pkg/health"
+	"todoapp/pkg/logging"
+	"todoapp/pkg/metrics"
+	taskv1 "todoapp/pkg/proto/task/v1"
+	"todoapp/pkg/ratelimit"
+	"todoapp/pkg/requestid"
+	"todoapp/pkg/tracing"
 	authadapter "todoapp/services/task-service/internal/adapters/auth"
 	analyticsgrpc "todoapp/services/task-service/internal/adapters/clients/analyticsgrpc"
+	"todoapp/services/task-service/internal/adapters/clients/degraded"
+	usercache "todoapp/services/task-service/internal/adapters/clients/usercache"
 	usergrpc "todoapp/services/task-service/internal/adapters/clients/usergrpc"
 	dbadapter "todoapp/services/task-service/internal/adapters/database"
 	rabbitpublisher "todoapp/services/task-service/internal/adapters/events/rabbitmq"
+	taskgrpc "todoapp/services/task-service/internal/adapters/grpc"
+	"todoapp/services/task-service/internal/adapters/storage/localfs"
+	s3store "todoapp/services/task-service/internal/adapters/storage/s3"
+	webhookadapter "todoapp/services/task-service/internal/adapters/webhook"
 	"todoapp/services/task-service/internal/infrastructure/app"
 	"todoapp/services/task-service/internal/infrastructure/config"
 	"todoapp/services/task-service/internal/infrastructure/postgres"
+	"todoapp/services/task-service/internal/ports"
 	"todoapp/services/task-service/internal/service"
 )
 
 func main() {
+	logger := logging.New("task-service")
+	slog.SetDefault(logger)
+
 	cfg, err := config.Load()
 	if err != nil {
-		log.Fatalf("failed to load config: %v", err)
+		logging.Fatal(logger, "failed to load config", err)
 	}
 
 	ctx := context.Background()
 
+	shutdownTracing, err := tracing.Setup(ctx, "task-service", tracing.Config{
+		Exporter:    cfg.Tracing.Exporter,
+		Endpoint:    cfg.Tracing.Endpoint,
+		Insecure:    cfg.Tracing.Insecure,
+		FilePath:    cfg.Tracing.FilePath,
+		SampleRatio: cfg.Tracing.SampleRatio,
+	})
+	if err != nil {
+		logging.Fatal(logger, "failed to set up tracing", err)
+	}
+	defer func() {
+		flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(flushCtx); err != nil {
+			logger.Error("failed to flush traces", "error", err)
+		}
+	}()
+
 	pool, err := postgres.NewPool(ctx, cfg)
 	if err != nil {
-		log.Fatalf("failed to connect to postgres: %v", err)
+		logging.Fatal(logger, "failed to connect to postgres", err)
 	}
 	defer pool.Close()
+	if err := metrics.RegisterPool(pool, "primary"); err != nil {
+		logging.Fatal(logger, "failed to register pool metrics", err)
+	}
 
-	repo := dbadapter.NewPostgresTaskRepository(pool)
+	var repoOpts []dbadapter.TaskRepositoryOption
+	replicaPool, err := postgres.NewReplicaPool(ctx, cfg, pool, logger)
+	if err != nil {
+		logging.Fatal(logger, "failed to connect to postgres replica", err)
+	}
+	if replicaPool != nil {
+		defer replicaPool.Close()
+		if err := metrics.RegisterPool(replicaPool.Replica(), "replica"); err != nil {
+			logging.Fatal(logger, "failed to register pool metrics", err)
+		}
+		repoOpts = append(repoOpts, dbadapter.WithReadReplica(replicaPool))
+	}
 
-	logger := log.New(os.Stdout, "task-service ", log.LstdFlags|log.Lshortfile)
+	repo := dbadapter.NewPostgresTaskRepository(pool, repoOpts...)
+	txManager := dbadapter.NewTransactionManager(pool)
+	outbox := dbadapter.NewPostgresOutboxRepository(pool)
+	analyticsOutbox := dbadapter.NewPostgresAnalyticsOutbox(pool)
 
 	userClient, err := usergrpc.New(usergrpc.Config{
-		Address: cfg.UserService.GRPCAddr,
-		Timeout: cfg.UserService.Timeout,
+		Address:     cfg.UserService.GRPCAddr,
+		Timeout:     cfg.UserService.Timeout,
+		MaxAttempts: cfg.UserService.MaxAttempts,
+		Breaker: grpcclient.NewBreaker("user-service", grpcclient.BreakerConfig{
+			FailureThreshold: cfg.UserService.BreakerThreshold,
+			OpenTimeout:      cfg.UserService.BreakerOpenTimeout,
+		}, logger),
 	})
 	if err != nil {
-		log.Fatalf("failed to connect to user-service: %v", err)
+		logging.Fatal(logger, "failed to create user-service client", err)
 	}
 	defer userClient.Close()
 
+	userCache := usercache.New(userClient, usercache.Config{
+		TTL:         cfg.UserService.CacheTTL,
+		NegativeTTL: cfg.UserService.CacheNegativeTTL,
+		MaxEntries:  cfg.UserService.CacheSize,
+	})
+	userDirectory := degraded.New(userCache, degraded.Mode(cfg.UserService.Degradation), logger)
+	tokenCache := usercache.NewTokens(userClient, usercache.TokenConfig{
+		TTL:        cfg.UserService.TokenCacheTTL,
+		MaxEntries: cfg.UserService.CacheSize,
+	})
+	tokenValidator := degraded.NewTokenValidator(tokenCache, degraded.Mode(cfg.UserService.Degradation), logger)
+
 	analyticsClient, err := analyticsgrpc.New(analyticsgrpc.Config{
-		Address: cfg.Analytics.GRPCAddr,
-		Timeout: cfg.Analytics.Timeout,
+		Address:     cfg.Analytics.GRPCAddr,
+		Timeout:     cfg.Analytics.Timeout,
+		MaxAttempts: cfg.Analytics.MaxAttempts,
+		Breaker: grpcclient.NewBreaker("analytics-service", grpcclient.BreakerConfig{
+			FailureThreshold: cfg.Analytics.BreakerThreshold,
+			OpenTimeout:      cfg.Analytics.BreakerOpenTimeout,
+		}, logger),
 	})
 	if err != nil {
-		log.Fatalf("failed to connect to analytics service: %v", err)
+		logging.Fatal(logger, "failed to create analytics-service client", err)
 	}
 	defer analyticsClient.Close()
 
 	publisher, err := rabbitpublisher.New(cfg.Rabbit.URL, cfg.Rabbit.Queue)
 	if err != nil {
-		log.Fatalf("failed to initialize rabbitmq publisher: %v", err)
+		logging.Fatal(logger, "failed to initialize rabbitmq publisher", err)
 	}
 	defer publisher.Close()
 
+	blobStore, err := newBlobStore(ctx, cfg)
+	if err != nil {
+		logging.Fatal(logger, "failed to initialize blob storage", err)
+	}
+
+	attachmentService := service.NewAttachmentService(
+		repo,
+		dbadapter.NewPostgresAttachmentRepository(pool),
+		blobStore,
+		service.WithAttachmentUserDirectory(userDirectory),
+		service.WithAttachmentLimits(service.AttachmentLimits{
+			MaxFileSize: cfg.Storage.MaxFileSize,
+			UserQuota:   cfg.Storage.UserQuota,
+		}),
+		service.WithAttachmentLogger(logger),
+	)
+
+	timeTrackingService := service.NewTimeTrackingService(
+		dbadapter.NewPostgresTimeEntryRepository(pool),
+		repo,
+		service.WithTimeTrackingUserDirectory(userDirectory),
+		service.WithTimeTrackingAnalytics(analyticsOutbox),
+		service.WithTimeTrackingTransactionManager(txManager),
+		service.WithTimeTrackingLogger(logger),
+	)
+
+	webhookRepo := dbadapter.NewPostgresWebhookRepository(pool)
+	webhookSender := webhookadapter.NewSender(webhookadapter.Config{
+		AllowPrivateNetworks: cfg.Webhooks.AllowPrivateNetworks,
+	})
+	webhookService := service.NewWebhookService(
+		webhookRepo,
+		webhookSender,
+		service.WithWebhookUserDirectory(userDirectory),
+	)
+
+	workflowRepo := dbadapter.NewPostgresWorkflowRepository(pool)
+	customFieldRepo := dbadapter.NewPostgresCustomFieldRepository(pool)
+
+	changeRepo := dbadapter.NewPostgresChangeRepository(pool)
+	changeHub := service.NewChangeHub(
+		dbadapter.NewPostgresChangeListener(pool),
+		changeRepo,
+		service.WithChangeLogger(logger),
+	)
+
 	taskService := service.NewTaskService(
 		repo,
-		service.WithUserDirectory(userClient),
-		service.WithAnalyticsTracker(analyticsClient),
-		service.WithEventPublisher(publisher),
+		service.WithUserDirectory(userDirectory),
+		service.WithAnalyticsTracker(analyticsOutbox),
+		service.WithEventPublisher(outbox),
+		service.WithWebhookDispatcher(webhookService),
+		service.WithChangeRecorder(changeRepo),
+		service.WithTransactionManager(txManager),
+		service.WithAttachmentPurger(attachmentService),
+		service.WithTimerStopper(timeTrackingService),
+		service.WithWorkflowResolver(workflowRepo),
+		service.WithCustomFieldLister(customFieldRepo),
 		service.WithLogger(logger),
 	)
+	templateService := service.NewTemplateService(
+		dbadapter.NewPostgresTemplateRepository(pool),
+		repo,
+		taskService,
+		service.WithTemplateUserDirectory(userDirectory),
+		service.WithTemplateTransactionManager(txManager),
+	)
+	quickAddService := service.NewQuickAddService(
+		repo,
+		taskService,
+		service.WithQuickAddUserDirectory(userDirectory),
+	)
+	viewService := service.NewViewService(
+		dbadapter.NewPostgresViewRepository(pool),
+		repo,
+		service.WithViewUserDirectory(userDirectory),
+		service.WithViewCustomFields(customFieldRepo),
+	)
+	workflowService := service.NewWorkflowService(
+		workflowRepo,
+		service.WithWorkflowUserDirectory(userDirectory),
+	)
+	customFieldService := service.NewCustomFieldService(
+		customFieldRepo,
+		service.WithCustomFieldUserDirectory(userDirectory),
+	)
 	tokenManager := authadapter.NewJWTManager(cfg.JWT.AccessSecret, cfg.JWT.RefreshSecret, cfg.JWT.AccessTTL, cfg.JWT.RefreshTTL)
 
+	rateLimiter, closeRateLimiter := newRateLimiter(cfg, logger)
+	defer closeRateLimiter()
+
+	readiness := health.NewChecker("task-service")
+	readiness.Add("postgres", health.Postgres(pool, migrations.Version()))
+	if replicaPool != nil {
+		readiness.AddOptional("postgres-replica", health.Postgres(replicaPool.Replica(), migrations.Version()))
+	}
+	// Without a degradation mode no request gets past a user lookup while
+	// user-service is down. Events wait in the outboxes for the others.
+	if degraded.Mode(cfg.UserService.Degradation) == degraded.ModeOff {
+		readiness.Add("user-service", userClient.HealthCheck())
+	} else {
+		readiness.AddOptional("user-service", userClient.HealthCheck())
+	}
+	readiness.AddOptional("analytics-service", analyticsClient.HealthCheck())
+	readiness.AddOptional("rabbitmq", health.RabbitMQ(cfg.Rabbit.URL))
+
 	router, err := app.NewRouter(app.HTTPDeps{
-		TaskService: taskService,
-		TokenMgr:    tokenManager,
-		ServiceName: cfg.ServiceName,
+		TaskService:         taskService,
+		AttachmentService:   attachmentService,
+		MaxAttachmentSize:   cfg.Storage.MaxFileSize,
+		TemplateService:     templateService,
+		QuickAddService:     quickAddService,
+		ViewService:         viewService,
+		TimeTrackingService: timeTrackingService,
+		WebhookService:      webhookService,
+		WorkflowService:     workflowService,
+		CustomFieldService:  customFieldService,
+		ChangeStream:        changeHub,
+		TokenMgr:            tokenManager,
+		TokenValidator:      tokenValidator,
+		RateLimiter:         rateLimiter,
+		TrustedProxies:      cfg.TrustedProxies,
+		ServiceName:         cfg.ServiceName,
+		Readiness:           readiness,
+		Logger:              logger,
 	})
 	if err != nil {
-		log.Fatalf("failed to initialize router: %v", err)
+		logging.Fatal(logger, "failed to initialize router", err)
 	}
 
 	server := &http.Server{
 		Addr:    cfg.HTTPAddr,
 		Handler: router,
 	}
+	// Streams never finish on their own; end them so Shutdown can drain.
+	server.RegisterOnShutdown(changeHub.CloseSubscriptions)
+
+	grpcListener, err := net.Listen("tcp", cfg.GRPCAddr)
+	if err != nil {
+		logging.Fatal(logger, "failed to listen on "+cfg.GRPCAddr, err)
+	}
+	defer grpcListener.Close()
+
+	grpcServer := grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(requestid.UnaryServerInterceptor(), metrics.UnaryServerInterceptor()),
+	)
+	taskv1.RegisterTaskServiceServer(grpcServer, taskgrpc.NewServer(taskService))
+	healthServer := grpchealth.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+
+	overdueScanner := service.NewOverdueScanner(
+		dbadapter.NewPostgresOverdueRepository(pool),
+		userDirectory,
+		outbox,
+		service.WithOverdueTransactionManager(txManager),
+		service.WithOverdueLogger(logger),
+	)
+	outboxRelay := service.NewOutboxRelay(
+		outbox,
+		txManager,
+		publisher,
+		service.WithOutboxLogger(logger),
+	)
+	analyticsRelay := service.NewAnalyticsRelay(
+		analyticsOutbox,
+		txManager,
+		analyticsClient,
+		service.WithAnalyticsLogger(logger),
+	)
+	userEventConsumer := rabbitpublisher.NewUserEventConsumer(cfg.Rabbit.URL, logger, userCache, tokenCache).WithSessions(changeHub)
+	webhookWorker := service.NewWebhookWorker(
+		webhookRepo,
+		webhookSender,
+		service.WithWebhookLogger(logger),
+	)
+
+	workersCtx, stopWorkers := context.WithCancel(ctx)
+	var workers sync.WaitGroup
+
+	workers.Go(func() {
+		overdueScanner.Run(workersCtx)
+	})
+	workers.Go(func() {
+		outboxRelay.Run(workersCtx)
+	})
+	workers.Go(func() {
+		analyticsRelay.Run(workersCtx)
+	})
+	workers.Go(func() {
+		webhookWorker.Run(workersCtx)
+	})
+	workers.Go(func() {
+		changeHub.Run(workersCtx)
+	})
+	workers.Go(func() {
+		userEventConsumer.Run(workersCtx)
+	})
+	workers.Go(func() {
+		readiness.Publish(workersCtx, healthServer, 10*time.Second, taskv1.TaskService_ServiceDesc.ServiceName)
+	})
+	if replicaPool != nil {
+		workers.Go(func() {
+			replicaPool.Run(workersCtx)
+		})
+	}
 
 	done := make(chan struct{})
 
@@ -92,7 +361,13 @@ func main() {
 		defer close(done)
 
 		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
-			log.Printf("server error: %v", err)
+			logger.Error("http server stopped", "error", err)
+		}
+	}()
+
+	go func() {
+		if err := grpcServer.Serve(grpcListener); err != nil {
+			logger.Error("grpc server stopped", "error", err)
 		}
 	}()
 
@@ -104,8 +379,63 @@ func main() {
 	defer cancel()
 
 	if err := server.Shutdown(shutdownCtx); err != nil {
-		log.Printf("shutdown error: %v", err)
+		logger.Error("shutdown failed", "error", err)
 	}
+	healthServer.Shutdown()
+	grpcServer.GracefulStop()
 
 	<-done
+
+	stopWorkers()
+	workers.Wait()
+}
+
+func newBlobStore(ctx context.Context, cfg config.Config) (ports.BlobStore, error) {
+	switch cfg.Storage.Driver {
+	case "s3":
+		store, err := s3store.New(s3store.Config{
+			Endpoint:  cfg.Storage.S3.Endpoint,
+			AccessKey: cfg.Storage.S3.AccessKey,
+			SecretKey: cfg.Storage.S3.SecretKey,
+			Bucket:    cfg.Storage.S3.Bucket,
+			Region:    cfg.Storage.S3.Region,
+			UseSSL:    cfg.Storage.S3.UseSSL,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := store.EnsureBucket(ctx); err != nil {
+			return nil, err
+		}
+		return store, nil
+	default:
+		return localfs.New(cfg.Storage.LocalDir)
+	}
+}
+
+// newRateLimiter shares limits between instances through Redis when it is
+// configured and keeps them per instance otherwise.
+func newRateLimiter(cfg config.Config, logger *slog.Logger) (ratelimit.Limiter, func()) {
+	rule := ratelimit.Rule{
+		Limit:  cfg.RateLimit.Requests,
+		Window: cfg.RateLimit.Window,
+	}
+	opts := []ratelimit.Option{
+		ratelimit.WithPrefix("ratelimit:task-service"),
+		ratelimit.WithLogger(logger),
+	}
+
+	if cfg.Redis.Addr == "" {
+		return ratelimit.New(nil, rule, opts...), func() {}
+	}
+
+	client := redis.NewClient(&redis.Options{
+		Addr:     cfg.Redis.Addr,
+		Password: cfg.Redis.Password,
+		DB:       cfg.Redis.DB,
+	})
+
+	return ratelimit.New(client, rule, opts...), func() {
+		_ = client.Close()
+	}
 }
@@ -7,16 +7,24 @@ import (
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/status"
 
+	"todoapp/pkg/grpcclient"
+	"todoapp/pkg/health"
 	analyticsv1 "todoapp/pkg/proto/analytics/v1"
 	"todoapp/services/task-service/internal/ports"
 )
 
 type Config struct {
 	Address string
+	// Timeout bounds a call including its retries. A shorter deadline on the
+	// caller's context takes precedence.
 	Timeout time.Duration
+	// MaxAttempts is how often a call is tried while analytics-service is
+	// unreachable.
+	MaxAttempts int
+	// Breaker defaults to one with the default settings.
+	Breaker *grpcclient.Breaker
 }
 
 type grpcConn interface {
@@ -30,11 +38,12 @@ type Client struct {
 	timeout time.Duration
 }
 
-var _ ports.AnalyticsTracker = (*Client)(nil)
+var (
+	_ ports.AnalyticsTracker      = (*Client)(nil)
+	_ ports.AnalyticsBatchTracker = (*Client)(nil)
+)
 
-var dialGRPC = func(ctx context.Context, target string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
-	return grpc.DialContext(ctx, target, opts...)
-}
+var dialGRPC = grpc.NewClient
 
 func New(cfg Config) (*Client, error) {
 	if cfg.Address == "" {
@@ -43,8 +52,25 @@ func New(cfg Config) (*Client, error) {
 	if cfg.Timeout <= 0 {
 		cfg.Timeout = 2 * time.Second
 	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 3
+	}
+	if cfg.Breaker == nil {
+		cfg.Breaker = grpcclient.NewBreaker("analytics-service", grpcclient.BreakerConfig{}, nil)
+	}
 
-	conn, err := dialGRPC(context.Background(), cfg.Address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	// Tracking is safe to repeat: analytics-service skips event IDs it has
+	// already processed.
+	conn, err := dialGRPC(cfg.Address, grpcclient.DialOptions(
+		grpcclient.WithRetry(grpcclient.RetryPolicy{
+			MaxAttempts: cfg.MaxAttempts,
+			Methods: []string{
+				analyticsv1.AnalyticsService_TrackTaskEvent_FullMethodName,
+				analyticsv1.AnalyticsService_BatchTrackTaskEvents_FullMethodName,
+			},
+		}),
+		grpcclient.WithBreaker(cfg.Breaker),
+	)...)
 	if err != nil {
 		return nil, err
 	}
@@ -63,15 +89,14 @@ func (c *Client) Close() error {
 	return c.conn.Close()
 }
 
+// HealthCheck asks analytics-service whether it is serving, over the connection
+// the client calls it on.
+func (c *Client) HealthCheck() health.CheckFunc {
+	return health.GRPC(c.conn, analyticsv1.AnalyticsService_ServiceDesc.ServiceName)
+}
+
 func (c *Client) TrackTaskEvent(ctx context.Context, event ports.AnalyticsEvent) error {
-	req := &analyticsv1.TrackTaskEventRequest{
-		Type:       event.Type,
-		UserId:     event.UserID,
-		TaskId:     event.TaskID,
-		Status:     event.Status,
-		Priority:   event.Priority,
-		OccurredAt: event.OccurredAt.Unix(),
-	}
+	req := trackRequest(event)
 
 	ctx, cancel := context.WithTimeout(ctx, c.timeout)
 	defer cancel()
@@ -87,3 +112,33 @@ func (c *Client) TrackTaskEvent(ctx context.Context, event ports.AnalyticsEvent)
 
 	return nil
 }
+
+// BatchTrackTaskEvents sends the events in one call. Events rejected as
+// invalid by analytics-service are skipped on its side and not reported.
+func (c *Client) BatchTrackTaskEvents(ctx context.Context, events []ports.AnalyticsEvent) error {
+	req := &analyticsv1.BatchTrackTaskEventsRequest{
+		Events: make([]*analyticsv1.TrackTaskEventRequest, 0, len(events)),
+	}
+	for _, event := range events {
+		req.Events = append(req.Events, trackRequest(event))
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	_, err := c.client.BatchTrackTaskEvents(ctx, req)
+	return err
+}
+
+func trackRequest(event ports.AnalyticsEvent) *analyticsv1.TrackTaskEventRequest {
+	return &analyticsv1.TrackTaskEventRequest{
+		EventId:         event.ID,
+		Type:            event.Type,
+		UserId:          event.UserID,
+		TaskId:          event.TaskID,
+		Status:          event.Status,
+		Priority:        event.Priority,
+		OccurredAt:      event.OccurredAt.Unix(),
+		DurationSeconds: int64(event.Duration / time.Second),
+	}
+}
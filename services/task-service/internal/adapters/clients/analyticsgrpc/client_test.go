@@ -25,10 +25,11 @@ func (fakeConn) NewStream(ctx context.Context, desc *grpc.StreamDesc, method str
 func (fakeConn) Close() error { return nil }
 
 type analyticsClientStub struct {
-	err      error
-	lastReq  *analyticsv1.TrackTaskEventRequest
-	metrics  *analyticsv1.GetDailyMetricsResponse
-	metricsE error
+	err       error
+	lastReq   *analyticsv1.TrackTaskEventRequest
+	lastBatch *analyticsv1.BatchTrackTaskEventsRequest
+	metrics   *analyticsv1.GetDailyMetricsResponse
+	metricsE  error
 }
 
 func (c *analyticsClientStub) TrackTaskEvent(ctx context.Context, in *analyticsv1.TrackTaskEventRequest, opts ...grpc.CallOption) (*analyticsv1.TrackTaskEventResponse, error) {
@@ -36,6 +37,11 @@ func (c *analyticsClientStub) TrackTaskEvent(ctx context.Context, in *analyticsv
 	return &analyticsv1.TrackTaskEventResponse{}, c.err
 }
 
+func (c *analyticsClientStub) BatchTrackTaskEvents(ctx context.Context, in *analyticsv1.BatchTrackTaskEventsRequest, opts ...grpc.CallOption) (*analyticsv1.BatchTrackTaskEventsResponse, error) {
+	c.lastBatch = in
+	return &analyticsv1.BatchTrackTaskEventsResponse{}, c.err
+}
+
 func (c *analyticsClientStub) GetDailyMetrics(ctx context.Context, in *analyticsv1.GetDailyMetricsRequest, opts ...grpc.CallOption) (*analyticsv1.GetDailyMetricsResponse, error) {
 	return c.metrics, c.metricsE
 }
@@ -49,7 +55,7 @@ func TestNewValidation(t *testing.T) {
 func TestNewSetsDefaultTimeout(t *testing.T) {
 	origDial := dialGRPC
 	defer func() { dialGRPC = origDial }()
-	dialGRPC = func(ctx context.Context, target string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	dialGRPC = func(target string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
 		return &grpc.ClientConn{}, nil
 	}
 
@@ -98,3 +104,42 @@ func TestTrackTaskEvent(t *testing.T) {
 		})
 	}
 }
+
+func TestTrackTaskEvent_Duration(t *testing.T) {
+	stub := &analyticsClientStub{}
+	client := &Client{client: stub, timeout: time.Second}
+
+	err := client.TrackTaskEvent(context.Background(), ports.AnalyticsEvent{
+		Type:     analyticsv1.TaskEventType_TASK_EVENT_TYPE_TIME_TRACKED,
+		UserID:   1,
+		TaskID:   2,
+		Duration: 90*time.Second + 400*time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stub.lastReq.DurationSeconds != 90 {
+		t.Fatalf("expected 90 seconds, got %d", stub.lastReq.DurationSeconds)
+	}
+}
+
+func TestBatchTrackTaskEvents(t *testing.T) {
+	stub := &analyticsClientStub{}
+	client := &Client{client: stub, timeout: time.Second}
+
+	err := client.BatchTrackTaskEvents(context.Background(), []ports.AnalyticsEvent{
+		{ID: "a", Type: analyticsv1.TaskEventType_TASK_EVENT_TYPE_CREATED, UserID: 1, TaskID: 2},
+		{ID: "b", Type: analyticsv1.TaskEventType_TASK_EVENT_TYPE_DELETED, UserID: 1, TaskID: 2},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(stub.lastBatch.GetEvents()) != 2 || stub.lastBatch.GetEvents()[1].GetEventId() != "b" {
+		t.Fatalf("unexpected batch: %+v", stub.lastBatch)
+	}
+
+	stub.err = status.Error(codes.Unavailable, "down")
+	if err := client.BatchTrackTaskEvents(context.Background(), []ports.AnalyticsEvent{{ID: "c"}}); err == nil {
+		t.Fatalf("expected error to be returned for retry")
+	}
+}
@@ -0,0 +1,68 @@
+// Package degraded decides what task-service does while user-service cannot
+// be reached.
+package degraded
+
+import (
+	"context"
+	"log/slog"
+
+	"todoapp/pkg/errors"
+	"todoapp/services/task-service/internal/ports"
+)
+
+type Mode string
+
+const (
+	// ModeOff fails every request that needs a user lookup.
+	ModeOff Mode = "off"
+	// ModeReads lets read-only requests through and refuses writes.
+	ModeReads Mode = "reads"
+)
+
+type readOnlyKey struct{}
+
+// WithReadOnly marks ctx as belonging to a request that changes nothing.
+func WithReadOnly(ctx context.Context) context.Context {
+	return context.WithValue(ctx, readOnlyKey{}, true)
+}
+
+func isReadOnly(ctx context.Context) bool {
+	readOnly, _ := ctx.Value(readOnlyKey{}).(bool)
+	return readOnly
+}
+
+// Directory is a ports.UserDirectory that, in ModeReads, answers lookups
+// for read-only requests while user-service is unavailable. The user it
+// returns is only known to be active, so a user deactivated during the
+// outage can still read their tasks until user-service is back.
+type Directory struct {
+	next   ports.UserDirectory
+	mode   Mode
+	logger *slog.Logger
+}
+
+var _ ports.UserDirectory = (*Directory)(nil)
+
+func New(next ports.UserDirectory, mode Mode, logger *slog.Logger) *Directory {
+	if mode != ModeReads {
+		mode = ModeOff
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &Directory{next: next, mode: mode, logger: logger}
+}
+
+func (d *Directory) GetUser(ctx context.Context, userID int64) (*ports.UserInfo, error) {
+	user, err := d.next.GetUser(ctx, userID)
+	if err == nil || !errors.IsCode(err, errors.CodeServiceUnavailable) {
+		return user, err
+	}
+
+	if d.mode == ModeReads && isReadOnly(ctx) {
+		d.logger.WarnContext(ctx, "user-service unavailable, serving read without verification", "user_id", userID, "error", err)
+		return &ports.UserInfo{ID: userID, Active: true}, nil
+	}
+	return nil, err
+}
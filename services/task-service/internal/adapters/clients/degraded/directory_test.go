@@ -0,0 +1,70 @@
+package degraded
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"todoapp/pkg/errors"
+	"todoapp/services/task-service/internal/ports"
+)
+
+type directoryStub struct {
+	err error
+}
+
+func (s directoryStub) GetUser(ctx context.Context, userID int64) (*ports.UserInfo, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &ports.UserInfo{ID: userID, Email: "user@example.com", Active: true}, nil
+}
+
+func newTestDirectory(err error, mode Mode) *Directory {
+	return New(directoryStub{err: err}, mode, slog.New(slog.DiscardHandler))
+}
+
+func TestReadsAllowedWhileUnavailable(t *testing.T) {
+	d := newTestDirectory(errors.ErrServiceUnavailable, ModeReads)
+
+	user, err := d.GetUser(WithReadOnly(context.Background()), 7)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if user.ID != 7 || !user.Active {
+		t.Fatalf("unexpected user: %+v", user)
+	}
+}
+
+func TestWritesRefusedWhileUnavailable(t *testing.T) {
+	d := newTestDirectory(errors.ErrServiceUnavailable, ModeReads)
+
+	if _, err := d.GetUser(context.Background(), 7); !errors.IsCode(err, errors.CodeServiceUnavailable) {
+		t.Fatalf("expected service unavailable, got %v", err)
+	}
+}
+
+func TestModeOffRefusesReads(t *testing.T) {
+	d := newTestDirectory(errors.ErrServiceUnavailable, Mode("unknown"))
+
+	if _, err := d.GetUser(WithReadOnly(context.Background()), 7); !errors.IsCode(err, errors.CodeServiceUnavailable) {
+		t.Fatalf("expected service unavailable, got %v", err)
+	}
+}
+
+func TestOtherErrorsPassThrough(t *testing.T) {
+	d := newTestDirectory(errors.ErrUserNotFound, ModeReads)
+
+	if _, err := d.GetUser(WithReadOnly(context.Background()), 7); !errors.IsCode(err, errors.CodeUserNotFound) {
+		t.Fatalf("expected user not found, got %v", err)
+	}
+}
+
+func TestAvailableUserIsReturned(t *testing.T) {
+	d := newTestDirectory(nil, ModeReads)
+
+	user, err := d.GetUser(WithReadOnly(context.Background()), 7)
+	if err != nil || user.Email != "user@example.com" {
+		t.Fatalf("unexpected result: %+v, %v", user, err)
+	}
+}
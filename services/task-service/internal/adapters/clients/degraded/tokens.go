@@ -0,0 +1,44 @@
+package degraded
+
+import (
+	"context"
+	"log/slog"
+
+	"todoapp/pkg/errors"
+	"todoapp/services/task-service/internal/ports"
+)
+
+// TokenValidator is a ports.TokenValidator that, in ModeReads, accepts
+// tokens on read-only requests while user-service is unavailable. Such
+// tokens have still passed the local signature and expiry checks.
+type TokenValidator struct {
+	next   ports.TokenValidator
+	mode   Mode
+	logger *slog.Logger
+}
+
+var _ ports.TokenValidator = (*TokenValidator)(nil)
+
+func NewTokenValidator(next ports.TokenValidator, mode Mode, logger *slog.Logger) *TokenValidator {
+	if mode != ModeReads {
+		mode = ModeOff
+	}
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &TokenValidator{next: next, mode: mode, logger: logger}
+}
+
+func (v *TokenValidator) ValidateAccessToken(ctx context.Context, token string, claims *ports.TokenClaims) error {
+	err := v.next.ValidateAccessToken(ctx, token, claims)
+	if err == nil || !errors.IsCode(err, errors.CodeServiceUnavailable) {
+		return err
+	}
+
+	if v.mode == ModeReads && isReadOnly(ctx) {
+		v.logger.WarnContext(ctx, "user-service unavailable, accepting token without revocation check", "user_id", claims.UserID, "error", err)
+		return nil
+	}
+	return err
+}
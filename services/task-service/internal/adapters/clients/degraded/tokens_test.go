@@ -0,0 +1,52 @@
+package degraded
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"todoapp/pkg/errors"
+	"todoapp/services/task-service/internal/ports"
+)
+
+type validatorStub struct {
+	err error
+}
+
+func (s validatorStub) ValidateAccessToken(ctx context.Context, token string, claims *ports.TokenClaims) error {
+	return s.err
+}
+
+func TestTokenValidatorDegradation(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		mode       Mode
+		readOnly   bool
+		expectCode errors.ErrorCode
+	}{
+		{name: "read while unavailable", err: errors.ErrServiceUnavailable, mode: ModeReads, readOnly: true},
+		{name: "write while unavailable", err: errors.ErrServiceUnavailable, mode: ModeReads, expectCode: errors.CodeServiceUnavailable},
+		{name: "mode off", err: errors.ErrServiceUnavailable, mode: ModeOff, readOnly: true, expectCode: errors.CodeServiceUnavailable},
+		{name: "revoked read", err: errors.ErrTokenRevoked, mode: ModeReads, readOnly: true, expectCode: errors.CodeTokenRevoked},
+	}
+
+	for _, tt := range tests {
+		v := NewTokenValidator(validatorStub{err: tt.err}, tt.mode, slog.New(slog.DiscardHandler))
+		ctx := context.Background()
+		if tt.readOnly {
+			ctx = WithReadOnly(ctx)
+		}
+
+		err := v.ValidateAccessToken(ctx, "token", &ports.TokenClaims{UserID: 7})
+		if tt.expectCode == "" {
+			if err != nil {
+				t.Fatalf("%s: unexpected error: %v", tt.name, err)
+			}
+			continue
+		}
+		if !errors.IsCode(err, tt.expectCode) {
+			t.Fatalf("%s: expected %s, got %v", tt.name, tt.expectCode, err)
+		}
+	}
+}
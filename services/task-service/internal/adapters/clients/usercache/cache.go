@@ -0,0 +1,94 @@
+// Package usercache keeps recently used users in memory, so most requests do
+// not need a round trip to user-service.
+package usercache
+
+import (
+	"context"
+	"time"
+
+	"todoapp/pkg/errors"
+	"todoapp/services/task-service/internal/ports"
+)
+
+const (
+	defaultTTL         = time.Minute
+	defaultNegativeTTL = 10 * time.Second
+	defaultMaxEntries  = 10000
+)
+
+type Config struct {
+	// TTL bounds how long a change can go unnoticed when its event is lost.
+	TTL time.Duration
+	// NegativeTTL is how long a missing user is remembered.
+	NegativeTTL time.Duration
+	MaxEntries  int
+}
+
+// Directory is a ports.UserDirectory that caches another one. Concurrent
+// misses for the same user share a single lookup, and the least recently
+// used users are evicted once MaxEntries is reached. Only "user not found"
+// errors are cached.
+type Directory struct {
+	next        ports.UserDirectory
+	ttl         time.Duration
+	negativeTTL time.Duration
+	cache       *lruCache[int64, *ports.UserInfo]
+}
+
+var (
+	_ ports.UserDirectory = (*Directory)(nil)
+	_ ports.UserCache     = (*Directory)(nil)
+)
+
+func New(next ports.UserDirectory, cfg Config) *Directory {
+	if cfg.TTL <= 0 {
+		cfg.TTL = defaultTTL
+	}
+	if cfg.NegativeTTL <= 0 {
+		cfg.NegativeTTL = defaultNegativeTTL
+	}
+	if cfg.MaxEntries <= 0 {
+		cfg.MaxEntries = defaultMaxEntries
+	}
+
+	return &Directory{
+		next:        next,
+		ttl:         cfg.TTL,
+		negativeTTL: cfg.NegativeTTL,
+		cache:       newLRUCache[int64, *ports.UserInfo](cfg.MaxEntries),
+	}
+}
+
+func (d *Directory) GetUser(ctx context.Context, userID int64) (*ports.UserInfo, error) {
+	user, err := d.cache.get(ctx, userID, userID, func(ctx context.Context) (*ports.UserInfo, error, time.Duration) {
+		user, err := d.next.GetUser(ctx, userID)
+		switch {
+		case err == nil:
+			return user, nil, d.ttl
+		case errors.IsCode(err, errors.CodeUserNotFound):
+			return nil, err, d.negativeTTL
+		}
+		return nil, err, 0
+	})
+	if err != nil {
+		return nil, err
+	}
+	return copyUser(user), nil
+}
+
+func (d *Directory) InvalidateUser(userID int64) {
+	d.cache.invalidate(userID)
+}
+
+func (d *Directory) InvalidateAll() {
+	d.cache.invalidateAll()
+}
+
+// copyUser keeps callers from modifying the cached user.
+func copyUser(user *ports.UserInfo) *ports.UserInfo {
+	if user == nil {
+		return nil
+	}
+	clone := *user
+	return &clone
+}
@@ -0,0 +1,223 @@
+package usercache
+
+import (
+	"context"
+	stderrors "errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"todoapp/pkg/errors"
+	"todoapp/services/task-service/internal/ports"
+)
+
+type directoryStub struct {
+	calls   atomic.Int32
+	err     error
+	release chan struct{}
+}
+
+func (s *directoryStub) GetUser(ctx context.Context, userID int64) (*ports.UserInfo, error) {
+	s.calls.Add(1)
+	if s.release != nil {
+		<-s.release
+	}
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &ports.UserInfo{ID: userID, Email: "user@example.com", Active: true}, nil
+}
+
+func newTestDirectory(next ports.UserDirectory, cfg Config) (*Directory, *time.Time) {
+	now := time.Date(2024, 12, 10, 9, 0, 0, 0, time.UTC)
+	d := New(next, cfg)
+	d.cache.now = func() time.Time { return now }
+	return d, &now
+}
+
+func TestGetUserCachesUntilTTL(t *testing.T) {
+	stub := &directoryStub{}
+	d, now := newTestDirectory(stub, Config{TTL: time.Minute})
+
+	for range 3 {
+		user, err := d.GetUser(context.Background(), 7)
+		if err != nil || user.ID != 7 {
+			t.Fatalf("unexpected result: %+v, %v", user, err)
+		}
+	}
+	if stub.calls.Load() != 1 {
+		t.Fatalf("expected one lookup, got %d", stub.calls.Load())
+	}
+
+	*now = now.Add(time.Minute)
+	if _, err := d.GetUser(context.Background(), 7); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if stub.calls.Load() != 2 {
+		t.Fatalf("expected expired entry to be reloaded, got %d lookups", stub.calls.Load())
+	}
+}
+
+func TestGetUserReturnsCopies(t *testing.T) {
+	d, _ := newTestDirectory(&directoryStub{}, Config{})
+
+	user, _ := d.GetUser(context.Background(), 7)
+	user.Active = false
+
+	again, _ := d.GetUser(context.Background(), 7)
+	if !again.Active {
+		t.Fatalf("cached user was modified through a returned copy")
+	}
+}
+
+func TestGetUserCachesNotFound(t *testing.T) {
+	stub := &directoryStub{err: errors.ErrUserNotFound}
+	d, now := newTestDirectory(stub, Config{NegativeTTL: 10 * time.Second})
+
+	for range 2 {
+		if _, err := d.GetUser(context.Background(), 7); !errors.IsCode(err, errors.CodeUserNotFound) {
+			t.Fatalf("expected not found, got %v", err)
+		}
+	}
+	if stub.calls.Load() != 1 {
+		t.Fatalf("expected one lookup, got %d", stub.calls.Load())
+	}
+
+	*now = now.Add(10 * time.Second)
+	_, _ = d.GetUser(context.Background(), 7)
+	if stub.calls.Load() != 2 {
+		t.Fatalf("expected negative entry to expire, got %d lookups", stub.calls.Load())
+	}
+}
+
+func TestGetUserDoesNotCacheOtherErrors(t *testing.T) {
+	stub := &directoryStub{err: stderrors.New("unavailable")}
+	d, _ := newTestDirectory(stub, Config{})
+
+	_, _ = d.GetUser(context.Background(), 7)
+	_, _ = d.GetUser(context.Background(), 7)
+	if stub.calls.Load() != 2 {
+		t.Fatalf("expected every call to reach user-service, got %d", stub.calls.Load())
+	}
+}
+
+func TestGetUserSharesConcurrentLookups(t *testing.T) {
+	stub := &directoryStub{release: make(chan struct{})}
+	d, _ := newTestDirectory(stub, Config{})
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Go(func() {
+			if _, err := d.GetUser(context.Background(), 7); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+
+	for stub.calls.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	// Give the other callers time to join the lookup in flight.
+	time.Sleep(20 * time.Millisecond)
+	close(stub.release)
+	wg.Wait()
+
+	if stub.calls.Load() != 1 {
+		t.Fatalf("expected one shared lookup, got %d", stub.calls.Load())
+	}
+}
+
+func TestEvictsLeastRecentlyUsed(t *testing.T) {
+	stub := &directoryStub{}
+	d, _ := newTestDirectory(stub, Config{MaxEntries: 2})
+
+	_, _ = d.GetUser(context.Background(), 1)
+	_, _ = d.GetUser(context.Background(), 2)
+	_, _ = d.GetUser(context.Background(), 1)
+	_, _ = d.GetUser(context.Background(), 3)
+
+	if _, ok := d.cache.entries[2]; ok {
+		t.Fatalf("expected least recently used user to be evicted")
+	}
+	if _, ok := d.cache.entries[1]; !ok {
+		t.Fatalf("expected recently used user to stay cached")
+	}
+}
+
+func TestInvalidateUser(t *testing.T) {
+	stub := &directoryStub{}
+	d, _ := newTestDirectory(stub, Config{})
+
+	_, _ = d.GetUser(context.Background(), 7)
+	_, _ = d.GetUser(context.Background(), 8)
+	d.InvalidateUser(7)
+	_, _ = d.GetUser(context.Background(), 7)
+	_, _ = d.GetUser(context.Background(), 8)
+
+	if stub.calls.Load() != 3 {
+		t.Fatalf("expected only the invalidated user to be reloaded, got %d lookups", stub.calls.Load())
+	}
+
+	d.InvalidateAll()
+	if len(d.cache.entries) != 0 || d.cache.lru.Len() != 0 {
+		t.Fatalf("expected empty cache")
+	}
+}
+
+func TestInvalidationDuringLookupIsNotOverwritten(t *testing.T) {
+	stub := &directoryStub{release: make(chan struct{})}
+	d, _ := newTestDirectory(stub, Config{})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = d.GetUser(context.Background(), 7)
+	}()
+
+	for stub.calls.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	d.InvalidateUser(7)
+	close(stub.release)
+	<-done
+
+	if _, ok := d.cache.entries[7]; ok {
+		t.Fatalf("expected lookup started before invalidation not to be cached")
+	}
+}
+
+func TestGetUserHonorsCallerContext(t *testing.T) {
+	stub := &directoryStub{release: make(chan struct{})}
+	defer close(stub.release)
+	d, _ := newTestDirectory(stub, Config{})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := d.GetUser(ctx, 7); !stderrors.Is(err, context.Canceled) {
+		t.Fatalf("expected context error, got %v", err)
+	}
+}
+
+func TestInvalidatingAnotherUserKeepsLookup(t *testing.T) {
+	stub := &directoryStub{release: make(chan struct{})}
+	d, _ := newTestDirectory(stub, Config{})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = d.GetUser(context.Background(), 7)
+	}()
+
+	for stub.calls.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	d.InvalidateUser(8)
+	close(stub.release)
+	<-done
+
+	if _, ok := d.cache.entries[7]; !ok {
+		t.Fatalf("expected lookup to be cached when another user was invalidated")
+	}
+}
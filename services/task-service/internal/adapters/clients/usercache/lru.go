@@ -0,0 +1,185 @@
+package usercache
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+)
+
+// lruCache holds the answers of a slower source, each for its own TTL.
+// Concurrent misses for the same key share a single load, and the least
+// recently used keys are evicted once maxEntries is reached. Every entry
+// belongs to a user, so the entries of a user can be dropped together.
+type lruCache[K comparable, V any] struct {
+	maxEntries int
+	now        func() time.Time
+
+	group singleflight.Group
+
+	mu      sync.Mutex
+	entries map[K]*list.Element
+	lru     *list.List
+	// loading holds the loads in flight. An invalidation marks the ones it
+	// covers as stale, so a load that started before it does not store what
+	// may be an outdated answer, while loads for other users still do.
+	loading map[K]*pendingLoad
+}
+
+type pendingLoad struct {
+	userID int64
+	stale  bool
+}
+
+type lruEntry[K comparable, V any] struct {
+	key     K
+	userID  int64
+	value   V
+	err     error
+	expires time.Time
+}
+
+// loadFunc asks the source for a value and says how long the answer may be
+// cached; a ttl of zero leaves it out of the cache.
+type loadFunc[V any] func(ctx context.Context) (value V, err error, ttl time.Duration)
+
+func newLRUCache[K comparable, V any](maxEntries int) *lruCache[K, V] {
+	return &lruCache[K, V]{
+		maxEntries: maxEntries,
+		now:        time.Now,
+		entries:    make(map[K]*list.Element),
+		lru:        list.New(),
+		loading:    make(map[K]*pendingLoad),
+	}
+}
+
+// get returns the cached answer for key, or the one load gives for the user
+// it belongs to.
+func (c *lruCache[K, V]) get(ctx context.Context, key K, userID int64, load loadFunc[V]) (V, error) {
+	if value, err, ok := c.lookup(key); ok {
+		return value, err
+	}
+
+	// The shared load must not fail because the caller that started it went
+	// away; the source's own timeout still bounds it.
+	result := c.group.DoChan(fmt.Sprint(key), func() (any, error) {
+		return c.load(context.WithoutCancel(ctx), key, userID, load)
+	})
+
+	select {
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	case res := <-result:
+		return res.Val.(V), res.Err
+	}
+}
+
+func (c *lruCache[K, V]) invalidate(key K) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if p, ok := c.loading[key]; ok {
+		p.stale = true
+	}
+	if elem, ok := c.entries[key]; ok {
+		c.lru.Remove(elem)
+		delete(c.entries, key)
+	}
+}
+
+func (c *lruCache[K, V]) invalidateUser(userID int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, p := range c.loading {
+		if p.userID == userID {
+			p.stale = true
+		}
+	}
+	for elem := c.lru.Front(); elem != nil; {
+		next := elem.Next()
+		if e := elem.Value.(*lruEntry[K, V]); e.userID == userID {
+			c.lru.Remove(elem)
+			delete(c.entries, e.key)
+		}
+		elem = next
+	}
+}
+
+func (c *lruCache[K, V]) invalidateAll() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, p := range c.loading {
+		p.stale = true
+	}
+	clear(c.entries)
+	c.lru.Init()
+}
+
+func (c *lruCache[K, V]) lookup(key K) (V, error, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var zero V
+	elem, ok := c.entries[key]
+	if !ok {
+		return zero, nil, false
+	}
+
+	e := elem.Value.(*lruEntry[K, V])
+	if !c.now().Before(e.expires) {
+		c.lru.Remove(elem)
+		delete(c.entries, key)
+		return zero, nil, false
+	}
+
+	c.lru.MoveToFront(elem)
+	return e.value, e.err, true
+}
+
+func (c *lruCache[K, V]) load(ctx context.Context, key K, userID int64, load loadFunc[V]) (V, error) {
+	// Concurrent misses for key share this load, so no other load for it
+	// is in flight.
+	pending := &pendingLoad{userID: userID}
+	c.mu.Lock()
+	c.loading[key] = pending
+	c.mu.Unlock()
+
+	value, err, ttl := load(ctx)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.loading, key)
+	if ttl > 0 && !pending.stale {
+		c.store(&lruEntry[K, V]{
+			key:     key,
+			userID:  userID,
+			value:   value,
+			err:     err,
+			expires: c.now().Add(ttl),
+		})
+	}
+
+	return value, err
+}
+
+// store must be called with c.mu held.
+func (c *lruCache[K, V]) store(e *lruEntry[K, V]) {
+	if elem, ok := c.entries[e.key]; ok {
+		elem.Value = e
+		c.lru.MoveToFront(elem)
+		return
+	}
+
+	c.entries[e.key] = c.lru.PushFront(e)
+	for c.lru.Len() > c.maxEntries {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*lruEntry[K, V]).key)
+	}
+}
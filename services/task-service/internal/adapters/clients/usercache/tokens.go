@@ -0,0 +1,73 @@
+package usercache
+
+import (
+	"context"
+	"time"
+
+	"todoapp/pkg/errors"
+	"todoapp/services/task-service/internal/ports"
+)
+
+const defaultTokenTTL = 5 * time.Second
+
+type TokenConfig struct {
+	// TTL bounds how long a revoked token is still accepted when the event
+	// announcing the revocation is lost.
+	TTL        time.Duration
+	MaxEntries int
+}
+
+// Tokens is a ports.TokenValidator that remembers, per token ID, what
+// another one answered. Like Directory it shares concurrent lookups and
+// evicts the least recently used tokens; only "valid" and "revoked" answers
+// are cached. Tokens without an ID are always checked.
+type Tokens struct {
+	next  ports.TokenValidator
+	ttl   time.Duration
+	cache *lruCache[string, struct{}]
+}
+
+var (
+	_ ports.TokenValidator = (*Tokens)(nil)
+	_ ports.UserCache      = (*Tokens)(nil)
+)
+
+func NewTokens(next ports.TokenValidator, cfg TokenConfig) *Tokens {
+	if cfg.TTL <= 0 {
+		cfg.TTL = defaultTokenTTL
+	}
+	if cfg.MaxEntries <= 0 {
+		cfg.MaxEntries = defaultMaxEntries
+	}
+
+	return &Tokens{
+		next:  next,
+		ttl:   cfg.TTL,
+		cache: newLRUCache[string, struct{}](cfg.MaxEntries),
+	}
+}
+
+func (t *Tokens) ValidateAccessToken(ctx context.Context, token string, claims *ports.TokenClaims) error {
+	if claims.TokenID == "" {
+		return t.next.ValidateAccessToken(ctx, token, claims)
+	}
+
+	_, err := t.cache.get(ctx, claims.TokenID, claims.UserID, func(ctx context.Context) (struct{}, error, time.Duration) {
+		err := t.next.ValidateAccessToken(ctx, token, claims)
+		if err == nil || errors.IsCode(err, errors.CodeTokenRevoked) {
+			return struct{}{}, err, t.ttl
+		}
+		return struct{}{}, err, 0
+	})
+	return err
+}
+
+// InvalidateUser forgets the user's tokens, so a revocation announced by an
+// event applies to the next request.
+func (t *Tokens) InvalidateUser(userID int64) {
+	t.cache.invalidateUser(userID)
+}
+
+func (t *Tokens) InvalidateAll() {
+	t.cache.invalidateAll()
+}
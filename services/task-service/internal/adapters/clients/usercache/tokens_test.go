@@ -0,0 +1,128 @@
+package usercache
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"todoapp/pkg/errors"
+	"todoapp/services/task-service/internal/ports"
+)
+
+type validatorStub struct {
+	calls atomic.Int32
+	err   error
+}
+
+func (s *validatorStub) ValidateAccessToken(ctx context.Context, token string, claims *ports.TokenClaims) error {
+	s.calls.Add(1)
+	return s.err
+}
+
+func newTestTokens(next ports.TokenValidator, cfg TokenConfig) (*Tokens, *time.Time) {
+	now := time.Date(2024, 12, 10, 9, 0, 0, 0, time.UTC)
+	t := NewTokens(next, cfg)
+	t.cache.now = func() time.Time { return now }
+	return t, &now
+}
+
+func TestValidateAccessTokenCachesUntilTTL(t *testing.T) {
+	stub := &validatorStub{}
+	tokens, now := newTestTokens(stub, TokenConfig{TTL: 5 * time.Second})
+	claims := &ports.TokenClaims{UserID: 7, TokenID: "jti"}
+
+	for range 3 {
+		if err := tokens.ValidateAccessToken(context.Background(), "token", claims); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if stub.calls.Load() != 1 {
+		t.Fatalf("expected one check, got %d", stub.calls.Load())
+	}
+
+	*now = now.Add(5 * time.Second)
+	_ = tokens.ValidateAccessToken(context.Background(), "token", claims)
+	if stub.calls.Load() != 2 {
+		t.Fatalf("expected expired entry to be rechecked, got %d checks", stub.calls.Load())
+	}
+}
+
+func TestValidateAccessTokenCachesRevocation(t *testing.T) {
+	stub := &validatorStub{err: errors.ErrTokenRevoked}
+	tokens, _ := newTestTokens(stub, TokenConfig{})
+	claims := &ports.TokenClaims{UserID: 7, TokenID: "jti"}
+
+	for range 2 {
+		if err := tokens.ValidateAccessToken(context.Background(), "token", claims); !errors.IsCode(err, errors.CodeTokenRevoked) {
+			t.Fatalf("expected revoked, got %v", err)
+		}
+	}
+	if stub.calls.Load() != 1 {
+		t.Fatalf("expected one check, got %d", stub.calls.Load())
+	}
+}
+
+func TestValidateAccessTokenDoesNotCacheOtherErrors(t *testing.T) {
+	stub := &validatorStub{err: errors.ErrServiceUnavailable}
+	tokens, _ := newTestTokens(stub, TokenConfig{})
+	claims := &ports.TokenClaims{UserID: 7, TokenID: "jti"}
+
+	_ = tokens.ValidateAccessToken(context.Background(), "token", claims)
+	_ = tokens.ValidateAccessToken(context.Background(), "token", claims)
+	if stub.calls.Load() != 2 {
+		t.Fatalf("expected every call to reach user-service, got %d", stub.calls.Load())
+	}
+}
+
+func TestValidateAccessTokenWithoutIDIsNotCached(t *testing.T) {
+	stub := &validatorStub{}
+	tokens, _ := newTestTokens(stub, TokenConfig{})
+	claims := &ports.TokenClaims{UserID: 7}
+
+	_ = tokens.ValidateAccessToken(context.Background(), "token", claims)
+	_ = tokens.ValidateAccessToken(context.Background(), "token", claims)
+	if stub.calls.Load() != 2 {
+		t.Fatalf("expected every call to reach user-service, got %d", stub.calls.Load())
+	}
+}
+
+func TestTokensInvalidateUser(t *testing.T) {
+	stub := &validatorStub{}
+	tokens, _ := newTestTokens(stub, TokenConfig{})
+	first := &ports.TokenClaims{UserID: 7, TokenID: "a"}
+	second := &ports.TokenClaims{UserID: 7, TokenID: "b"}
+	other := &ports.TokenClaims{UserID: 8, TokenID: "c"}
+
+	for _, claims := range []*ports.TokenClaims{first, second, other} {
+		_ = tokens.ValidateAccessToken(context.Background(), "token", claims)
+	}
+	tokens.InvalidateUser(7)
+
+	if len(tokens.cache.entries) != 1 {
+		t.Fatalf("expected only the other user's token to stay cached, got %d", len(tokens.cache.entries))
+	}
+	if _, ok := tokens.cache.entries["c"]; !ok {
+		t.Fatalf("expected other user's token to stay cached")
+	}
+
+	tokens.InvalidateAll()
+	if len(tokens.cache.entries) != 0 || tokens.cache.lru.Len() != 0 {
+		t.Fatalf("expected empty cache")
+	}
+}
+
+func TestTokensEvictLeastRecentlyUsed(t *testing.T) {
+	tokens, _ := newTestTokens(&validatorStub{}, TokenConfig{MaxEntries: 2})
+
+	for _, id := range []string{"a", "b", "a", "c"} {
+		_ = tokens.ValidateAccessToken(context.Background(), "token", &ports.TokenClaims{UserID: 7, TokenID: id})
+	}
+
+	if _, ok := tokens.cache.entries["b"]; ok {
+		t.Fatalf("expected least recently used token to be evicted")
+	}
+	if _, ok := tokens.cache.entries["a"]; !ok {
+		t.Fatalf("expected recently used token to stay cached")
+	}
+}
@@ -7,17 +7,25 @@ import (
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/status"
 
 	"todoapp/pkg/errors"
+	"todoapp/pkg/grpcclient"
+	"todoapp/pkg/health"
 	userv1 "todoapp/pkg/proto/user/v1"
 	"todoapp/services/task-service/internal/ports"
 )
 
 type Config struct {
 	Address string
+	// Timeout bounds a call including its retries. A shorter deadline on the
+	// caller's context, e.g. that of the HTTP request, takes precedence.
 	Timeout time.Duration
+	// MaxAttempts is how often a lookup is tried while user-service is
+	// unreachable.
+	MaxAttempts int
+	// Breaker defaults to one with the default settings.
+	Breaker *grpcclient.Breaker
 }
 
 type grpcConn interface {
@@ -31,11 +39,12 @@ type Client struct {
 	timeout time.Duration
 }
 
-var _ ports.UserDirectory = (*Client)(nil)
+var (
+	_ ports.UserDirectory  = (*Client)(nil)
+	_ ports.TokenValidator = (*Client)(nil)
+)
 
-var dialGRPC = func(ctx context.Context, target string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
-	return grpc.DialContext(ctx, target, opts...)
-}
+var dialGRPC = grpc.NewClient
 
 func New(cfg Config) (*Client, error) {
 	if cfg.Address == "" {
@@ -44,8 +53,25 @@ func New(cfg Config) (*Client, error) {
 	if cfg.Timeout <= 0 {
 		cfg.Timeout = 3 * time.Second
 	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 3
+	}
+	if cfg.Breaker == nil {
+		cfg.Breaker = grpcclient.NewBreaker("user-service", grpcclient.BreakerConfig{}, nil)
+	}
 
-	conn, err := dialGRPC(context.Background(), cfg.Address, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	// The connection is established by the first call, so task-service
+	// starts even when user-service is down.
+	conn, err := dialGRPC(cfg.Address, grpcclient.DialOptions(
+		grpcclient.WithRetry(grpcclient.RetryPolicy{
+			MaxAttempts: cfg.MaxAttempts,
+			Methods: []string{
+				userv1.UserService_GetUser_FullMethodName,
+				userv1.UserService_ValidateToken_FullMethodName,
+			},
+		}),
+		grpcclient.WithBreaker(cfg.Breaker),
+	)...)
 	if err != nil {
 		return nil, err
 	}
@@ -64,6 +90,12 @@ func (c *Client) Close() error {
 	return c.conn.Close()
 }
 
+// HealthCheck asks user-service whether it is serving, over the connection
+// the client calls it on.
+func (c *Client) HealthCheck() health.CheckFunc {
+	return health.GRPC(c.conn, userv1.UserService_ServiceDesc.ServiceName)
+}
+
 func (c *Client) GetUser(ctx context.Context, userID int64) (*ports.UserInfo, error) {
 	ctx, cancel := context.WithTimeout(ctx, c.timeout)
 	defer cancel()
@@ -79,14 +111,31 @@ func (c *Client) GetUser(ctx context.Context, userID int64) (*ports.UserInfo, er
 	}
 
 	return &ports.UserInfo{
-		ID:     user.GetId(),
-		Email:  user.GetEmail(),
-		Name:   user.GetName(),
-		Role:   user.GetRole(),
-		Active: user.GetIsActive(),
+		ID:       user.GetId(),
+		Email:    user.GetEmail(),
+		Name:     user.GetName(),
+		Role:     user.GetRole(),
+		Active:   user.GetIsActive(),
+		Timezone: user.GetTimezone(),
 	}, nil
 }
 
+// ValidateAccessToken reports errors.ErrTokenRevoked when user-service no
+// longer accepts the token.
+func (c *Client) ValidateAccessToken(ctx context.Context, token string, claims *ports.TokenClaims) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	_, err := c.client.ValidateToken(ctx, &userv1.ValidateTokenRequest{AccessToken: token})
+	if err == nil {
+		return nil
+	}
+	if status.Code(err) == codes.Unauthenticated {
+		return errors.ErrTokenRevoked
+	}
+	return translateError(err)
+}
+
 func translateError(err error) error {
 	st, ok := status.FromError(err)
 	if !ok {
@@ -100,6 +149,8 @@ func translateError(err error) error {
 		return errors.ErrForbidden
 	case codes.InvalidArgument:
 		return errors.ErrValidation.WithDetails(st.Message())
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return errors.ErrServiceUnavailable.WithCause(err)
 	default:
 		return errors.ErrInternal.WithCause(err)
 	}
@@ -8,10 +8,12 @@ import (
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/status"
 
 	"todoapp/pkg/errors"
 	userv1 "todoapp/pkg/proto/user/v1"
+	"todoapp/services/task-service/internal/ports"
 )
 
 type fakeUserConn struct{}
@@ -25,9 +27,10 @@ func (fakeUserConn) NewStream(ctx context.Context, desc *grpc.StreamDesc, method
 func (fakeUserConn) Close() error { return nil }
 
 type userClientStub struct {
-	err      error
-	response *userv1.GetUserResponse
-	req      *userv1.GetUserRequest
+	err         error
+	response    *userv1.GetUserResponse
+	req         *userv1.GetUserRequest
+	validateReq *userv1.ValidateTokenRequest
 }
 
 func (s *userClientStub) GetUser(ctx context.Context, in *userv1.GetUserRequest, opts ...grpc.CallOption) (*userv1.GetUserResponse, error) {
@@ -36,7 +39,11 @@ func (s *userClientStub) GetUser(ctx context.Context, in *userv1.GetUserRequest,
 }
 
 func (s *userClientStub) ValidateToken(ctx context.Context, in *userv1.ValidateTokenRequest, opts ...grpc.CallOption) (*userv1.ValidateTokenResponse, error) {
-	return nil, nil
+	s.validateReq = in
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &userv1.ValidateTokenResponse{}, nil
 }
 
 func TestUserClientNewValidation(t *testing.T) {
@@ -48,7 +55,7 @@ func TestUserClientNewValidation(t *testing.T) {
 func TestUserClientNewDefaultTimeout(t *testing.T) {
 	origDial := dialGRPC
 	defer func() { dialGRPC = origDial }()
-	dialGRPC = func(ctx context.Context, target string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	dialGRPC = func(target string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
 		return &grpc.ClientConn{}, nil
 	}
 
@@ -70,6 +77,7 @@ func TestGetUserSuccess(t *testing.T) {
 				Name:     "User",
 				Role:     "admin",
 				IsActive: true,
+				Timezone: "Europe/Moscow",
 			},
 		},
 	}
@@ -79,7 +87,7 @@ func TestGetUserSuccess(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	if user.ID != 10 || user.Email != "user@example.com" || user.Role != "admin" || !user.Active {
+	if user.ID != 10 || user.Email != "user@example.com" || user.Role != "admin" || !user.Active || user.Timezone != "Europe/Moscow" {
 		t.Fatalf("unexpected user: %+v", user)
 	}
 	if stub.req == nil || stub.req.UserId != 10 {
@@ -96,6 +104,8 @@ func TestGetUserErrorTranslation(t *testing.T) {
 		{status.Error(codes.NotFound, ""), errors.CodeUserNotFound, "user not found"},
 		{status.Error(codes.PermissionDenied, ""), errors.CodeForbidden, "access denied"},
 		{status.Error(codes.InvalidArgument, "bad"), errors.CodeValidation, "bad"},
+		{status.Error(codes.Unavailable, "connection refused"), errors.CodeServiceUnavailable, "service temporarily unavailable"},
+		{status.Error(codes.DeadlineExceeded, ""), errors.CodeServiceUnavailable, "service temporarily unavailable"},
 		{stderrors.New("other"), errors.CodeInternal, "internal server error"},
 		{nil, errors.CodeUserNotFound, "user not found"},
 	}
@@ -119,3 +129,58 @@ func TestGetUserErrorTranslation(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateAccessToken(t *testing.T) {
+	tests := []struct {
+		err        error
+		expectCode errors.ErrorCode
+	}{
+		{nil, ""},
+		{status.Error(codes.Unauthenticated, "access token has been revoked"), errors.CodeTokenRevoked},
+		{status.Error(codes.Unavailable, ""), errors.CodeServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		stub := &userClientStub{err: tt.err}
+		client := &Client{client: stub, timeout: time.Second}
+
+		err := client.ValidateAccessToken(context.Background(), "token", &ports.TokenClaims{UserID: 1})
+		if stub.validateReq == nil || stub.validateReq.AccessToken != "token" {
+			t.Fatalf("request not sent to stub")
+		}
+		if tt.expectCode == "" {
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			continue
+		}
+		if !errors.IsCode(err, tt.expectCode) {
+			t.Fatalf("expected code %s, got %v", tt.expectCode, err)
+		}
+	}
+}
+
+type healthConn struct {
+	fakeUserConn
+	method  string
+	service string
+}
+
+func (c *healthConn) Invoke(ctx context.Context, method string, args, reply any, opts ...grpc.CallOption) error {
+	c.method = method
+	c.service = args.(*healthpb.HealthCheckRequest).GetService()
+	reply.(*healthpb.HealthCheckResponse).Status = healthpb.HealthCheckResponse_SERVING
+	return nil
+}
+
+func TestHealthCheck(t *testing.T) {
+	conn := &healthConn{}
+	client := &Client{conn: conn, timeout: time.Second}
+
+	if err := client.HealthCheck()(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if conn.method != healthpb.Health_Check_FullMethodName || conn.service != "user.v1.UserService" {
+		t.Fatalf("expected a health check of user.v1.UserService, got %s for %q", conn.method, conn.service)
+	}
+}
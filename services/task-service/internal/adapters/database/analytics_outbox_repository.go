@@ -0,0 +1,155 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	analyticsv1 "todoapp/pkg/proto/analytics/v1"
+	"todoapp/services/task-service/internal/ports"
+)
+
+// PostgresAnalyticsOutbox stores analytics events until they are sent. Its
+// TrackTaskEvent joins the caller's transaction, so an event is only recorded
+// if the change that produced it commits.
+type PostgresAnalyticsOutbox struct {
+	pool Pool
+}
+
+func NewPostgresAnalyticsOutbox(pool Pool) *PostgresAnalyticsOutbox {
+	return &PostgresAnalyticsOutbox{pool: pool}
+}
+
+var (
+	_ ports.AnalyticsOutboxRepository = (*PostgresAnalyticsOutbox)(nil)
+	_ ports.AnalyticsTracker          = (*PostgresAnalyticsOutbox)(nil)
+)
+
+// analyticsPayload is the stored form of ports.AnalyticsEvent.
+type analyticsPayload struct {
+	Type            analyticsv1.TaskEventType `json:"type"`
+	UserID          int64                     `json:"userId"`
+	TaskID          int64                     `json:"taskId"`
+	Status          string                    `json:"status,omitempty"`
+	Priority        string                    `json:"priority,omitempty"`
+	OccurredAt      time.Time                 `json:"occurredAt"`
+	DurationSeconds int64                     `json:"durationSeconds,omitempty"`
+}
+
+func (r *PostgresAnalyticsOutbox) TrackTaskEvent(ctx context.Context, event ports.AnalyticsEvent) error {
+	const query = `
+INSERT INTO task_service.analytics_outbox (
+    event_id,
+    payload,
+    created_at,
+    next_attempt_at
+) VALUES ($1,$2,$3,$3)
+`
+
+	if event.ID == "" {
+		event.ID = uuid.NewString()
+	}
+
+	payload, err := json.Marshal(analyticsPayload{
+		Type:            event.Type,
+		UserID:          event.UserID,
+		TaskID:          event.TaskID,
+		Status:          event.Status,
+		Priority:        event.Priority,
+		OccurredAt:      event.OccurredAt.UTC(),
+		DurationSeconds: int64(event.Duration / time.Second),
+	})
+	if err != nil {
+		return err
+	}
+
+	q := querierFor(ctx, r.pool)
+
+	_, err = q.Exec(ctx, query, event.ID, payload, time.Now().UTC())
+	return err
+}
+
+func (r *PostgresAnalyticsOutbox) FetchPendingAnalytics(ctx context.Context, now time.Time, limit int) ([]ports.AnalyticsOutboxMessage, error) {
+	const query = `
+SELECT id, event_id, payload, attempts
+FROM task_service.analytics_outbox
+WHERE next_attempt_at <= $1
+ORDER BY id
+LIMIT $2
+FOR UPDATE SKIP LOCKED
+`
+
+	q := querierFor(ctx, r.pool)
+
+	rows, err := q.Query(ctx, query, now.UTC(), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var messages []ports.AnalyticsOutboxMessage
+
+	for rows.Next() {
+		var (
+			message ports.AnalyticsOutboxMessage
+			raw     []byte
+			payload analyticsPayload
+		)
+		if err := rows.Scan(&message.ID, &message.Event.ID, &raw, &message.Attempts); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(raw, &payload); err != nil {
+			return nil, err
+		}
+
+		message.Event.Type = payload.Type
+		message.Event.UserID = payload.UserID
+		message.Event.TaskID = payload.TaskID
+		message.Event.Status = payload.Status
+		message.Event.Priority = payload.Priority
+		message.Event.OccurredAt = payload.OccurredAt
+		message.Event.Duration = time.Duration(payload.DurationSeconds) * time.Second
+
+		messages = append(messages, message)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+func (r *PostgresAnalyticsOutbox) DeleteAnalytics(ctx context.Context, ids []int64) error {
+	const query = `
+DELETE FROM task_service.analytics_outbox
+WHERE id = ANY($1)
+`
+
+	q := querierFor(ctx, r.pool)
+
+	_, err := q.Exec(ctx, query, ids)
+	return err
+}
+
+func (r *PostgresAnalyticsOutbox) MarkAnalyticsFailed(ctx context.Context, ids []int64, nextAttemptAt time.Time, lastError string) error {
+	const query = `
+UPDATE task_service.analytics_outbox
+SET attempts = attempts + 1,
+    next_attempt_at = $2,
+    last_error = $3
+WHERE id = ANY($1)
+`
+
+	if len(lastError) > maxOutboxErrorLength {
+		lastError = strings.ToValidUTF8(lastError[:maxOutboxErrorLength], "")
+	}
+
+	q := querierFor(ctx, r.pool)
+
+	_, err := q.Exec(ctx, query, ids, nextAttemptAt.UTC(), lastError)
+	return err
+}
@@ -0,0 +1,234 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"todoapp/services/task-service/internal/domain"
+	"todoapp/services/task-service/internal/domain/entities"
+	"todoapp/services/task-service/internal/ports"
+)
+
+type PostgresAttachmentRepository struct {
+	pool Pool
+}
+
+func NewPostgresAttachmentRepository(pool Pool) *PostgresAttachmentRepository {
+	return &PostgresAttachmentRepository{pool: pool}
+}
+
+var _ ports.AttachmentRepository = (*PostgresAttachmentRepository)(nil)
+
+// CreateAttachment holds a lock on the user's storage until the transaction
+// ends, so the usage it checks includes every upload that got there first.
+func (r *PostgresAttachmentRepository) CreateAttachment(ctx context.Context, attachment *entities.Attachment, quota int64) error {
+	run := func(ctx context.Context) error {
+		q := querierFor(ctx, r.pool)
+
+		if _, err := q.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtextextended('task_service.task_attachments', $1))`, attachment.UserID); err != nil {
+			return err
+		}
+
+		used, err := r.UserStorageUsage(ctx, attachment.UserID)
+		if err != nil {
+			return err
+		}
+		if used+attachment.SizeBytes > quota {
+			return domain.ErrQuotaExceeded.WithDetails(fmt.Sprintf("quota is %d bytes, %d bytes used", quota, used))
+		}
+
+		return r.insertAttachment(ctx, attachment)
+	}
+
+	if TxFromContext(ctx) != nil {
+		return run(ctx)
+	}
+	return WithTransaction(ctx, r.pool, run)
+}
+
+func (r *PostgresAttachmentRepository) insertAttachment(ctx context.Context, attachment *entities.Attachment) error {
+	const query = `
+INSERT INTO task_service.task_attachments (
+    task_id,
+    user_id,
+    file_name,
+    content_type,
+    size_bytes,
+    storage_key
+) VALUES ($1,$2,$3,$4,$5,$6)
+RETURNING id, created_at
+`
+
+	q := querierFor(ctx, r.pool)
+
+	return q.QueryRow(ctx, query,
+		attachment.TaskID,
+		attachment.UserID,
+		attachment.FileName,
+		attachment.ContentType,
+		attachment.SizeBytes,
+		attachment.StorageKey,
+	).Scan(&attachment.ID, &attachment.CreatedAt)
+}
+
+func (r *PostgresAttachmentRepository) GetAttachment(ctx context.Context, userID, taskID, attachmentID int64) (*entities.Attachment, error) {
+	q := querierFor(ctx, r.pool)
+
+	row := q.QueryRow(ctx, baseAttachmentSelect()+`
+WHERE a.id = $1
+  AND a.task_id = $2
+  AND a.user_id = $3
+  AND t.deleted_at IS NULL
+`, attachmentID, taskID, userID)
+
+	attachment, err := scanAttachment(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrAttachmentNotFound
+		}
+		return nil, err
+	}
+
+	return attachment, nil
+}
+
+func (r *PostgresAttachmentRepository) ListAttachments(ctx context.Context, userID, taskID int64) ([]entities.Attachment, error) {
+	q := querierFor(ctx, r.pool)
+
+	rows, err := q.Query(ctx, baseAttachmentSelect()+`
+WHERE a.task_id = $1
+  AND a.user_id = $2
+  AND t.deleted_at IS NULL
+ORDER BY a.created_at ASC
+`, taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var attachments []entities.Attachment
+
+	for rows.Next() {
+		attachment, err := scanAttachment(rows)
+		if err != nil {
+			return nil, err
+		}
+		attachments = append(attachments, *attachment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return attachments, nil
+}
+
+func (r *PostgresAttachmentRepository) DeleteAttachment(ctx context.Context, userID, taskID, attachmentID int64) error {
+	const query = `
+DELETE FROM task_service.task_attachments
+WHERE id = $1
+  AND task_id = $2
+  AND user_id = $3
+`
+
+	q := querierFor(ctx, r.pool)
+
+	tag, err := q.Exec(ctx, query, attachmentID, taskID, userID)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domain.ErrAttachmentNotFound
+	}
+
+	return nil
+}
+
+func (r *PostgresAttachmentRepository) DeleteTaskAttachments(ctx context.Context, userID, taskID int64) ([]entities.Attachment, error) {
+	const query = `
+DELETE FROM task_service.task_attachments a
+WHERE a.task_id = $1
+  AND a.user_id = $2
+RETURNING a.id, a.task_id, a.user_id, a.file_name, a.content_type, a.size_bytes, a.storage_key, a.created_at
+`
+
+	q := querierFor(ctx, r.pool)
+
+	rows, err := q.Query(ctx, query, taskID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var removed []entities.Attachment
+
+	for rows.Next() {
+		attachment, err := scanAttachment(rows)
+		if err != nil {
+			return nil, err
+		}
+		removed = append(removed, *attachment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return removed, nil
+}
+
+func (r *PostgresAttachmentRepository) UserStorageUsage(ctx context.Context, userID int64) (int64, error) {
+	const query = `
+SELECT COALESCE(SUM(size_bytes), 0)
+FROM task_service.task_attachments
+WHERE user_id = $1
+`
+
+	q := querierFor(ctx, r.pool)
+
+	var used int64
+	if err := q.QueryRow(ctx, query, userID).Scan(&used); err != nil {
+		return 0, err
+	}
+
+	return used, nil
+}
+
+func baseAttachmentSelect() string {
+	return `
+SELECT
+    a.id,
+    a.task_id,
+    a.user_id,
+    a.file_name,
+    a.content_type,
+    a.size_bytes,
+    a.storage_key,
+    a.created_at
+FROM task_service.task_attachments a
+JOIN task_service.tasks t ON t.id = a.task_id
+`
+}
+
+func scanAttachment(row rowScanner) (*entities.Attachment, error) {
+	var attachment entities.Attachment
+
+	if err := row.Scan(
+		&attachment.ID,
+		&attachment.TaskID,
+		&attachment.UserID,
+		&attachment.FileName,
+		&attachment.ContentType,
+		&attachment.SizeBytes,
+		&attachment.StorageKey,
+		&attachment.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &attachment, nil
+}
@@ -0,0 +1,290 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"todoapp/services/task-service/internal/domain/entities"
+	"todoapp/services/task-service/internal/ports"
+)
+
+// changeChannel is the NOTIFY channel announcing change event IDs.
+const changeChannel = "task_service_changes"
+
+// changeCatchUpPage is how many events one catch-up query returns; the
+// catch-up pages until it has replayed every stored event.
+const changeCatchUpPage = 1000
+
+// changeCatchUpWindow is how long before the last seen event the catch-up
+// looks again. IDs are taken before commit, so an event with a lower ID can
+// commit after the last seen one; this bounds how long such a transaction may
+// take and still be replayed.
+const changeCatchUpWindow = time.Minute
+
+// PostgresChangeRepository stores change events and announces them with
+// NOTIFY. Both join the caller's transaction, so listeners only hear about
+// changes that committed.
+type PostgresChangeRepository struct {
+	pool Pool
+}
+
+func NewPostgresChangeRepository(pool Pool) *PostgresChangeRepository {
+	return &PostgresChangeRepository{pool: pool}
+}
+
+var _ ports.ChangeEventRepository = (*PostgresChangeRepository)(nil)
+
+// changePayload is the stored form of the changed object.
+type changePayload struct {
+	Task     *entities.Task        `json:"task,omitempty"`
+	Category *entities.Category    `json:"category,omitempty"`
+	Comment  *entities.TaskComment `json:"comment,omitempty"`
+}
+
+func (r *PostgresChangeRepository) RecordChange(ctx context.Context, event *entities.ChangeEvent) error {
+	const insertQuery = `
+INSERT INTO task_service.change_events (
+    user_id,
+    type,
+    payload,
+    created_at
+) VALUES ($1,$2,$3,$4)
+RETURNING id
+`
+	const notifyQuery = `SELECT pg_notify($1, $2)`
+
+	payload, err := json.Marshal(changePayload{
+		Task:     event.Task,
+		Category: event.Category,
+		Comment:  event.Comment,
+	})
+	if err != nil {
+		return err
+	}
+
+	event.CreatedAt = time.Now().UTC()
+
+	q := querierFor(ctx, r.pool)
+
+	if err := q.QueryRow(ctx, insertQuery,
+		event.UserID,
+		string(event.Type),
+		payload,
+		event.CreatedAt,
+	).Scan(&event.ID); err != nil {
+		return err
+	}
+
+	_, err = q.Exec(ctx, notifyQuery, changeChannel, strconv.FormatInt(event.ID, 10))
+	return err
+}
+
+func (r *PostgresChangeRepository) PurgeChanges(ctx context.Context, before time.Time) (int64, error) {
+	const query = `
+DELETE FROM task_service.change_events
+WHERE created_at < $1
+`
+
+	q := querierFor(ctx, r.pool)
+
+	tag, err := q.Exec(ctx, query, before.UTC())
+	if err != nil {
+		return 0, err
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+type connAcquirer interface {
+	Acquire(ctx context.Context) (*pgxpool.Conn, error)
+}
+
+// PostgresChangeListener LISTENs for change events on a dedicated connection.
+// It remembers the events it delivered within the catch-up window, so the
+// events a reconnect replays again are not delivered twice.
+type PostgresChangeListener struct {
+	pool connAcquirer
+
+	mu        sync.Mutex
+	delivered map[int64]time.Time
+	newest    time.Time
+	prunedAt  time.Time
+}
+
+func NewPostgresChangeListener(pool connAcquirer) *PostgresChangeListener {
+	return &PostgresChangeListener{
+		pool:      pool,
+		delivered: make(map[int64]time.Time),
+	}
+}
+
+var _ ports.ChangeListener = (*PostgresChangeListener)(nil)
+
+func (l *PostgresChangeListener) Listen(ctx context.Context, afterID int64, fn func(entities.ChangeEvent)) error {
+	pooled, err := l.pool.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	// A listening connection must not go back to the pool.
+	conn := pooled.Hijack()
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+changeChannel); err != nil {
+		return err
+	}
+
+	// Listening starts before the catch-up, so an event committed meanwhile
+	// is both replayed and announced; deliver drops the second one.
+	if afterID > 0 {
+		if err := l.catchUp(ctx, conn, afterID, fn); err != nil {
+			return err
+		}
+	}
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		id, err := strconv.ParseInt(notification.Payload, 10, 64)
+		if err != nil {
+			continue
+		}
+
+		event, err := scanChangeEvent(conn.QueryRow(ctx, baseChangeSelect()+`
+WHERE id = $1
+`, id))
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				// Purged before we got to it.
+				continue
+			}
+			return err
+		}
+		l.deliver(event, fn)
+	}
+}
+
+// catchUp replays the events recorded after afterID, and those with a lower ID
+// recorded within changeCatchUpWindow before it, which may have committed
+// after it was seen.
+func (l *PostgresChangeListener) catchUp(ctx context.Context, conn *pgx.Conn, afterID int64, fn func(entities.ChangeEvent)) error {
+	var lastSeenAt time.Time
+	err := conn.QueryRow(ctx, `
+SELECT created_at
+FROM task_service.change_events
+WHERE id = $1
+`, afterID).Scan(&lastSeenAt)
+	switch {
+	case err == nil:
+		if err := l.replay(ctx, conn, 0, afterID, lastSeenAt.Add(-changeCatchUpWindow), fn); err != nil {
+			return err
+		}
+	case !errors.Is(err, pgx.ErrNoRows):
+		return err
+	}
+
+	return l.replay(ctx, conn, afterID, math.MaxInt64, time.Time{}, fn)
+}
+
+// replay delivers, in ID order, the events with fromID < id <= toID recorded
+// at or after since, a page at a time.
+func (l *PostgresChangeListener) replay(ctx context.Context, conn *pgx.Conn, fromID, toID int64, since time.Time, fn func(entities.ChangeEvent)) error {
+	for {
+		rows, err := conn.Query(ctx, baseChangeSelect()+`
+WHERE id > $1
+  AND id <= $2
+  AND created_at >= $3
+ORDER BY id ASC
+LIMIT $4
+`, fromID, toID, since, changeCatchUpPage)
+		if err != nil {
+			return err
+		}
+		events, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (entities.ChangeEvent, error) {
+			return scanChangeEvent(row)
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, event := range events {
+			l.deliver(event, fn)
+		}
+		if len(events) < changeCatchUpPage {
+			return nil
+		}
+		fromID = events[len(events)-1].ID
+	}
+}
+
+// deliver calls fn unless the event was delivered before. IDs are kept for
+// twice the catch-up window, as creation times come from the clocks of
+// different replicas.
+func (l *PostgresChangeListener) deliver(event entities.ChangeEvent, fn func(entities.ChangeEvent)) {
+	l.mu.Lock()
+	if _, ok := l.delivered[event.ID]; ok {
+		l.mu.Unlock()
+		return
+	}
+	l.delivered[event.ID] = event.CreatedAt
+	if event.CreatedAt.After(l.newest) {
+		l.newest = event.CreatedAt
+	}
+	if l.newest.Sub(l.prunedAt) > changeCatchUpWindow {
+		cutoff := l.newest.Add(-2 * changeCatchUpWindow)
+		for id, createdAt := range l.delivered {
+			if createdAt.Before(cutoff) {
+				delete(l.delivered, id)
+			}
+		}
+		l.prunedAt = l.newest
+	}
+	l.mu.Unlock()
+
+	fn(event)
+}
+
+func baseChangeSelect() string {
+	return `
+SELECT
+    id,
+    user_id,
+    type,
+    payload,
+    created_at
+FROM task_service.change_events
+`
+}
+
+func scanChangeEvent(row rowScanner) (entities.ChangeEvent, error) {
+	var (
+		event      entities.ChangeEvent
+		changeType string
+		raw        []byte
+		payload    changePayload
+	)
+
+	if err := row.Scan(&event.ID, &event.UserID, &changeType, &raw, &event.CreatedAt); err != nil {
+		return entities.ChangeEvent{}, err
+	}
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return entities.ChangeEvent{}, err
+	}
+
+	event.Type = entities.ChangeType(changeType)
+	event.Task = payload.Task
+	event.Category = payload.Category
+	event.Comment = payload.Comment
+
+	return event, nil
+}
@@ -0,0 +1,204 @@
+package database
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+
+	"todoapp/services/task-service/internal/domain"
+	"todoapp/services/task-service/internal/domain/entities"
+	"todoapp/services/task-service/internal/ports"
+)
+
+type PostgresCustomFieldRepository struct {
+	pool Pool
+}
+
+func NewPostgresCustomFieldRepository(pool Pool) *PostgresCustomFieldRepository {
+	return &PostgresCustomFieldRepository{pool: pool}
+}
+
+var _ ports.CustomFieldRepository = (*PostgresCustomFieldRepository)(nil)
+
+func (r *PostgresCustomFieldRepository) CreateCustomField(ctx context.Context, field *entities.CustomField) error {
+	const query = `
+INSERT INTO task_service.custom_fields (
+    user_id,
+    key,
+    name,
+    type,
+    options
+) VALUES ($1,$2,$3,$4,$5)
+RETURNING id, created_at, updated_at
+`
+
+	q := querierFor(ctx, r.pool)
+
+	if err := q.QueryRow(ctx, query,
+		field.UserID,
+		field.Key,
+		field.Name,
+		string(field.Type),
+		optionsArray(field.Options),
+	).Scan(&field.ID, &field.CreatedAt, &field.UpdatedAt); err != nil {
+		if isUniqueViolation(err) {
+			return domain.ErrCustomFieldExists
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (r *PostgresCustomFieldRepository) GetCustomField(ctx context.Context, userID, fieldID int64) (*entities.CustomField, error) {
+	q := querierFor(ctx, r.pool)
+
+	row := q.QueryRow(ctx, baseCustomFieldSelect()+`
+WHERE id = $1
+  AND user_id = $2
+`, fieldID, userID)
+
+	field, err := scanCustomField(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrCustomFieldNotFound
+		}
+		return nil, err
+	}
+
+	return field, nil
+}
+
+func (r *PostgresCustomFieldRepository) ListCustomFields(ctx context.Context, userID int64) ([]entities.CustomField, error) {
+	q := querierFor(ctx, r.pool)
+
+	rows, err := q.Query(ctx, baseCustomFieldSelect()+`
+WHERE user_id = $1
+ORDER BY id ASC
+`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var fields []entities.CustomField
+
+	for rows.Next() {
+		field, err := scanCustomField(rows)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, *field)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
+
+func (r *PostgresCustomFieldRepository) UpdateCustomField(ctx context.Context, field *entities.CustomField) error {
+	const query = `
+UPDATE task_service.custom_fields
+SET name = $1,
+    options = $2,
+    updated_at = NOW()
+WHERE id = $3
+  AND user_id = $4
+RETURNING updated_at
+`
+
+	q := querierFor(ctx, r.pool)
+
+	if err := q.QueryRow(ctx, query,
+		field.Name,
+		optionsArray(field.Options),
+		field.ID,
+		field.UserID,
+	).Scan(&field.UpdatedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrCustomFieldNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+// DeleteCustomField strips the field's values from the user's tasks in the
+// same transaction, so a new field with the same key starts out empty.
+func (r *PostgresCustomFieldRepository) DeleteCustomField(ctx context.Context, userID, fieldID int64) error {
+	run := func(ctx context.Context) error {
+		q := querierFor(ctx, r.pool)
+
+		var key string
+		if err := q.QueryRow(ctx, `
+DELETE FROM task_service.custom_fields
+WHERE id = $1
+  AND user_id = $2
+RETURNING key
+`, fieldID, userID).Scan(&key); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return domain.ErrCustomFieldNotFound
+			}
+			return err
+		}
+
+		_, err := q.Exec(ctx, `
+UPDATE task_service.tasks
+SET custom_fields = custom_fields - $2::text
+WHERE user_id = $1
+  AND custom_fields ? $2::text
+`, userID, key)
+		return err
+	}
+
+	if TxFromContext(ctx) != nil {
+		return run(ctx)
+	}
+	return WithTransaction(ctx, r.pool, run)
+}
+
+func baseCustomFieldSelect() string {
+	return `
+SELECT
+    id,
+    user_id,
+    key,
+    name,
+    type,
+    options,
+    created_at,
+    updated_at
+FROM task_service.custom_fields
+`
+}
+
+func scanCustomField(row rowScanner) (*entities.CustomField, error) {
+	var field entities.CustomField
+
+	if err := row.Scan(
+		&field.ID,
+		&field.UserID,
+		&field.Key,
+		&field.Name,
+		&field.Type,
+		&field.Options,
+		&field.CreatedAt,
+		&field.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	return &field, nil
+}
+
+// optionsArray keeps a nil slice from being written as NULL.
+func optionsArray(options []string) []string {
+	if options == nil {
+		return []string{}
+	}
+	return options
+}
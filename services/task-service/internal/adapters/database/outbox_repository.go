@@ -0,0 +1,189 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"todoapp/pkg/events"
+	"todoapp/pkg/requestid"
+	"todoapp/pkg/tracing"
+	"todoapp/services/task-service/internal/ports"
+)
+
+// maxOutboxErrorLength keeps last_error readable.
+const maxOutboxErrorLength = 1000
+
+// PostgresOutboxRepository stores task events in the outbox table. Its Publish
+// joins the caller's transaction, so an event is only recorded if the change
+// that produced it commits.
+type PostgresOutboxRepository struct {
+	pool Pool
+}
+
+func NewPostgresOutboxRepository(pool Pool) *PostgresOutboxRepository {
+	return &PostgresOutboxRepository{pool: pool}
+}
+
+var (
+	_ ports.OutboxRepository   = (*PostgresOutboxRepository)(nil)
+	_ ports.TaskEventPublisher = (*PostgresOutboxRepository)(nil)
+)
+
+func (r *PostgresOutboxRepository) Publish(ctx context.Context, event events.TaskEvent) error {
+	const query = `
+INSERT INTO task_service.event_outbox (
+    event_id,
+    event_type,
+    task_id,
+    payload,
+    created_at,
+    next_attempt_at,
+    request_id,
+    trace_parent
+) VALUES ($1,$2,$3,$4,$5,$5,NULLIF($6,''),NULLIF($7,''))
+`
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	q := querierFor(ctx, r.pool)
+
+	_, err = q.Exec(ctx, query,
+		event.ID,
+		string(event.Type),
+		event.TaskID,
+		payload,
+		event.CreatedAt.UTC(),
+		requestid.FromContext(ctx),
+		tracing.TraceParent(ctx),
+	)
+	return err
+}
+
+func (r *PostgresOutboxRepository) FetchPendingOutbox(ctx context.Context, now time.Time, limit int) ([]ports.OutboxMessage, error) {
+	const query = `
+SELECT o.id, o.payload, o.attempts, COALESCE(o.request_id, ''), COALESCE(o.trace_parent, '')
+FROM task_service.event_outbox o
+WHERE o.sent_at IS NULL
+  AND o.next_attempt_at <= $1
+  AND NOT EXISTS (
+      SELECT 1
+      FROM task_service.event_outbox prev
+      WHERE prev.task_id = o.task_id
+        AND prev.sent_at IS NULL
+        AND prev.id < o.id
+  )
+ORDER BY o.id
+LIMIT $2
+FOR UPDATE SKIP LOCKED
+`
+
+	q := querierFor(ctx, r.pool)
+
+	rows, err := q.Query(ctx, query, now.UTC(), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var messages []ports.OutboxMessage
+
+	for rows.Next() {
+		var (
+			message ports.OutboxMessage
+			payload []byte
+		)
+		if err := rows.Scan(&message.ID, &payload, &message.Attempts, &message.RequestID, &message.TraceParent); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(payload, &message.Event); err != nil {
+			return nil, err
+		}
+		messages = append(messages, message)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return messages, nil
+}
+
+func (r *PostgresOutboxRepository) MarkOutboxSent(ctx context.Context, id int64, sentAt time.Time) error {
+	const query = `
+UPDATE task_service.event_outbox
+SET sent_at = $2,
+    attempts = attempts + 1,
+    last_error = NULL
+WHERE id = $1
+`
+
+	q := querierFor(ctx, r.pool)
+
+	_, err := q.Exec(ctx, query, id, sentAt.UTC())
+	return err
+}
+
+func (r *PostgresOutboxRepository) MarkOutboxFailed(ctx context.Context, id int64, nextAttemptAt time.Time, lastError string) error {
+	const query = `
+UPDATE task_service.event_outbox
+SET attempts = attempts + 1,
+    next_attempt_at = $2,
+    last_error = $3
+WHERE id = $1
+`
+
+	if len(lastError) > maxOutboxErrorLength {
+		lastError = strings.ToValidUTF8(lastError[:maxOutboxErrorLength], "")
+	}
+
+	q := querierFor(ctx, r.pool)
+
+	_, err := q.Exec(ctx, query, id, nextAttemptAt.UTC(), lastError)
+	return err
+}
+
+func (r *PostgresOutboxRepository) PurgeSentOutbox(ctx context.Context, before time.Time) (int64, error) {
+	const query = `
+DELETE FROM task_service.event_outbox
+WHERE sent_at IS NOT NULL
+  AND sent_at < $1
+`
+
+	q := querierFor(ctx, r.pool)
+
+	tag, err := q.Exec(ctx, query, before.UTC())
+	if err != nil {
+		return 0, err
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+func (r *PostgresOutboxRepository) OutboxBacklog(ctx context.Context) (ports.OutboxBacklog, error) {
+	const query = `
+SELECT COUNT(*), MIN(created_at)
+FROM task_service.event_outbox
+WHERE sent_at IS NULL
+`
+
+	var (
+		backlog ports.OutboxBacklog
+		oldest  *time.Time
+	)
+
+	q := querierFor(ctx, r.pool)
+
+	if err := q.QueryRow(ctx, query).Scan(&backlog.Pending, &oldest); err != nil {
+		return ports.OutboxBacklog{}, err
+	}
+	if oldest != nil {
+		backlog.Oldest = oldest.UTC()
+	}
+
+	return backlog, nil
+}
@@ -0,0 +1,89 @@
+package database
+
+import (
+	"context"
+	"time"
+
+	"todoapp/services/task-service/internal/domain/entities"
+	"todoapp/services/task-service/internal/ports"
+)
+
+type PostgresOverdueRepository struct {
+	pool Pool
+}
+
+func NewPostgresOverdueRepository(pool Pool) *PostgresOverdueRepository {
+	return &PostgresOverdueRepository{pool: pool}
+}
+
+var _ ports.OverdueRepository = (*PostgresOverdueRepository)(nil)
+
+func (r *PostgresOverdueRepository) ListOverdueTasks(ctx context.Context, now time.Time, limit int) ([]entities.Task, error) {
+	q := querierFor(ctx, r.pool)
+
+	rows, err := q.Query(ctx, baseTaskSelect()+`
+WHERE t.deleted_at IS NULL
+  AND t.completed_at IS NULL
+  AND t.status <> 'archived'
+  AND t.due_date IS NOT NULL
+  AND t.due_date < $1
+  AND NOT EXISTS (
+      SELECT 1
+      FROM task_service.task_overdue_notifications n
+      WHERE n.task_id = t.id
+        AND n.due_date = t.due_date
+  )
+ORDER BY t.due_date ASC, t.id ASC
+LIMIT $2
+`, now.UTC(), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tasks []entities.Task
+
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, *task)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+func (r *PostgresOverdueRepository) ClaimOverdueTask(ctx context.Context, taskID int64, dueDate time.Time) (bool, error) {
+	const query = `
+INSERT INTO task_service.task_overdue_notifications (task_id, due_date)
+VALUES ($1, $2)
+ON CONFLICT (task_id, due_date) DO NOTHING
+`
+
+	q := querierFor(ctx, r.pool)
+
+	tag, err := q.Exec(ctx, query, taskID, dueDate)
+	if err != nil {
+		return false, err
+	}
+
+	return tag.RowsAffected() == 1, nil
+}
+
+func (r *PostgresOverdueRepository) ReleaseOverdueTask(ctx context.Context, taskID int64, dueDate time.Time) error {
+	const query = `
+DELETE FROM task_service.task_overdue_notifications
+WHERE task_id = $1
+  AND due_date = $2
+`
+
+	q := querierFor(ctx, r.pool)
+
+	_, err := q.Exec(ctx, query, taskID, dueDate)
+	return err
+}
@@ -0,0 +1,47 @@
+package database
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const replicaReadsContextKey contextKey = "taskService.replicaReads"
+
+// ReadPool serves queries that may see data slightly older than the primary,
+// such as a streaming replica.
+type ReadPool interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+type TaskRepositoryOption func(*PostgresTaskRepository)
+
+// WithReadReplica sends task, category and comment reads to replica when the
+// context allows it; see WithReplicaReads.
+func WithReadReplica(replica ReadPool) TaskRepositoryOption {
+	return func(r *PostgresTaskRepository) {
+		r.replica = replica
+	}
+}
+
+// WithReplicaReads marks ctx as belonging to a request that writes nothing,
+// so its reads may lag behind the primary. Requests that write keep reading
+// from the primary and always see their own changes.
+func WithReplicaReads(ctx context.Context) context.Context {
+	return context.WithValue(ctx, replicaReadsContextKey, true)
+}
+
+func replicaReadsAllowed(ctx context.Context) bool {
+	allowed, _ := ctx.Value(replicaReadsContextKey).(bool)
+	return allowed
+}
+
+// readerFor returns the replica for reads outside a transaction in contexts
+// marked by WithReplicaReads, and querierFor otherwise.
+func readerFor(ctx context.Context, pool Pool, replica ReadPool) ReadPool {
+	if replica == nil || TxFromContext(ctx) != nil || !replicaReadsAllowed(ctx) {
+		return querierFor(ctx, pool)
+	}
+	return replica
+}
@@ -3,6 +3,7 @@ package database
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"strconv"
 	"strings"
@@ -32,11 +33,16 @@ type Pool interface {
 }
 
 type PostgresTaskRepository struct {
-	pool Pool
+	pool    Pool
+	replica ReadPool
 }
 
-func NewPostgresTaskRepository(pool Pool) *PostgresTaskRepository {
-	return &PostgresTaskRepository{pool: pool}
+func NewPostgresTaskRepository(pool Pool, opts ...TaskRepositoryOption) *PostgresTaskRepository {
+	r := &PostgresTaskRepository{pool: pool}
+	for _, opt := range opts {
+		opt(r)
+	}
+	return r
 }
 
 var _ ports.TaskRepository = (*PostgresTaskRepository)(nil)
@@ -50,11 +56,25 @@ INSERT INTO task_service.tasks (
     status,
     priority,
     due_date,
-    category_id
-) VALUES ($1,$2,$3,$4,$5,$6,$7)
-RETURNING id, created_at, updated_at
+    category_id,
+    estimate_minutes,
+    completed_at,
+    custom_fields,
+    position
+) VALUES (
+    $1,$2,$3,$4,$5,$6,$7,$9,$10,$11::jsonb,
+    (SELECT COALESCE(MAX(position), 0) + $8
+     FROM task_service.tasks
+     WHERE user_id = $1 AND status = $4 AND deleted_at IS NULL)
+)
+RETURNING id, position, created_at, updated_at
 `
 
+	customFields, err := encodeCustomFields(task.CustomFields)
+	if err != nil {
+		return err
+	}
+
 	q := r.querier(ctx)
 
 	if err := q.QueryRow(ctx, query,
@@ -65,13 +85,19 @@ RETURNING id, created_at, updated_at
 		string(task.Priority),
 		task.DueDate,
 		task.CategoryID,
-	).Scan(&task.ID, &task.CreatedAt, &task.UpdatedAt); err != nil {
+		float64(entities.TaskPositionStep),
+		task.EstimateMinutes,
+		task.CompletedAt,
+		customFields,
+	).Scan(&task.ID, &task.Position, &task.CreatedAt, &task.UpdatedAt); err != nil {
 		return err
 	}
 
 	return nil
 }
 
+// UpdateTask appends the task to the end of its new column when the status
+// changes.
 func (r *PostgresTaskRepository) UpdateTask(ctx context.Context, task *entities.Task) error {
 	const query = `
 UPDATE task_service.tasks
@@ -81,12 +107,26 @@ SET title = $1,
     priority = $4,
     due_date = $5,
     category_id = $6,
+    estimate_minutes = $10,
+    completed_at = $11,
+    custom_fields = $12::jsonb,
+    position = CASE
+        WHEN status = $3 THEN position
+        ELSE (SELECT COALESCE(MAX(position), 0) + $9
+              FROM task_service.tasks
+              WHERE user_id = $8 AND status = $3 AND deleted_at IS NULL)
+    END,
     updated_at = NOW()
 WHERE id = $7
   AND user_id = $8
-RETURNING updated_at
+RETURNING position, updated_at
 `
 
+	customFields, err := encodeCustomFields(task.CustomFields)
+	if err != nil {
+		return err
+	}
+
 	q := r.querier(ctx)
 
 	if err := q.QueryRow(ctx, query,
@@ -98,7 +138,11 @@ RETURNING updated_at
 		task.CategoryID,
 		task.ID,
 		task.UserID,
-	).Scan(&task.UpdatedAt); err != nil {
+		float64(entities.TaskPositionStep),
+		task.EstimateMinutes,
+		task.CompletedAt,
+		customFields,
+	).Scan(&task.Position, &task.UpdatedAt); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return domain.ErrTaskNotFound
 		}
@@ -134,7 +178,7 @@ WHERE id = $1
 }
 
 func (r *PostgresTaskRepository) GetTask(ctx context.Context, userID, taskID int64) (*entities.Task, error) {
-	q := r.querier(ctx)
+	q := r.reader(ctx)
 
 	row := q.QueryRow(ctx, baseTaskSelect()+`
 WHERE t.id = $1
@@ -179,11 +223,19 @@ func (r *PostgresTaskRepository) ListTasks(ctx context.Context, userID int64, fi
 	}
 
 	if filter.CategoryID != nil {
-		clauses = append(clauses, "t.category_id = $"+itoa(argsIndex))
+		if filter.IncludeSubcategories {
+			clauses = append(clauses, "t.category_id IN ("+categorySubtreeQuery("$"+itoa(argsIndex), "$1")+")")
+		} else {
+			clauses = append(clauses, "t.category_id = $"+itoa(argsIndex))
+		}
 		args = append(args, *filter.CategoryID)
 		argsIndex++
 	}
 
+	if filter.WithoutCategory {
+		clauses = append(clauses, "t.category_id IS NULL")
+	}
+
 	if filter.Search != "" {
 		search := "%" + strings.ToLower(filter.Search) + "%"
 		clauses = append(clauses, "(LOWER(t.title) LIKE $"+itoa(argsIndex)+" OR LOWER(t.description) LIKE $"+itoa(argsIndex)+")")
@@ -203,11 +255,45 @@ func (r *PostgresTaskRepository) ListTasks(ctx context.Context, userID int64, fi
 		argsIndex++
 	}
 
-	query := baseTaskSelect() + "\nWHERE " + strings.Join(clauses, " AND ") + "\nORDER BY COALESCE(t.due_date, t.created_at) ASC\nLIMIT $" + itoa(argsIndex) + "\nOFFSET $" + itoa(argsIndex+1)
+	// Containment (@>) is served by the GIN index on custom_fields. An unset
+	// checkbox counts as unchecked.
+	for _, field := range filter.CustomFields {
+		value, err := json.Marshal(map[string]any{field.Key: field.Value})
+		if err != nil {
+			return nil, err
+		}
+		if checked, ok := field.Value.(bool); ok && !checked {
+			value, err = json.Marshal(map[string]any{field.Key: true})
+			if err != nil {
+				return nil, err
+			}
+			clauses = append(clauses, "NOT t.custom_fields @> $"+itoa(argsIndex)+"::jsonb")
+		} else {
+			clauses = append(clauses, "t.custom_fields @> $"+itoa(argsIndex)+"::jsonb")
+		}
+		args = append(args, string(value))
+		argsIndex++
+	}
+
+	orderBy := "COALESCE(t.due_date, t.created_at) ASC"
+	if filter.OrderByPosition {
+		orderBy = "t.position ASC, t.id ASC"
+	}
+	if filter.SortField != "" {
+		direction := "ASC"
+		if filter.SortDescending {
+			direction = "DESC"
+		}
+		orderBy = "t.custom_fields -> $" + itoa(argsIndex) + " " + direction + " NULLS LAST, t.id ASC"
+		args = append(args, filter.SortField)
+		argsIndex++
+	}
+
+	query := baseTaskSelect() + "\nWHERE " + strings.Join(clauses, " AND ") + "\nORDER BY " + orderBy + "\nLIMIT $" + itoa(argsIndex) + "\nOFFSET $" + itoa(argsIndex+1)
 
 	args = append(args, filter.Limit, filter.Offset)
 
-	q := r.querier(ctx)
+	q := r.reader(ctx)
 
 	rows, err := q.Query(ctx, query, args...)
 	if err != nil {
@@ -232,36 +318,181 @@ func (r *PostgresTaskRepository) ListTasks(ctx context.Context, userID int64, fi
 	return tasks, nil
 }
 
+// MoveTask only touches the moved row; neighbours keep their positions.
+func (r *PostgresTaskRepository) MoveTask(ctx context.Context, task *entities.Task) error {
+	const query = `
+UPDATE task_service.tasks
+SET status = $1,
+    position = $2,
+    completed_at = $5,
+    updated_at = NOW()
+WHERE id = $3
+  AND user_id = $4
+  AND deleted_at IS NULL
+RETURNING updated_at
+`
+
+	q := r.querier(ctx)
+
+	if err := q.QueryRow(ctx, query,
+		string(task.Status),
+		task.Position,
+		task.ID,
+		task.UserID,
+		task.CompletedAt,
+	).Scan(&task.UpdatedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrTaskNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (r *PostgresTaskRepository) AdjacentTaskPosition(ctx context.Context, userID int64, status entities.TaskStatus, position float64, after bool, excludeTaskID int64) (*float64, error) {
+	query := `
+SELECT MIN(position)
+FROM task_service.tasks
+WHERE user_id = $1
+  AND status = $2
+  AND deleted_at IS NULL
+  AND id <> $4
+  AND position > $3
+`
+	if !after {
+		query = `
+SELECT MAX(position)
+FROM task_service.tasks
+WHERE user_id = $1
+  AND status = $2
+  AND deleted_at IS NULL
+  AND id <> $4
+  AND position < $3
+`
+	}
+
+	q := r.querier(ctx)
+
+	var adjacent sql.NullFloat64
+	if err := q.QueryRow(ctx, query, userID, string(status), position, excludeTaskID).Scan(&adjacent); err != nil {
+		return nil, err
+	}
+
+	if !adjacent.Valid {
+		return nil, nil
+	}
+	return &adjacent.Float64, nil
+}
+
+func (r *PostgresTaskRepository) LockTaskColumn(ctx context.Context, userID int64, status entities.TaskStatus) error {
+	_, err := r.querier(ctx).Exec(ctx, `
+SELECT id
+FROM task_service.tasks
+WHERE user_id = $1
+  AND status = $2
+  AND deleted_at IS NULL
+ORDER BY id
+FOR UPDATE
+`, userID, string(status))
+	return err
+}
+
+// RebalanceTaskPositions locks the column before renumbering it so
+// concurrent moves into the same column wait for the new positions.
+func (r *PostgresTaskRepository) RebalanceTaskPositions(ctx context.Context, userID int64, status entities.TaskStatus) error {
+	run := func(ctx context.Context) error {
+		if err := r.LockTaskColumn(ctx, userID, status); err != nil {
+			return err
+		}
+
+		_, err := r.querier(ctx).Exec(ctx, `
+UPDATE task_service.tasks t
+SET position = ranked.position
+FROM (
+    SELECT id, ROW_NUMBER() OVER (ORDER BY position, id) * $3::double precision AS position
+    FROM task_service.tasks
+    WHERE user_id = $1
+      AND status = $2
+      AND deleted_at IS NULL
+) ranked
+WHERE ranked.id = t.id
+`, userID, string(status), float64(entities.TaskPositionStep))
+		return err
+	}
+
+	if TxFromContext(ctx) != nil {
+		return run(ctx)
+	}
+	return WithTransaction(ctx, r.pool, run)
+}
+
 func (r *PostgresTaskRepository) CreateCategory(ctx context.Context, category *entities.Category) error {
 	const query = `
-INSERT INTO task_service.categories (user_id, name)
-VALUES ($1,$2)
-RETURNING id, created_at
+INSERT INTO task_service.categories (user_id, parent_id, name, color, icon, position)
+VALUES (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    (SELECT COALESCE(MAX(position), 0) + 1 FROM task_service.categories WHERE user_id = $1)
+)
+RETURNING id, position, created_at, updated_at
 `
 
 	q := r.querier(ctx)
 
 	if err := q.QueryRow(ctx, query,
 		category.UserID,
+		category.ParentID,
 		category.Name,
-	).Scan(&category.ID, &category.CreatedAt); err != nil {
+		category.Color,
+		category.Icon,
+	).Scan(&category.ID, &category.Position, &category.CreatedAt, &category.UpdatedAt); err != nil {
+		if isUniqueViolation(err) {
+			return domain.ErrCategoryExists
+		}
 		return err
 	}
 
-	category.UpdatedAt = category.CreatedAt
-
 	return nil
 }
 
-func (r *PostgresTaskRepository) ListCategories(ctx context.Context, userID int64) ([]entities.Category, error) {
-	const query = `
-SELECT id, user_id, name, created_at
+// LockCategories locks the user's categories until the transaction ends.
+func (r *PostgresTaskRepository) LockCategories(ctx context.Context, userID int64) error {
+	_, err := r.querier(ctx).Exec(ctx, `
+SELECT id
 FROM task_service.categories
 WHERE user_id = $1
-ORDER BY name ASC
+ORDER BY id
+FOR UPDATE
+`, userID)
+	return err
+}
+
+func (r *PostgresTaskRepository) ListCategories(ctx context.Context, userID int64) ([]entities.Category, error) {
+	const query = `
+SELECT
+    c.id,
+    c.user_id,
+    c.parent_id,
+    c.name,
+    c.color,
+    c.icon,
+    c.position,
+    c.created_at,
+    c.updated_at,
+    COUNT(t.id) FILTER (WHERE t.completed_at IS NULL AND t.status <> 'archived'),
+    COUNT(t.id) FILTER (WHERE t.completed_at IS NOT NULL AND t.status <> 'archived')
+FROM task_service.categories c
+LEFT JOIN task_service.tasks t ON t.category_id = c.id AND t.deleted_at IS NULL
+WHERE c.user_id = $1
+GROUP BY c.id
+ORDER BY c.position ASC, c.name ASC
 `
 
-	q := r.querier(ctx)
+	q := r.reader(ctx)
 
 	rows, err := q.Query(ctx, query, userID)
 	if err != nil {
@@ -273,10 +504,21 @@ ORDER BY name ASC
 
 	for rows.Next() {
 		var category entities.Category
-		if err := rows.Scan(&category.ID, &category.UserID, &category.Name, &category.CreatedAt); err != nil {
+		if err := rows.Scan(
+			&category.ID,
+			&category.UserID,
+			&category.ParentID,
+			&category.Name,
+			&category.Color,
+			&category.Icon,
+			&category.Position,
+			&category.CreatedAt,
+			&category.UpdatedAt,
+			&category.OpenTasks,
+			&category.CompletedTasks,
+		); err != nil {
 			return nil, err
 		}
-		category.UpdatedAt = category.CreatedAt
 		categories = append(categories, category)
 	}
 
@@ -289,7 +531,7 @@ ORDER BY name ASC
 
 func (r *PostgresTaskRepository) GetCategory(ctx context.Context, userID, categoryID int64) (*entities.Category, error) {
 	const query = `
-SELECT id, user_id, name, created_at
+SELECT id, user_id, parent_id, name, color, icon, position, created_at, updated_at
 FROM task_service.categories
 WHERE id = $1
   AND user_id = $2
@@ -302,8 +544,13 @@ WHERE id = $1
 	if err := q.QueryRow(ctx, query, categoryID, userID).Scan(
 		&category.ID,
 		&category.UserID,
+		&category.ParentID,
 		&category.Name,
+		&category.Color,
+		&category.Icon,
+		&category.Position,
 		&category.CreatedAt,
+		&category.UpdatedAt,
 	); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, domain.ErrCategoryNotFound
@@ -311,31 +558,149 @@ WHERE id = $1
 		return nil, err
 	}
 
-	category.UpdatedAt = category.CreatedAt
 	return &category, nil
 }
 
-func (r *PostgresTaskRepository) DeleteCategory(ctx context.Context, userID, categoryID int64) error {
+func (r *PostgresTaskRepository) UpdateCategory(ctx context.Context, category *entities.Category) error {
 	const query = `
-DELETE FROM task_service.categories
-WHERE id = $1
-  AND user_id = $2
+UPDATE task_service.categories
+SET name = $1,
+    color = $2,
+    icon = $3,
+    parent_id = $4,
+    updated_at = NOW()
+WHERE id = $5
+  AND user_id = $6
+RETURNING updated_at
 `
 
 	q := r.querier(ctx)
 
-	tag, err := q.Exec(ctx, query, categoryID, userID)
+	if err := q.QueryRow(ctx, query,
+		category.Name,
+		category.Color,
+		category.Icon,
+		category.ParentID,
+		category.ID,
+		category.UserID,
+	).Scan(&category.UpdatedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrCategoryNotFound
+		}
+		if isUniqueViolation(err) {
+			return domain.ErrCategoryExists
+		}
+		return err
+	}
+
+	return nil
+}
+
+// ReorderCategories rewrites positions in a single statement so the new order
+// is applied atomically.
+func (r *PostgresTaskRepository) ReorderCategories(ctx context.Context, userID int64, categoryIDs []int64) error {
+	const query = `
+UPDATE task_service.categories c
+SET position = o.ord,
+    updated_at = NOW()
+FROM unnest($2::int[]) WITH ORDINALITY AS o(id, ord)
+WHERE c.id = o.id
+  AND c.user_id = $1
+`
+
+	q := r.querier(ctx)
+
+	tag, err := q.Exec(ctx, query, userID, categoryIDs)
 	if err != nil {
 		return err
 	}
 
-	if tag.RowsAffected() == 0 {
+	if tag.RowsAffected() != int64(len(categoryIDs)) {
 		return domain.ErrCategoryNotFound
 	}
 
 	return nil
 }
 
+// DeleteCategory removes a category and handles its subcategories according
+// to mode. All statements run in one transaction.
+func (r *PostgresTaskRepository) DeleteCategory(ctx context.Context, userID, categoryID int64, mode entities.CategoryDeleteMode) error {
+	run := func(ctx context.Context) error {
+		q := r.querier(ctx)
+
+		var parentID *int64
+		if err := q.QueryRow(ctx, `
+SELECT parent_id
+FROM task_service.categories
+WHERE id = $1
+  AND user_id = $2
+FOR UPDATE
+`, categoryID, userID).Scan(&parentID); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return domain.ErrCategoryNotFound
+			}
+			return err
+		}
+
+		switch mode {
+		case entities.CategoryDeleteCascade:
+			_, err := q.Exec(ctx, `
+DELETE FROM task_service.categories
+WHERE id IN (`+categorySubtreeQuery("$1", "$2")+`)
+`, categoryID, userID)
+			return err
+
+		case entities.CategoryDeleteReparent:
+			if _, err := q.Exec(ctx, `
+UPDATE task_service.categories
+SET parent_id = $1,
+    updated_at = NOW()
+WHERE parent_id = $2
+  AND user_id = $3
+`, parentID, categoryID, userID); err != nil {
+				if isUniqueViolation(err) {
+					return domain.ErrCategoryExists
+				}
+				return err
+			}
+			if _, err := q.Exec(ctx, `
+UPDATE task_service.tasks
+SET category_id = $1,
+    updated_at = NOW()
+WHERE category_id = $2
+  AND user_id = $3
+`, parentID, categoryID, userID); err != nil {
+				return err
+			}
+
+		default:
+			var hasChildren bool
+			if err := q.QueryRow(ctx, `
+SELECT EXISTS (
+    SELECT 1 FROM task_service.categories WHERE parent_id = $1 AND user_id = $2
+)
+`, categoryID, userID).Scan(&hasChildren); err != nil {
+				return err
+			}
+			if hasChildren {
+				return domain.ErrCategoryHasChildren
+			}
+		}
+
+		_, err := q.Exec(ctx, `
+DELETE FROM task_service.categories
+WHERE id = $1
+  AND user_id = $2
+`, categoryID, userID)
+		return err
+	}
+
+	if TxFromContext(ctx) != nil {
+		return run(ctx)
+	}
+	return WithTransaction(ctx, r.pool, run)
+}
+
 func (r *PostgresTaskRepository) CreateComment(ctx context.Context, comment *entities.TaskComment) error {
 	const query = `
 INSERT INTO task_service.task_comments (task_id, user_id, content)
@@ -365,7 +730,7 @@ WHERE task_id = $1
 ORDER BY created_at ASC
 `
 
-	q := r.querier(ctx)
+	q := r.reader(ctx)
 
 	rows, err := q.Query(ctx, query, taskID, userID)
 	if err != nil {
@@ -391,10 +756,19 @@ ORDER BY created_at ASC
 }
 
 func (r *PostgresTaskRepository) querier(ctx context.Context) querier {
+	return querierFor(ctx, r.pool)
+}
+
+func (r *PostgresTaskRepository) reader(ctx context.Context) ReadPool {
+	return readerFor(ctx, r.pool, r.replica)
+}
+
+// querierFor returns the transaction bound to ctx, falling back to the pool.
+func querierFor(ctx context.Context, pool Pool) querier {
 	if tx := TxFromContext(ctx); tx != nil {
 		return tx
 	}
-	return r.pool
+	return pool
 }
 
 func TxFromContext(ctx context.Context) pgx.Tx {
@@ -422,6 +796,19 @@ func WithTransaction(ctx context.Context, pool Pool, fn func(context.Context) er
 	return tx.Commit(txCtx)
 }
 
+// categorySubtreeQuery selects the ids of a category and all of its
+// descendants. rootArg and userArg are placeholders such as "$1". UNION
+// drops categories already visited, so a parent cycle cannot make it loop.
+func categorySubtreeQuery(rootArg, userArg string) string {
+	return `
+WITH RECURSIVE subtree AS (
+    SELECT id FROM task_service.categories WHERE id = ` + rootArg + ` AND user_id = ` + userArg + `
+    UNION
+    SELECT c.id FROM task_service.categories c JOIN subtree s ON c.parent_id = s.id
+)
+SELECT id FROM subtree`
+}
+
 func baseTaskSelect() string {
 	return `
 SELECT
@@ -433,18 +820,35 @@ SELECT
     t.priority,
     t.due_date,
     t.category_id,
+    t.position,
+    t.estimate_minutes,
+    (SELECT COALESCE(SUM(te.duration_seconds), 0)
+     FROM task_service.time_entries te
+     WHERE te.task_id = t.id AND te.ended_at IS NOT NULL),
+    t.completed_at,
+    t.custom_fields,
     t.created_at,
     t.updated_at,
     t.deleted_at,
     c.id,
     c.user_id,
     c.name,
+    c.color,
+    c.icon,
     c.created_at
 FROM task_service.tasks t
 LEFT JOIN task_service.categories c ON c.id = t.category_id
 `
 }
 
+// encodeCustomFields returns the JSONB value of a task's custom fields.
+func encodeCustomFields(values entities.CustomFieldValues) ([]byte, error) {
+	if len(values) == 0 {
+		return []byte("{}"), nil
+	}
+	return json.Marshal(values)
+}
+
 func scanTask(row rowScanner) (*entities.Task, error) {
 	var (
 		task            entities.Task
@@ -453,8 +857,14 @@ func scanTask(row rowScanner) (*entities.Task, error) {
 		categoryEntity  sql.NullInt64
 		categoryUserID  sql.NullInt64
 		categoryName    sql.NullString
+		categoryColor   sql.NullString
+		categoryIcon    sql.NullString
 		categoryCreated sql.NullTime
+		completedAt     sql.NullTime
+		customFields    []byte
 		deletedAt       sql.NullTime
+		estimate        sql.NullInt32
+		trackedSeconds  int64
 	)
 
 	if err := row.Scan(
@@ -466,12 +876,19 @@ func scanTask(row rowScanner) (*entities.Task, error) {
 		&task.Priority,
 		&dueDate,
 		&categoryID,
+		&task.Position,
+		&estimate,
+		&trackedSeconds,
+		&completedAt,
+		&customFields,
 		&task.CreatedAt,
 		&task.UpdatedAt,
 		&deletedAt,
 		&categoryEntity,
 		&categoryUserID,
 		&categoryName,
+		&categoryColor,
+		&categoryIcon,
 		&categoryCreated,
 	); err != nil {
 		return nil, err
@@ -487,11 +904,19 @@ func scanTask(row rowScanner) (*entities.Task, error) {
 		task.CategoryID = &value
 	}
 
+	if estimate.Valid {
+		value := int(estimate.Int32)
+		task.EstimateMinutes = &value
+	}
+	task.TrackedTime = time.Duration(trackedSeconds) * time.Second
+
 	if categoryEntity.Valid {
 		category := entities.Category{
 			ID:     categoryEntity.Int64,
 			UserID: categoryUserID.Int64,
 			Name:   categoryName.String,
+			Color:  categoryColor.String,
+			Icon:   categoryIcon.String,
 		}
 		if categoryCreated.Valid {
 			category.CreatedAt = categoryCreated.Time
@@ -500,6 +925,17 @@ func scanTask(row rowScanner) (*entities.Task, error) {
 		task.Category = &category
 	}
 
+	if completedAt.Valid {
+		value := completedAt.Time
+		task.CompletedAt = &value
+	}
+
+	if len(customFields) > 0 {
+		if err := json.Unmarshal(customFields, &task.CustomFields); err != nil {
+			return nil, err
+		}
+	}
+
 	if deletedAt.Valid {
 		value := deletedAt.Time
 		task.DeletedAt = &value
@@ -528,6 +964,11 @@ func prioritiesToTextArray(priorities []entities.TaskPriority) []string {
 	return out
 }
 
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505"
+}
+
 func itoa(value int) string {
 	return strconv.Itoa(value)
 }
@@ -0,0 +1,259 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+
+	"todoapp/services/task-service/internal/domain"
+	"todoapp/services/task-service/internal/domain/entities"
+	"todoapp/services/task-service/internal/ports"
+)
+
+type PostgresTemplateRepository struct {
+	pool Pool
+}
+
+func NewPostgresTemplateRepository(pool Pool) *PostgresTemplateRepository {
+	return &PostgresTemplateRepository{pool: pool}
+}
+
+var _ ports.TemplateRepository = (*PostgresTemplateRepository)(nil)
+
+// templateItemRecord is the JSONB representation of a template item.
+type templateItemRecord struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Priority    string `json:"priority,omitempty"`
+	DueOffset   string `json:"dueOffset,omitempty"`
+}
+
+func (r *PostgresTemplateRepository) CreateTemplate(ctx context.Context, template *entities.TaskTemplate) error {
+	const query = `
+INSERT INTO task_service.task_templates (
+    user_id,
+    name,
+    title,
+    description,
+    priority,
+    category_id,
+    due_offset,
+    items
+) VALUES ($1,$2,$3,$4,$5,$6,$7,$8)
+RETURNING id, created_at, updated_at
+`
+
+	items, err := marshalTemplateItems(template.Items)
+	if err != nil {
+		return err
+	}
+
+	q := querierFor(ctx, r.pool)
+
+	if err := q.QueryRow(ctx, query,
+		template.UserID,
+		template.Name,
+		template.Title,
+		template.Description,
+		string(template.Priority),
+		template.CategoryID,
+		template.DueOffset,
+		items,
+	).Scan(&template.ID, &template.CreatedAt, &template.UpdatedAt); err != nil {
+		if isUniqueViolation(err) {
+			return domain.ErrTemplateExists
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (r *PostgresTemplateRepository) GetTemplate(ctx context.Context, userID, templateID int64) (*entities.TaskTemplate, error) {
+	q := querierFor(ctx, r.pool)
+
+	row := q.QueryRow(ctx, baseTemplateSelect()+`
+WHERE id = $1
+  AND user_id = $2
+`, templateID, userID)
+
+	template, err := scanTemplate(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrTemplateNotFound
+		}
+		return nil, err
+	}
+
+	return template, nil
+}
+
+func (r *PostgresTemplateRepository) ListTemplates(ctx context.Context, userID int64) ([]entities.TaskTemplate, error) {
+	q := querierFor(ctx, r.pool)
+
+	rows, err := q.Query(ctx, baseTemplateSelect()+`
+WHERE user_id = $1
+ORDER BY name ASC
+`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var templates []entities.TaskTemplate
+
+	for rows.Next() {
+		template, err := scanTemplate(rows)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, *template)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return templates, nil
+}
+
+func (r *PostgresTemplateRepository) UpdateTemplate(ctx context.Context, template *entities.TaskTemplate) error {
+	const query = `
+UPDATE task_service.task_templates
+SET name = $1,
+    title = $2,
+    description = $3,
+    priority = $4,
+    category_id = $5,
+    due_offset = $6,
+    items = $7,
+    updated_at = NOW()
+WHERE id = $8
+  AND user_id = $9
+RETURNING created_at, updated_at
+`
+
+	items, err := marshalTemplateItems(template.Items)
+	if err != nil {
+		return err
+	}
+
+	q := querierFor(ctx, r.pool)
+
+	if err := q.QueryRow(ctx, query,
+		template.Name,
+		template.Title,
+		template.Description,
+		string(template.Priority),
+		template.CategoryID,
+		template.DueOffset,
+		items,
+		template.ID,
+		template.UserID,
+	).Scan(&template.CreatedAt, &template.UpdatedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrTemplateNotFound
+		}
+		if isUniqueViolation(err) {
+			return domain.ErrTemplateExists
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (r *PostgresTemplateRepository) DeleteTemplate(ctx context.Context, userID, templateID int64) error {
+	const query = `
+DELETE FROM task_service.task_templates
+WHERE id = $1
+  AND user_id = $2
+`
+
+	q := querierFor(ctx, r.pool)
+
+	tag, err := q.Exec(ctx, query, templateID, userID)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domain.ErrTemplateNotFound
+	}
+
+	return nil
+}
+
+func baseTemplateSelect() string {
+	return `
+SELECT
+    id,
+    user_id,
+    name,
+    title,
+    description,
+    priority,
+    category_id,
+    due_offset,
+    items,
+    created_at,
+    updated_at
+FROM task_service.task_templates
+`
+}
+
+func scanTemplate(row rowScanner) (*entities.TaskTemplate, error) {
+	var (
+		template entities.TaskTemplate
+		priority string
+		items    []byte
+	)
+
+	if err := row.Scan(
+		&template.ID,
+		&template.UserID,
+		&template.Name,
+		&template.Title,
+		&template.Description,
+		&priority,
+		&template.CategoryID,
+		&template.DueOffset,
+		&items,
+		&template.CreatedAt,
+		&template.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	template.Priority = entities.TaskPriority(priority)
+
+	var records []templateItemRecord
+	if err := json.Unmarshal(items, &records); err != nil {
+		return nil, err
+	}
+	for _, record := range records {
+		template.Items = append(template.Items, entities.TemplateItem{
+			Title:       record.Title,
+			Description: record.Description,
+			Priority:    entities.TaskPriority(record.Priority),
+			DueOffset:   record.DueOffset,
+		})
+	}
+
+	return &template, nil
+}
+
+func marshalTemplateItems(items []entities.TemplateItem) ([]byte, error) {
+	records := make([]templateItemRecord, 0, len(items))
+	for _, item := range items {
+		records = append(records, templateItemRecord{
+			Title:       item.Title,
+			Description: item.Description,
+			Priority:    string(item.Priority),
+			DueOffset:   item.DueOffset,
+		})
+	}
+	return json.Marshal(records)
+}
@@ -0,0 +1,253 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"todoapp/services/task-service/internal/domain"
+	"todoapp/services/task-service/internal/domain/entities"
+	"todoapp/services/task-service/internal/ports"
+)
+
+type PostgresTimeEntryRepository struct {
+	pool Pool
+}
+
+func NewPostgresTimeEntryRepository(pool Pool) *PostgresTimeEntryRepository {
+	return &PostgresTimeEntryRepository{pool: pool}
+}
+
+var _ ports.TimeEntryRepository = (*PostgresTimeEntryRepository)(nil)
+
+func (r *PostgresTimeEntryRepository) CreateTimeEntry(ctx context.Context, entry *entities.TimeEntry) error {
+	const query = `
+INSERT INTO task_service.time_entries (
+    user_id,
+    task_id,
+    started_at,
+    ended_at,
+    duration_seconds,
+    note
+) VALUES ($1,$2,$3,$4,$5,$6)
+RETURNING id, created_at
+`
+
+	q := querierFor(ctx, r.pool)
+
+	if err := q.QueryRow(ctx, query,
+		entry.UserID,
+		entry.TaskID,
+		entry.StartedAt,
+		entry.EndedAt,
+		int64(entry.Duration/time.Second),
+		entry.Note,
+	).Scan(&entry.ID, &entry.CreatedAt); err != nil {
+		if isUniqueViolation(err) {
+			return domain.ErrTimerRunning
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (r *PostgresTimeEntryRepository) GetRunningTimeEntry(ctx context.Context, userID int64) (*entities.TimeEntry, error) {
+	q := querierFor(ctx, r.pool)
+
+	row := q.QueryRow(ctx, baseTimeEntrySelect()+`
+WHERE e.user_id = $1
+  AND e.ended_at IS NULL
+`, userID)
+
+	entry, err := scanTimeEntry(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrNoRunningTimer
+		}
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+func (r *PostgresTimeEntryRepository) StopTimeEntry(ctx context.Context, entry *entities.TimeEntry) error {
+	const query = `
+UPDATE task_service.time_entries
+SET ended_at = $1,
+    duration_seconds = $2
+WHERE id = $3
+  AND user_id = $4
+  AND ended_at IS NULL
+`
+
+	q := querierFor(ctx, r.pool)
+
+	tag, err := q.Exec(ctx, query,
+		entry.EndedAt,
+		int64(entry.Duration/time.Second),
+		entry.ID,
+		entry.UserID,
+	)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domain.ErrNoRunningTimer
+	}
+
+	return nil
+}
+
+func (r *PostgresTimeEntryRepository) ListTimeEntries(ctx context.Context, userID int64, filter ports.TimeEntryFilter) ([]entities.TimeEntry, error) {
+	var (
+		args      []any
+		clauses   []string
+		argsIndex = 1
+	)
+
+	clauses = append(clauses, "e.user_id = $"+itoa(argsIndex))
+	args = append(args, userID)
+	argsIndex++
+
+	clauses = append(clauses, "t.deleted_at IS NULL")
+
+	if filter.TaskID != nil {
+		clauses = append(clauses, "e.task_id = $"+itoa(argsIndex))
+		args = append(args, *filter.TaskID)
+		argsIndex++
+	}
+
+	if filter.From != nil {
+		clauses = append(clauses, "e.started_at >= $"+itoa(argsIndex))
+		args = append(args, *filter.From)
+		argsIndex++
+	}
+
+	if filter.To != nil {
+		clauses = append(clauses, "e.started_at < $"+itoa(argsIndex))
+		args = append(args, *filter.To)
+	}
+
+	query := baseTimeEntrySelect() + "\nWHERE " + strings.Join(clauses, " AND ") + "\nORDER BY e.started_at DESC, e.id DESC"
+
+	q := querierFor(ctx, r.pool)
+
+	rows, err := q.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []entities.TimeEntry
+
+	for rows.Next() {
+		entry, err := scanTimeEntry(rows)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, *entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+func (r *PostgresTimeEntryRepository) DeleteTimeEntry(ctx context.Context, userID, taskID, entryID int64) error {
+	const query = `
+DELETE FROM task_service.time_entries
+WHERE id = $1
+  AND task_id = $2
+  AND user_id = $3
+`
+
+	q := querierFor(ctx, r.pool)
+
+	tag, err := q.Exec(ctx, query, entryID, taskID, userID)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domain.ErrTimeEntryNotFound
+	}
+
+	return nil
+}
+
+func baseTimeEntrySelect() string {
+	return `
+SELECT
+    e.id,
+    e.user_id,
+    e.task_id,
+    e.started_at,
+    e.ended_at,
+    e.duration_seconds,
+    e.note,
+    e.created_at,
+    t.title,
+    t.estimate_minutes,
+    c.id,
+    c.name
+FROM task_service.time_entries e
+JOIN task_service.tasks t ON t.id = e.task_id
+LEFT JOIN task_service.categories c ON c.id = t.category_id
+`
+}
+
+func scanTimeEntry(row rowScanner) (*entities.TimeEntry, error) {
+	var (
+		entry        entities.TimeEntry
+		endedAt      sql.NullTime
+		seconds      int64
+		estimate     sql.NullInt32
+		categoryID   sql.NullInt64
+		categoryName sql.NullString
+	)
+
+	if err := row.Scan(
+		&entry.ID,
+		&entry.UserID,
+		&entry.TaskID,
+		&entry.StartedAt,
+		&endedAt,
+		&seconds,
+		&entry.Note,
+		&entry.CreatedAt,
+		&entry.TaskTitle,
+		&estimate,
+		&categoryID,
+		&categoryName,
+	); err != nil {
+		return nil, err
+	}
+
+	entry.Duration = time.Duration(seconds) * time.Second
+
+	if endedAt.Valid {
+		value := endedAt.Time
+		entry.EndedAt = &value
+	}
+
+	if estimate.Valid {
+		value := int(estimate.Int32)
+		entry.TaskEstimateMinutes = &value
+	}
+
+	if categoryID.Valid {
+		value := categoryID.Int64
+		entry.CategoryID = &value
+		entry.CategoryName = categoryName.String
+	}
+
+	return &entry, nil
+}
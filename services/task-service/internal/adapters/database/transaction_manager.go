@@ -0,0 +1,28 @@
+package database
+
+import (
+	"context"
+
+	"todoapp/services/task-service/internal/ports"
+)
+
+// TransactionManager runs service code in a database transaction. Repositories
+// pick the transaction up from the context, so every write in fn commits or
+// rolls back together.
+type TransactionManager struct {
+	pool Pool
+}
+
+func NewTransactionManager(pool Pool) *TransactionManager {
+	return &TransactionManager{pool: pool}
+}
+
+var _ ports.TransactionManager = (*TransactionManager)(nil)
+
+// WithinTransaction joins the transaction already carried by ctx, if any.
+func (m *TransactionManager) WithinTransaction(ctx context.Context, fn func(context.Context) error) error {
+	if TxFromContext(ctx) != nil {
+		return fn(ctx)
+	}
+	return WithTransaction(ctx, m.pool, fn)
+}
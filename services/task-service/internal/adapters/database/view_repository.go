@@ -0,0 +1,240 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+
+	"todoapp/services/task-service/internal/domain"
+	"todoapp/services/task-service/internal/domain/entities"
+	"todoapp/services/task-service/internal/ports"
+)
+
+type PostgresViewRepository struct {
+	pool Pool
+}
+
+func NewPostgresViewRepository(pool Pool) *PostgresViewRepository {
+	return &PostgresViewRepository{pool: pool}
+}
+
+var _ ports.ViewRepository = (*PostgresViewRepository)(nil)
+
+// viewFilterRecord is the JSONB representation of a view filter.
+type viewFilterRecord struct {
+	Statuses             []string `json:"statuses,omitempty"`
+	Priorities           []string `json:"priorities,omitempty"`
+	CategoryID           *int64   `json:"categoryId,omitempty"`
+	IncludeSubcategories bool     `json:"includeSubcategories,omitempty"`
+	WithoutCategory      bool     `json:"withoutCategory,omitempty"`
+	Search               string   `json:"search,omitempty"`
+	DueFrom              string   `json:"dueFrom,omitempty"`
+	DueTo                string   `json:"dueTo,omitempty"`
+}
+
+func (r *PostgresViewRepository) CreateView(ctx context.Context, view *entities.SavedView) error {
+	const query = `
+INSERT INTO task_service.saved_views (
+    user_id,
+    name,
+    filter
+) VALUES ($1,$2,$3)
+RETURNING id, created_at, updated_at
+`
+
+	filter, err := marshalViewFilter(view.Filter)
+	if err != nil {
+		return err
+	}
+
+	q := querierFor(ctx, r.pool)
+
+	if err := q.QueryRow(ctx, query,
+		view.UserID,
+		view.Name,
+		filter,
+	).Scan(&view.ID, &view.CreatedAt, &view.UpdatedAt); err != nil {
+		if isUniqueViolation(err) {
+			return domain.ErrViewExists
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (r *PostgresViewRepository) GetView(ctx context.Context, userID, viewID int64) (*entities.SavedView, error) {
+	q := querierFor(ctx, r.pool)
+
+	row := q.QueryRow(ctx, baseViewSelect()+`
+WHERE id = $1
+  AND user_id = $2
+`, viewID, userID)
+
+	view, err := scanView(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrViewNotFound
+		}
+		return nil, err
+	}
+
+	return view, nil
+}
+
+func (r *PostgresViewRepository) ListViews(ctx context.Context, userID int64) ([]entities.SavedView, error) {
+	q := querierFor(ctx, r.pool)
+
+	rows, err := q.Query(ctx, baseViewSelect()+`
+WHERE user_id = $1
+ORDER BY name ASC
+`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var views []entities.SavedView
+
+	for rows.Next() {
+		view, err := scanView(rows)
+		if err != nil {
+			return nil, err
+		}
+		views = append(views, *view)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return views, nil
+}
+
+func (r *PostgresViewRepository) UpdateView(ctx context.Context, view *entities.SavedView) error {
+	const query = `
+UPDATE task_service.saved_views
+SET name = $1,
+    filter = $2,
+    updated_at = NOW()
+WHERE id = $3
+  AND user_id = $4
+RETURNING created_at, updated_at
+`
+
+	filter, err := marshalViewFilter(view.Filter)
+	if err != nil {
+		return err
+	}
+
+	q := querierFor(ctx, r.pool)
+
+	if err := q.QueryRow(ctx, query,
+		view.Name,
+		filter,
+		view.ID,
+		view.UserID,
+	).Scan(&view.CreatedAt, &view.UpdatedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrViewNotFound
+		}
+		if isUniqueViolation(err) {
+			return domain.ErrViewExists
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (r *PostgresViewRepository) DeleteView(ctx context.Context, userID, viewID int64) error {
+	const query = `
+DELETE FROM task_service.saved_views
+WHERE id = $1
+  AND user_id = $2
+`
+
+	q := querierFor(ctx, r.pool)
+
+	tag, err := q.Exec(ctx, query, viewID, userID)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domain.ErrViewNotFound
+	}
+
+	return nil
+}
+
+func baseViewSelect() string {
+	return `
+SELECT
+    id,
+    user_id,
+    name,
+    filter,
+    created_at,
+    updated_at
+FROM task_service.saved_views
+`
+}
+
+func scanView(row rowScanner) (*entities.SavedView, error) {
+	var (
+		view   entities.SavedView
+		filter []byte
+	)
+
+	if err := row.Scan(
+		&view.ID,
+		&view.UserID,
+		&view.Name,
+		&filter,
+		&view.CreatedAt,
+		&view.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	var record viewFilterRecord
+	if err := json.Unmarshal(filter, &record); err != nil {
+		return nil, err
+	}
+
+	for _, status := range record.Statuses {
+		view.Filter.Statuses = append(view.Filter.Statuses, entities.TaskStatus(status))
+	}
+	for _, priority := range record.Priorities {
+		view.Filter.Priorities = append(view.Filter.Priorities, entities.TaskPriority(priority))
+	}
+	view.Filter.CategoryID = record.CategoryID
+	view.Filter.IncludeSubcategories = record.IncludeSubcategories
+	view.Filter.WithoutCategory = record.WithoutCategory
+	view.Filter.Search = record.Search
+	view.Filter.DueFrom = record.DueFrom
+	view.Filter.DueTo = record.DueTo
+
+	return &view, nil
+}
+
+func marshalViewFilter(filter entities.ViewFilter) ([]byte, error) {
+	record := viewFilterRecord{
+		CategoryID:           filter.CategoryID,
+		IncludeSubcategories: filter.IncludeSubcategories,
+		WithoutCategory:      filter.WithoutCategory,
+		Search:               filter.Search,
+		DueFrom:              filter.DueFrom,
+		DueTo:                filter.DueTo,
+	}
+	for _, status := range filter.Statuses {
+		record.Statuses = append(record.Statuses, string(status))
+	}
+	for _, priority := range filter.Priorities {
+		record.Priorities = append(record.Priorities, string(priority))
+	}
+	return json.Marshal(record)
+}
@@ -0,0 +1,518 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"todoapp/pkg/events"
+	"todoapp/services/task-service/internal/domain"
+	"todoapp/services/task-service/internal/domain/entities"
+	"todoapp/services/task-service/internal/ports"
+)
+
+type PostgresWebhookRepository struct {
+	pool Pool
+}
+
+func NewPostgresWebhookRepository(pool Pool) *PostgresWebhookRepository {
+	return &PostgresWebhookRepository{pool: pool}
+}
+
+var _ ports.WebhookRepository = (*PostgresWebhookRepository)(nil)
+
+func (r *PostgresWebhookRepository) CreateWebhook(ctx context.Context, webhook *entities.Webhook) error {
+	const query = `
+INSERT INTO task_service.webhooks (
+    user_id,
+    url,
+    secret,
+    event_types,
+    active
+) VALUES ($1,$2,$3,$4,$5)
+RETURNING id, created_at, updated_at
+`
+
+	q := querierFor(ctx, r.pool)
+
+	return q.QueryRow(ctx, query,
+		webhook.UserID,
+		webhook.URL,
+		webhook.Secret,
+		eventTypeStrings(webhook.EventTypes),
+		webhook.Active,
+	).Scan(&webhook.ID, &webhook.CreatedAt, &webhook.UpdatedAt)
+}
+
+func (r *PostgresWebhookRepository) CountWebhooks(ctx context.Context, userID int64) (int, error) {
+	const query = `
+SELECT COUNT(*)
+FROM task_service.webhooks
+WHERE user_id = $1
+`
+
+	q := querierFor(ctx, r.pool)
+
+	var count int
+	if err := q.QueryRow(ctx, query, userID).Scan(&count); err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (r *PostgresWebhookRepository) ListWebhooks(ctx context.Context, userID int64) ([]entities.Webhook, error) {
+	q := querierFor(ctx, r.pool)
+
+	rows, err := q.Query(ctx, baseWebhookSelect()+`
+WHERE user_id = $1
+ORDER BY id ASC
+`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var webhooks []entities.Webhook
+
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, *webhook)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return webhooks, nil
+}
+
+func (r *PostgresWebhookRepository) GetWebhook(ctx context.Context, userID, webhookID int64) (*entities.Webhook, error) {
+	q := querierFor(ctx, r.pool)
+
+	row := q.QueryRow(ctx, baseWebhookSelect()+`
+WHERE id = $1
+  AND user_id = $2
+`, webhookID, userID)
+
+	webhook, err := scanWebhook(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrWebhookNotFound
+		}
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+func (r *PostgresWebhookRepository) UpdateWebhook(ctx context.Context, webhook *entities.Webhook) error {
+	const query = `
+UPDATE task_service.webhooks
+SET url = $1,
+    event_types = $2,
+    active = $3,
+    consecutive_failures = $4,
+    disabled_at = $5,
+    updated_at = NOW()
+WHERE id = $6
+  AND user_id = $7
+RETURNING updated_at
+`
+
+	q := querierFor(ctx, r.pool)
+
+	if err := q.QueryRow(ctx, query,
+		webhook.URL,
+		eventTypeStrings(webhook.EventTypes),
+		webhook.Active,
+		webhook.ConsecutiveFailures,
+		webhook.DisabledAt,
+		webhook.ID,
+		webhook.UserID,
+	).Scan(&webhook.UpdatedAt); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrWebhookNotFound
+		}
+		return err
+	}
+
+	return nil
+}
+
+func (r *PostgresWebhookRepository) DeleteWebhook(ctx context.Context, userID, webhookID int64) error {
+	const query = `
+DELETE FROM task_service.webhooks
+WHERE id = $1
+  AND user_id = $2
+`
+
+	q := querierFor(ctx, r.pool)
+
+	tag, err := q.Exec(ctx, query, webhookID, userID)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domain.ErrWebhookNotFound
+	}
+
+	return nil
+}
+
+func (r *PostgresWebhookRepository) ListWebhookDeliveries(ctx context.Context, webhookID int64, limit int) ([]entities.WebhookDelivery, error) {
+	q := querierFor(ctx, r.pool)
+
+	rows, err := q.Query(ctx, `
+SELECT `+webhookDeliveryColumns("d")+`
+FROM task_service.webhook_deliveries d
+WHERE d.webhook_id = $1
+ORDER BY d.id DESC
+LIMIT $2
+`, webhookID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deliveries []entities.WebhookDelivery
+
+	for rows.Next() {
+		var delivery entities.WebhookDelivery
+		if err := scanWebhookDelivery(rows, &delivery); err != nil {
+			return nil, err
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (r *PostgresWebhookRepository) EnqueueWebhookDeliveries(ctx context.Context, userID int64, event events.TaskEvent, payload []byte) (int, error) {
+	const query = `
+INSERT INTO task_service.webhook_deliveries (
+    webhook_id,
+    event_id,
+    event_type,
+    payload,
+    next_attempt_at,
+    created_at
+)
+SELECT id, $2, $3, $4, $5, $5
+FROM task_service.webhooks
+WHERE user_id = $1
+  AND active
+  AND (cardinality(event_types) = 0 OR $3 = ANY(event_types))
+`
+
+	q := querierFor(ctx, r.pool)
+
+	tag, err := q.Exec(ctx, query,
+		userID,
+		event.ID,
+		string(event.Type),
+		payload,
+		event.CreatedAt.UTC(),
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(tag.RowsAffected()), nil
+}
+
+func (r *PostgresWebhookRepository) CreateWebhookDelivery(ctx context.Context, delivery *entities.WebhookDelivery) error {
+	const query = `
+INSERT INTO task_service.webhook_deliveries (
+    webhook_id,
+    event_id,
+    event_type,
+    payload,
+    status,
+    next_attempt_at,
+    created_at
+) VALUES ($1,$2,$3,$4,$5,$6,$6)
+RETURNING id, created_at
+`
+
+	q := querierFor(ctx, r.pool)
+
+	return q.QueryRow(ctx, query,
+		delivery.WebhookID,
+		delivery.EventID,
+		string(delivery.EventType),
+		delivery.Payload,
+		string(delivery.Status),
+		delivery.NextAttemptAt.UTC(),
+	).Scan(&delivery.ID, &delivery.CreatedAt)
+}
+
+func (r *PostgresWebhookRepository) LeaseDueWebhookDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]ports.DueWebhookDelivery, error) {
+	query := `
+UPDATE task_service.webhook_deliveries d
+SET next_attempt_at = $2
+FROM task_service.webhooks w
+WHERE w.id = d.webhook_id
+  AND d.id IN (
+      SELECT due.id
+      FROM task_service.webhook_deliveries due
+      JOIN task_service.webhooks hook ON hook.id = due.webhook_id
+      WHERE due.status = 'pending'
+        AND due.next_attempt_at <= $1
+        AND hook.active
+      ORDER BY due.next_attempt_at, due.id
+      LIMIT $3
+      FOR UPDATE OF due SKIP LOCKED
+  )
+RETURNING ` + webhookDeliveryColumns("d") + `, w.url, w.secret
+`
+
+	q := querierFor(ctx, r.pool)
+
+	rows, err := q.Query(ctx, query, now.UTC(), leaseUntil.UTC(), limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var due []ports.DueWebhookDelivery
+
+	for rows.Next() {
+		var item ports.DueWebhookDelivery
+		if err := scanWebhookDelivery(rows, &item.Delivery, &item.URL, &item.Secret); err != nil {
+			return nil, err
+		}
+		due = append(due, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return due, nil
+}
+
+func (r *PostgresWebhookRepository) RecordWebhookAttempt(ctx context.Context, attempt ports.WebhookAttempt, disableAfter int) (bool, error) {
+	const deliveryQuery = `
+UPDATE task_service.webhook_deliveries
+SET attempts = attempts + 1,
+    status = $2,
+    response_code = $3,
+    last_error = $4,
+    next_attempt_at = COALESCE($5, next_attempt_at),
+    completed_at = $6
+WHERE id = $1
+`
+	const succeededQuery = `
+UPDATE task_service.webhooks
+SET consecutive_failures = 0
+WHERE id = $1
+`
+	const failedQuery = `
+UPDATE task_service.webhooks
+SET consecutive_failures = consecutive_failures + 1,
+    active = CASE WHEN consecutive_failures + 1 >= $2 THEN FALSE ELSE active END,
+    disabled_at = CASE WHEN active AND consecutive_failures + 1 >= $2 THEN $3 ELSE disabled_at END
+WHERE id = $1
+RETURNING NOT active AND consecutive_failures = $2
+`
+
+	status := entities.WebhookDeliveryFailed
+	switch {
+	case attempt.Succeeded:
+		status = entities.WebhookDeliverySucceeded
+	case attempt.NextAttemptAt != nil:
+		status = entities.WebhookDeliveryPending
+	}
+
+	var (
+		nextAttemptAt *time.Time
+		completedAt   *time.Time
+	)
+	if status == entities.WebhookDeliveryPending {
+		next := attempt.NextAttemptAt.UTC()
+		nextAttemptAt = &next
+	} else {
+		at := attempt.At.UTC()
+		completedAt = &at
+	}
+
+	lastError := attempt.Error
+	if len(lastError) > maxOutboxErrorLength {
+		lastError = strings.ToValidUTF8(lastError[:maxOutboxErrorLength], "")
+	}
+
+	disabled := false
+
+	err := NewTransactionManager(r.pool).WithinTransaction(ctx, func(ctx context.Context) error {
+		q := querierFor(ctx, r.pool)
+
+		if _, err := q.Exec(ctx, deliveryQuery,
+			attempt.DeliveryID,
+			string(status),
+			attempt.ResponseCode,
+			nullableString(lastError),
+			nextAttemptAt,
+			completedAt,
+		); err != nil {
+			return err
+		}
+
+		if attempt.Test {
+			return nil
+		}
+
+		if attempt.Succeeded {
+			_, err := q.Exec(ctx, succeededQuery, attempt.WebhookID)
+			return err
+		}
+
+		err := q.QueryRow(ctx, failedQuery, attempt.WebhookID, disableAfter, attempt.At.UTC()).Scan(&disabled)
+		if errors.Is(err, pgx.ErrNoRows) {
+			// The webhook was deleted meanwhile.
+			return nil
+		}
+		return err
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return disabled, nil
+}
+
+func (r *PostgresWebhookRepository) PurgeWebhookDeliveries(ctx context.Context, before time.Time) (int64, error) {
+	const query = `
+DELETE FROM task_service.webhook_deliveries
+WHERE status <> 'pending'
+  AND completed_at < $1
+`
+
+	q := querierFor(ctx, r.pool)
+
+	tag, err := q.Exec(ctx, query, before.UTC())
+	if err != nil {
+		return 0, err
+	}
+
+	return tag.RowsAffected(), nil
+}
+
+func baseWebhookSelect() string {
+	return `
+SELECT
+    id,
+    user_id,
+    url,
+    secret,
+    event_types,
+    active,
+    consecutive_failures,
+    disabled_at,
+    created_at,
+    updated_at
+FROM task_service.webhooks
+`
+}
+
+func scanWebhook(row rowScanner) (*entities.Webhook, error) {
+	var (
+		webhook    entities.Webhook
+		eventTypes []string
+	)
+
+	if err := row.Scan(
+		&webhook.ID,
+		&webhook.UserID,
+		&webhook.URL,
+		&webhook.Secret,
+		&eventTypes,
+		&webhook.Active,
+		&webhook.ConsecutiveFailures,
+		&webhook.DisabledAt,
+		&webhook.CreatedAt,
+		&webhook.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	for _, eventType := range eventTypes {
+		webhook.EventTypes = append(webhook.EventTypes, events.TaskEventType(eventType))
+	}
+
+	return &webhook, nil
+}
+
+func webhookDeliveryColumns(alias string) string {
+	columns := []string{
+		"id", "webhook_id", "event_id", "event_type", "payload", "status", "attempts",
+		"next_attempt_at", "response_code", "last_error", "created_at", "completed_at",
+	}
+	for i, column := range columns {
+		columns[i] = alias + "." + column
+	}
+	return strings.Join(columns, ", ")
+}
+
+// scanWebhookDelivery scans the columns of webhookDeliveryColumns followed by
+// any extra destinations.
+func scanWebhookDelivery(row rowScanner, delivery *entities.WebhookDelivery, extra ...any) error {
+	var (
+		eventType string
+		status    string
+		lastError *string
+	)
+
+	dest := []any{
+		&delivery.ID,
+		&delivery.WebhookID,
+		&delivery.EventID,
+		&eventType,
+		&delivery.Payload,
+		&status,
+		&delivery.Attempts,
+		&delivery.NextAttemptAt,
+		&delivery.ResponseCode,
+		&lastError,
+		&delivery.CreatedAt,
+		&delivery.CompletedAt,
+	}
+
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return err
+	}
+
+	delivery.EventType = events.TaskEventType(eventType)
+	delivery.Status = entities.WebhookDeliveryStatus(status)
+	if lastError != nil {
+		delivery.LastError = *lastError
+	}
+
+	return nil
+}
+
+func eventTypeStrings(eventTypes []events.TaskEventType) []string {
+	values := make([]string, 0, len(eventTypes))
+	for _, eventType := range eventTypes {
+		values = append(values, string(eventType))
+	}
+	return values
+}
+
+func nullableString(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
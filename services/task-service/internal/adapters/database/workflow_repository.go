@@ -0,0 +1,366 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+
+	"todoapp/services/task-service/internal/domain"
+	"todoapp/services/task-service/internal/domain/entities"
+	"todoapp/services/task-service/internal/ports"
+)
+
+type PostgresWorkflowRepository struct {
+	pool Pool
+}
+
+func NewPostgresWorkflowRepository(pool Pool) *PostgresWorkflowRepository {
+	return &PostgresWorkflowRepository{pool: pool}
+}
+
+var _ ports.WorkflowRepository = (*PostgresWorkflowRepository)(nil)
+
+// workflowStatusRecord and workflowTransitionRecord are the JSON rows
+// aggregated by baseWorkflowSelect.
+type workflowStatusRecord struct {
+	Key       string `json:"key"`
+	Name      string `json:"name"`
+	Completed bool   `json:"completed"`
+}
+
+type workflowTransitionRecord struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+func (r *PostgresWorkflowRepository) CreateWorkflow(ctx context.Context, workflow *entities.Workflow) error {
+	const query = `
+INSERT INTO task_service.workflows (user_id, category_id, name)
+SELECT $1::int, $2::int, $3::text
+WHERE $2::int IS NULL
+   OR EXISTS (SELECT 1 FROM task_service.categories WHERE id = $2 AND user_id = $1)
+RETURNING id, created_at, updated_at
+`
+
+	run := func(ctx context.Context) error {
+		q := querierFor(ctx, r.pool)
+
+		if err := q.QueryRow(ctx, query,
+			workflow.UserID,
+			workflow.CategoryID,
+			workflow.Name,
+		).Scan(&workflow.ID, &workflow.CreatedAt, &workflow.UpdatedAt); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return domain.ErrCategoryNotFound
+			}
+			return workflowWriteError(err)
+		}
+
+		return r.writeStatuses(ctx, workflow)
+	}
+
+	if TxFromContext(ctx) != nil {
+		return run(ctx)
+	}
+	return WithTransaction(ctx, r.pool, run)
+}
+
+func (r *PostgresWorkflowRepository) GetWorkflow(ctx context.Context, userID, workflowID int64) (*entities.Workflow, error) {
+	q := querierFor(ctx, r.pool)
+
+	row := q.QueryRow(ctx, baseWorkflowSelect()+`
+WHERE w.id = $1
+  AND w.user_id = $2
+`, workflowID, userID)
+
+	workflow, err := scanWorkflow(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrWorkflowNotFound
+		}
+		return nil, err
+	}
+
+	return workflow, nil
+}
+
+func (r *PostgresWorkflowRepository) ListWorkflows(ctx context.Context, userID int64) ([]entities.Workflow, error) {
+	q := querierFor(ctx, r.pool)
+
+	rows, err := q.Query(ctx, baseWorkflowSelect()+`
+WHERE w.user_id = $1
+ORDER BY w.category_id NULLS FIRST, w.name ASC
+`, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var workflows []entities.Workflow
+
+	for rows.Next() {
+		workflow, err := scanWorkflow(rows)
+		if err != nil {
+			return nil, err
+		}
+		workflows = append(workflows, *workflow)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return workflows, nil
+}
+
+// UpdateWorkflow rewrites the statuses and transitions of the workflow. Tasks
+// keep their status even when it is no longer part of the workflow.
+func (r *PostgresWorkflowRepository) UpdateWorkflow(ctx context.Context, workflow *entities.Workflow) error {
+	const query = `
+UPDATE task_service.workflows
+SET name = $1,
+    category_id = $2,
+    updated_at = NOW()
+WHERE id = $3
+  AND user_id = $4
+RETURNING created_at, updated_at
+`
+
+	run := func(ctx context.Context) error {
+		q := querierFor(ctx, r.pool)
+
+		if workflow.CategoryID != nil {
+			var exists bool
+			if err := q.QueryRow(ctx, `
+SELECT EXISTS (
+    SELECT 1 FROM task_service.categories WHERE id = $1 AND user_id = $2
+)
+`, *workflow.CategoryID, workflow.UserID).Scan(&exists); err != nil {
+				return err
+			}
+			if !exists {
+				return domain.ErrCategoryNotFound
+			}
+		}
+
+		if err := q.QueryRow(ctx, query,
+			workflow.Name,
+			workflow.CategoryID,
+			workflow.ID,
+			workflow.UserID,
+		).Scan(&workflow.CreatedAt, &workflow.UpdatedAt); err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return domain.ErrWorkflowNotFound
+			}
+			return workflowWriteError(err)
+		}
+
+		// Transitions go with their statuses.
+		if _, err := q.Exec(ctx, `
+DELETE FROM task_service.workflow_statuses
+WHERE workflow_id = $1
+`, workflow.ID); err != nil {
+			return err
+		}
+
+		return r.writeStatuses(ctx, workflow)
+	}
+
+	if TxFromContext(ctx) != nil {
+		return run(ctx)
+	}
+	return WithTransaction(ctx, r.pool, run)
+}
+
+func (r *PostgresWorkflowRepository) DeleteWorkflow(ctx context.Context, userID, workflowID int64) error {
+	const query = `
+DELETE FROM task_service.workflows
+WHERE id = $1
+  AND user_id = $2
+`
+
+	q := querierFor(ctx, r.pool)
+
+	tag, err := q.Exec(ctx, query, workflowID, userID)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domain.ErrWorkflowNotFound
+	}
+
+	return nil
+}
+
+// ResolveWorkflow walks up from the category to the closest category with a
+// workflow, falling back to the user's default. The CYCLE clause ends the
+// walk if the parent chain loops.
+func (r *PostgresWorkflowRepository) ResolveWorkflow(ctx context.Context, userID int64, categoryID *int64) (*entities.Workflow, error) {
+	q := querierFor(ctx, r.pool)
+
+	row := q.QueryRow(ctx, `
+WITH RECURSIVE ancestors AS (
+    SELECT id, parent_id, 0 AS depth
+    FROM task_service.categories
+    WHERE id = $2 AND user_id = $1
+    UNION ALL
+    SELECT c.id, c.parent_id, a.depth + 1
+    FROM task_service.categories c
+    JOIN ancestors a ON c.id = a.parent_id
+) CYCLE id SET is_cycle USING path`+baseWorkflowSelect()+`
+LEFT JOIN ancestors a ON a.id = w.category_id AND NOT a.is_cycle
+WHERE w.user_id = $1
+  AND (w.category_id IS NULL OR a.id IS NOT NULL)
+ORDER BY a.depth ASC NULLS LAST
+LIMIT 1
+`, userID, categoryID)
+
+	workflow, err := scanWorkflow(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return workflow, nil
+}
+
+// writeStatuses registers the workflow's status keys and inserts its statuses
+// and transitions.
+func (r *PostgresWorkflowRepository) writeStatuses(ctx context.Context, workflow *entities.Workflow) error {
+	q := querierFor(ctx, r.pool)
+
+	keys := make([]string, 0, len(workflow.Statuses))
+	names := make([]string, 0, len(workflow.Statuses))
+	completed := make([]bool, 0, len(workflow.Statuses))
+	for _, status := range workflow.Statuses {
+		keys = append(keys, string(status.Key))
+		names = append(names, status.Name)
+		completed = append(completed, status.Completed)
+	}
+
+	if _, err := q.Exec(ctx, `
+INSERT INTO task_service.task_statuses (key)
+SELECT unnest($1::text[])
+ON CONFLICT (key) DO NOTHING
+`, keys); err != nil {
+		return err
+	}
+
+	if _, err := q.Exec(ctx, `
+INSERT INTO task_service.workflow_statuses (workflow_id, status, name, position, is_completed)
+SELECT $1::int, s.key, s.name, s.ord, s.completed
+FROM unnest($2::text[], $3::text[], $4::bool[]) WITH ORDINALITY AS s(key, name, completed, ord)
+`, workflow.ID, keys, names, completed); err != nil {
+		return err
+	}
+
+	if len(workflow.Transitions) == 0 {
+		return nil
+	}
+
+	from := make([]string, 0, len(workflow.Transitions))
+	to := make([]string, 0, len(workflow.Transitions))
+	for _, transition := range workflow.Transitions {
+		from = append(from, string(transition.From))
+		to = append(to, string(transition.To))
+	}
+
+	_, err := q.Exec(ctx, `
+INSERT INTO task_service.workflow_transitions (workflow_id, from_status, to_status)
+SELECT $1::int, t.from_status, t.to_status
+FROM unnest($2::text[], $3::text[]) AS t(from_status, to_status)
+`, workflow.ID, from, to)
+	return err
+}
+
+// workflowWriteError maps unique violations to the rule they broke.
+func workflowWriteError(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || pgErr.Code != "23505" {
+		return err
+	}
+	switch pgErr.ConstraintName {
+	case "idx_workflows_user_default", "idx_workflows_category":
+		return domain.ErrWorkflowScopeTaken
+	default:
+		return domain.ErrWorkflowExists
+	}
+}
+
+func baseWorkflowSelect() string {
+	return `
+SELECT
+    w.id,
+    w.user_id,
+    w.category_id,
+    w.name,
+    COALESCE((
+        SELECT json_agg(json_build_object('key', s.status, 'name', s.name, 'completed', s.is_completed) ORDER BY s.position)
+        FROM task_service.workflow_statuses s
+        WHERE s.workflow_id = w.id
+    ), '[]'::json),
+    COALESCE((
+        SELECT json_agg(json_build_object('from', t.from_status, 'to', t.to_status) ORDER BY t.from_status, t.to_status)
+        FROM task_service.workflow_transitions t
+        WHERE t.workflow_id = w.id
+    ), '[]'::json),
+    w.created_at,
+    w.updated_at
+FROM task_service.workflows w
+`
+}
+
+func scanWorkflow(row rowScanner) (*entities.Workflow, error) {
+	var (
+		workflow    entities.Workflow
+		rawStatuses []byte
+		rawMoves    []byte
+		statuses    []workflowStatusRecord
+		transitions []workflowTransitionRecord
+	)
+
+	if err := row.Scan(
+		&workflow.ID,
+		&workflow.UserID,
+		&workflow.CategoryID,
+		&workflow.Name,
+		&rawStatuses,
+		&rawMoves,
+		&workflow.CreatedAt,
+		&workflow.UpdatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(rawStatuses, &statuses); err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(rawMoves, &transitions); err != nil {
+		return nil, err
+	}
+
+	workflow.Statuses = make([]entities.WorkflowStatus, 0, len(statuses))
+	for _, status := range statuses {
+		workflow.Statuses = append(workflow.Statuses, entities.WorkflowStatus{
+			Key:       entities.TaskStatus(status.Key),
+			Name:      status.Name,
+			Completed: status.Completed,
+		})
+	}
+
+	workflow.Transitions = make([]entities.WorkflowTransition, 0, len(transitions))
+	for _, transition := range transitions {
+		workflow.Transitions = append(workflow.Transitions, entities.WorkflowTransition{
+			From: entities.TaskStatus(transition.From),
+			To:   entities.TaskStatus(transition.To),
+		})
+	}
+
+	return &workflow, nil
+}
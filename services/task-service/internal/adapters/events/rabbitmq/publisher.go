@@ -4,17 +4,31 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"sync"
 
 	amqp "github.com/rabbitmq/amqp091-go"
 
 	"todoapp/pkg/events"
+	"todoapp/pkg/metrics"
+	"todoapp/pkg/requestid"
+	"todoapp/pkg/tracing"
 	"todoapp/services/task-service/internal/ports"
 )
 
+var errNotConfirmed = errors.New("rabbitmq: message was not confirmed by the broker")
+
+// Publisher sends task events to a durable queue with publisher confirms:
+// Publish returns only after the broker has taken responsibility for the
+// message. The connection is opened by the first Publish, and re-opened by
+// the next one once closed, so task-service starts while RabbitMQ is down and
+// the outbox relay retries until it is back.
 type Publisher struct {
-	conn  *amqp.Connection
-	ch    *amqp.Channel
+	url   string
 	queue string
+
+	mu   sync.Mutex
+	conn *amqp.Connection
+	ch   *amqp.Channel
 }
 
 var _ ports.TaskEventPublisher = (*Publisher)(nil)
@@ -27,45 +41,106 @@ func New(url, queue string) (*Publisher, error) {
 		return nil, errors.New("rabbitmq: queue is required")
 	}
 
-	conn, err := amqp.Dial(url)
+	return &Publisher{url: url, queue: queue}, nil
+}
+
+func (p *Publisher) Publish(ctx context.Context, event events.TaskEvent) (err error) {
+	ctx, span := tracing.StartPublish(ctx, p.queue, tracing.TaskID(event.TaskID), tracing.UserID(event.UserID))
+	defer func() {
+		metrics.MessagePublished(p.queue, err)
+		tracing.End(span, err)
+	}()
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	// Confirms are matched to publishes by order on the channel, so publishes
+	// are serialized.
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.ch == nil || p.ch.IsClosed() {
+		if err := p.connect(); err != nil {
+			return err
+		}
+	}
+
+	confirm, err := p.ch.PublishWithDeferredConfirmWithContext(ctx, "", p.queue, false, false, amqp.Publishing{
+		Headers:      tracing.InjectHeaders(ctx, requestid.Headers(ctx)),
+		ContentType:  "application/json",
+		DeliveryMode: amqp.Persistent,
+		MessageId:    event.ID,
+		Type:         string(event.Type),
+		Body:         payload,
+	})
+	if err != nil {
+		return err
+	}
+
+	acked, err := confirm.WaitContext(ctx)
+	if err != nil {
+		return err
+	}
+	if !acked {
+		return errNotConfirmed
+	}
+
+	return nil
+}
+
+func (p *Publisher) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.closeLocked()
+}
+
+// connect replaces the current connection. It must be called with mu held.
+func (p *Publisher) connect() error {
+	_ = p.closeLocked()
+
+	conn, err := amqp.Dial(p.url)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	ch, err := conn.Channel()
 	if err != nil {
 		conn.Close()
-		return nil, err
+		return err
 	}
 
-	if _, err := ch.QueueDeclare(queue, true, false, false, false, nil); err != nil {
+	if err := ch.Confirm(false); err != nil {
 		ch.Close()
 		conn.Close()
-		return nil, err
+		return err
 	}
 
-	return &Publisher{conn: conn, ch: ch, queue: queue}, nil
-}
-
-func (p *Publisher) Publish(ctx context.Context, event events.TaskEvent) error {
-	payload, err := json.Marshal(event)
-	if err != nil {
+	if _, err := ch.QueueDeclare(p.queue, true, false, false, false, nil); err != nil {
+		ch.Close()
+		conn.Close()
 		return err
 	}
 
-	return p.ch.PublishWithContext(ctx, "", p.queue, false, false, amqp.Publishing{
-		ContentType:  "application/json",
-		DeliveryMode: amqp.Persistent,
-		Body:         payload,
-	})
+	p.conn = conn
+	p.ch = ch
+
+	return nil
 }
 
-func (p *Publisher) Close() error {
+func (p *Publisher) closeLocked() error {
 	if p.ch != nil {
 		_ = p.ch.Close()
+		p.ch = nil
 	}
 	if p.conn != nil {
-		return p.conn.Close()
+		err := p.conn.Close()
+		p.conn = nil
+		if err != nil && !errors.Is(err, amqp.ErrClosed) {
+			return err
+		}
 	}
 	return nil
 }
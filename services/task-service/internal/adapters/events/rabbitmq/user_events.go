@@ -0,0 +1,142 @@
+package rabbitmq
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"todoapp/pkg/events"
+	"todoapp/pkg/metrics"
+	"todoapp/pkg/requestid"
+	"todoapp/pkg/tracing"
+	"todoapp/services/task-service/internal/ports"
+)
+
+const userEventsReconnectDelay = 5 * time.Second
+
+// UserEventConsumer invalidates cached users and their tokens when
+// user-service reports a change. Each instance reads from its own temporary queue bound to the user
+// events exchange, so every instance sees every event.
+type UserEventConsumer struct {
+	url      string
+	caches   []ports.UserCache
+	sessions []ports.SessionCloser
+	logger   *slog.Logger
+}
+
+func NewUserEventConsumer(url string, logger *slog.Logger, caches ...ports.UserCache) *UserEventConsumer {
+	if logger == nil {
+		logger = slog.Default()
+	}
+
+	return &UserEventConsumer{url: url, caches: caches, logger: logger}
+}
+
+// WithSessions ends the user's open streams when their tokens are revoked or
+// their status changes, so a stream does not outlive the token it was opened
+// with.
+func (c *UserEventConsumer) WithSessions(sessions ...ports.SessionCloser) *UserEventConsumer {
+	c.sessions = append(c.sessions, sessions...)
+	return c
+}
+
+// Run consumes until ctx is done, reconnecting after failures. Events sent
+// while disconnected are lost, so the caches are dropped and the streams
+// ended on every (re)connect.
+func (c *UserEventConsumer) Run(ctx context.Context) {
+	for {
+		err := c.consume(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		c.logger.Warn("user events consumer stopped, reconnecting", "delay", userEventsReconnectDelay.String(), "error", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(userEventsReconnectDelay):
+		}
+	}
+}
+
+func (c *UserEventConsumer) consume(ctx context.Context) error {
+	conn, err := amqp.Dial(c.url)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ch, err := conn.Channel()
+	if err != nil {
+		return err
+	}
+	defer ch.Close()
+
+	if err := ch.ExchangeDeclare(events.UserEventsExchange, amqp.ExchangeFanout, true, false, false, false, nil); err != nil {
+		return err
+	}
+
+	queue, err := ch.QueueDeclare("", false, true, true, false, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := ch.QueueBind(queue.Name, "", events.UserEventsExchange, false, nil); err != nil {
+		return err
+	}
+
+	msgs, err := ch.Consume(queue.Name, "", true, true, false, false, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, cache := range c.caches {
+		cache.InvalidateAll()
+	}
+	for _, sessions := range c.sessions {
+		sessions.CloseSubscriptions()
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case msg, ok := <-msgs:
+			if !ok {
+				return errors.New("rabbitmq: deliveries channel closed")
+			}
+			metrics.MessageConsumed(events.UserEventsExchange)
+			msgCtx, span := tracing.StartConsume(requestid.WithContext(ctx, requestid.FromHeaders(msg.Headers)), events.UserEventsExchange, msg.Headers)
+			c.handle(msgCtx, msg.Body)
+			span.End()
+		}
+	}
+}
+
+func (c *UserEventConsumer) handle(ctx context.Context, body []byte) {
+	var event events.UserEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		c.logger.WarnContext(ctx, "failed to decode user event", "error", err)
+		return
+	}
+	if event.IsZero() {
+		c.logger.WarnContext(ctx, "ignoring incomplete user event", "event_id", event.ID)
+		return
+	}
+	tracing.SetUserID(ctx, event.UserID)
+
+	for _, cache := range c.caches {
+		cache.InvalidateUser(event.UserID)
+	}
+
+	switch event.Type {
+	case events.UserEventTokensRevoked, events.UserEventStatusChanged:
+		for _, sessions := range c.sessions {
+			sessions.CloseUser(event.UserID)
+		}
+	}
+}
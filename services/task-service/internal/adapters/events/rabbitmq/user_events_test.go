@@ -0,0 +1,34 @@
+package rabbitmq
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"todoapp/pkg/events"
+)
+
+type sessionCloserStub struct {
+	closed []int64
+}
+
+func (s *sessionCloserStub) CloseUser(userID int64) {
+	s.closed = append(s.closed, userID)
+}
+
+func (s *sessionCloserStub) CloseSubscriptions() {}
+
+func TestUserEventConsumerClosesRevokedSessions(t *testing.T) {
+	sessions := &sessionCloserStub{}
+	consumer := NewUserEventConsumer("", nil).WithSessions(sessions)
+
+	for i, eventType := range []events.UserEventType{events.UserEventUpdated, events.UserEventTokensRevoked, events.UserEventStatusChanged} {
+		body, _ := json.Marshal(events.UserEvent{ID: "e", Type: eventType, UserID: int64(i + 1), OccurredAt: time.Now()})
+		consumer.handle(context.Background(), body)
+	}
+
+	if len(sessions.closed) != 2 || sessions.closed[0] != 2 || sessions.closed[1] != 3 {
+		t.Fatalf("expected the sessions of users 2 and 3 to be closed, got %v", sessions.closed)
+	}
+}
@@ -0,0 +1,385 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"todoapp/pkg/errors"
+	taskv1 "todoapp/pkg/proto/task/v1"
+	"todoapp/pkg/tracing"
+	"todoapp/services/task-service/internal/domain/entities"
+	"todoapp/services/task-service/internal/ports"
+)
+
+const (
+	defaultListLimit = 20
+	maxListLimit     = 100
+)
+
+// Server exposes ports.TaskService over gRPC for internal callers. Requests
+// carry the acting user's ID instead of a token.
+type Server struct {
+	taskv1.UnimplementedTaskServiceServer
+
+	service ports.TaskService
+}
+
+func NewServer(service ports.TaskService) *Server {
+	return &Server{service: service}
+}
+
+func (s *Server) GetTask(ctx context.Context, req *taskv1.GetTaskRequest) (*taskv1.GetTaskResponse, error) {
+	if err := requireIDs(req.GetUserId(), req.GetTaskId()); err != nil {
+		return nil, err
+	}
+
+	tracing.SetUserID(ctx, req.GetUserId())
+	tracing.SetTaskID(ctx, req.GetTaskId())
+
+	task, err := s.service.GetTask(ctx, req.GetUserId(), req.GetTaskId())
+	if err != nil {
+		return nil, mapToGRPCError(err)
+	}
+
+	return &taskv1.GetTaskResponse{Task: toProtoTask(*task)}, nil
+}
+
+func (s *Server) ListTasks(ctx context.Context, req *taskv1.ListTasksRequest) (*taskv1.ListTasksResponse, error) {
+	if err := requireIDs(req.GetUserId()); err != nil {
+		return nil, err
+	}
+
+	filter := ports.TaskFilter{
+		CategoryID:           req.CategoryId,
+		IncludeSubcategories: req.GetIncludeSubcategories(),
+		WithoutCategory:      req.GetWithoutCategory(),
+		Search:               req.GetSearch(),
+		DueFrom:              fromUnix(req.DueFrom),
+		DueTo:                fromUnix(req.DueTo),
+		Limit:                clampLimit(int(req.GetLimit())),
+		Offset:               max(int(req.GetOffset()), 0),
+	}
+	for _, value := range req.GetStatuses() {
+		filter.Statuses = append(filter.Statuses, entities.TaskStatus(value))
+	}
+	for _, value := range req.GetPriorities() {
+		filter.Priorities = append(filter.Priorities, entities.TaskPriority(value))
+	}
+
+	tasks, err := s.service.ListTasks(ctx, req.GetUserId(), filter)
+	if err != nil {
+		return nil, mapToGRPCError(err)
+	}
+
+	response := &taskv1.ListTasksResponse{Tasks: make([]*taskv1.Task, 0, len(tasks))}
+	for _, task := range tasks {
+		response.Tasks = append(response.Tasks, toProtoTask(task))
+	}
+
+	return response, nil
+}
+
+func (s *Server) CreateTask(ctx context.Context, req *taskv1.CreateTaskRequest) (*taskv1.CreateTaskResponse, error) {
+	if err := requireIDs(req.GetUserId()); err != nil {
+		return nil, err
+	}
+
+	input := ports.CreateTaskInput{
+		UserID:          req.GetUserId(),
+		Title:           req.GetTitle(),
+		Description:     req.GetDescription(),
+		Priority:        entities.TaskPriorityMedium,
+		DueDate:         fromUnix(req.DueDate),
+		CategoryID:      req.CategoryId,
+		EstimateMinutes: toIntPtr(req.EstimateMinutes),
+	}
+	if req.GetStatus() != "" {
+		input.Status = entities.TaskStatus(req.GetStatus())
+	}
+	if req.GetPriority() != "" {
+		input.Priority = entities.TaskPriority(req.GetPriority())
+	}
+
+	task, err := s.service.CreateTask(ctx, input)
+	if err != nil {
+		return nil, mapToGRPCError(err)
+	}
+
+	return &taskv1.CreateTaskResponse{Task: toProtoTask(*task)}, nil
+}
+
+func (s *Server) UpdateTask(ctx context.Context, req *taskv1.UpdateTaskRequest) (*taskv1.UpdateTaskResponse, error) {
+	if err := requireIDs(req.GetUserId(), req.GetTaskId()); err != nil {
+		return nil, err
+	}
+
+	input := ports.UpdateTaskInput{
+		UserID:          req.GetUserId(),
+		TaskID:          req.GetTaskId(),
+		Title:           req.Title,
+		Description:     req.Description,
+		DueDate:         fromUnix(req.DueDate),
+		ClearDueDate:    req.GetClearDueDate(),
+		CategoryID:      req.CategoryId,
+		ClearCategory:   req.GetClearCategory(),
+		EstimateMinutes: toIntPtr(req.EstimateMinutes),
+		ClearEstimate:   req.GetClearEstimate(),
+	}
+	if req.Status != nil {
+		taskStatus := entities.TaskStatus(req.GetStatus())
+		input.Status = &taskStatus
+	}
+	if req.Priority != nil {
+		priority := entities.TaskPriority(req.GetPriority())
+		input.Priority = &priority
+	}
+
+	task, err := s.service.UpdateTask(ctx, input)
+	if err != nil {
+		return nil, mapToGRPCError(err)
+	}
+
+	return &taskv1.UpdateTaskResponse{Task: toProtoTask(*task)}, nil
+}
+
+func (s *Server) DeleteTask(ctx context.Context, req *taskv1.DeleteTaskRequest) (*taskv1.DeleteTaskResponse, error) {
+	if err := requireIDs(req.GetUserId(), req.GetTaskId()); err != nil {
+		return nil, err
+	}
+
+	if err := s.service.DeleteTask(ctx, req.GetUserId(), req.GetTaskId()); err != nil {
+		return nil, mapToGRPCError(err)
+	}
+
+	return &taskv1.DeleteTaskResponse{}, nil
+}
+
+func (s *Server) GetCategory(ctx context.Context, req *taskv1.GetCategoryRequest) (*taskv1.GetCategoryResponse, error) {
+	if err := requireIDs(req.GetUserId(), req.GetCategoryId()); err != nil {
+		return nil, err
+	}
+
+	category, err := s.service.GetCategory(ctx, req.GetUserId(), req.GetCategoryId())
+	if err != nil {
+		return nil, mapToGRPCError(err)
+	}
+
+	return &taskv1.GetCategoryResponse{Category: toProtoCategory(*category)}, nil
+}
+
+func (s *Server) ListCategories(ctx context.Context, req *taskv1.ListCategoriesRequest) (*taskv1.ListCategoriesResponse, error) {
+	if err := requireIDs(req.GetUserId()); err != nil {
+		return nil, err
+	}
+
+	categories, err := s.service.ListCategories(ctx, req.GetUserId())
+	if err != nil {
+		return nil, mapToGRPCError(err)
+	}
+
+	response := &taskv1.ListCategoriesResponse{Categories: make([]*taskv1.Category, 0, len(categories))}
+	for _, category := range categories {
+		response.Categories = append(response.Categories, toProtoCategory(category))
+	}
+
+	return response, nil
+}
+
+func (s *Server) CreateCategory(ctx context.Context, req *taskv1.CreateCategoryRequest) (*taskv1.CreateCategoryResponse, error) {
+	if err := requireIDs(req.GetUserId()); err != nil {
+		return nil, err
+	}
+
+	category, err := s.service.CreateCategory(ctx, ports.CreateCategoryInput{
+		UserID:   req.GetUserId(),
+		ParentID: req.ParentId,
+		Name:     req.GetName(),
+		Color:    req.GetColor(),
+		Icon:     req.GetIcon(),
+	})
+	if err != nil {
+		return nil, mapToGRPCError(err)
+	}
+
+	return &taskv1.CreateCategoryResponse{Category: toProtoCategory(*category)}, nil
+}
+
+func (s *Server) UpdateCategory(ctx context.Context, req *taskv1.UpdateCategoryRequest) (*taskv1.UpdateCategoryResponse, error) {
+	if err := requireIDs(req.GetUserId(), req.GetCategoryId()); err != nil {
+		return nil, err
+	}
+
+	category, err := s.service.UpdateCategory(ctx, ports.UpdateCategoryInput{
+		UserID:      req.GetUserId(),
+		CategoryID:  req.GetCategoryId(),
+		Name:        req.Name,
+		Color:       req.Color,
+		Icon:        req.Icon,
+		ParentID:    req.ParentId,
+		ClearParent: req.GetClearParent(),
+	})
+	if err != nil {
+		return nil, mapToGRPCError(err)
+	}
+
+	return &taskv1.UpdateCategoryResponse{Category: toProtoCategory(*category)}, nil
+}
+
+func (s *Server) DeleteCategory(ctx context.Context, req *taskv1.DeleteCategoryRequest) (*taskv1.DeleteCategoryResponse, error) {
+	if err := requireIDs(req.GetUserId(), req.GetCategoryId()); err != nil {
+		return nil, err
+	}
+
+	mode := entities.CategoryDeleteRefuse
+	if req.GetMode() != "" {
+		mode = entities.CategoryDeleteMode(req.GetMode())
+	}
+
+	if err := s.service.DeleteCategory(ctx, req.GetUserId(), req.GetCategoryId(), mode); err != nil {
+		return nil, mapToGRPCError(err)
+	}
+
+	return &taskv1.DeleteCategoryResponse{}, nil
+}
+
+func (s *Server) AddComment(ctx context.Context, req *taskv1.AddCommentRequest) (*taskv1.AddCommentResponse, error) {
+	if err := requireIDs(req.GetUserId(), req.GetTaskId()); err != nil {
+		return nil, err
+	}
+
+	comment, err := s.service.AddComment(ctx, ports.AddCommentInput{
+		UserID:  req.GetUserId(),
+		TaskID:  req.GetTaskId(),
+		Content: req.GetContent(),
+	})
+	if err != nil {
+		return nil, mapToGRPCError(err)
+	}
+
+	return &taskv1.AddCommentResponse{Comment: toProtoComment(*comment)}, nil
+}
+
+func (s *Server) ListComments(ctx context.Context, req *taskv1.ListCommentsRequest) (*taskv1.ListCommentsResponse, error) {
+	if err := requireIDs(req.GetUserId(), req.GetTaskId()); err != nil {
+		return nil, err
+	}
+
+	comments, err := s.service.ListComments(ctx, req.GetUserId(), req.GetTaskId())
+	if err != nil {
+		return nil, mapToGRPCError(err)
+	}
+
+	response := &taskv1.ListCommentsResponse{Comments: make([]*taskv1.Comment, 0, len(comments))}
+	for _, comment := range comments {
+		response.Comments = append(response.Comments, toProtoComment(comment))
+	}
+
+	return response, nil
+}
+
+func toProtoTask(task entities.Task) *taskv1.Task {
+	result := &taskv1.Task{
+		Id:             task.ID,
+		UserId:         task.UserID,
+		Title:          task.Title,
+		Description:    task.Description,
+		Status:         string(task.Status),
+		Priority:       string(task.Priority),
+		DueDate:        toUnix(task.DueDate),
+		CategoryId:     task.CategoryID,
+		TrackedSeconds: int64(task.TrackedTime / time.Second),
+		Position:       task.Position,
+		CreatedAt:      task.CreatedAt.Unix(),
+		UpdatedAt:      task.UpdatedAt.Unix(),
+	}
+	if task.Category != nil {
+		result.Category = toProtoCategory(*task.Category)
+	}
+	if task.EstimateMinutes != nil {
+		estimate := int32(*task.EstimateMinutes)
+		result.EstimateMinutes = &estimate
+	}
+	return result
+}
+
+func toProtoCategory(category entities.Category) *taskv1.Category {
+	return &taskv1.Category{
+		Id:             category.ID,
+		UserId:         category.UserID,
+		ParentId:       category.ParentID,
+		Name:           category.Name,
+		Color:          category.Color,
+		Icon:           category.Icon,
+		Position:       int32(category.Position),
+		CreatedAt:      category.CreatedAt.Unix(),
+		UpdatedAt:      category.UpdatedAt.Unix(),
+		OpenTasks:      int32(category.OpenTasks),
+		CompletedTasks: int32(category.CompletedTasks),
+	}
+}
+
+func toProtoComment(comment entities.TaskComment) *taskv1.Comment {
+	return &taskv1.Comment{
+		Id:        comment.ID,
+		TaskId:    comment.TaskID,
+		UserId:    comment.UserID,
+		Content:   comment.Content,
+		CreatedAt: comment.CreatedAt.Unix(),
+	}
+}
+
+func requireIDs(ids ...int64) error {
+	for _, id := range ids {
+		if id <= 0 {
+			return status.Error(codes.InvalidArgument, "user and resource ids must be positive")
+		}
+	}
+	return nil
+}
+
+func fromUnix(value *int64) *time.Time {
+	if value == nil {
+		return nil
+	}
+	t := time.Unix(*value, 0).UTC()
+	return &t
+}
+
+func toUnix(value *time.Time) *int64 {
+	if value == nil {
+		return nil
+	}
+	unix := value.Unix()
+	return &unix
+}
+
+func toIntPtr(value *int32) *int {
+	if value == nil {
+		return nil
+	}
+	converted := int(*value)
+	return &converted
+}
+
+func clampLimit(limit int) int {
+	switch {
+	case limit <= 0:
+		return defaultListLimit
+	case limit > maxListLimit:
+		return maxListLimit
+	default:
+		return limit
+	}
+}
+
+func mapToGRPCError(err error) error {
+	if err == nil {
+		return status.Error(codes.Internal, "unknown error")
+	}
+
+	appErr := errors.AsAppError(err)
+	return status.Error(appErr.GRPCCode(), appErr.Message)
+}
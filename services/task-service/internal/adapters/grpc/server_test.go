@@ -0,0 +1,185 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	taskv1 "todoapp/pkg/proto/task/v1"
+	"todoapp/services/task-service/internal/domain"
+	"todoapp/services/task-service/internal/domain/entities"
+	"todoapp/services/task-service/internal/ports"
+)
+
+type taskServiceMock struct {
+	ports.TaskService
+
+	task        *entities.Task
+	err         error
+	createInput ports.CreateTaskInput
+	updateInput ports.UpdateTaskInput
+	filter      ports.TaskFilter
+	deleteMode  entities.CategoryDeleteMode
+}
+
+func (m *taskServiceMock) GetTask(ctx context.Context, userID, taskID int64) (*entities.Task, error) {
+	return m.task, m.err
+}
+
+func (m *taskServiceMock) ListTasks(ctx context.Context, userID int64, filter ports.TaskFilter) ([]entities.Task, error) {
+	m.filter = filter
+	return []entities.Task{*m.task}, m.err
+}
+
+func (m *taskServiceMock) CreateTask(ctx context.Context, input ports.CreateTaskInput) (*entities.Task, error) {
+	m.createInput = input
+	return m.task, m.err
+}
+
+func (m *taskServiceMock) UpdateTask(ctx context.Context, input ports.UpdateTaskInput) (*entities.Task, error) {
+	m.updateInput = input
+	return m.task, m.err
+}
+
+func (m *taskServiceMock) DeleteCategory(ctx context.Context, userID, categoryID int64, mode entities.CategoryDeleteMode) error {
+	m.deleteMode = mode
+	return m.err
+}
+
+func sampleTask() *entities.Task {
+	due := time.Date(2024, 12, 10, 9, 0, 0, 0, time.UTC)
+	categoryID := int64(3)
+	estimate := 90
+	return &entities.Task{
+		ID:              7,
+		UserID:          42,
+		Title:           "Write report",
+		Status:          entities.TaskStatusPending,
+		Priority:        entities.TaskPriorityHigh,
+		DueDate:         &due,
+		CategoryID:      &categoryID,
+		Category:        &entities.Category{ID: 3, UserID: 42, Name: "Work"},
+		EstimateMinutes: &estimate,
+		TrackedTime:     30 * time.Minute,
+	}
+}
+
+func TestGetTask(t *testing.T) {
+	server := NewServer(&taskServiceMock{task: sampleTask()})
+
+	resp, err := server.GetTask(context.Background(), &taskv1.GetTaskRequest{UserId: 42, TaskId: 7})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	task := resp.GetTask()
+	if task.GetId() != 7 || task.GetStatus() != "pending" || task.GetPriority() != "high" {
+		t.Fatalf("unexpected task: %+v", task)
+	}
+	if task.GetDueDate() != time.Date(2024, 12, 10, 9, 0, 0, 0, time.UTC).Unix() {
+		t.Fatalf("unexpected due date: %d", task.GetDueDate())
+	}
+	if task.GetCategory().GetName() != "Work" || task.GetEstimateMinutes() != 90 || task.GetTrackedSeconds() != 1800 {
+		t.Fatalf("unexpected task details: %+v", task)
+	}
+}
+
+func TestGetTask_MapsErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *taskv1.GetTaskRequest
+		err  error
+		code codes.Code
+	}{
+		{name: "missing task id", req: &taskv1.GetTaskRequest{UserId: 42}, code: codes.InvalidArgument},
+		{name: "not found", req: &taskv1.GetTaskRequest{UserId: 42, TaskId: 7}, err: domain.ErrTaskNotFound, code: codes.NotFound},
+		{name: "forbidden", req: &taskv1.GetTaskRequest{UserId: 42, TaskId: 7}, err: domain.ErrForbiddenTaskAccess, code: codes.PermissionDenied},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := NewServer(&taskServiceMock{err: tt.err})
+
+			_, err := server.GetTask(context.Background(), tt.req)
+			if status.Code(err) != tt.code {
+				t.Fatalf("expected %v, got %v", tt.code, err)
+			}
+		})
+	}
+}
+
+func TestCreateTask_Defaults(t *testing.T) {
+	mock := &taskServiceMock{task: sampleTask()}
+	server := NewServer(mock)
+
+	due := time.Date(2024, 12, 10, 9, 0, 0, 0, time.UTC).Unix()
+	_, err := server.CreateTask(context.Background(), &taskv1.CreateTaskRequest{UserId: 42, Title: "Write report", DueDate: &due})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	input := mock.createInput
+	if input.Status != "" || input.Priority != entities.TaskPriorityMedium {
+		t.Fatalf("expected default status and priority, got %+v", input)
+	}
+	if input.DueDate == nil || input.DueDate.Unix() != due || input.CategoryID != nil || input.EstimateMinutes != nil {
+		t.Fatalf("unexpected optional fields: %+v", input)
+	}
+}
+
+func TestUpdateTask_OnlySetFields(t *testing.T) {
+	mock := &taskServiceMock{task: sampleTask()}
+	server := NewServer(mock)
+
+	title := "Renamed"
+	completed := "completed"
+	_, err := server.UpdateTask(context.Background(), &taskv1.UpdateTaskRequest{
+		UserId:        42,
+		TaskId:        7,
+		Title:         &title,
+		Status:        &completed,
+		ClearCategory: true,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	input := mock.updateInput
+	if input.Title == nil || *input.Title != "Renamed" || input.Status == nil || *input.Status != entities.TaskStatusCompleted {
+		t.Fatalf("expected title and status to be set, got %+v", input)
+	}
+	if input.Description != nil || input.Priority != nil || input.DueDate != nil || !input.ClearCategory {
+		t.Fatalf("expected other fields to be left alone, got %+v", input)
+	}
+}
+
+func TestListTasks_ClampsLimit(t *testing.T) {
+	mock := &taskServiceMock{task: sampleTask()}
+	server := NewServer(mock)
+
+	resp, err := server.ListTasks(context.Background(), &taskv1.ListTasksRequest{UserId: 42, Statuses: []string{"pending"}, Limit: 1000})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(resp.GetTasks()) != 1 {
+		t.Fatalf("expected 1 task, got %d", len(resp.GetTasks()))
+	}
+	if mock.filter.Limit != maxListLimit || len(mock.filter.Statuses) != 1 {
+		t.Fatalf("unexpected filter: %+v", mock.filter)
+	}
+}
+
+func TestDeleteCategory_DefaultMode(t *testing.T) {
+	mock := &taskServiceMock{}
+	server := NewServer(mock)
+
+	if _, err := server.DeleteCategory(context.Background(), &taskv1.DeleteCategoryRequest{UserId: 42, CategoryId: 3}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mock.deleteMode != entities.CategoryDeleteRefuse {
+		t.Fatalf("expected refuse mode, got %q", mock.deleteMode)
+	}
+}
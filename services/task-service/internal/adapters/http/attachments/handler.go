@@ -0,0 +1,182 @@
+package attachments
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"todoapp/services/task-service/internal/adapters/http/common"
+	"todoapp/services/task-service/internal/adapters/http/middleware"
+	"todoapp/services/task-service/internal/domain"
+	"todoapp/services/task-service/internal/dto"
+	"todoapp/services/task-service/internal/ports"
+)
+
+// formFileField is the multipart field that carries the uploaded file.
+const formFileField = "file"
+
+// multipartOverhead leaves room for the multipart boundaries and part headers
+// around the uploaded file.
+const multipartOverhead = 64 << 10
+
+// Handler serves task attachment uploads and downloads.
+type Handler struct {
+	service     ports.AttachmentService
+	maxFileSize int64
+}
+
+// New creates a new attachments handler. Upload bodies larger than
+// maxFileSize, plus room for the multipart framing, are rejected before the
+// form is parsed.
+func New(service ports.AttachmentService, maxFileSize int64) *Handler {
+	return &Handler{service: service, maxFileSize: maxFileSize}
+}
+
+// RegisterRoutes registers attachment routes on the given router.
+func (h *Handler) RegisterRoutes(router gin.IRoutes) {
+	router.GET("/tasks/:id/attachments", h.ListAttachments)
+	router.POST("/tasks/:id/attachments", h.UploadAttachment)
+	router.GET("/tasks/:id/attachments/:attachmentId", h.DownloadAttachment)
+	router.DELETE("/tasks/:id/attachments/:attachmentId", h.DeleteAttachment)
+}
+
+// UploadAttachment stores a multipart-uploaded file on a task.
+func (h *Handler) UploadAttachment(ctx *gin.Context) {
+	claims, ok := middleware.CurrentUser(ctx)
+	if !ok {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "UNAUTHORIZED"})
+		return
+	}
+
+	taskID, err := parseID(ctx.Param("id"))
+	if err != nil {
+		common.WriteValidationError(ctx, err)
+		return
+	}
+
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, h.maxFileSize+multipartOverhead)
+	header, err := ctx.FormFile(formFileField)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			common.WriteDomainError(ctx, domain.ErrFileTooLarge)
+			return
+		}
+		common.WriteValidationError(ctx, err)
+		return
+	}
+
+	file, err := header.Open()
+	if err != nil {
+		common.WriteValidationError(ctx, err)
+		return
+	}
+	defer file.Close()
+
+	attachment, err := h.service.UploadAttachment(ctx.Request.Context(), ports.UploadAttachmentInput{
+		UserID:   claims.UserID,
+		TaskID:   taskID,
+		FileName: header.Filename,
+		Size:     header.Size,
+		Content:  file,
+	})
+	if err != nil {
+		common.WriteDomainError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dto.NewAttachmentResponse(*attachment))
+}
+
+// ListAttachments returns attachment metadata for a task.
+func (h *Handler) ListAttachments(ctx *gin.Context) {
+	claims, ok := middleware.CurrentUser(ctx)
+	if !ok {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "UNAUTHORIZED"})
+		return
+	}
+
+	taskID, err := parseID(ctx.Param("id"))
+	if err != nil {
+		common.WriteValidationError(ctx, err)
+		return
+	}
+
+	attachments, err := h.service.ListAttachments(ctx.Request.Context(), claims.UserID, taskID)
+	if err != nil {
+		common.WriteDomainError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewAttachmentResponses(attachments))
+}
+
+// DownloadAttachment streams the attachment content.
+func (h *Handler) DownloadAttachment(ctx *gin.Context) {
+	claims, ok := middleware.CurrentUser(ctx)
+	if !ok {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "UNAUTHORIZED"})
+		return
+	}
+
+	taskID, attachmentID, err := parseAttachmentPath(ctx)
+	if err != nil {
+		common.WriteValidationError(ctx, err)
+		return
+	}
+
+	attachment, body, err := h.service.OpenAttachment(ctx.Request.Context(), claims.UserID, taskID, attachmentID)
+	if err != nil {
+		common.WriteDomainError(ctx, err)
+		return
+	}
+	defer body.Close()
+
+	ctx.DataFromReader(http.StatusOK, attachment.SizeBytes, attachment.ContentType, body, map[string]string{
+		"Content-Disposition":    mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}),
+		"X-Content-Type-Options": "nosniff",
+	})
+}
+
+// DeleteAttachment removes an attachment and its content.
+func (h *Handler) DeleteAttachment(ctx *gin.Context) {
+	claims, ok := middleware.CurrentUser(ctx)
+	if !ok {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "UNAUTHORIZED"})
+		return
+	}
+
+	taskID, attachmentID, err := parseAttachmentPath(ctx)
+	if err != nil {
+		common.WriteValidationError(ctx, err)
+		return
+	}
+
+	if err := h.service.DeleteAttachment(ctx.Request.Context(), claims.UserID, taskID, attachmentID); err != nil {
+		common.WriteDomainError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func parseAttachmentPath(ctx *gin.Context) (int64, int64, error) {
+	taskID, err := parseID(ctx.Param("id"))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	attachmentID, err := parseID(ctx.Param("attachmentId"))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return taskID, attachmentID, nil
+}
+
+func parseID(raw string) (int64, error) {
+	return strconv.ParseInt(raw, 10, 64)
+}
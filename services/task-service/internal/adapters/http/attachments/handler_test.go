@@ -0,0 +1,185 @@
+package attachments
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"todoapp/services/task-service/internal/adapters/http/middleware"
+	"todoapp/services/task-service/internal/domain"
+	"todoapp/services/task-service/internal/domain/entities"
+	"todoapp/services/task-service/internal/ports"
+)
+
+type mockAttachmentService struct {
+	uploadInput   ports.UploadAttachmentInput
+	uploadContent []byte
+	uploadErr     error
+	stored        *entities.Attachment
+	content       []byte
+}
+
+func (m *mockAttachmentService) UploadAttachment(_ context.Context, input ports.UploadAttachmentInput) (*entities.Attachment, error) {
+	m.uploadInput = input
+	m.uploadContent, _ = io.ReadAll(input.Content)
+	if m.uploadErr != nil {
+		return nil, m.uploadErr
+	}
+	return &entities.Attachment{ID: 1, TaskID: input.TaskID, FileName: input.FileName, ContentType: "image/png", SizeBytes: input.Size}, nil
+}
+
+func (m *mockAttachmentService) ListAttachments(_ context.Context, _, _ int64) ([]entities.Attachment, error) {
+	return nil, nil
+}
+
+func (m *mockAttachmentService) OpenAttachment(_ context.Context, _, _, _ int64) (*entities.Attachment, io.ReadCloser, error) {
+	if m.stored == nil {
+		return nil, nil, domain.ErrAttachmentNotFound
+	}
+	return m.stored, io.NopCloser(bytes.NewReader(m.content)), nil
+}
+
+func (m *mockAttachmentService) DeleteAttachment(_ context.Context, _, _, _ int64) error { return nil }
+
+func (m *mockAttachmentService) PurgeTaskAttachments(_ context.Context, _, _ int64) error { return nil }
+
+// testMaxFileSize is the upload cap the test router is built with.
+const testMaxFileSize = 1 << 10
+
+func setupTestRouter(service ports.AttachmentService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set(middleware.ContextUserClaimsKey, &ports.TokenClaims{UserID: 42})
+		c.Next()
+	})
+	New(service, testMaxFileSize).RegisterRoutes(router)
+	return router
+}
+
+func newUploadRequest(t *testing.T, field, filename string, content []byte) *http.Request {
+	t.Helper()
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, err := writer.CreateFormFile(field, filename)
+	if err != nil {
+		t.Fatalf("failed to create form file: %v", err)
+	}
+	_, _ = part.Write(content)
+	_ = writer.Close()
+
+	req := httptest.NewRequest(http.MethodPost, "/tasks/5/attachments", &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+func TestUploadAttachment(t *testing.T) {
+	service := &mockAttachmentService{}
+	router := setupTestRouter(service)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, newUploadRequest(t, "file", "shot.png", []byte("png-bytes")))
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if service.uploadInput.UserID != 42 || service.uploadInput.TaskID != 5 || service.uploadInput.FileName != "shot.png" {
+		t.Fatalf("unexpected upload input: %+v", service.uploadInput)
+	}
+	if string(service.uploadContent) != "png-bytes" || service.uploadInput.Size != 9 {
+		t.Fatalf("file content not forwarded")
+	}
+
+	var response map[string]any
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if response["fileName"] != "shot.png" {
+		t.Fatalf("unexpected response: %v", response)
+	}
+}
+
+func TestUploadAttachmentErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		field  string
+		err    error
+		status int
+	}{
+		{name: "missing file field", field: "other", status: http.StatusBadRequest},
+		{name: "too large", field: "file", err: domain.ErrFileTooLarge, status: http.StatusRequestEntityTooLarge},
+		{name: "unsupported type", field: "file", err: domain.ErrUnsupportedFileType, status: http.StatusUnsupportedMediaType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := setupTestRouter(&mockAttachmentService{uploadErr: tt.err})
+
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, newUploadRequest(t, tt.field, "a.bin", []byte("x")))
+
+			if rec.Code != tt.status {
+				t.Fatalf("expected %d, got %d", tt.status, rec.Code)
+			}
+		})
+	}
+}
+
+func TestUploadAttachmentRejectsOversizedBody(t *testing.T) {
+	service := &mockAttachmentService{}
+	router := setupTestRouter(service)
+
+	content := bytes.Repeat([]byte("x"), testMaxFileSize+multipartOverhead)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, newUploadRequest(t, "file", "big.bin", content))
+
+	if rec.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("expected 413, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if service.uploadInput.TaskID != 0 {
+		t.Fatalf("oversized upload reached the service: %+v", service.uploadInput)
+	}
+}
+
+func TestDownloadAttachment(t *testing.T) {
+	service := &mockAttachmentService{
+		stored:  &entities.Attachment{ID: 1, TaskID: 5, FileName: "report.pdf", ContentType: "application/pdf", SizeBytes: 4},
+		content: []byte("%PDF"),
+	}
+	router := setupTestRouter(service)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/tasks/5/attachments/1", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rec.Code)
+	}
+	if rec.Header().Get("Content-Type") != "application/pdf" {
+		t.Fatalf("unexpected content type %q", rec.Header().Get("Content-Type"))
+	}
+	if rec.Header().Get("Content-Disposition") != `attachment; filename=report.pdf` {
+		t.Fatalf("unexpected disposition %q", rec.Header().Get("Content-Disposition"))
+	}
+	if rec.Body.String() != "%PDF" {
+		t.Fatalf("unexpected body %q", rec.Body.String())
+	}
+}
+
+func TestDownloadAttachmentNotFound(t *testing.T) {
+	router := setupTestRouter(&mockAttachmentService{})
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/tasks/5/attachments/9", nil))
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", rec.Code)
+	}
+}
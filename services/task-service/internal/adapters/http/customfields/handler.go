@@ -0,0 +1,146 @@
+package customfields
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"todoapp/services/task-service/internal/adapters/http/common"
+	"todoapp/services/task-service/internal/adapters/http/middleware"
+	"todoapp/services/task-service/internal/dto"
+	"todoapp/services/task-service/internal/ports"
+)
+
+// Handler serves custom field definition CRUD.
+type Handler struct {
+	service ports.CustomFieldService
+}
+
+// New creates a new custom fields handler.
+func New(service ports.CustomFieldService) *Handler {
+	return &Handler{service: service}
+}
+
+// RegisterRoutes registers custom field routes on the given router.
+func (h *Handler) RegisterRoutes(router gin.IRoutes) {
+	router.GET("/custom-fields", h.ListCustomFields)
+	router.POST("/custom-fields", h.CreateCustomField)
+	router.GET("/custom-fields/:id", h.GetCustomField)
+	router.PATCH("/custom-fields/:id", h.UpdateCustomField)
+	router.DELETE("/custom-fields/:id", h.DeleteCustomField)
+}
+
+func (h *Handler) ListCustomFields(ctx *gin.Context) {
+	claims, ok := middleware.CurrentUser(ctx)
+	if !ok {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "UNAUTHORIZED"})
+		return
+	}
+
+	fields, err := h.service.ListCustomFields(ctx.Request.Context(), claims.UserID)
+	if err != nil {
+		common.WriteDomainError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewCustomFieldResponses(fields))
+}
+
+func (h *Handler) CreateCustomField(ctx *gin.Context) {
+	claims, ok := middleware.CurrentUser(ctx)
+	if !ok {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "UNAUTHORIZED"})
+		return
+	}
+
+	var request dto.CreateCustomFieldRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		common.WriteValidationError(ctx, err)
+		return
+	}
+
+	field, err := h.service.CreateCustomField(ctx.Request.Context(), request.ToInput(claims.UserID))
+	if err != nil {
+		common.WriteDomainError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dto.NewCustomFieldResponse(*field))
+}
+
+func (h *Handler) GetCustomField(ctx *gin.Context) {
+	claims, ok := middleware.CurrentUser(ctx)
+	if !ok {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "UNAUTHORIZED"})
+		return
+	}
+
+	fieldID, err := parseID(ctx.Param("id"))
+	if err != nil {
+		common.WriteValidationError(ctx, err)
+		return
+	}
+
+	field, err := h.service.GetCustomField(ctx.Request.Context(), claims.UserID, fieldID)
+	if err != nil {
+		common.WriteDomainError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewCustomFieldResponse(*field))
+}
+
+func (h *Handler) UpdateCustomField(ctx *gin.Context) {
+	claims, ok := middleware.CurrentUser(ctx)
+	if !ok {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "UNAUTHORIZED"})
+		return
+	}
+
+	fieldID, err := parseID(ctx.Param("id"))
+	if err != nil {
+		common.WriteValidationError(ctx, err)
+		return
+	}
+
+	var request dto.UpdateCustomFieldRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		common.WriteValidationError(ctx, err)
+		return
+	}
+
+	field, err := h.service.UpdateCustomField(ctx.Request.Context(), request.ToInput(claims.UserID, fieldID))
+	if err != nil {
+		common.WriteDomainError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewCustomFieldResponse(*field))
+}
+
+// DeleteCustomField removes the field and its values from all tasks.
+func (h *Handler) DeleteCustomField(ctx *gin.Context) {
+	claims, ok := middleware.CurrentUser(ctx)
+	if !ok {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "UNAUTHORIZED"})
+		return
+	}
+
+	fieldID, err := parseID(ctx.Param("id"))
+	if err != nil {
+		common.WriteValidationError(ctx, err)
+		return
+	}
+
+	if err := h.service.DeleteCustomField(ctx.Request.Context(), claims.UserID, fieldID); err != nil {
+		common.WriteDomainError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func parseID(raw string) (int64, error) {
+	return strconv.ParseInt(raw, 10, 64)
+}
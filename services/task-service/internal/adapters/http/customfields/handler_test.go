@@ -0,0 +1,92 @@
+package customfields
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"todoapp/services/task-service/internal/adapters/http/middleware"
+	"todoapp/services/task-service/internal/domain"
+	"todoapp/services/task-service/internal/domain/entities"
+	"todoapp/services/task-service/internal/ports"
+)
+
+type mockCustomFieldService struct {
+	ports.CustomFieldService
+	createInput ports.CreateCustomFieldInput
+	createErr   error
+	deleteErr   error
+}
+
+func (m *mockCustomFieldService) CreateCustomField(_ context.Context, input ports.CreateCustomFieldInput) (*entities.CustomField, error) {
+	m.createInput = input
+	if m.createErr != nil {
+		return nil, m.createErr
+	}
+	return &entities.CustomField{ID: 1, UserID: input.UserID, Key: input.Key, Name: input.Name, Type: input.Type, Options: input.Options}, nil
+}
+
+func (m *mockCustomFieldService) DeleteCustomField(_ context.Context, _, _ int64) error {
+	return m.deleteErr
+}
+
+func setupTestRouter(service ports.CustomFieldService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set(middleware.ContextUserClaimsKey, &ports.TokenClaims{UserID: 42})
+		c.Next()
+	})
+	New(service).RegisterRoutes(router)
+	return router
+}
+
+func TestCreateCustomField(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   string
+		err    error
+		status int
+	}{
+		{name: "select", body: `{"key":"Env","name":"Environment","type":"select","options":["dev","prod"]}`, status: http.StatusCreated},
+		{name: "unknown type", body: `{"key":"env","name":"Environment","type":"color"}`, status: http.StatusBadRequest},
+		{name: "duplicate key", body: `{"key":"env","name":"Environment","type":"text"}`, err: domain.ErrCustomFieldExists, status: http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &mockCustomFieldService{createErr: tt.err}
+			router := setupTestRouter(service)
+
+			req := httptest.NewRequest(http.MethodPost, "/custom-fields", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.status {
+				t.Fatalf("expected %d, got %d: %s", tt.status, rec.Code, rec.Body.String())
+			}
+			if tt.status == http.StatusCreated {
+				input := service.createInput
+				if input.UserID != 42 || input.Key != "env" || input.Type != entities.CustomFieldSelect || len(input.Options) != 2 {
+					t.Fatalf("unexpected input: %+v", input)
+				}
+			}
+		})
+	}
+}
+
+func TestDeleteCustomField(t *testing.T) {
+	router := setupTestRouter(&mockCustomFieldService{deleteErr: domain.ErrCustomFieldNotFound})
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/custom-fields/9", nil))
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
@@ -2,9 +2,11 @@ package export
 
 import (
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 
+	"todoapp/services/task-service/internal/adapters/http/common"
 	"todoapp/services/task-service/internal/adapters/http/middleware"
 	"todoapp/services/task-service/internal/domain/entities"
 	"todoapp/services/task-service/internal/ports"
@@ -13,11 +15,13 @@ import (
 // Handler handles task export HTTP requests.
 type Handler struct {
 	service ports.TaskService
+	views   ports.ViewService
 }
 
-// New creates a new export handler.
-func New(service ports.TaskService) *Handler {
-	return &Handler{service: service}
+// New creates a new export handler. views may be nil, in which case exporting
+// a saved view is not supported.
+func New(service ports.TaskService, views ports.ViewService) *Handler {
+	return &Handler{service: service, views: views}
 }
 
 // RegisterRoutes registers export routes on the given router.
@@ -36,6 +40,8 @@ func (h *Handler) ExportICal(ctx *gin.Context) {
 	h.export(ctx, entities.ExportFormatICal)
 }
 
+// export writes all tasks of the user, or only those matching the saved view
+// given by the viewId query parameter.
 func (h *Handler) export(ctx *gin.Context, format entities.ExportFormat) {
 	claims, ok := middleware.CurrentUser(ctx)
 	if !ok {
@@ -43,9 +49,28 @@ func (h *Handler) export(ctx *gin.Context, format entities.ExportFormat) {
 		return
 	}
 
-	data, filename, err := h.service.ExportTasks(ctx.Request.Context(), claims.UserID, format)
+	var (
+		data     []byte
+		filename string
+		err      error
+	)
+
+	if rawViewID := ctx.Query("viewId"); rawViewID != "" {
+		viewID, parseErr := strconv.ParseInt(rawViewID, 10, 64)
+		if parseErr != nil {
+			common.WriteValidationError(ctx, parseErr)
+			return
+		}
+		if h.views == nil {
+			ctx.AbortWithStatusJSON(http.StatusNotImplemented, gin.H{"error": "VIEWS_UNAVAILABLE"})
+			return
+		}
+		data, filename, err = h.views.ExportView(ctx.Request.Context(), claims.UserID, viewID, format)
+	} else {
+		data, filename, err = h.service.ExportTasks(ctx.Request.Context(), claims.UserID, format)
+	}
 	if err != nil {
-		ctx.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		common.WriteDomainError(ctx, err)
 		return
 	}
 
@@ -46,13 +46,33 @@ func (m *mockTaskService) GetTask(_ context.Context, _, _ int64) (*entities.Task
 func (m *mockTaskService) ListTasks(_ context.Context, _ int64, _ ports.TaskFilter) ([]entities.Task, error) {
 	return nil, nil
 }
+func (m *mockTaskService) MoveTask(_ context.Context, _ ports.MoveTaskInput) (*entities.Task, error) {
+	return nil, nil
+}
+func (m *mockTaskService) GetBoard(_ context.Context, _ int64, _ ports.TaskFilter) ([]entities.BoardColumn, error) {
+	return nil, nil
+}
 func (m *mockTaskService) CreateCategory(_ context.Context, _ ports.CreateCategoryInput) (*entities.Category, error) {
 	return nil, nil
 }
+func (m *mockTaskService) GetCategory(_ context.Context, _, _ int64) (*entities.Category, error) {
+	return nil, nil
+}
 func (m *mockTaskService) ListCategories(_ context.Context, _ int64) ([]entities.Category, error) {
 	return nil, nil
 }
-func (m *mockTaskService) DeleteCategory(_ context.Context, _, _ int64) error { return nil }
+func (m *mockTaskService) ListCategoryTree(_ context.Context, _ int64) ([]entities.Category, error) {
+	return nil, nil
+}
+func (m *mockTaskService) UpdateCategory(_ context.Context, _ ports.UpdateCategoryInput) (*entities.Category, error) {
+	return nil, nil
+}
+func (m *mockTaskService) ReorderCategories(_ context.Context, _ int64, _ []int64) ([]entities.Category, error) {
+	return nil, nil
+}
+func (m *mockTaskService) DeleteCategory(_ context.Context, _, _ int64, _ entities.CategoryDeleteMode) error {
+	return nil
+}
 func (m *mockTaskService) AddComment(_ context.Context, _ ports.AddCommentInput) (*entities.TaskComment, error) {
 	return nil, nil
 }
@@ -74,7 +94,7 @@ func setupTestRouter(service ports.TaskService) *gin.Engine {
 		c.Next()
 	})
 
-	handler := New(service)
+	handler := New(service, nil)
 	handler.RegisterRoutes(router)
 
 	return router
@@ -84,7 +104,7 @@ func setupTestRouterWithoutAuth(service ports.TaskService) *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
 
-	handler := New(service)
+	handler := New(service, nil)
 	handler.RegisterRoutes(router)
 
 	return router
@@ -212,3 +232,50 @@ func TestHandler_RegisterRoutes(t *testing.T) {
 		t.Error("expected /export/ical route to exist")
 	}
 }
+
+// mockViewService is a test double for ports.ViewService
+type mockViewService struct {
+	ports.ViewService
+	exportViewID int64
+	exportFormat entities.ExportFormat
+}
+
+func (m *mockViewService) ExportView(_ context.Context, _ int64, viewID int64, format entities.ExportFormat) ([]byte, string, error) {
+	m.exportViewID = viewID
+	m.exportFormat = format
+	return []byte("BEGIN:VCALENDAR\r\n"), "tasks_2024-12-10.ics", nil
+}
+
+func TestExport_SavedView(t *testing.T) {
+	tasks := &mockTaskService{}
+	views := &mockViewService{}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set(middleware.ContextUserClaimsKey, &ports.TokenClaims{UserID: 42})
+		c.Next()
+	})
+	New(tasks, views).RegisterRoutes(router)
+
+	req := httptest.NewRequest(http.MethodGet, "/export/ical?viewId=7", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", w.Code)
+	}
+	if views.exportViewID != 7 || views.exportFormat != entities.ExportFormatICal {
+		t.Errorf("unexpected view export: id=%d format=%s", views.exportViewID, views.exportFormat)
+	}
+	if tasks.exportCalled {
+		t.Error("ExportTasks should not be called when viewId is given")
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/export/csv?viewId=abc", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for invalid viewId, got %d", w.Code)
+	}
+}
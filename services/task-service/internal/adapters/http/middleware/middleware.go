@@ -6,28 +6,64 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	"todoapp/pkg/logging"
+	"todoapp/pkg/ratelimit"
+	"todoapp/pkg/tracing"
+	"todoapp/services/task-service/internal/adapters/http/common"
 	"todoapp/services/task-service/internal/ports"
 )
 
 const ContextUserClaimsKey = "taskService.userClaims"
 
 type Middleware struct {
-	tokens ports.TokenManager
+	tokens    ports.TokenManager
+	validator ports.TokenValidator
 }
 
-func New(tokens ports.TokenManager) *Middleware {
-	return &Middleware{tokens: tokens}
+type Option func(*Middleware)
+
+// WithTokenValidator also asks validator about every token that parses, so
+// tokens revoked before they expire are rejected.
+func WithTokenValidator(validator ports.TokenValidator) Option {
+	return func(m *Middleware) {
+		m.validator = validator
+	}
+}
+
+func New(tokens ports.TokenManager, opts ...Option) *Middleware {
+	m := &Middleware{tokens: tokens}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
 }
 
 func (m *Middleware) JWT() gin.HandlerFunc {
+	return m.jwt(false)
+}
+
+// JWTOrQueryToken also accepts the token in the access_token query parameter,
+// for clients that cannot set headers such as EventSource and browser
+// WebSockets. Use it only on routes that need it, since URLs end up in logs.
+func (m *Middleware) JWTOrQueryToken() gin.HandlerFunc {
+	return m.jwt(true)
+}
+
+func (m *Middleware) jwt(allowQuery bool) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
+		var token string
+
 		header := ctx.GetHeader("Authorization")
-		if header == "" {
+		switch {
+		case header != "":
+			token = extractBearerToken(header)
+		case allowQuery && ctx.Query("access_token") != "":
+			token = ctx.Query("access_token")
+		default:
 			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "MISSING_TOKEN"})
 			return
 		}
 
-		token := extractBearerToken(header)
 		if token == "" {
 			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "INVALID_TOKEN"})
 			return
@@ -39,7 +75,17 @@ func (m *Middleware) JWT() gin.HandlerFunc {
 			return
 		}
 
+		if m.validator != nil {
+			if err := m.validator.ValidateAccessToken(ctx.Request.Context(), token, claims); err != nil {
+				common.WriteDomainError(ctx, err)
+				ctx.Abort()
+				return
+			}
+		}
+
 		ctx.Set(ContextUserClaimsKey, claims)
+		ctx.Request = ctx.Request.WithContext(logging.WithUserID(ctx.Request.Context(), claims.UserID))
+		tracing.SetUserID(ctx.Request.Context(), claims.UserID)
 		ctx.Next()
 	}
 }
@@ -54,6 +100,16 @@ func CurrentUser(ctx *gin.Context) (*ports.TokenClaims, bool) {
 	return claims, ok
 }
 
+// RateLimitKey counts requests per user when the JWT middleware ran before
+// it, and per client IP otherwise.
+func RateLimitKey(ctx *gin.Context) string {
+	if claims, ok := CurrentUser(ctx); ok {
+		return ratelimit.UserKey(claims.UserID)
+	}
+
+	return ratelimit.ClientIP(ctx)
+}
+
 func extractBearerToken(header string) string {
 	const prefix = "Bearer "
 
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"net/http/httptest"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	apperrors "todoapp/pkg/errors"
 	"todoapp/services/task-service/internal/ports"
 )
 
@@ -28,6 +30,14 @@ func (t tokenStub) ParseRefreshToken(token string) (*ports.TokenClaims, error) {
 	return t.claims, t.err
 }
 
+type validatorStub struct {
+	err error
+}
+
+func (v validatorStub) ValidateAccessToken(ctx context.Context, token string, claims *ports.TokenClaims) error {
+	return v.err
+}
+
 func TestJWTMiddlewareValidation(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	tests := []struct {
@@ -80,3 +90,59 @@ func TestJWTMiddlewareSuccessAndCurrentUser(t *testing.T) {
 		t.Fatalf("expected 200, got %d", rec.Code)
 	}
 }
+
+func TestJWTOrQueryToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		target     string
+		middleware func(*Middleware) gin.HandlerFunc
+		wantStatus int
+	}{
+		{name: "query token", target: "/?access_token=token", middleware: (*Middleware).JWTOrQueryToken, wantStatus: http.StatusOK},
+		{name: "missing token", target: "/", middleware: (*Middleware).JWTOrQueryToken, wantStatus: http.StatusUnauthorized},
+		{name: "query token on plain JWT", target: "/?access_token=token", middleware: (*Middleware).JWT, wantStatus: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		router := gin.New()
+		router.Use(tt.middleware(New(tokenStub{claims: &ports.TokenClaims{UserID: 1}})))
+		router.GET("/", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+		router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.target, nil))
+		if rec.Code != tt.wantStatus {
+			t.Fatalf("%s: expected %d, got %d", tt.name, tt.wantStatus, rec.Code)
+		}
+	}
+}
+
+func TestJWTMiddlewareTokenValidator(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{name: "valid", wantStatus: http.StatusOK},
+		{name: "revoked", err: apperrors.ErrTokenRevoked, wantStatus: http.StatusUnauthorized},
+		{name: "user-service down", err: apperrors.ErrServiceUnavailable, wantStatus: http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		router := gin.New()
+		security := New(tokenStub{claims: &ports.TokenClaims{UserID: 1}}, WithTokenValidator(validatorStub{err: tt.err}))
+		router.Use(security.JWT())
+		router.GET("/", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Authorization", "Bearer token")
+		router.ServeHTTP(rec, req)
+		if rec.Code != tt.wantStatus {
+			t.Fatalf("%s: expected %d, got %d", tt.name, tt.wantStatus, rec.Code)
+		}
+	}
+}
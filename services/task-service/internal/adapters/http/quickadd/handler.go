@@ -0,0 +1,51 @@
+package quickadd
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"todoapp/services/task-service/internal/adapters/http/common"
+	"todoapp/services/task-service/internal/adapters/http/middleware"
+	"todoapp/services/task-service/internal/dto"
+	"todoapp/services/task-service/internal/ports"
+)
+
+// Handler serves natural-language task creation.
+type Handler struct {
+	service ports.QuickAddService
+}
+
+// New creates a new quick-add handler.
+func New(service ports.QuickAddService) *Handler {
+	return &Handler{service: service}
+}
+
+// RegisterRoutes registers quick-add routes on the given router.
+func (h *Handler) RegisterRoutes(router gin.IRoutes) {
+	router.POST("/tasks/quick", h.QuickAddTask)
+}
+
+// QuickAddTask parses a line such as "Call Anna tomorrow 17:00 !high #work"
+// and creates the task.
+func (h *Handler) QuickAddTask(ctx *gin.Context) {
+	claims, ok := middleware.CurrentUser(ctx)
+	if !ok {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "UNAUTHORIZED"})
+		return
+	}
+
+	var request dto.QuickAddRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		common.WriteValidationError(ctx, err)
+		return
+	}
+
+	result, err := h.service.QuickAddTask(ctx.Request.Context(), claims.UserID, request.Text)
+	if err != nil {
+		common.WriteDomainError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dto.NewQuickAddResponse(*result))
+}
@@ -0,0 +1,205 @@
+package stream
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/websocket"
+
+	"todoapp/services/task-service/internal/adapters/http/common"
+	"todoapp/services/task-service/internal/adapters/http/middleware"
+	"todoapp/services/task-service/internal/dto"
+	"todoapp/services/task-service/internal/ports"
+)
+
+const (
+	heartbeatInterval = 25 * time.Second
+	writeTimeout      = 10 * time.Second
+	// reconnectDelay is the SSE retry hint in milliseconds.
+	reconnectDelay = 3000
+	resetType      = "reset"
+	pingType       = "ping"
+)
+
+// Handler pushes the authenticated user's task, category and comment changes
+// over Server-Sent Events and WebSocket. Both resume from the last event ID.
+type Handler struct {
+	stream ports.ChangeStream
+}
+
+// New creates a new stream handler.
+func New(stream ports.ChangeStream) *Handler {
+	return &Handler{stream: stream}
+}
+
+// RegisterRoutes registers stream routes on the given router.
+func (h *Handler) RegisterRoutes(router gin.IRoutes) {
+	router.GET("/stream", h.Events)
+	router.GET("/stream/ws", h.WebSocket)
+}
+
+// Events serves the stream as Server-Sent Events. Every message is sent
+// without an event name, so EventSource delivers it to onmessage, and carries
+// its ID so the browser resumes with Last-Event-ID after a reconnect.
+func (h *Handler) Events(ctx *gin.Context) {
+	claims, ok := middleware.CurrentUser(ctx)
+	if !ok {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "UNAUTHORIZED"})
+		return
+	}
+
+	lastEventID, err := parseLastEventID(ctx)
+	if err != nil {
+		common.WriteValidationError(ctx, err)
+		return
+	}
+
+	sub, replay, resumed := h.stream.Subscribe(claims.UserID, lastEventID)
+	defer sub.Close()
+
+	header := ctx.Writer.Header()
+	header.Set("Content-Type", "text/event-stream")
+	header.Set("Cache-Control", "no-cache")
+	header.Set("Connection", "keep-alive")
+	header.Set("X-Accel-Buffering", "no")
+	ctx.Status(http.StatusOK)
+
+	w := ctx.Writer
+	fmt.Fprintf(w, "retry: %d\n\n", reconnectDelay)
+	if !resumed {
+		writeSSE(w, dto.ChangeEventResponse{Type: resetType})
+	}
+	for _, event := range replay {
+		writeSSE(w, dto.NewChangeEventResponse(event))
+	}
+	w.Flush()
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-ctx.Request.Context().Done():
+			return
+		case <-sub.Done():
+			return
+		case event, ok := <-sub.Events():
+			if !ok {
+				return
+			}
+			writeSSE(w, dto.NewChangeEventResponse(event))
+		case <-heartbeat.C:
+			io.WriteString(w, ": ping\n\n")
+		}
+		w.Flush()
+	}
+}
+
+// WebSocket serves the stream as JSON text messages of the same shape as the
+// SSE data. Messages from the client are ignored.
+func (h *Handler) WebSocket(ctx *gin.Context) {
+	claims, ok := middleware.CurrentUser(ctx)
+	if !ok {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "UNAUTHORIZED"})
+		return
+	}
+
+	lastEventID, err := parseLastEventID(ctx)
+	if err != nil {
+		common.WriteValidationError(ctx, err)
+		return
+	}
+
+	server := websocket.Server{
+		// The token travels in the query rather than in cookies, so a foreign
+		// origin gains nothing and any origin is accepted.
+		Handshake: func(*websocket.Config, *http.Request) error { return nil },
+		Handler: func(conn *websocket.Conn) {
+			h.serveWebSocket(conn, claims.UserID, lastEventID)
+		},
+	}
+	server.ServeHTTP(ctx.Writer, ctx.Request)
+}
+
+func (h *Handler) serveWebSocket(conn *websocket.Conn, userID, lastEventID int64) {
+	defer conn.Close()
+
+	sub, replay, resumed := h.stream.Subscribe(userID, lastEventID)
+	defer sub.Close()
+
+	// Reading is the only way to notice that the client went away.
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		var message string
+		for websocket.Message.Receive(conn, &message) == nil {
+		}
+	}()
+
+	send := func(message dto.ChangeEventResponse) bool {
+		_ = conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+		return websocket.JSON.Send(conn, message) == nil
+	}
+
+	if !resumed && !send(dto.ChangeEventResponse{Type: resetType}) {
+		return
+	}
+	for _, event := range replay {
+		if !send(dto.NewChangeEventResponse(event)) {
+			return
+		}
+	}
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		var ok bool
+		select {
+		case <-closed:
+			return
+		case <-sub.Done():
+			return
+		case event, open := <-sub.Events():
+			if !open {
+				return
+			}
+			ok = send(dto.NewChangeEventResponse(event))
+		case <-heartbeat.C:
+			ok = send(dto.ChangeEventResponse{Type: pingType})
+		}
+		if !ok {
+			return
+		}
+	}
+}
+
+func writeSSE(w io.Writer, message dto.ChangeEventResponse) {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return
+	}
+	if message.ID > 0 {
+		fmt.Fprintf(w, "id: %d\n", message.ID)
+	}
+	fmt.Fprintf(w, "data: %s\n\n", data)
+}
+
+// parseLastEventID reads the Last-Event-ID header sent by EventSource on
+// reconnect, or the lastEventId query parameter for the first connection and
+// for WebSocket clients.
+func parseLastEventID(ctx *gin.Context) (int64, error) {
+	raw := ctx.GetHeader("Last-Event-ID")
+	if raw == "" {
+		raw = ctx.Query("lastEventId")
+	}
+	if raw == "" {
+		return 0, nil
+	}
+	return strconv.ParseInt(raw, 10, 64)
+}
@@ -0,0 +1,154 @@
+package stream
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/websocket"
+
+	"todoapp/services/task-service/internal/adapters/http/middleware"
+	"todoapp/services/task-service/internal/domain/entities"
+	"todoapp/services/task-service/internal/ports"
+)
+
+// subscriptionStub hands out its events and then closes the channel, which
+// ends the stream.
+type subscriptionStub struct {
+	events chan entities.ChangeEvent
+	done   chan struct{}
+}
+
+func (s *subscriptionStub) Events() <-chan entities.ChangeEvent { return s.events }
+func (s *subscriptionStub) Done() <-chan struct{}               { return s.done }
+func (s *subscriptionStub) Close()                              {}
+
+type streamStub struct {
+	replay      []entities.ChangeEvent
+	live        []entities.ChangeEvent
+	resumed     bool
+	userID      int64
+	lastEventID int64
+}
+
+func (s *streamStub) Subscribe(userID, lastEventID int64) (ports.ChangeSubscription, []entities.ChangeEvent, bool) {
+	s.userID = userID
+	s.lastEventID = lastEventID
+
+	sub := &subscriptionStub{
+		events: make(chan entities.ChangeEvent, len(s.live)),
+		done:   make(chan struct{}),
+	}
+	for _, event := range s.live {
+		sub.events <- event
+	}
+	close(sub.events)
+	return sub, s.replay, s.resumed
+}
+
+func setupTestRouter(stream ports.ChangeStream) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set(middleware.ContextUserClaimsKey, &ports.TokenClaims{UserID: 42})
+		c.Next()
+	})
+	New(stream).RegisterRoutes(router)
+	return router
+}
+
+func TestEvents_ReplaysAndStreams(t *testing.T) {
+	stream := &streamStub{
+		resumed: true,
+		replay:  []entities.ChangeEvent{{ID: 4, UserID: 42, Type: entities.ChangeTaskDeleted, Task: &entities.Task{ID: 9, UserID: 42}}},
+		live:    []entities.ChangeEvent{{ID: 5, UserID: 42, Type: entities.ChangeCategoryCreated, Category: &entities.Category{ID: 3, UserID: 42, Name: "Work"}}},
+	}
+	router := setupTestRouter(stream)
+
+	req := httptest.NewRequest(http.MethodGet, "/stream", nil)
+	req.Header.Set("Last-Event-ID", "3")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if got := rec.Header().Get("Content-Type"); got != "text/event-stream" {
+		t.Fatalf("unexpected content type %q", got)
+	}
+	if stream.userID != 42 || stream.lastEventID != 3 {
+		t.Fatalf("unexpected subscription: user %d, last event %d", stream.userID, stream.lastEventID)
+	}
+
+	body := rec.Body.String()
+	for _, want := range []string{
+		"retry: 3000\n\n",
+		"id: 4\ndata: {\"id\":4,\"type\":\"task.deleted\",\"data\":{\"id\":9}}\n\n",
+		"id: 5\ndata: {\"id\":5,\"type\":\"category.created\",\"data\":{",
+	} {
+		if !strings.Contains(body, want) {
+			t.Fatalf("expected %q in %q", want, body)
+		}
+	}
+	if strings.Contains(body, `"type":"reset"`) {
+		t.Fatalf("unexpected reset in %q", body)
+	}
+}
+
+func TestEvents_SendsResetWhenNotResumed(t *testing.T) {
+	router := setupTestRouter(&streamStub{})
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/stream?lastEventId=99", nil))
+
+	if !strings.Contains(rec.Body.String(), "data: {\"id\":0,\"type\":\"reset\",\"data\":null}\n\n") {
+		t.Fatalf("expected a reset message, got %q", rec.Body.String())
+	}
+}
+
+func TestEvents_InvalidLastEventID(t *testing.T) {
+	router := setupTestRouter(&streamStub{})
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/stream?lastEventId=abc", nil))
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestWebSocket_SendsJSONMessages(t *testing.T) {
+	stream := &streamStub{
+		live: []entities.ChangeEvent{{ID: 5, UserID: 42, Type: entities.ChangeTaskDeleted, Task: &entities.Task{ID: 9, UserID: 42}}},
+	}
+	server := httptest.NewServer(setupTestRouter(stream))
+	defer server.Close()
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/stream/ws"
+	conn, err := websocket.Dial(url, "", server.URL)
+	if err != nil {
+		t.Fatalf("dial: %v", err)
+	}
+	defer conn.Close()
+
+	var messages []string
+	for {
+		var message string
+		if err := websocket.Message.Receive(conn, &message); err != nil {
+			break
+		}
+		messages = append(messages, message)
+	}
+
+	want := []string{
+		`{"id":0,"type":"reset","data":null}`,
+		`{"id":5,"type":"task.deleted","data":{"id":9}}`,
+	}
+	if len(messages) != len(want) {
+		t.Fatalf("expected %d messages, got %q", len(want), messages)
+	}
+	for i := range want {
+		if strings.TrimSpace(messages[i]) != want[i] {
+			t.Fatalf("message %d: expected %s, got %s", i, want[i], messages[i])
+		}
+	}
+}
@@ -27,12 +27,17 @@ func (h *Handler) RegisterRoutes(router gin.IRoutes) {
 	router.PUT("/tasks/:id", h.UpdateTask)
 	router.PATCH("/tasks/:id/status", h.UpdateTaskStatus)
 	router.DELETE("/tasks/:id", h.DeleteTask)
+	router.POST("/tasks/:id/move", h.MoveTask)
+	router.GET("/board", h.GetBoard)
 
 	router.GET("/tasks/:id/comments", h.ListComments)
 	router.POST("/tasks/:id/comments", h.CreateComment)
 
 	router.GET("/categories", h.ListCategories)
 	router.POST("/categories", h.CreateCategory)
+	router.GET("/categories/tree", h.ListCategoryTree)
+	router.POST("/categories/reorder", h.ReorderCategories)
+	router.PUT("/categories/:id", h.UpdateCategory)
 	router.DELETE("/categories/:id", h.DeleteCategory)
 }
 
@@ -48,6 +53,7 @@ func (h *Handler) ListTasks(ctx *gin.Context) {
 		common.WriteValidationError(ctx, err)
 		return
 	}
+	filter.CustomFields = ctx.QueryMap("cf")
 
 	tasks, err := h.service.ListTasks(ctx.Request.Context(), claims.UserID, filter.ToFilter())
 	if err != nil {
@@ -158,6 +164,57 @@ func (h *Handler) UpdateTaskStatus(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, dto.NewTaskResponse(*task))
 }
 
+func (h *Handler) MoveTask(ctx *gin.Context) {
+	claims, ok := middleware.CurrentUser(ctx)
+	if !ok {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "UNAUTHORIZED"})
+		return
+	}
+
+	taskID, err := parseID(ctx.Param("id"))
+	if err != nil {
+		common.WriteValidationError(ctx, err)
+		return
+	}
+
+	var request dto.MoveTaskRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		common.WriteValidationError(ctx, err)
+		return
+	}
+
+	task, err := h.service.MoveTask(ctx.Request.Context(), request.ToInput(claims.UserID, taskID))
+	if err != nil {
+		common.WriteDomainError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewTaskResponse(*task))
+}
+
+func (h *Handler) GetBoard(ctx *gin.Context) {
+	claims, ok := middleware.CurrentUser(ctx)
+	if !ok {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "UNAUTHORIZED"})
+		return
+	}
+
+	var request dto.BoardRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		common.WriteValidationError(ctx, err)
+		return
+	}
+	request.CustomFields = ctx.QueryMap("cf")
+
+	columns, err := h.service.GetBoard(ctx.Request.Context(), claims.UserID, request.ToFilter())
+	if err != nil {
+		common.WriteDomainError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewBoardResponse(columns))
+}
+
 func (h *Handler) DeleteTask(ctx *gin.Context) {
 	claims, ok := middleware.CurrentUser(ctx)
 	if !ok {
@@ -195,6 +252,22 @@ func (h *Handler) ListCategories(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, dto.NewCategoryResponses(categories))
 }
 
+func (h *Handler) ListCategoryTree(ctx *gin.Context) {
+	claims, ok := middleware.CurrentUser(ctx)
+	if !ok {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "UNAUTHORIZED"})
+		return
+	}
+
+	categories, err := h.service.ListCategoryTree(ctx.Request.Context(), claims.UserID)
+	if err != nil {
+		common.WriteDomainError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewCategoryTreeResponses(categories))
+}
+
 func (h *Handler) CreateCategory(ctx *gin.Context) {
 	claims, ok := middleware.CurrentUser(ctx)
 	if !ok {
@@ -217,6 +290,56 @@ func (h *Handler) CreateCategory(ctx *gin.Context) {
 	ctx.JSON(http.StatusCreated, dto.NewCategoryResponse(*category))
 }
 
+func (h *Handler) UpdateCategory(ctx *gin.Context) {
+	claims, ok := middleware.CurrentUser(ctx)
+	if !ok {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "UNAUTHORIZED"})
+		return
+	}
+
+	categoryID, err := parseID(ctx.Param("id"))
+	if err != nil {
+		common.WriteValidationError(ctx, err)
+		return
+	}
+
+	var request dto.UpdateCategoryRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		common.WriteValidationError(ctx, err)
+		return
+	}
+
+	category, err := h.service.UpdateCategory(ctx.Request.Context(), request.ToInput(claims.UserID, categoryID))
+	if err != nil {
+		common.WriteDomainError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewCategoryResponse(*category))
+}
+
+func (h *Handler) ReorderCategories(ctx *gin.Context) {
+	claims, ok := middleware.CurrentUser(ctx)
+	if !ok {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "UNAUTHORIZED"})
+		return
+	}
+
+	var request dto.ReorderCategoriesRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		common.WriteValidationError(ctx, err)
+		return
+	}
+
+	categories, err := h.service.ReorderCategories(ctx.Request.Context(), claims.UserID, request.CategoryIDs)
+	if err != nil {
+		common.WriteDomainError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewCategoryResponses(categories))
+}
+
 func (h *Handler) DeleteCategory(ctx *gin.Context) {
 	claims, ok := middleware.CurrentUser(ctx)
 	if !ok {
@@ -230,7 +353,13 @@ func (h *Handler) DeleteCategory(ctx *gin.Context) {
 		return
 	}
 
-	if err := h.service.DeleteCategory(ctx.Request.Context(), claims.UserID, categoryID); err != nil {
+	var request dto.DeleteCategoryRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		common.WriteValidationError(ctx, err)
+		return
+	}
+
+	if err := h.service.DeleteCategory(ctx.Request.Context(), claims.UserID, categoryID, request.ToMode()); err != nil {
 		common.WriteDomainError(ctx, err)
 		return
 	}
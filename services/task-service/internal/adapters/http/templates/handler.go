@@ -0,0 +1,178 @@
+package templates
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"todoapp/services/task-service/internal/adapters/http/common"
+	"todoapp/services/task-service/internal/adapters/http/middleware"
+	"todoapp/services/task-service/internal/dto"
+	"todoapp/services/task-service/internal/ports"
+)
+
+// Handler serves task template CRUD and instantiation.
+type Handler struct {
+	service ports.TemplateService
+}
+
+// New creates a new templates handler.
+func New(service ports.TemplateService) *Handler {
+	return &Handler{service: service}
+}
+
+// RegisterRoutes registers template routes on the given router.
+func (h *Handler) RegisterRoutes(router gin.IRoutes) {
+	router.GET("/templates", h.ListTemplates)
+	router.POST("/templates", h.CreateTemplate)
+	router.GET("/templates/:id", h.GetTemplate)
+	router.PUT("/templates/:id", h.UpdateTemplate)
+	router.DELETE("/templates/:id", h.DeleteTemplate)
+	router.POST("/templates/:id/instantiate", h.InstantiateTemplate)
+}
+
+func (h *Handler) ListTemplates(ctx *gin.Context) {
+	claims, ok := middleware.CurrentUser(ctx)
+	if !ok {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "UNAUTHORIZED"})
+		return
+	}
+
+	templates, err := h.service.ListTemplates(ctx.Request.Context(), claims.UserID)
+	if err != nil {
+		common.WriteDomainError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewTemplateResponses(templates))
+}
+
+func (h *Handler) CreateTemplate(ctx *gin.Context) {
+	claims, ok := middleware.CurrentUser(ctx)
+	if !ok {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "UNAUTHORIZED"})
+		return
+	}
+
+	var request dto.TemplateRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		common.WriteValidationError(ctx, err)
+		return
+	}
+
+	template, err := h.service.CreateTemplate(ctx.Request.Context(), request.ToCreateInput(claims.UserID))
+	if err != nil {
+		common.WriteDomainError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dto.NewTemplateResponse(*template))
+}
+
+func (h *Handler) GetTemplate(ctx *gin.Context) {
+	claims, ok := middleware.CurrentUser(ctx)
+	if !ok {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "UNAUTHORIZED"})
+		return
+	}
+
+	templateID, err := parseID(ctx.Param("id"))
+	if err != nil {
+		common.WriteValidationError(ctx, err)
+		return
+	}
+
+	template, err := h.service.GetTemplate(ctx.Request.Context(), claims.UserID, templateID)
+	if err != nil {
+		common.WriteDomainError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewTemplateResponse(*template))
+}
+
+func (h *Handler) UpdateTemplate(ctx *gin.Context) {
+	claims, ok := middleware.CurrentUser(ctx)
+	if !ok {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "UNAUTHORIZED"})
+		return
+	}
+
+	templateID, err := parseID(ctx.Param("id"))
+	if err != nil {
+		common.WriteValidationError(ctx, err)
+		return
+	}
+
+	var request dto.TemplateRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		common.WriteValidationError(ctx, err)
+		return
+	}
+
+	template, err := h.service.UpdateTemplate(ctx.Request.Context(), request.ToUpdateInput(claims.UserID, templateID))
+	if err != nil {
+		common.WriteDomainError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewTemplateResponse(*template))
+}
+
+func (h *Handler) DeleteTemplate(ctx *gin.Context) {
+	claims, ok := middleware.CurrentUser(ctx)
+	if !ok {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "UNAUTHORIZED"})
+		return
+	}
+
+	templateID, err := parseID(ctx.Param("id"))
+	if err != nil {
+		common.WriteValidationError(ctx, err)
+		return
+	}
+
+	if err := h.service.DeleteTemplate(ctx.Request.Context(), claims.UserID, templateID); err != nil {
+		common.WriteDomainError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// InstantiateTemplate creates tasks from a template. The request body is
+// optional.
+func (h *Handler) InstantiateTemplate(ctx *gin.Context) {
+	claims, ok := middleware.CurrentUser(ctx)
+	if !ok {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "UNAUTHORIZED"})
+		return
+	}
+
+	templateID, err := parseID(ctx.Param("id"))
+	if err != nil {
+		common.WriteValidationError(ctx, err)
+		return
+	}
+
+	var request dto.InstantiateTemplateRequest
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&request); err != nil {
+			common.WriteValidationError(ctx, err)
+			return
+		}
+	}
+
+	tasks, err := h.service.InstantiateTemplate(ctx.Request.Context(), request.ToInput(claims.UserID, templateID))
+	if err != nil {
+		common.WriteDomainError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dto.NewTaskResponses(tasks))
+}
+
+func parseID(raw string) (int64, error) {
+	return strconv.ParseInt(raw, 10, 64)
+}
@@ -0,0 +1,99 @@
+package templates
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"todoapp/services/task-service/internal/adapters/http/middleware"
+	"todoapp/services/task-service/internal/domain"
+	"todoapp/services/task-service/internal/domain/entities"
+	"todoapp/services/task-service/internal/ports"
+)
+
+type mockTemplateService struct {
+	ports.TemplateService
+	createInput      ports.CreateTemplateInput
+	instantiateInput ports.InstantiateTemplateInput
+	instantiateErr   error
+}
+
+func (m *mockTemplateService) CreateTemplate(_ context.Context, input ports.CreateTemplateInput) (*entities.TaskTemplate, error) {
+	m.createInput = input
+	return &entities.TaskTemplate{ID: 1, Name: input.Name, Title: input.Title, Items: input.Items}, nil
+}
+
+func (m *mockTemplateService) InstantiateTemplate(_ context.Context, input ports.InstantiateTemplateInput) ([]entities.Task, error) {
+	m.instantiateInput = input
+	if m.instantiateErr != nil {
+		return nil, m.instantiateErr
+	}
+	return []entities.Task{{ID: 10, Title: "Release v1"}}, nil
+}
+
+func setupTestRouter(service ports.TemplateService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set(middleware.ContextUserClaimsKey, &ports.TokenClaims{UserID: 42})
+		c.Next()
+	})
+	New(service).RegisterRoutes(router)
+	return router
+}
+
+func TestCreateTemplate(t *testing.T) {
+	service := &mockTemplateService{}
+	router := setupTestRouter(service)
+
+	body := `{"name":"Release","title":"Release {{version}}","priority":"high","dueOffset":"+3d","items":[{"title":"Tag"}]}`
+	req := httptest.NewRequest(http.MethodPost, "/templates", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	input := service.createInput
+	if input.UserID != 42 || input.Priority != entities.TaskPriorityHigh || len(input.Items) != 1 || input.Items[0].Title != "Tag" {
+		t.Fatalf("unexpected input: %+v", input)
+	}
+}
+
+func TestInstantiateTemplate(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   string
+		err    error
+		status int
+	}{
+		{name: "without body", status: http.StatusCreated},
+		{name: "with variables", body: `{"variables":{"version":"v1"}}`, status: http.StatusCreated},
+		{name: "missing variables", body: `{}`, err: domain.ErrValidationFailed, status: http.StatusBadRequest},
+		{name: "unknown template", err: domain.ErrTemplateNotFound, status: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &mockTemplateService{instantiateErr: tt.err}
+			router := setupTestRouter(service)
+
+			req := httptest.NewRequest(http.MethodPost, "/templates/7/instantiate", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.status {
+				t.Fatalf("expected %d, got %d: %s", tt.status, rec.Code, rec.Body.String())
+			}
+			if service.instantiateInput.TemplateID != 7 || service.instantiateInput.UserID != 42 {
+				t.Fatalf("unexpected input: %+v", service.instantiateInput)
+			}
+		})
+	}
+}
@@ -0,0 +1,212 @@
+package timetracking
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"todoapp/services/task-service/internal/adapters/http/common"
+	"todoapp/services/task-service/internal/adapters/http/middleware"
+	"todoapp/services/task-service/internal/domain"
+	"todoapp/services/task-service/internal/dto"
+	"todoapp/services/task-service/internal/ports"
+)
+
+// Handler serves task timers, manual time entries and time reports.
+type Handler struct {
+	service ports.TimeTrackingService
+}
+
+// New creates a new time tracking handler.
+func New(service ports.TimeTrackingService) *Handler {
+	return &Handler{service: service}
+}
+
+// RegisterRoutes registers time tracking routes on the given router.
+func (h *Handler) RegisterRoutes(router gin.IRoutes) {
+	router.POST("/tasks/:id/timer/start", h.StartTimer)
+	router.POST("/timer/stop", h.StopTimer)
+	router.GET("/timer", h.RunningTimer)
+	router.GET("/tasks/:id/time-entries", h.ListTimeEntries)
+	router.POST("/tasks/:id/time-entries", h.AddTimeEntry)
+	router.DELETE("/tasks/:id/time-entries/:entryId", h.DeleteTimeEntry)
+	router.GET("/time-report", h.TimeReport)
+}
+
+func (h *Handler) StartTimer(ctx *gin.Context) {
+	claims, ok := middleware.CurrentUser(ctx)
+	if !ok {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "UNAUTHORIZED"})
+		return
+	}
+
+	taskID, err := parseID(ctx.Param("id"))
+	if err != nil {
+		common.WriteValidationError(ctx, err)
+		return
+	}
+
+	var request dto.StartTimerRequest
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&request); err != nil {
+			common.WriteValidationError(ctx, err)
+			return
+		}
+	}
+
+	entry, err := h.service.StartTimer(ctx.Request.Context(), claims.UserID, taskID, request.Note)
+	if err != nil {
+		common.WriteDomainError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dto.NewTimeEntryResponse(*entry))
+}
+
+func (h *Handler) StopTimer(ctx *gin.Context) {
+	claims, ok := middleware.CurrentUser(ctx)
+	if !ok {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "UNAUTHORIZED"})
+		return
+	}
+
+	entry, err := h.service.StopTimer(ctx.Request.Context(), claims.UserID)
+	if err != nil {
+		common.WriteDomainError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewTimeEntryResponse(*entry))
+}
+
+// RunningTimer responds with 204 when no timer is running.
+func (h *Handler) RunningTimer(ctx *gin.Context) {
+	claims, ok := middleware.CurrentUser(ctx)
+	if !ok {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "UNAUTHORIZED"})
+		return
+	}
+
+	entry, err := h.service.RunningTimer(ctx.Request.Context(), claims.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNoRunningTimer) {
+			ctx.Status(http.StatusNoContent)
+			return
+		}
+		common.WriteDomainError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewTimeEntryResponse(*entry))
+}
+
+func (h *Handler) ListTimeEntries(ctx *gin.Context) {
+	claims, ok := middleware.CurrentUser(ctx)
+	if !ok {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "UNAUTHORIZED"})
+		return
+	}
+
+	taskID, err := parseID(ctx.Param("id"))
+	if err != nil {
+		common.WriteValidationError(ctx, err)
+		return
+	}
+
+	entries, err := h.service.ListTimeEntries(ctx.Request.Context(), claims.UserID, taskID)
+	if err != nil {
+		common.WriteDomainError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewTimeEntryResponses(entries))
+}
+
+func (h *Handler) AddTimeEntry(ctx *gin.Context) {
+	claims, ok := middleware.CurrentUser(ctx)
+	if !ok {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "UNAUTHORIZED"})
+		return
+	}
+
+	taskID, err := parseID(ctx.Param("id"))
+	if err != nil {
+		common.WriteValidationError(ctx, err)
+		return
+	}
+
+	var request dto.AddTimeEntryRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		common.WriteValidationError(ctx, err)
+		return
+	}
+
+	input, ok := request.ToInput(claims.UserID, taskID)
+	if !ok {
+		common.WriteValidationError(ctx, errors.New("startedAt must be an RFC3339 timestamp"))
+		return
+	}
+
+	entry, err := h.service.AddTimeEntry(ctx.Request.Context(), input)
+	if err != nil {
+		common.WriteDomainError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dto.NewTimeEntryResponse(*entry))
+}
+
+func (h *Handler) DeleteTimeEntry(ctx *gin.Context) {
+	claims, ok := middleware.CurrentUser(ctx)
+	if !ok {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "UNAUTHORIZED"})
+		return
+	}
+
+	taskID, err := parseID(ctx.Param("id"))
+	if err != nil {
+		common.WriteValidationError(ctx, err)
+		return
+	}
+
+	entryID, err := parseID(ctx.Param("entryId"))
+	if err != nil {
+		common.WriteValidationError(ctx, err)
+		return
+	}
+
+	if err := h.service.DeleteTimeEntry(ctx.Request.Context(), claims.UserID, taskID, entryID); err != nil {
+		common.WriteDomainError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (h *Handler) TimeReport(ctx *gin.Context) {
+	claims, ok := middleware.CurrentUser(ctx)
+	if !ok {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "UNAUTHORIZED"})
+		return
+	}
+
+	var request dto.TimeReportRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		common.WriteValidationError(ctx, err)
+		return
+	}
+
+	report, err := h.service.TimeReport(ctx.Request.Context(), request.ToInput(claims.UserID))
+	if err != nil {
+		common.WriteDomainError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewTimeReportResponse(*report))
+}
+
+func parseID(raw string) (int64, error) {
+	return strconv.ParseInt(raw, 10, 64)
+}
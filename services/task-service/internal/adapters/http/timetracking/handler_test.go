@@ -0,0 +1,147 @@
+package timetracking
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"todoapp/services/task-service/internal/adapters/http/middleware"
+	"todoapp/services/task-service/internal/domain"
+	"todoapp/services/task-service/internal/domain/entities"
+	"todoapp/services/task-service/internal/ports"
+)
+
+type mockTimeTrackingService struct {
+	ports.TimeTrackingService
+	addInput    ports.AddTimeEntryInput
+	reportInput ports.TimeReportInput
+	runningErr  error
+}
+
+func (m *mockTimeTrackingService) StartTimer(_ context.Context, userID, taskID int64, note string) (*entities.TimeEntry, error) {
+	return &entities.TimeEntry{ID: 1, UserID: userID, TaskID: taskID, Note: note}, nil
+}
+
+func (m *mockTimeTrackingService) RunningTimer(_ context.Context, userID int64) (*entities.TimeEntry, error) {
+	if m.runningErr != nil {
+		return nil, m.runningErr
+	}
+	return &entities.TimeEntry{ID: 1, UserID: userID, TaskID: 3}, nil
+}
+
+func (m *mockTimeTrackingService) AddTimeEntry(_ context.Context, input ports.AddTimeEntryInput) (*entities.TimeEntry, error) {
+	m.addInput = input
+	ended := input.StartedAt.Add(input.Duration)
+	return &entities.TimeEntry{ID: 2, TaskID: input.TaskID, StartedAt: input.StartedAt, EndedAt: &ended, Duration: input.Duration}, nil
+}
+
+func (m *mockTimeTrackingService) TimeReport(_ context.Context, input ports.TimeReportInput) (*entities.TimeReport, error) {
+	m.reportInput = input
+	return &entities.TimeReport{From: input.From, To: input.To, Total: time.Hour}, nil
+}
+
+func setupTestRouter(service ports.TimeTrackingService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set(middleware.ContextUserClaimsKey, &ports.TokenClaims{UserID: 42})
+		c.Next()
+	})
+	New(service).RegisterRoutes(router)
+	return router
+}
+
+func TestStartTimerWithoutBody(t *testing.T) {
+	router := setupTestRouter(&mockTimeTrackingService{})
+
+	req := httptest.NewRequest(http.MethodPost, "/tasks/3/timer/start", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if !strings.Contains(rec.Body.String(), `"running":true`) {
+		t.Fatalf("expected running timer, got %s", rec.Body.String())
+	}
+}
+
+func TestRunningTimerNone(t *testing.T) {
+	router := setupTestRouter(&mockTimeTrackingService{runningErr: domain.ErrNoRunningTimer})
+
+	req := httptest.NewRequest(http.MethodGet, "/timer", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("expected 204, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestAddTimeEntry(t *testing.T) {
+	service := &mockTimeTrackingService{}
+	router := setupTestRouter(service)
+
+	body := `{"startedAt":"2024-12-04T09:00:00Z","durationSeconds":5400,"note":"pairing"}`
+	req := httptest.NewRequest(http.MethodPost, "/tasks/3/time-entries", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	input := service.addInput
+	if input.UserID != 42 || input.TaskID != 3 || input.Duration != 90*time.Minute || input.Note != "pairing" {
+		t.Fatalf("unexpected input: %+v", input)
+	}
+	if !strings.Contains(rec.Body.String(), `"durationSeconds":5400`) {
+		t.Fatalf("expected duration in response, got %s", rec.Body.String())
+	}
+}
+
+func TestAddTimeEntryRejectsBadTimestamp(t *testing.T) {
+	router := setupTestRouter(&mockTimeTrackingService{})
+
+	body := `{"startedAt":"yesterday","durationSeconds":60}`
+	req := httptest.NewRequest(http.MethodPost, "/tasks/3/time-entries", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestTimeReport(t *testing.T) {
+	service := &mockTimeTrackingService{}
+	router := setupTestRouter(service)
+
+	req := httptest.NewRequest(http.MethodGet, "/time-report?from=2024-12-01&to=2024-12-31", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if service.reportInput.From.Day() != 1 || service.reportInput.To.Day() != 31 {
+		t.Fatalf("unexpected input: %+v", service.reportInput)
+	}
+	if !strings.Contains(rec.Body.String(), `"totalSeconds":3600`) {
+		t.Fatalf("expected total in response, got %s", rec.Body.String())
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/time-report?from=01.12.2024", nil)
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for malformed date, got %d", rec.Code)
+	}
+}
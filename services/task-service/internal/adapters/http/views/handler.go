@@ -0,0 +1,175 @@
+package views
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"todoapp/services/task-service/internal/adapters/http/common"
+	"todoapp/services/task-service/internal/adapters/http/middleware"
+	"todoapp/services/task-service/internal/dto"
+	"todoapp/services/task-service/internal/ports"
+)
+
+// Handler serves saved view CRUD and the tasks matching a view.
+type Handler struct {
+	service ports.ViewService
+}
+
+// New creates a new views handler.
+func New(service ports.ViewService) *Handler {
+	return &Handler{service: service}
+}
+
+// RegisterRoutes registers view routes on the given router.
+func (h *Handler) RegisterRoutes(router gin.IRoutes) {
+	router.GET("/views", h.ListViews)
+	router.POST("/views", h.CreateView)
+	router.GET("/views/:id", h.GetView)
+	router.PUT("/views/:id", h.UpdateView)
+	router.DELETE("/views/:id", h.DeleteView)
+	router.GET("/views/:id/tasks", h.ListViewTasks)
+}
+
+func (h *Handler) ListViews(ctx *gin.Context) {
+	claims, ok := middleware.CurrentUser(ctx)
+	if !ok {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "UNAUTHORIZED"})
+		return
+	}
+
+	views, err := h.service.ListViews(ctx.Request.Context(), claims.UserID)
+	if err != nil {
+		common.WriteDomainError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewViewResponses(views))
+}
+
+func (h *Handler) CreateView(ctx *gin.Context) {
+	claims, ok := middleware.CurrentUser(ctx)
+	if !ok {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "UNAUTHORIZED"})
+		return
+	}
+
+	var request dto.ViewRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		common.WriteValidationError(ctx, err)
+		return
+	}
+
+	view, err := h.service.CreateView(ctx.Request.Context(), request.ToCreateInput(claims.UserID))
+	if err != nil {
+		common.WriteDomainError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dto.NewViewResponse(*view))
+}
+
+func (h *Handler) GetView(ctx *gin.Context) {
+	claims, ok := middleware.CurrentUser(ctx)
+	if !ok {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "UNAUTHORIZED"})
+		return
+	}
+
+	viewID, err := parseID(ctx.Param("id"))
+	if err != nil {
+		common.WriteValidationError(ctx, err)
+		return
+	}
+
+	view, err := h.service.GetView(ctx.Request.Context(), claims.UserID, viewID)
+	if err != nil {
+		common.WriteDomainError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewViewResponse(*view))
+}
+
+func (h *Handler) UpdateView(ctx *gin.Context) {
+	claims, ok := middleware.CurrentUser(ctx)
+	if !ok {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "UNAUTHORIZED"})
+		return
+	}
+
+	viewID, err := parseID(ctx.Param("id"))
+	if err != nil {
+		common.WriteValidationError(ctx, err)
+		return
+	}
+
+	var request dto.ViewRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		common.WriteValidationError(ctx, err)
+		return
+	}
+
+	view, err := h.service.UpdateView(ctx.Request.Context(), request.ToUpdateInput(claims.UserID, viewID))
+	if err != nil {
+		common.WriteDomainError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewViewResponse(*view))
+}
+
+func (h *Handler) DeleteView(ctx *gin.Context) {
+	claims, ok := middleware.CurrentUser(ctx)
+	if !ok {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "UNAUTHORIZED"})
+		return
+	}
+
+	viewID, err := parseID(ctx.Param("id"))
+	if err != nil {
+		common.WriteValidationError(ctx, err)
+		return
+	}
+
+	if err := h.service.DeleteView(ctx.Request.Context(), claims.UserID, viewID); err != nil {
+		common.WriteDomainError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (h *Handler) ListViewTasks(ctx *gin.Context) {
+	claims, ok := middleware.CurrentUser(ctx)
+	if !ok {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "UNAUTHORIZED"})
+		return
+	}
+
+	viewID, err := parseID(ctx.Param("id"))
+	if err != nil {
+		common.WriteValidationError(ctx, err)
+		return
+	}
+
+	var request dto.ViewTasksRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		common.WriteValidationError(ctx, err)
+		return
+	}
+
+	limit, offset := request.Pagination()
+	tasks, err := h.service.ListViewTasks(ctx.Request.Context(), claims.UserID, viewID, limit, offset)
+	if err != nil {
+		common.WriteDomainError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewTaskResponses(tasks))
+}
+
+func parseID(raw string) (int64, error) {
+	return strconv.ParseInt(raw, 10, 64)
+}
@@ -0,0 +1,116 @@
+package views
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"todoapp/services/task-service/internal/adapters/http/middleware"
+	"todoapp/services/task-service/internal/domain"
+	"todoapp/services/task-service/internal/domain/entities"
+	"todoapp/services/task-service/internal/ports"
+)
+
+type mockViewService struct {
+	ports.ViewService
+	createInput ports.CreateViewInput
+	limit       int
+	offset      int
+	listErr     error
+}
+
+func (m *mockViewService) CreateView(_ context.Context, input ports.CreateViewInput) (*entities.SavedView, error) {
+	m.createInput = input
+	return &entities.SavedView{ID: 1, UserID: input.UserID, Name: input.Name, Filter: input.Filter}, nil
+}
+
+func (m *mockViewService) ListViewTasks(_ context.Context, _, _ int64, limit, offset int) ([]entities.Task, error) {
+	m.limit, m.offset = limit, offset
+	if m.listErr != nil {
+		return nil, m.listErr
+	}
+	return []entities.Task{{ID: 7, Title: "Overdue"}}, nil
+}
+
+func setupTestRouter(service ports.ViewService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set(middleware.ContextUserClaimsKey, &ports.TokenClaims{UserID: 42})
+		c.Next()
+	})
+	New(service).RegisterRoutes(router)
+	return router
+}
+
+func TestCreateView(t *testing.T) {
+	service := &mockViewService{}
+	router := setupTestRouter(service)
+
+	body := `{"name":"Overdue high priority","filter":{"statuses":["pending","in_progress"],"priorities":["high"],"dueTo":"now"}}`
+	req := httptest.NewRequest(http.MethodPost, "/views", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+
+	input := service.createInput
+	if input.UserID != 42 || input.Name != "Overdue high priority" {
+		t.Fatalf("unexpected input: %+v", input)
+	}
+	if len(input.Filter.Statuses) != 2 || input.Filter.Priorities[0] != entities.TaskPriorityHigh || input.Filter.DueTo != "now" {
+		t.Fatalf("unexpected filter: %+v", input.Filter)
+	}
+	if !strings.Contains(rec.Body.String(), `"dueTo":"now"`) {
+		t.Fatalf("expected dueTo expression in response, got %s", rec.Body.String())
+	}
+}
+
+func TestCreateViewRejectsInvalidStatus(t *testing.T) {
+	router := setupTestRouter(&mockViewService{})
+
+	body := `{"name":"Bad","filter":{"statuses":["` + strings.Repeat("x", 51) + `"]}}`
+	req := httptest.NewRequest(http.MethodPost, "/views", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", rec.Code)
+	}
+}
+
+func TestListViewTasks(t *testing.T) {
+	service := &mockViewService{}
+	router := setupTestRouter(service)
+
+	req := httptest.NewRequest(http.MethodGet, "/views/3/tasks?limit=500&offset=10", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if service.limit != 100 || service.offset != 10 {
+		t.Fatalf("unexpected pagination: limit=%d offset=%d", service.limit, service.offset)
+	}
+}
+
+func TestListViewTasksNotFound(t *testing.T) {
+	router := setupTestRouter(&mockViewService{listErr: domain.ErrViewNotFound})
+
+	req := httptest.NewRequest(http.MethodGet, "/views/3/tasks", nil)
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", rec.Code)
+	}
+}
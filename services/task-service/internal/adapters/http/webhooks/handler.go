@@ -0,0 +1,193 @@
+package webhooks
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"todoapp/services/task-service/internal/adapters/http/common"
+	"todoapp/services/task-service/internal/adapters/http/middleware"
+	"todoapp/services/task-service/internal/dto"
+	"todoapp/services/task-service/internal/ports"
+)
+
+// Handler serves webhook management, the delivery log and test events.
+type Handler struct {
+	service ports.WebhookService
+}
+
+// New creates a new webhooks handler.
+func New(service ports.WebhookService) *Handler {
+	return &Handler{service: service}
+}
+
+// RegisterRoutes registers webhook routes on the given router.
+func (h *Handler) RegisterRoutes(router gin.IRoutes) {
+	router.GET("/webhooks", h.ListWebhooks)
+	router.POST("/webhooks", h.CreateWebhook)
+	router.GET("/webhooks/:id", h.GetWebhook)
+	router.PATCH("/webhooks/:id", h.UpdateWebhook)
+	router.DELETE("/webhooks/:id", h.DeleteWebhook)
+	router.GET("/webhooks/:id/deliveries", h.ListDeliveries)
+	router.POST("/webhooks/:id/test", h.SendTestEvent)
+}
+
+func (h *Handler) ListWebhooks(ctx *gin.Context) {
+	claims, ok := middleware.CurrentUser(ctx)
+	if !ok {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "UNAUTHORIZED"})
+		return
+	}
+
+	webhooks, err := h.service.ListWebhooks(ctx.Request.Context(), claims.UserID)
+	if err != nil {
+		common.WriteDomainError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewWebhookResponses(webhooks))
+}
+
+func (h *Handler) CreateWebhook(ctx *gin.Context) {
+	claims, ok := middleware.CurrentUser(ctx)
+	if !ok {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "UNAUTHORIZED"})
+		return
+	}
+
+	var request dto.CreateWebhookRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		common.WriteValidationError(ctx, err)
+		return
+	}
+
+	webhook, err := h.service.CreateWebhook(ctx.Request.Context(), request.ToInput(claims.UserID))
+	if err != nil {
+		common.WriteDomainError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dto.NewCreateWebhookResponse(*webhook))
+}
+
+func (h *Handler) GetWebhook(ctx *gin.Context) {
+	claims, ok := middleware.CurrentUser(ctx)
+	if !ok {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "UNAUTHORIZED"})
+		return
+	}
+
+	webhookID, err := parseID(ctx.Param("id"))
+	if err != nil {
+		common.WriteValidationError(ctx, err)
+		return
+	}
+
+	webhook, err := h.service.GetWebhook(ctx.Request.Context(), claims.UserID, webhookID)
+	if err != nil {
+		common.WriteDomainError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewWebhookResponse(*webhook))
+}
+
+func (h *Handler) UpdateWebhook(ctx *gin.Context) {
+	claims, ok := middleware.CurrentUser(ctx)
+	if !ok {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "UNAUTHORIZED"})
+		return
+	}
+
+	webhookID, err := parseID(ctx.Param("id"))
+	if err != nil {
+		common.WriteValidationError(ctx, err)
+		return
+	}
+
+	var request dto.UpdateWebhookRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		common.WriteValidationError(ctx, err)
+		return
+	}
+
+	webhook, err := h.service.UpdateWebhook(ctx.Request.Context(), request.ToInput(claims.UserID, webhookID))
+	if err != nil {
+		common.WriteDomainError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewWebhookResponse(*webhook))
+}
+
+func (h *Handler) DeleteWebhook(ctx *gin.Context) {
+	claims, ok := middleware.CurrentUser(ctx)
+	if !ok {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "UNAUTHORIZED"})
+		return
+	}
+
+	webhookID, err := parseID(ctx.Param("id"))
+	if err != nil {
+		common.WriteValidationError(ctx, err)
+		return
+	}
+
+	if err := h.service.DeleteWebhook(ctx.Request.Context(), claims.UserID, webhookID); err != nil {
+		common.WriteDomainError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (h *Handler) ListDeliveries(ctx *gin.Context) {
+	claims, ok := middleware.CurrentUser(ctx)
+	if !ok {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "UNAUTHORIZED"})
+		return
+	}
+
+	webhookID, err := parseID(ctx.Param("id"))
+	if err != nil {
+		common.WriteValidationError(ctx, err)
+		return
+	}
+
+	deliveries, err := h.service.ListWebhookDeliveries(ctx.Request.Context(), claims.UserID, webhookID)
+	if err != nil {
+		common.WriteDomainError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewWebhookDeliveryResponses(deliveries))
+}
+
+// SendTestEvent answers 200 with the recorded delivery even when the endpoint
+// rejected the event; the delivery status tells the outcome.
+func (h *Handler) SendTestEvent(ctx *gin.Context) {
+	claims, ok := middleware.CurrentUser(ctx)
+	if !ok {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "UNAUTHORIZED"})
+		return
+	}
+
+	webhookID, err := parseID(ctx.Param("id"))
+	if err != nil {
+		common.WriteValidationError(ctx, err)
+		return
+	}
+
+	delivery, err := h.service.SendTestEvent(ctx.Request.Context(), claims.UserID, webhookID)
+	if err != nil {
+		common.WriteDomainError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewWebhookDeliveryResponse(*delivery))
+}
+
+func parseID(raw string) (int64, error) {
+	return strconv.ParseInt(raw, 10, 64)
+}
@@ -0,0 +1,173 @@
+package webhooks
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"todoapp/pkg/events"
+	"todoapp/services/task-service/internal/adapters/http/middleware"
+	"todoapp/services/task-service/internal/domain"
+	"todoapp/services/task-service/internal/domain/entities"
+	"todoapp/services/task-service/internal/ports"
+)
+
+type mockWebhookService struct {
+	ports.WebhookService
+	createInput ports.CreateWebhookInput
+	updateInput ports.UpdateWebhookInput
+	err         error
+}
+
+func (m *mockWebhookService) CreateWebhook(_ context.Context, input ports.CreateWebhookInput) (*entities.Webhook, error) {
+	m.createInput = input
+	return &entities.Webhook{ID: 1, UserID: input.UserID, URL: input.URL, Secret: "topsecret", EventTypes: input.EventTypes, Active: true}, nil
+}
+
+func (m *mockWebhookService) GetWebhook(_ context.Context, _, _ int64) (*entities.Webhook, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &entities.Webhook{ID: 1, URL: "https://example.com", Secret: "topsecret", Active: true}, nil
+}
+
+func (m *mockWebhookService) UpdateWebhook(_ context.Context, input ports.UpdateWebhookInput) (*entities.Webhook, error) {
+	m.updateInput = input
+	return &entities.Webhook{ID: input.WebhookID, URL: "https://example.com", Active: true}, nil
+}
+
+func (m *mockWebhookService) SendTestEvent(_ context.Context, _, _ int64) (*entities.WebhookDelivery, error) {
+	code := 200
+	return &entities.WebhookDelivery{
+		ID:           5,
+		EventType:    entities.WebhookTestEvent,
+		Status:       entities.WebhookDeliverySucceeded,
+		Attempts:     1,
+		ResponseCode: &code,
+		Payload:      []byte(`{"type":"webhook.test"}`),
+	}, nil
+}
+
+func setupTestRouter(service ports.WebhookService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set(middleware.ContextUserClaimsKey, &ports.TokenClaims{UserID: 42})
+		c.Next()
+	})
+	New(service).RegisterRoutes(router)
+	return router
+}
+
+func TestCreateWebhook_ReturnsSecret(t *testing.T) {
+	service := &mockWebhookService{}
+	router := setupTestRouter(service)
+
+	body := `{"url":"https://example.com/hooks","eventTypes":["task.created","task.commented"]}`
+	req := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if service.createInput.UserID != 42 || len(service.createInput.EventTypes) != 2 || service.createInput.EventTypes[1] != events.TaskEventCommented {
+		t.Fatalf("unexpected input: %+v", service.createInput)
+	}
+	if !strings.Contains(rec.Body.String(), `"secret":"topsecret"`) {
+		t.Fatalf("expected the secret in the response, got %s", rec.Body.String())
+	}
+}
+
+func TestCreateWebhook_RejectsUnknownEvent(t *testing.T) {
+	router := setupTestRouter(&mockWebhookService{})
+
+	body := `{"url":"https://example.com/hooks","eventTypes":["task.overdue"]}`
+	req := httptest.NewRequest(http.MethodPost, "/webhooks", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d: %s", rec.Code, rec.Body.String())
+	}
+}
+
+func TestGetWebhook_HidesSecret(t *testing.T) {
+	router := setupTestRouter(&mockWebhookService{})
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/webhooks/1", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if strings.Contains(rec.Body.String(), "topsecret") {
+		t.Fatalf("expected no secret in the response, got %s", rec.Body.String())
+	}
+}
+
+func TestGetWebhook_NotFound(t *testing.T) {
+	router := setupTestRouter(&mockWebhookService{err: domain.ErrWebhookNotFound})
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/webhooks/9", nil))
+
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if !strings.Contains(rec.Body.String(), "WEBHOOK_NOT_FOUND") {
+		t.Fatalf("expected WEBHOOK_NOT_FOUND, got %s", rec.Body.String())
+	}
+}
+
+func TestUpdateWebhook_DistinguishesOmittedEventTypes(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantNil bool
+	}{
+		{name: "omitted", body: `{"active":true}`, wantNil: true},
+		{name: "empty", body: `{"eventTypes":[]}`, wantNil: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &mockWebhookService{}
+			router := setupTestRouter(service)
+
+			req := httptest.NewRequest(http.MethodPatch, "/webhooks/1", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+			}
+			if (service.updateInput.EventTypes == nil) != tt.wantNil {
+				t.Fatalf("unexpected event types: %#v", service.updateInput.EventTypes)
+			}
+		})
+	}
+}
+
+func TestSendTestEvent(t *testing.T) {
+	router := setupTestRouter(&mockWebhookService{})
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/webhooks/1/test", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	for _, want := range []string{`"status":"succeeded"`, `"responseCode":200`, `"payload":{"type":"webhook.test"}`} {
+		if !strings.Contains(rec.Body.String(), want) {
+			t.Fatalf("expected %s in %s", want, rec.Body.String())
+		}
+	}
+}
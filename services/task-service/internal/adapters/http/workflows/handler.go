@@ -0,0 +1,170 @@
+package workflows
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"todoapp/services/task-service/internal/adapters/http/common"
+	"todoapp/services/task-service/internal/adapters/http/middleware"
+	"todoapp/services/task-service/internal/dto"
+	"todoapp/services/task-service/internal/ports"
+)
+
+// Handler serves status workflow CRUD.
+type Handler struct {
+	service ports.WorkflowService
+}
+
+// New creates a new workflows handler.
+func New(service ports.WorkflowService) *Handler {
+	return &Handler{service: service}
+}
+
+// RegisterRoutes registers workflow routes on the given router.
+func (h *Handler) RegisterRoutes(router gin.IRoutes) {
+	router.GET("/workflows", h.ListWorkflows)
+	router.POST("/workflows", h.CreateWorkflow)
+	router.GET("/workflows/effective", h.EffectiveWorkflow)
+	router.GET("/workflows/:id", h.GetWorkflow)
+	router.PUT("/workflows/:id", h.UpdateWorkflow)
+	router.DELETE("/workflows/:id", h.DeleteWorkflow)
+}
+
+func (h *Handler) ListWorkflows(ctx *gin.Context) {
+	claims, ok := middleware.CurrentUser(ctx)
+	if !ok {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "UNAUTHORIZED"})
+		return
+	}
+
+	workflows, err := h.service.ListWorkflows(ctx.Request.Context(), claims.UserID)
+	if err != nil {
+		common.WriteDomainError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewWorkflowResponses(workflows))
+}
+
+func (h *Handler) CreateWorkflow(ctx *gin.Context) {
+	claims, ok := middleware.CurrentUser(ctx)
+	if !ok {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "UNAUTHORIZED"})
+		return
+	}
+
+	var request dto.WorkflowRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		common.WriteValidationError(ctx, err)
+		return
+	}
+
+	workflow, err := h.service.CreateWorkflow(ctx.Request.Context(), request.ToCreateInput(claims.UserID))
+	if err != nil {
+		common.WriteDomainError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, dto.NewWorkflowResponse(*workflow))
+}
+
+// EffectiveWorkflow returns the workflow that applies to tasks in the given
+// category, or to uncategorized tasks when no category is given.
+func (h *Handler) EffectiveWorkflow(ctx *gin.Context) {
+	claims, ok := middleware.CurrentUser(ctx)
+	if !ok {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "UNAUTHORIZED"})
+		return
+	}
+
+	var request dto.EffectiveWorkflowRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		common.WriteValidationError(ctx, err)
+		return
+	}
+
+	workflow, err := h.service.EffectiveWorkflow(ctx.Request.Context(), claims.UserID, request.CategoryID)
+	if err != nil {
+		common.WriteDomainError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewWorkflowResponse(*workflow))
+}
+
+func (h *Handler) GetWorkflow(ctx *gin.Context) {
+	claims, ok := middleware.CurrentUser(ctx)
+	if !ok {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "UNAUTHORIZED"})
+		return
+	}
+
+	workflowID, err := parseID(ctx.Param("id"))
+	if err != nil {
+		common.WriteValidationError(ctx, err)
+		return
+	}
+
+	workflow, err := h.service.GetWorkflow(ctx.Request.Context(), claims.UserID, workflowID)
+	if err != nil {
+		common.WriteDomainError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewWorkflowResponse(*workflow))
+}
+
+func (h *Handler) UpdateWorkflow(ctx *gin.Context) {
+	claims, ok := middleware.CurrentUser(ctx)
+	if !ok {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "UNAUTHORIZED"})
+		return
+	}
+
+	workflowID, err := parseID(ctx.Param("id"))
+	if err != nil {
+		common.WriteValidationError(ctx, err)
+		return
+	}
+
+	var request dto.WorkflowRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		common.WriteValidationError(ctx, err)
+		return
+	}
+
+	workflow, err := h.service.UpdateWorkflow(ctx.Request.Context(), request.ToUpdateInput(claims.UserID, workflowID))
+	if err != nil {
+		common.WriteDomainError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.NewWorkflowResponse(*workflow))
+}
+
+func (h *Handler) DeleteWorkflow(ctx *gin.Context) {
+	claims, ok := middleware.CurrentUser(ctx)
+	if !ok {
+		ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "UNAUTHORIZED"})
+		return
+	}
+
+	workflowID, err := parseID(ctx.Param("id"))
+	if err != nil {
+		common.WriteValidationError(ctx, err)
+		return
+	}
+
+	if err := h.service.DeleteWorkflow(ctx.Request.Context(), claims.UserID, workflowID); err != nil {
+		common.WriteDomainError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func parseID(raw string) (int64, error) {
+	return strconv.ParseInt(raw, 10, 64)
+}
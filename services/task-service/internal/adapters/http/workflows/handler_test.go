@@ -0,0 +1,121 @@
+package workflows
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"todoapp/services/task-service/internal/adapters/http/middleware"
+	"todoapp/services/task-service/internal/domain"
+	"todoapp/services/task-service/internal/domain/entities"
+	"todoapp/services/task-service/internal/ports"
+)
+
+type mockWorkflowService struct {
+	ports.WorkflowService
+	createInput ports.CreateWorkflowInput
+	createErr   error
+	categoryID  *int64
+}
+
+func (m *mockWorkflowService) CreateWorkflow(_ context.Context, input ports.CreateWorkflowInput) (*entities.Workflow, error) {
+	m.createInput = input
+	if m.createErr != nil {
+		return nil, m.createErr
+	}
+	return &entities.Workflow{ID: 1, UserID: input.UserID, Name: input.Name, Statuses: input.Statuses, Transitions: input.Transitions}, nil
+}
+
+func (m *mockWorkflowService) EffectiveWorkflow(_ context.Context, _ int64, categoryID *int64) (*entities.Workflow, error) {
+	m.categoryID = categoryID
+	return entities.DefaultWorkflow(), nil
+}
+
+func setupTestRouter(service ports.WorkflowService) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set(middleware.ContextUserClaimsKey, &ports.TokenClaims{UserID: 42})
+		c.Next()
+	})
+	New(service).RegisterRoutes(router)
+	return router
+}
+
+func TestCreateWorkflow(t *testing.T) {
+	tests := []struct {
+		name   string
+		body   string
+		err    error
+		status int
+	}{
+		{
+			name:   "valid",
+			body:   `{"name":"Review","statuses":[{"key":"Todo"},{"key":"review"},{"key":"done","completed":true}],"transitions":[{"from":"todo","to":"review"}]}`,
+			status: http.StatusCreated,
+		},
+		{name: "missing statuses", body: `{"name":"Review"}`, status: http.StatusBadRequest},
+		{
+			name:   "scope taken",
+			body:   `{"name":"Review","statuses":[{"key":"todo"},{"key":"done","completed":true}]}`,
+			err:    domain.ErrWorkflowScopeTaken,
+			status: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &mockWorkflowService{createErr: tt.err}
+			router := setupTestRouter(service)
+
+			req := httptest.NewRequest(http.MethodPost, "/workflows", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			if rec.Code != tt.status {
+				t.Fatalf("expected %d, got %d: %s", tt.status, rec.Code, rec.Body.String())
+			}
+			if tt.status == http.StatusCreated {
+				input := service.createInput
+				if input.UserID != 42 || len(input.Statuses) != 3 || input.Statuses[0].Key != "todo" || len(input.Transitions) != 1 {
+					t.Fatalf("unexpected input: %+v", input)
+				}
+			}
+		})
+	}
+}
+
+func TestEffectiveWorkflow(t *testing.T) {
+	service := &mockWorkflowService{}
+	router := setupTestRouter(service)
+
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/workflows/effective?categoryId=7", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rec.Code, rec.Body.String())
+	}
+	if service.categoryID == nil || *service.categoryID != 7 {
+		t.Fatalf("unexpected category: %v", service.categoryID)
+	}
+
+	var response struct {
+		BuiltIn   bool    `json:"builtIn"`
+		CreatedAt *string `json:"createdAt"`
+		Statuses  []struct {
+			Key string `json:"key"`
+		} `json:"statuses"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if !response.BuiltIn || response.CreatedAt != nil || len(response.Statuses) != 4 {
+		t.Fatalf("unexpected response: %s", rec.Body.String())
+	}
+}
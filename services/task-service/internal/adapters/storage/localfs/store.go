@@ -0,0 +1,122 @@
+package localfs
+
+import (
+	"context"
+	stderrors "errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"todoapp/services/task-service/internal/domain"
+	"todoapp/services/task-service/internal/ports"
+)
+
+// Store keeps blobs as plain files below a root directory.
+type Store struct {
+	root string
+}
+
+var _ ports.BlobStore = (*Store)(nil)
+
+func New(root string) (*Store, error) {
+	if root == "" {
+		return nil, stderrors.New("localfs: root directory is required")
+	}
+
+	if err := os.MkdirAll(root, 0o750); err != nil {
+		return nil, err
+	}
+
+	return &Store{root: root}, nil
+}
+
+// Put writes the blob to a temporary file first and renames it into place, so
+// readers never observe a partially written object.
+func (s *Store) Put(ctx context.Context, key string, body io.Reader, size int64, _ string) error {
+	path, err := s.resolve(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	written, err := io.Copy(tmp, contextReader{ctx: ctx, r: body})
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+
+	if size >= 0 && written != size {
+		return fmt.Errorf("localfs: short write for %s: %d of %d bytes", key, written, size)
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+func (s *Store) Get(_ context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.resolve(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		if stderrors.Is(err, fs.ErrNotExist) {
+			return nil, domain.ErrAttachmentNotFound
+		}
+		return nil, err
+	}
+
+	return file, nil
+}
+
+func (s *Store) Delete(_ context.Context, key string) error {
+	path, err := s.resolve(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !stderrors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+// resolve maps a key onto a path inside the root and rejects keys that would
+// escape it.
+func (s *Store) resolve(key string) (string, error) {
+	cleaned := filepath.Clean(filepath.FromSlash(key))
+	if key == "" || filepath.IsAbs(cleaned) || cleaned == "." || cleaned == ".." ||
+		strings.HasPrefix(cleaned, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("localfs: invalid key %q", key)
+	}
+
+	return filepath.Join(s.root, cleaned), nil
+}
+
+// contextReader stops copying once the request context is cancelled.
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (c contextReader) Read(p []byte) (int, error) {
+	if err := c.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return c.r.Read(p)
+}
@@ -0,0 +1,64 @@
+package localfs
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"todoapp/services/task-service/internal/domain"
+)
+
+func TestStoreRoundTrip(t *testing.T) {
+	store, err := New(t.TempDir())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ctx := context.Background()
+
+	if err := store.Put(ctx, "users/1/tasks/2/blob", strings.NewReader("hello"), 5, "text/plain"); err != nil {
+		t.Fatalf("put failed: %v", err)
+	}
+
+	body, err := store.Get(ctx, "users/1/tasks/2/blob")
+	if err != nil {
+		t.Fatalf("get failed: %v", err)
+	}
+	data, _ := io.ReadAll(body)
+	body.Close()
+	if string(data) != "hello" {
+		t.Fatalf("unexpected content %q", data)
+	}
+
+	if err := store.Delete(ctx, "users/1/tasks/2/blob"); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+	if err := store.Delete(ctx, "users/1/tasks/2/blob"); err != nil {
+		t.Fatalf("deleting a missing blob must be a no-op: %v", err)
+	}
+	if _, err := store.Get(ctx, "users/1/tasks/2/blob"); !errors.Is(err, domain.ErrAttachmentNotFound) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+}
+
+func TestStoreRejectsShortWrite(t *testing.T) {
+	store, _ := New(t.TempDir())
+
+	if err := store.Put(context.Background(), "blob", strings.NewReader("abc"), 10, ""); err == nil {
+		t.Fatalf("expected short write error")
+	}
+	if _, err := store.Get(context.Background(), "blob"); !errors.Is(err, domain.ErrAttachmentNotFound) {
+		t.Fatalf("partial blob must not be visible, got %v", err)
+	}
+}
+
+func TestStoreRejectsEscapingKeys(t *testing.T) {
+	store, _ := New(t.TempDir())
+
+	for _, key := range []string{"", "..", "../outside", "/etc/passwd", "a/../../b"} {
+		if err := store.Put(context.Background(), key, strings.NewReader("x"), 1, ""); err == nil {
+			t.Fatalf("expected key %q to be rejected", key)
+		}
+	}
+}
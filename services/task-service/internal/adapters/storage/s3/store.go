@@ -0,0 +1,108 @@
+package s3
+
+import (
+	"context"
+	stderrors "errors"
+	"io"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+
+	"todoapp/services/task-service/internal/domain"
+	"todoapp/services/task-service/internal/ports"
+)
+
+// Config describes an S3-compatible endpoint such as AWS S3 or MinIO.
+type Config struct {
+	Endpoint  string
+	AccessKey string
+	SecretKey string
+	Bucket    string
+	Region    string
+	UseSSL    bool
+}
+
+// Store keeps blobs as objects in a single bucket.
+type Store struct {
+	client *minio.Client
+	bucket string
+	region string
+}
+
+var _ ports.BlobStore = (*Store)(nil)
+
+func New(cfg Config) (*Store, error) {
+	switch {
+	case cfg.Endpoint == "":
+		return nil, stderrors.New("s3: endpoint is required")
+	case cfg.Bucket == "":
+		return nil, stderrors.New("s3: bucket is required")
+	}
+	if cfg.Region == "" {
+		cfg.Region = "us-east-1"
+	}
+
+	// Path-style addressing works with every S3-compatible server, and a fixed
+	// region avoids a GetBucketLocation round trip before each request.
+	client, err := minio.New(cfg.Endpoint, &minio.Options{
+		Creds:        credentials.NewStaticV4(cfg.AccessKey, cfg.SecretKey, ""),
+		Secure:       cfg.UseSSL,
+		Region:       cfg.Region,
+		BucketLookup: minio.BucketLookupPath,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Store{client: client, bucket: cfg.Bucket, region: cfg.Region}, nil
+}
+
+// EnsureBucket creates the bucket when it does not exist yet.
+func (s *Store) EnsureBucket(ctx context.Context) error {
+	exists, err := s.client.BucketExists(ctx, s.bucket)
+	if err != nil {
+		return err
+	}
+	if exists {
+		return nil
+	}
+	return s.client.MakeBucket(ctx, s.bucket, minio.MakeBucketOptions{Region: s.region})
+}
+
+func (s *Store) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	_, err := s.client.PutObject(ctx, s.bucket, key, body, size, minio.PutObjectOptions{
+		ContentType: contentType,
+	})
+	return err
+}
+
+func (s *Store) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	object, err := s.client.GetObject(ctx, s.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, translateError(err)
+	}
+
+	// GetObject is lazy; Stat issues the request so a missing key is reported
+	// here instead of on the first Read.
+	if _, err := object.Stat(); err != nil {
+		_ = object.Close()
+		return nil, translateError(err)
+	}
+
+	return object, nil
+}
+
+func (s *Store) Delete(ctx context.Context, key string) error {
+	err := s.client.RemoveObject(ctx, s.bucket, key, minio.RemoveObjectOptions{})
+	if err != nil && minio.ToErrorResponse(err).Code == minio.NoSuchKey {
+		return nil
+	}
+	return err
+}
+
+func translateError(err error) error {
+	if minio.ToErrorResponse(err).Code == minio.NoSuchKey {
+		return domain.ErrAttachmentNotFound
+	}
+	return err
+}
@@ -0,0 +1,169 @@
+package s3
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"todoapp/services/task-service/internal/domain"
+)
+
+// fakeS3 is a minimal in-memory stand-in for a MinIO server. It understands
+// path-style bucket and object requests and ignores request signatures.
+type fakeS3 struct {
+	mu      sync.Mutex
+	buckets map[string]bool
+	objects map[string][]byte
+}
+
+func newFakeS3() *fakeS3 {
+	return &fakeS3{buckets: map[string]bool{}, objects: map[string][]byte{}}
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	path := strings.TrimPrefix(r.URL.Path, "/")
+	bucket, key, _ := strings.Cut(path, "/")
+
+	if key == "" {
+		switch r.Method {
+		case http.MethodHead:
+			if !f.buckets[bucket] {
+				writeS3Error(w, http.StatusNotFound, "NoSuchBucket")
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		case http.MethodPut:
+			f.buckets[bucket] = true
+			w.WriteHeader(http.StatusOK)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		data, _ := io.ReadAll(r.Body)
+		if strings.HasPrefix(r.Header.Get("X-Amz-Content-Sha256"), "STREAMING-") {
+			data = decodeAWSChunked(data)
+		}
+		f.objects[path] = data
+		w.Header().Set("ETag", `"d41d8cd98f00b204e9800998ecf8427e"`)
+		w.WriteHeader(http.StatusOK)
+	case http.MethodGet, http.MethodHead:
+		data, ok := f.objects[path]
+		if !ok {
+			writeS3Error(w, http.StatusNotFound, "NoSuchKey")
+			return
+		}
+		w.Header().Set("ETag", `"d41d8cd98f00b204e9800998ecf8427e"`)
+		w.Header().Set("Last-Modified", "Mon, 02 Jan 2006 15:04:05 GMT")
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.WriteHeader(http.StatusOK)
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(data)
+		}
+	case http.MethodDelete:
+		delete(f.objects, path)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// decodeAWSChunked strips the "size;chunk-signature=..." framing that SigV4
+// streaming uploads use over plain HTTP.
+func decodeAWSChunked(body []byte) []byte {
+	var out []byte
+	rest := string(body)
+	for {
+		header, tail, ok := strings.Cut(rest, "\r\n")
+		if !ok {
+			return out
+		}
+		sizeHex, _, _ := strings.Cut(header, ";")
+		size, err := strconv.ParseInt(sizeHex, 16, 64)
+		if err != nil || size == 0 || int64(len(tail)) < size {
+			return out
+		}
+		out = append(out, tail[:size]...)
+		rest = strings.TrimPrefix(tail[size:], "\r\n")
+	}
+}
+
+func writeS3Error(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	_, _ = io.WriteString(w, `<?xml version="1.0" encoding="UTF-8"?><Error><Code>`+code+`</Code><Message>`+code+`</Message></Error>`)
+}
+
+func newTestStore(t *testing.T) (*Store, *fakeS3) {
+	t.Helper()
+
+	fake := newFakeS3()
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	store, err := New(Config{
+		Endpoint:  strings.TrimPrefix(server.URL, "http://"),
+		AccessKey: "minio",
+		SecretKey: "minio123",
+		Bucket:    "attachments",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return store, fake
+}
+
+func TestNewValidatesConfig(t *testing.T) {
+	if _, err := New(Config{Bucket: "b"}); err == nil {
+		t.Fatalf("expected error for empty endpoint")
+	}
+	if _, err := New(Config{Endpoint: "localhost:9000"}); err == nil {
+		t.Fatalf("expected error for empty bucket")
+	}
+}
+
+func TestStoreRoundTrip(t *testing.T) {
+	store, fake := newTestStore(t)
+	ctx := context.Background()
+
+	if err := store.EnsureBucket(ctx); err != nil {
+		t.Fatalf("ensure bucket failed: %v", err)
+	}
+	if !fake.buckets["attachments"] {
+		t.Fatalf("bucket was not created")
+	}
+
+	if err := store.Put(ctx, "users/1/tasks/2/blob", strings.NewReader("hello"), 5, "text/plain"); err != nil {
+		t.Fatalf("put failed: %v", err)
+	}
+
+	body, err := store.Get(ctx, "users/1/tasks/2/blob")
+	if err != nil {
+		t.Fatalf("get failed: %v", err)
+	}
+	data, _ := io.ReadAll(body)
+	body.Close()
+	if string(data) != "hello" {
+		t.Fatalf("unexpected content %q", data)
+	}
+
+	if err := store.Delete(ctx, "users/1/tasks/2/blob"); err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+	if _, err := store.Get(ctx, "users/1/tasks/2/blob"); !errors.Is(err, domain.ErrAttachmentNotFound) {
+		t.Fatalf("expected not found, got %v", err)
+	}
+}
@@ -0,0 +1,127 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"todoapp/services/task-service/internal/ports"
+)
+
+const (
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Webhook-Signature"
+
+	userAgent = "todoapp-webhooks/1.0"
+	// maxResponseBody is read and discarded so connections can be reused.
+	maxResponseBody = 64 << 10
+)
+
+var errPrivateAddress = errors.New("webhook: destination address is not allowed")
+
+// Config controls outgoing webhook requests.
+type Config struct {
+	// Timeout bounds a whole request; defaults to 10 seconds.
+	Timeout time.Duration
+	// AllowPrivateNetworks permits loopback, private and link-local
+	// destinations. Leave it off in production so user URLs cannot reach
+	// internal services.
+	AllowPrivateNetworks bool
+}
+
+// Sender posts signed webhook requests.
+type Sender struct {
+	client *http.Client
+}
+
+var _ ports.WebhookSender = (*Sender)(nil)
+
+func NewSender(cfg Config) *Sender {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 10 * time.Second
+	}
+
+	dialer := &net.Dialer{Timeout: 5 * time.Second}
+	if !cfg.AllowPrivateNetworks {
+		// Checking the resolved address at dial time also covers DNS names
+		// that point at internal hosts.
+		dialer.Control = func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip := net.ParseIP(host)
+			if ip == nil || !publicIP(ip) {
+				return errPrivateAddress
+			}
+			return nil
+		}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &Sender{
+		client: &http.Client{
+			Timeout:   cfg.Timeout,
+			Transport: transport,
+			// Redirects are not followed; a 3xx counts as a failed delivery.
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+// Send posts the payload and returns the response status. The error is only
+// set when no response was received.
+func (s *Sender) Send(ctx context.Context, req ports.WebhookRequest) (int, error) {
+	timestamp := strconv.FormatInt(req.Timestamp.Unix(), 10)
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, req.URL, bytes.NewReader(req.Payload))
+	if err != nil {
+		return 0, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("User-Agent", userAgent)
+	httpReq.Header.Set(HeaderEvent, string(req.EventType))
+	httpReq.Header.Set(HeaderDelivery, strconv.FormatInt(req.DeliveryID, 10))
+	httpReq.Header.Set(HeaderTimestamp, timestamp)
+	httpReq.Header.Set(HeaderSignature, Sign(req.Secret, timestamp, req.Payload))
+
+	resp, err := s.client.Do(httpReq)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseBody))
+
+	return resp.StatusCode, nil
+}
+
+// Sign returns the signature header value: "sha256=" followed by the hex
+// HMAC-SHA256 of "<timestamp>.<body>" keyed with the webhook secret.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%s.", timestamp)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func publicIP(ip net.IP) bool {
+	return ip.IsGlobalUnicast() && !ip.IsPrivate() && !ip.IsLoopback() && !ip.IsLinkLocalUnicast()
+}
@@ -0,0 +1,83 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"todoapp/pkg/events"
+	"todoapp/services/task-service/internal/ports"
+)
+
+func TestSend_SignsRequest(t *testing.T) {
+	var (
+		got  *http.Request
+		body []byte
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	sender := NewSender(Config{AllowPrivateNetworks: true})
+	payload := []byte(`{"type":"task.created"}`)
+
+	code, err := sender.Send(context.Background(), ports.WebhookRequest{
+		URL:        server.URL,
+		Secret:     "secret",
+		DeliveryID: 12,
+		EventType:  events.TaskEventCreated,
+		Payload:    payload,
+		Timestamp:  time.Unix(1700000000, 0),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if code != http.StatusAccepted {
+		t.Fatalf("expected 202, got %d", code)
+	}
+
+	if string(body) != string(payload) {
+		t.Fatalf("unexpected body: %s", body)
+	}
+	if got.Header.Get(HeaderEvent) != "task.created" || got.Header.Get(HeaderDelivery) != "12" || got.Header.Get(HeaderTimestamp) != "1700000000" {
+		t.Fatalf("unexpected headers: %v", got.Header)
+	}
+	// echo -n '1700000000.{"type":"task.created"}' | openssl dgst -sha256 -hmac secret
+	want := "sha256=3f03c0feadca8fbc611c9ad7f148175250d1f68785efde3800bb8099223bcff9"
+	if got.Header.Get(HeaderSignature) != want {
+		t.Fatalf("expected signature %s, got %s", want, got.Header.Get(HeaderSignature))
+	}
+}
+
+func TestSend_DoesNotFollowRedirects(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/elsewhere", http.StatusFound)
+	}))
+	defer server.Close()
+
+	code, err := NewSender(Config{AllowPrivateNetworks: true}).Send(context.Background(), ports.WebhookRequest{URL: server.URL})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if code != http.StatusFound {
+		t.Fatalf("expected 302, got %d", code)
+	}
+}
+
+func TestSend_RejectsPrivateNetworks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("request should not reach a loopback server")
+	}))
+	defer server.Close()
+
+	_, err := NewSender(Config{}).Send(context.Background(), ports.WebhookRequest{URL: server.URL})
+	if err == nil {
+		t.Fatal("expected loopback destination to be rejected")
+	}
+}
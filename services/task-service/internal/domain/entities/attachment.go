@@ -0,0 +1,16 @@
+package entities
+
+import "time"
+
+// Attachment describes a file uploaded to a task. The binary content lives in
+// a blob store under StorageKey; only metadata is kept in the database.
+type Attachment struct {
+	ID          int64
+	TaskID      int64
+	UserID      int64
+	FileName    string
+	ContentType string
+	SizeBytes   int64
+	StorageKey  string
+	CreatedAt   time.Time
+}
@@ -0,0 +1,29 @@
+package entities
+
+import "time"
+
+type ChangeType string
+
+const (
+	ChangeTaskCreated     ChangeType = "task.created"
+	ChangeTaskUpdated     ChangeType = "task.updated"
+	ChangeTaskDeleted     ChangeType = "task.deleted"
+	ChangeCategoryCreated ChangeType = "category.created"
+	ChangeCategoryUpdated ChangeType = "category.updated"
+	ChangeCategoryDeleted ChangeType = "category.deleted"
+	ChangeCommentCreated  ChangeType = "comment.created"
+)
+
+// ChangeEvent tells the clients of a user that one of their objects changed.
+// Exactly one of Task, Category and Comment is set; deletions only carry the
+// ID. ID is assigned when the event is recorded and identifies it in the
+// stream.
+type ChangeEvent struct {
+	ID        int64
+	UserID    int64
+	Type      ChangeType
+	Task      *Task
+	Category  *Category
+	Comment   *TaskComment
+	CreatedAt time.Time
+}
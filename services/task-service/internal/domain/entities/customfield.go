@@ -0,0 +1,53 @@
+package entities
+
+import "time"
+
+type CustomFieldType string
+
+const (
+	CustomFieldText        CustomFieldType = "text"
+	CustomFieldNumber      CustomFieldType = "number"
+	CustomFieldDate        CustomFieldType = "date"
+	CustomFieldSelect      CustomFieldType = "select"
+	CustomFieldMultiSelect CustomFieldType = "multi_select"
+	CustomFieldCheckbox    CustomFieldType = "checkbox"
+	CustomFieldURL         CustomFieldType = "url"
+)
+
+// CustomFieldDateLayout is the stored form of date values.
+const CustomFieldDateLayout = "2006-01-02"
+
+// IsValid checks if the field type is supported.
+func (t CustomFieldType) IsValid() bool {
+	switch t {
+	case CustomFieldText, CustomFieldNumber, CustomFieldDate, CustomFieldSelect,
+		CustomFieldMultiSelect, CustomFieldCheckbox, CustomFieldURL:
+		return true
+	default:
+		return false
+	}
+}
+
+// HasOptions reports whether values must be picked from the field's options.
+func (t CustomFieldType) HasOptions() bool {
+	return t == CustomFieldSelect || t == CustomFieldMultiSelect
+}
+
+// CustomField defines an extra task attribute. Key and Type are fixed once
+// the field exists; values are stored on tasks under Key.
+type CustomField struct {
+	ID     int64
+	UserID int64
+	Key    string
+	Name   string
+	Type   CustomFieldType
+	// Options lists the allowed values of select and multi_select fields.
+	Options   []string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// CustomFieldValues maps field keys to values in their JSON form: float64 for
+// numbers, bool for checkboxes, a list of strings for multi_select and a
+// string otherwise (dates use CustomFieldDateLayout).
+type CustomFieldValues map[string]any
@@ -29,18 +29,79 @@ type Task struct {
 	DueDate     *time.Time
 	CategoryID  *int64
 	Category    *Category
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
-	DeletedAt   *time.Time
-	Comments    []TaskComment
+	// EstimateMinutes is the expected effort, nil when not estimated.
+	EstimateMinutes *int
+	// TrackedTime is the sum of finished time entries; it is read-only.
+	TrackedTime time.Duration
+	// Position orders tasks within their status column. Values are sparse so
+	// a task can be moved between two others by updating only its own row.
+	Position float64
+	// CompletedAt is set while the task is in a completed status of its
+	// workflow.
+	CompletedAt *time.Time
+	// CustomFields holds the values of the user's custom fields by key.
+	CustomFields CustomFieldValues
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	DeletedAt    *time.Time
+	Comments     []TaskComment
 }
 
+// TaskPositionStep is the gap between neighbouring tasks after a task is
+// appended to a column or a column is rebalanced.
+const TaskPositionStep = 1024
+
+// BoardColumn holds the tasks of one status in position order.
+type BoardColumn struct {
+	Status TaskStatus
+	Tasks  []Task
+}
+
+// DefaultCategoryColor matches the column default of task_service.categories.color.
+const DefaultCategoryColor = "#3498db"
+
 type Category struct {
 	ID        int64
 	UserID    int64
+	ParentID  *int64
 	Name      string
+	Color     string
+	Icon      string
+	Position  int
 	CreatedAt time.Time
 	UpdatedAt time.Time
+
+	// OpenTasks and CompletedTasks are only populated by category listings.
+	OpenTasks      int
+	CompletedTasks int
+
+	// Children is only populated when categories are returned as a tree.
+	Children []Category
+}
+
+// CategoryDeleteMode defines what happens to subcategories when a parent
+// category is deleted.
+type CategoryDeleteMode string
+
+const (
+	// CategoryDeleteRefuse rejects the deletion while subcategories exist.
+	CategoryDeleteRefuse CategoryDeleteMode = "refuse"
+	// CategoryDeleteReparent moves subcategories and tasks to the deleted
+	// category's parent (or to the top level).
+	CategoryDeleteReparent CategoryDeleteMode = "reparent"
+	// CategoryDeleteCascade deletes the whole subtree; its tasks become
+	// uncategorized.
+	CategoryDeleteCascade CategoryDeleteMode = "cascade"
+)
+
+// IsValid checks if the delete mode is supported.
+func (m CategoryDeleteMode) IsValid() bool {
+	switch m {
+	case CategoryDeleteRefuse, CategoryDeleteReparent, CategoryDeleteCascade:
+		return true
+	default:
+		return false
+	}
 }
 
 type TaskComment struct {
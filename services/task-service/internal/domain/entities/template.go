@@ -0,0 +1,32 @@
+package entities
+
+import "time"
+
+// TaskTemplate describes a reusable task, optionally with checklist items
+// that are created as separate tasks alongside it.
+//
+// Title, Description and item texts may contain {{placeholders}} which are
+// substituted on instantiation. DueOffset values are relative to the moment
+// of instantiation, e.g. "+3d".
+type TaskTemplate struct {
+	ID          int64
+	UserID      int64
+	Name        string
+	Title       string
+	Description string
+	Priority    TaskPriority
+	CategoryID  *int64
+	DueOffset   string
+	Items       []TemplateItem
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// TemplateItem is a child task of a template. Empty Priority and DueOffset
+// fall back to the template values.
+type TemplateItem struct {
+	Title       string
+	Description string
+	Priority    TaskPriority
+	DueOffset   string
+}
@@ -0,0 +1,59 @@
+package entities
+
+import "time"
+
+// TimeEntry is a span of time spent on a task, either recorded by a timer or
+// entered manually. A running timer has a nil EndedAt and zero Duration.
+type TimeEntry struct {
+	ID        int64
+	UserID    int64
+	TaskID    int64
+	StartedAt time.Time
+	EndedAt   *time.Time
+	Duration  time.Duration
+	Note      string
+	CreatedAt time.Time
+
+	// TaskTitle, TaskEstimateMinutes, CategoryID and CategoryName describe the
+	// task at read time; they are only populated by listings.
+	TaskTitle           string
+	TaskEstimateMinutes *int
+	CategoryID          *int64
+	CategoryName        string
+}
+
+// Running reports whether the entry is an active timer.
+func (e TimeEntry) Running() bool {
+	return e.EndedAt == nil
+}
+
+// TimeReport aggregates finished time entries over a date range. Entries are
+// attributed to the day they started on, in the user's timezone.
+type TimeReport struct {
+	From       time.Time
+	To         time.Time
+	Total      time.Duration
+	ByDay      []TimeReportDay
+	ByCategory []TimeReportCategory
+	ByTask     []TimeReportTask
+}
+
+type TimeReportDay struct {
+	Date     time.Time
+	Duration time.Duration
+}
+
+// TimeReportCategory has a nil CategoryID for uncategorized tasks.
+type TimeReportCategory struct {
+	CategoryID *int64
+	Name       string
+	Duration   time.Duration
+}
+
+type TimeReportTask struct {
+	TaskID          int64
+	Title           string
+	CategoryID      *int64
+	EstimateMinutes *int
+	Duration        time.Duration
+}
@@ -0,0 +1,27 @@
+package entities
+
+import "time"
+
+// SavedView is a named task filter stored on the server.
+type SavedView struct {
+	ID        int64
+	UserID    int64
+	Name      string
+	Filter    ViewFilter
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// ViewFilter is the stored form of a task filter. DueFrom and DueTo hold date
+// expressions such as "today", "+7d" or "startOfWeek" that are resolved in the
+// user's timezone every time the view is queried.
+type ViewFilter struct {
+	Statuses             []TaskStatus
+	Priorities           []TaskPriority
+	CategoryID           *int64
+	IncludeSubcategories bool
+	WithoutCategory      bool
+	Search               string
+	DueFrom              string
+	DueTo                string
+}
@@ -0,0 +1,68 @@
+package entities
+
+import (
+	"time"
+
+	"todoapp/pkg/events"
+)
+
+// WebhookTestEvent is the type of the events sent by the "send test event"
+// endpoint. They carry no task.
+const WebhookTestEvent events.TaskEventType = "webhook.test"
+
+// Webhook is a user-registered URL that receives task events. Deliveries are
+// signed with Secret. A webhook is disabled automatically after too many
+// failed attempts in a row and stays disabled until the user re-enables it.
+type Webhook struct {
+	ID     int64
+	UserID int64
+	URL    string
+	Secret string
+	// EventTypes limits the events sent; empty means all of them.
+	EventTypes          []events.TaskEventType
+	Active              bool
+	ConsecutiveFailures int
+	DisabledAt          *time.Time
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+}
+
+// Subscribed reports whether the webhook receives events of the given type.
+func (w Webhook) Subscribed(eventType events.TaskEventType) bool {
+	if len(w.EventTypes) == 0 {
+		return true
+	}
+	for _, subscribed := range w.EventTypes {
+		if subscribed == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+type WebhookDeliveryStatus string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryStatus = "pending"
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	WebhookDeliveryFailed    WebhookDeliveryStatus = "failed"
+)
+
+// WebhookDelivery is one event sent to one webhook, retried until it succeeds
+// or runs out of attempts.
+type WebhookDelivery struct {
+	ID            int64
+	WebhookID     int64
+	EventID       string
+	EventType     events.TaskEventType
+	Payload       []byte
+	Status        WebhookDeliveryStatus
+	Attempts      int
+	NextAttemptAt time.Time
+	// ResponseCode is the HTTP status of the last attempt, nil when no
+	// response was received.
+	ResponseCode *int
+	LastError    string
+	CreatedAt    time.Time
+	CompletedAt  *time.Time
+}
@@ -0,0 +1,102 @@
+package entities
+
+import (
+	"regexp"
+	"time"
+)
+
+var statusKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
+
+// IsValid reports whether s is a well-formed status key. Whether a task may
+// use it depends on the task's workflow.
+func (s TaskStatus) IsValid() bool {
+	return statusKeyPattern.MatchString(string(s))
+}
+
+type WorkflowStatus struct {
+	Key  TaskStatus
+	Name string
+	// Completed statuses finish a task: entering one sets its completion time
+	// and counts as a completion for notifications and analytics.
+	Completed bool
+}
+
+type WorkflowTransition struct {
+	From TaskStatus
+	To   TaskStatus
+}
+
+// Workflow is the set of statuses a task moves through. It applies to all of
+// a user's tasks, or to the tasks of one category and its subcategories when
+// CategoryID is set.
+type Workflow struct {
+	ID         int64
+	UserID     int64
+	CategoryID *int64
+	Name       string
+	// Statuses are in board order; the first one is given to new tasks.
+	Statuses []WorkflowStatus
+	// Transitions lists the allowed status changes. When empty, any status
+	// can follow any other.
+	Transitions []WorkflowTransition
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// DefaultWorkflow is used by users who have not defined a workflow of their
+// own. It keeps the original fixed statuses and allows every transition.
+func DefaultWorkflow() *Workflow {
+	return &Workflow{
+		Name: "Default",
+		Statuses: []WorkflowStatus{
+			{Key: TaskStatusPending, Name: "Pending"},
+			{Key: TaskStatusInProgress, Name: "In progress"},
+			{Key: TaskStatusCompleted, Name: "Completed", Completed: true},
+			{Key: TaskStatusArchived, Name: "Archived"},
+		},
+	}
+}
+
+// IsDefault reports whether w is the built-in workflow.
+func (w *Workflow) IsDefault() bool {
+	return w.ID == 0
+}
+
+func (w *Workflow) Status(key TaskStatus) (WorkflowStatus, bool) {
+	for _, status := range w.Statuses {
+		if status.Key == key {
+			return status, true
+		}
+	}
+	return WorkflowStatus{}, false
+}
+
+func (w *Workflow) InitialStatus() TaskStatus {
+	if len(w.Statuses) == 0 {
+		return ""
+	}
+	return w.Statuses[0].Key
+}
+
+func (w *Workflow) IsCompleted(key TaskStatus) bool {
+	status, ok := w.Status(key)
+	return ok && status.Completed
+}
+
+// Allows reports whether a task may move from one status to another. Staying
+// put is always allowed, and so is leaving a status that is not part of the
+// workflow, e.g. after the task moved to a category with another workflow.
+func (w *Workflow) Allows(from, to TaskStatus) bool {
+	if from == to || len(w.Transitions) == 0 {
+		return true
+	}
+	if _, ok := w.Status(from); !ok {
+		return true
+	}
+	for _, transition := range w.Transitions {
+		if transition.From == from && transition.To == to {
+			return true
+		}
+	}
+	return false
+}
@@ -5,10 +5,32 @@ import "todoapp/pkg/errors"
 var (
 	ErrTaskNotFound        = errors.ErrTaskNotFound
 	ErrCategoryNotFound    = errors.ErrCategoryNotFound
+	ErrCategoryExists      = errors.ErrAlreadyExists.WithMessage("category with this name already exists")
+	ErrCategoryHasChildren = errors.ErrConflict.WithMessage("category has subcategories")
+	ErrCategoryCycle       = errors.ErrValidation.WithMessage("category cannot be nested under itself or its descendants")
 	ErrCommentNotFound     = errors.ErrCommentNotFound
+	ErrAttachmentNotFound  = errors.ErrAttachmentNotFound
+	ErrTemplateNotFound    = errors.ErrTemplateNotFound
+	ErrTemplateExists      = errors.ErrAlreadyExists.WithMessage("template with this name already exists")
+	ErrViewNotFound        = errors.ErrViewNotFound
+	ErrViewExists          = errors.ErrAlreadyExists.WithMessage("view with this name already exists")
+	ErrTimeEntryNotFound   = errors.ErrTimeEntryNotFound
+	ErrWebhookNotFound     = errors.ErrWebhookNotFound
+	ErrWebhookLimit        = errors.ErrConflict.WithMessage("webhook limit reached")
+	ErrWorkflowNotFound    = errors.ErrWorkflowNotFound
+	ErrWorkflowExists      = errors.ErrAlreadyExists.WithMessage("workflow with this name already exists")
+	ErrWorkflowScopeTaken  = errors.ErrConflict.WithMessage("another workflow already applies to these tasks")
+	ErrCustomFieldNotFound = errors.ErrCustomFieldNotFound
+	ErrCustomFieldExists   = errors.ErrAlreadyExists.WithMessage("custom field with this key already exists")
+	ErrTimerRunning        = errors.ErrConflict.WithMessage("another timer is already running")
+	ErrNoRunningTimer      = errors.ErrNotFound.WithMessage("no timer is running")
+	ErrPositionExhausted   = errors.ErrConflict.WithMessage("no free board position, please retry")
 	ErrUnknownUser         = errors.ErrUserNotFound
 	ErrInvalidTaskStatus   = errors.ErrInvalidTaskStatus
 	ErrInvalidTaskPriority = errors.ErrInvalidPriority
 	ErrForbiddenTaskAccess = errors.ErrForbidden.WithMessage("task access denied")
 	ErrValidationFailed    = errors.ErrValidation
+	ErrFileTooLarge        = errors.ErrFileTooLarge
+	ErrUnsupportedFileType = errors.ErrUnsupportedMediaType
+	ErrQuotaExceeded       = errors.ErrStorageQuotaExceeded
 )
@@ -0,0 +1,37 @@
+package dto
+
+import (
+	"time"
+
+	"todoapp/services/task-service/internal/domain/entities"
+)
+
+type AttachmentResponse struct {
+	ID          int64     `json:"id"`
+	TaskID      int64     `json:"taskId"`
+	FileName    string    `json:"fileName"`
+	ContentType string    `json:"contentType"`
+	SizeBytes   int64     `json:"sizeBytes"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+func NewAttachmentResponse(attachment entities.Attachment) AttachmentResponse {
+	return AttachmentResponse{
+		ID:          attachment.ID,
+		TaskID:      attachment.TaskID,
+		FileName:    attachment.FileName,
+		ContentType: attachment.ContentType,
+		SizeBytes:   attachment.SizeBytes,
+		CreatedAt:   attachment.CreatedAt,
+	}
+}
+
+func NewAttachmentResponses(attachments []entities.Attachment) []AttachmentResponse {
+	result := make([]AttachmentResponse, 0, len(attachments))
+
+	for _, attachment := range attachments {
+		result = append(result, NewAttachmentResponse(attachment))
+	}
+
+	return result
+}
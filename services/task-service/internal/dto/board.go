@@ -0,0 +1,86 @@
+package dto
+
+import (
+	"strings"
+
+	"todoapp/services/task-service/internal/domain/entities"
+	"todoapp/services/task-service/internal/ports"
+)
+
+// MoveTaskRequest moves a task into a status column. afterId is the task that
+// should end up directly above the moved one, beforeId the one directly below.
+type MoveTaskRequest struct {
+	Status   string `json:"status" binding:"required,max=50"`
+	AfterID  *int64 `json:"afterId" binding:"omitempty,gte=1"`
+	BeforeID *int64 `json:"beforeId" binding:"omitempty,gte=1"`
+}
+
+type BoardRequest struct {
+	// Statuses is a comma-separated list of columns, e.g. "pending,in_progress".
+	Statuses             string `form:"statuses"`
+	Priority             string `form:"priority"`
+	CategoryID           *int64 `form:"categoryId"`
+	IncludeSubcategories bool   `form:"includeSubcategories"`
+	WithoutCategory      bool   `form:"withoutCategory"`
+	Search               string `form:"search"`
+	Limit                int    `form:"limit,default=100"`
+	// CustomFields holds the cf[key]=value query parameters.
+	CustomFields map[string]string `form:"-"`
+}
+
+type BoardColumnResponse struct {
+	Status string         `json:"status"`
+	Tasks  []TaskResponse `json:"tasks"`
+}
+
+func (r MoveTaskRequest) ToInput(userID, taskID int64) ports.MoveTaskInput {
+	return ports.MoveTaskInput{
+		UserID:   userID,
+		TaskID:   taskID,
+		Status:   normalizeStatus(r.Status),
+		AfterID:  r.AfterID,
+		BeforeID: r.BeforeID,
+	}
+}
+
+// ToFilter ignores malformed statuses, like TaskFilterRequest does.
+func (r BoardRequest) ToFilter() ports.TaskFilter {
+	var (
+		statuses   []entities.TaskStatus
+		priorities []entities.TaskPriority
+	)
+
+	for _, raw := range strings.Split(r.Statuses, ",") {
+		if status, ok := parseStatus(raw); ok {
+			statuses = append(statuses, status)
+		}
+	}
+
+	if priority, ok := parsePriority(r.Priority); ok {
+		priorities = append(priorities, priority)
+	}
+
+	return ports.TaskFilter{
+		Statuses:             statuses,
+		Priorities:           priorities,
+		CategoryID:           r.CategoryID,
+		IncludeSubcategories: r.IncludeSubcategories,
+		WithoutCategory:      r.WithoutCategory,
+		Search:               strings.TrimSpace(r.Search),
+		CustomFields:         customFieldFilters(r.CustomFields),
+		Limit:                r.Limit,
+	}
+}
+
+func NewBoardResponse(columns []entities.BoardColumn) []BoardColumnResponse {
+	result := make([]BoardColumnResponse, 0, len(columns))
+
+	for _, column := range columns {
+		result = append(result, BoardColumnResponse{
+			Status: string(column.Status),
+			Tasks:  NewTaskResponses(column.Tasks),
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,37 @@
+package dto
+
+import "todoapp/services/task-service/internal/domain/entities"
+
+// ChangeEventResponse is one message of the real-time stream. Data holds the
+// changed task, category or comment; deletions only carry its id.
+type ChangeEventResponse struct {
+	ID   int64  `json:"id"`
+	Type string `json:"type"`
+	Data any    `json:"data"`
+}
+
+type DeletedResponse struct {
+	ID int64 `json:"id"`
+}
+
+func NewChangeEventResponse(event entities.ChangeEvent) ChangeEventResponse {
+	response := ChangeEventResponse{
+		ID:   event.ID,
+		Type: string(event.Type),
+	}
+
+	switch {
+	case event.Type == entities.ChangeTaskDeleted && event.Task != nil:
+		response.Data = DeletedResponse{ID: event.Task.ID}
+	case event.Type == entities.ChangeCategoryDeleted && event.Category != nil:
+		response.Data = DeletedResponse{ID: event.Category.ID}
+	case event.Task != nil:
+		response.Data = NewTaskResponse(*event.Task)
+	case event.Category != nil:
+		response.Data = NewCategoryResponse(*event.Category)
+	case event.Comment != nil:
+		response.Data = NewCommentResponse(*event.Comment)
+	}
+
+	return response
+}
@@ -0,0 +1,74 @@
+package dto
+
+import (
+	"strings"
+	"time"
+
+	"todoapp/services/task-service/internal/domain/entities"
+	"todoapp/services/task-service/internal/ports"
+)
+
+type CreateCustomFieldRequest struct {
+	Key     string   `json:"key" binding:"required,max=50"`
+	Name    string   `json:"name" binding:"required,min=1,max=100"`
+	Type    string   `json:"type" binding:"required,oneof=text number date select multi_select checkbox url"`
+	Options []string `json:"options" binding:"omitempty,max=50,dive,max=100"`
+}
+
+// UpdateCustomFieldRequest changes the name and options of a field; its key
+// and type are fixed.
+type UpdateCustomFieldRequest struct {
+	Name    *string  `json:"name" binding:"omitempty,min=1,max=100"`
+	Options []string `json:"options" binding:"omitempty,max=50,dive,max=100"`
+}
+
+type CustomFieldResponse struct {
+	ID        int64     `json:"id"`
+	Key       string    `json:"key"`
+	Name      string    `json:"name"`
+	Type      string    `json:"type"`
+	Options   []string  `json:"options,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+func (r CreateCustomFieldRequest) ToInput(userID int64) ports.CreateCustomFieldInput {
+	return ports.CreateCustomFieldInput{
+		UserID:  userID,
+		Key:     strings.ToLower(strings.TrimSpace(r.Key)),
+		Name:    strings.TrimSpace(r.Name),
+		Type:    entities.CustomFieldType(r.Type),
+		Options: r.Options,
+	}
+}
+
+func (r UpdateCustomFieldRequest) ToInput(userID, fieldID int64) ports.UpdateCustomFieldInput {
+	return ports.UpdateCustomFieldInput{
+		UserID:  userID,
+		FieldID: fieldID,
+		Name:    normalizePtr(r.Name),
+		Options: r.Options,
+	}
+}
+
+func NewCustomFieldResponse(field entities.CustomField) CustomFieldResponse {
+	return CustomFieldResponse{
+		ID:        field.ID,
+		Key:       field.Key,
+		Name:      field.Name,
+		Type:      string(field.Type),
+		Options:   field.Options,
+		CreatedAt: field.CreatedAt,
+		UpdatedAt: field.UpdatedAt,
+	}
+}
+
+func NewCustomFieldResponses(fields []entities.CustomField) []CustomFieldResponse {
+	result := make([]CustomFieldResponse, 0, len(fields))
+
+	for _, field := range fields {
+		result = append(result, NewCustomFieldResponse(field))
+	}
+
+	return result
+}
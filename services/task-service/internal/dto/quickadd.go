@@ -0,0 +1,44 @@
+package dto
+
+import (
+	"time"
+
+	"todoapp/services/task-service/internal/ports"
+)
+
+type QuickAddRequest struct {
+	Text string `json:"text" binding:"required,min=1,max=500"`
+}
+
+// QuickAddResponse shows what was understood from the text next to the
+// created task.
+type QuickAddResponse struct {
+	Parsed QuickAddParsedResponse `json:"parsed"`
+	Task   TaskResponse           `json:"task"`
+}
+
+type QuickAddParsedResponse struct {
+	Title        string     `json:"title"`
+	DueDate      *time.Time `json:"dueDate,omitempty"`
+	HasTime      bool       `json:"hasTime"`
+	Priority     string     `json:"priority"`
+	CategoryID   *int64     `json:"categoryId,omitempty"`
+	CategoryName string     `json:"categoryName,omitempty"`
+}
+
+func NewQuickAddResponse(result ports.QuickAddResult) QuickAddResponse {
+	response := QuickAddResponse{
+		Parsed: QuickAddParsedResponse{
+			Title:        result.Input.Title,
+			DueDate:      result.Input.DueDate,
+			HasTime:      result.HasTime,
+			Priority:     string(result.Input.Priority),
+			CategoryID:   result.Input.CategoryID,
+			CategoryName: result.CategoryName,
+		},
+	}
+	if result.Task != nil {
+		response.Task = NewTaskResponse(*result.Task)
+	}
+	return response
+}
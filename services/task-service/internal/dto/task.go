@@ -1,6 +1,8 @@
 package dto
 
 import (
+	"maps"
+	"slices"
 	"strings"
 	"time"
 
@@ -9,42 +11,76 @@ import (
 )
 
 type CreateTaskRequest struct {
-	Title       string  `json:"title" binding:"required,min=1,max=200"`
-	Description string  `json:"description" binding:"omitempty,max=2000"`
-	Status      *string `json:"status" binding:"omitempty,oneof=pending in_progress completed archived"`
-	Priority    *string `json:"priority" binding:"omitempty,oneof=low medium high"`
-	DueDate     *string `json:"dueDate" binding:"omitempty"`
-	CategoryID  *int64  `json:"categoryId" binding:"omitempty,gte=1"`
+	Title           string  `json:"title" binding:"required,min=1,max=200"`
+	Description     string  `json:"description" binding:"omitempty,max=2000"`
+	Status          *string `json:"status" binding:"omitempty,max=50"`
+	Priority        *string `json:"priority" binding:"omitempty,oneof=low medium high"`
+	DueDate         *string `json:"dueDate" binding:"omitempty"`
+	CategoryID      *int64  `json:"categoryId" binding:"omitempty,gte=1"`
+	EstimateMinutes *int    `json:"estimateMinutes" binding:"omitempty,gte=1,lte=60000"`
+	// CustomFields maps custom field keys to values.
+	CustomFields map[string]any `json:"customFields" binding:"omitempty,max=50"`
 }
 
 type UpdateTaskRequest struct {
-	Title         *string `json:"title" binding:"omitempty,min=1,max=200"`
-	Description   *string `json:"description" binding:"omitempty,max=2000"`
-	Status        *string `json:"status" binding:"omitempty,oneof=pending in_progress completed archived"`
-	Priority      *string `json:"priority" binding:"omitempty,oneof=low medium high"`
-	DueDate       *string `json:"dueDate"`
-	ClearDueDate  bool    `json:"clearDueDate"`
-	CategoryID    *int64  `json:"categoryId" binding:"omitempty,gte=1"`
-	ClearCategory bool    `json:"clearCategory"`
+	Title           *string `json:"title" binding:"omitempty,min=1,max=200"`
+	Description     *string `json:"description" binding:"omitempty,max=2000"`
+	Status          *string `json:"status" binding:"omitempty,max=50"`
+	Priority        *string `json:"priority" binding:"omitempty,oneof=low medium high"`
+	DueDate         *string `json:"dueDate"`
+	ClearDueDate    bool    `json:"clearDueDate"`
+	CategoryID      *int64  `json:"categoryId" binding:"omitempty,gte=1"`
+	ClearCategory   bool    `json:"clearCategory"`
+	EstimateMinutes *int    `json:"estimateMinutes" binding:"omitempty,gte=1,lte=60000"`
+	ClearEstimate   bool    `json:"clearEstimate"`
+	// CustomFields sets the listed fields; null removes a field's value.
+	CustomFields map[string]any `json:"customFields" binding:"omitempty,max=50"`
 }
 
 type UpdateTaskStatusRequest struct {
-	Status string `json:"status" binding:"required,oneof=pending in_progress completed archived"`
+	Status string `json:"status" binding:"required,max=50"`
 }
 
 type TaskFilterRequest struct {
-	Status     string  `form:"status"`
-	Priority   string  `form:"priority"`
-	CategoryID *int64  `form:"categoryId"`
-	Search     string  `form:"search"`
-	DueFrom    *string `form:"dueFrom"`
-	DueTo      *string `form:"dueTo"`
-	Limit      int     `form:"limit,default=20"`
-	Offset     int     `form:"offset,default=0"`
+	Status               string  `form:"status"`
+	Priority             string  `form:"priority"`
+	CategoryID           *int64  `form:"categoryId"`
+	IncludeSubcategories bool    `form:"includeSubcategories"`
+	WithoutCategory      bool    `form:"withoutCategory"`
+	Search               string  `form:"search"`
+	DueFrom              *string `form:"dueFrom"`
+	DueTo                *string `form:"dueTo"`
+	// SortField orders by a custom field; SortOrder is asc (default) or desc.
+	SortField string `form:"sortField" binding:"omitempty,max=50"`
+	SortOrder string `form:"sortOrder" binding:"omitempty,oneof=asc desc"`
+	Limit     int    `form:"limit,default=20"`
+	Offset    int    `form:"offset,default=0"`
+	// CustomFields holds the cf[key]=value query parameters, which the
+	// handler reads with QueryMap.
+	CustomFields map[string]string `form:"-"`
 }
 
 type CreateCategoryRequest struct {
-	Name string `json:"name" binding:"required,min=1,max=120"`
+	Name     string `json:"name" binding:"required,min=1,max=100"`
+	Color    string `json:"color" binding:"omitempty,max=7"`
+	Icon     string `json:"icon" binding:"omitempty,max=50"`
+	ParentID *int64 `json:"parentId" binding:"omitempty,gte=1"`
+}
+
+type UpdateCategoryRequest struct {
+	Name        *string `json:"name" binding:"omitempty,min=1,max=100"`
+	Color       *string `json:"color" binding:"omitempty,max=7"`
+	Icon        *string `json:"icon" binding:"omitempty,max=50"`
+	ParentID    *int64  `json:"parentId" binding:"omitempty,gte=1"`
+	ClearParent bool    `json:"clearParent"`
+}
+
+type DeleteCategoryRequest struct {
+	Mode string `form:"mode" binding:"omitempty,oneof=refuse reparent cascade"`
+}
+
+type ReorderCategoriesRequest struct {
+	CategoryIDs []int64 `json:"categoryIds" binding:"required,min=1,dive,gte=1"`
 }
 
 type CreateCommentRequest struct {
@@ -52,30 +88,52 @@ type CreateCommentRequest struct {
 }
 
 type TaskResponse struct {
-	ID          int64          `json:"id"`
-	UserID      int64          `json:"userId"`
-	Title       string         `json:"title"`
-	Description string         `json:"description"`
-	Status      string         `json:"status"`
-	Priority    string         `json:"priority"`
-	DueDate     *time.Time     `json:"dueDate,omitempty"`
-	CategoryID  *int64         `json:"categoryId,omitempty"`
-	Category    *CategoryShort `json:"category,omitempty"`
-	CreatedAt   time.Time      `json:"createdAt"`
-	UpdatedAt   time.Time      `json:"updatedAt"`
+	ID              int64          `json:"id"`
+	UserID          int64          `json:"userId"`
+	Title           string         `json:"title"`
+	Description     string         `json:"description"`
+	Status          string         `json:"status"`
+	Priority        string         `json:"priority"`
+	DueDate         *time.Time     `json:"dueDate,omitempty"`
+	CategoryID      *int64         `json:"categoryId,omitempty"`
+	Category        *CategoryShort `json:"category,omitempty"`
+	Position        float64        `json:"position"`
+	EstimateMinutes *int           `json:"estimateMinutes,omitempty"`
+	TrackedSeconds  int64          `json:"trackedSeconds"`
+	CompletedAt     *time.Time     `json:"completedAt,omitempty"`
+	CustomFields    map[string]any `json:"customFields,omitempty"`
+	CreatedAt       time.Time      `json:"createdAt"`
+	UpdatedAt       time.Time      `json:"updatedAt"`
 }
 
 type CategoryResponse struct {
-	ID        int64     `json:"id"`
-	UserID    int64     `json:"userId"`
-	Name      string    `json:"name"`
-	CreatedAt time.Time `json:"createdAt"`
-	UpdatedAt time.Time `json:"updatedAt"`
+	ID         int64              `json:"id"`
+	UserID     int64              `json:"userId"`
+	ParentID   *int64             `json:"parentId"`
+	Name       string             `json:"name"`
+	Color      string             `json:"color"`
+	Icon       string             `json:"icon,omitempty"`
+	Position   int                `json:"position"`
+	TaskCounts CategoryTaskCounts `json:"taskCounts"`
+	CreatedAt  time.Time          `json:"createdAt"`
+	UpdatedAt  time.Time          `json:"updatedAt"`
+}
+
+// CategoryTreeResponse is a category with its subcategories nested.
+type CategoryTreeResponse struct {
+	CategoryResponse
+	Children []CategoryTreeResponse `json:"children"`
+}
+
+type CategoryTaskCounts struct {
+	Open      int `json:"open"`
+	Completed int `json:"completed"`
 }
 
 type CategoryShort struct {
-	ID   int64  `json:"id"`
-	Name string `json:"name"`
+	ID    int64  `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color,omitempty"`
 }
 
 type CommentResponse struct {
@@ -94,9 +152,10 @@ func (r CreateTaskRequest) ToInput(userID int64) ports.CreateTaskInput {
 		}
 	}
 
-	status := entities.TaskStatusPending
+	// Without a status the task starts in the first status of its workflow.
+	var status entities.TaskStatus
 	if r.Status != nil {
-		status = toStatusOrDefault(*r.Status)
+		status = normalizeStatus(*r.Status)
 	}
 
 	priority := entities.TaskPriorityMedium
@@ -105,13 +164,15 @@ func (r CreateTaskRequest) ToInput(userID int64) ports.CreateTaskInput {
 	}
 
 	return ports.CreateTaskInput{
-		UserID:      userID,
-		Title:       r.Title,
-		Description: strings.TrimSpace(r.Description),
-		Status:      status,
-		Priority:    priority,
-		DueDate:     dueDate,
-		CategoryID:  r.CategoryID,
+		UserID:          userID,
+		Title:           r.Title,
+		Description:     strings.TrimSpace(r.Description),
+		Status:          status,
+		Priority:        priority,
+		DueDate:         dueDate,
+		CategoryID:      r.CategoryID,
+		EstimateMinutes: r.EstimateMinutes,
+		CustomFields:    r.CustomFields,
 	}
 }
 
@@ -120,7 +181,7 @@ func (r UpdateTaskRequest) ToInput(userID, taskID int64) ports.UpdateTaskInput {
 	var priority *entities.TaskPriority
 
 	if r.Status != nil {
-		value := entities.TaskStatus(strings.ToLower(strings.TrimSpace(*r.Status)))
+		value := normalizeStatus(*r.Status)
 		status = &value
 	}
 
@@ -137,28 +198,53 @@ func (r UpdateTaskRequest) ToInput(userID, taskID int64) ports.UpdateTaskInput {
 	}
 
 	return ports.UpdateTaskInput{
-		UserID:        userID,
-		TaskID:        taskID,
-		Title:         r.Title,
-		Description:   normalizePtr(r.Description),
-		Status:        status,
-		Priority:      priority,
-		DueDate:       dueDate,
-		ClearDueDate:  r.ClearDueDate,
-		CategoryID:    r.CategoryID,
-		ClearCategory: r.ClearCategory,
+		UserID:          userID,
+		TaskID:          taskID,
+		Title:           r.Title,
+		Description:     normalizePtr(r.Description),
+		Status:          status,
+		Priority:        priority,
+		DueDate:         dueDate,
+		ClearDueDate:    r.ClearDueDate,
+		CategoryID:      r.CategoryID,
+		ClearCategory:   r.ClearCategory,
+		EstimateMinutes: r.EstimateMinutes,
+		ClearEstimate:   r.ClearEstimate,
+		CustomFields:    r.CustomFields,
 	}
 }
 
 func (r UpdateTaskStatusRequest) ToStatus() entities.TaskStatus {
-	return toStatusOrDefault(r.Status)
+	return normalizeStatus(r.Status)
 }
 
 func (r CreateCategoryRequest) ToInput(userID int64) ports.CreateCategoryInput {
 	return ports.CreateCategoryInput{
-		UserID: userID,
-		Name:   strings.TrimSpace(r.Name),
+		UserID:   userID,
+		ParentID: r.ParentID,
+		Name:     strings.TrimSpace(r.Name),
+		Color:    strings.TrimSpace(r.Color),
+		Icon:     strings.TrimSpace(r.Icon),
+	}
+}
+
+func (r UpdateCategoryRequest) ToInput(userID, categoryID int64) ports.UpdateCategoryInput {
+	return ports.UpdateCategoryInput{
+		UserID:      userID,
+		CategoryID:  categoryID,
+		Name:        normalizePtr(r.Name),
+		Color:       normalizePtr(r.Color),
+		Icon:        normalizePtr(r.Icon),
+		ParentID:    r.ParentID,
+		ClearParent: r.ClearParent,
+	}
+}
+
+func (r DeleteCategoryRequest) ToMode() entities.CategoryDeleteMode {
+	if r.Mode == "" {
+		return entities.CategoryDeleteRefuse
 	}
+	return entities.CategoryDeleteMode(r.Mode)
 }
 
 func (r CreateCommentRequest) ToInput(userID, taskID int64) ports.AddCommentInput {
@@ -198,39 +284,68 @@ func (r TaskFilterRequest) ToFilter() ports.TaskFilter {
 	}
 
 	return ports.TaskFilter{
-		Statuses:   statuses,
-		Priorities: priorities,
-		CategoryID: r.CategoryID,
-		Search:     strings.TrimSpace(r.Search),
-		DueFrom:    dueFrom,
-		DueTo:      dueTo,
-		Limit:      clampLimit(r.Limit),
-		Offset:     clampOffset(r.Offset),
+		Statuses:             statuses,
+		Priorities:           priorities,
+		CategoryID:           r.CategoryID,
+		IncludeSubcategories: r.IncludeSubcategories,
+		WithoutCategory:      r.WithoutCategory,
+		Search:               strings.TrimSpace(r.Search),
+		DueFrom:              dueFrom,
+		DueTo:                dueTo,
+		CustomFields:         customFieldFilters(r.CustomFields),
+		SortField:            strings.ToLower(strings.TrimSpace(r.SortField)),
+		SortDescending:       r.SortOrder == "desc",
+		Limit:                clampLimit(r.Limit),
+		Offset:               clampOffset(r.Offset),
 	}
 }
 
+// customFieldFilters orders filters by key so equal requests produce equal
+// queries.
+func customFieldFilters(values map[string]string) []ports.CustomFieldFilter {
+	if len(values) == 0 {
+		return nil
+	}
+
+	keys := slices.Sorted(maps.Keys(values))
+	filters := make([]ports.CustomFieldFilter, 0, len(keys))
+	for _, key := range keys {
+		filters = append(filters, ports.CustomFieldFilter{
+			Key:   strings.ToLower(strings.TrimSpace(key)),
+			Value: values[key],
+		})
+	}
+	return filters
+}
+
 func NewTaskResponse(task entities.Task) TaskResponse {
 	var category *CategoryShort
 
 	if task.Category != nil {
 		category = &CategoryShort{
-			ID:   task.Category.ID,
-			Name: task.Category.Name,
+			ID:    task.Category.ID,
+			Name:  task.Category.Name,
+			Color: task.Category.Color,
 		}
 	}
 
 	return TaskResponse{
-		ID:          task.ID,
-		UserID:      task.UserID,
-		Title:       task.Title,
-		Description: task.Description,
-		Status:      string(task.Status),
-		Priority:    string(task.Priority),
-		DueDate:     task.DueDate,
-		CategoryID:  task.CategoryID,
-		Category:    category,
-		CreatedAt:   task.CreatedAt,
-		UpdatedAt:   task.UpdatedAt,
+		ID:              task.ID,
+		UserID:          task.UserID,
+		Title:           task.Title,
+		Description:     task.Description,
+		Status:          string(task.Status),
+		Priority:        string(task.Priority),
+		DueDate:         task.DueDate,
+		CategoryID:      task.CategoryID,
+		Category:        category,
+		Position:        task.Position,
+		CreatedAt:       task.CreatedAt,
+		UpdatedAt:       task.UpdatedAt,
+		EstimateMinutes: task.EstimateMinutes,
+		TrackedSeconds:  int64(task.TrackedTime / time.Second),
+		CompletedAt:     task.CompletedAt,
+		CustomFields:    task.CustomFields,
 	}
 }
 
@@ -246,9 +361,17 @@ func NewTaskResponses(tasks []entities.Task) []TaskResponse {
 
 func NewCategoryResponse(category entities.Category) CategoryResponse {
 	return CategoryResponse{
-		ID:        category.ID,
-		UserID:    category.UserID,
-		Name:      category.Name,
+		ID:       category.ID,
+		UserID:   category.UserID,
+		ParentID: category.ParentID,
+		Name:     category.Name,
+		Color:    category.Color,
+		Icon:     category.Icon,
+		Position: category.Position,
+		TaskCounts: CategoryTaskCounts{
+			Open:      category.OpenTasks,
+			Completed: category.CompletedTasks,
+		},
 		CreatedAt: category.CreatedAt,
 		UpdatedAt: category.UpdatedAt,
 	}
@@ -264,6 +387,19 @@ func NewCategoryResponses(categories []entities.Category) []CategoryResponse {
 	return result
 }
 
+func NewCategoryTreeResponses(categories []entities.Category) []CategoryTreeResponse {
+	result := make([]CategoryTreeResponse, 0, len(categories))
+
+	for _, category := range categories {
+		result = append(result, CategoryTreeResponse{
+			CategoryResponse: NewCategoryResponse(category),
+			Children:         NewCategoryTreeResponses(category.Children),
+		})
+	}
+
+	return result
+}
+
 func NewCommentResponse(comment entities.TaskComment) CommentResponse {
 	return CommentResponse{
 		ID:        comment.ID,
@@ -284,13 +420,6 @@ func NewCommentResponses(comments []entities.TaskComment) []CommentResponse {
 	return result
 }
 
-func toStatusOrDefault(raw string) entities.TaskStatus {
-	if status, ok := parseStatus(raw); ok {
-		return status
-	}
-	return entities.TaskStatusPending
-}
-
 func toPriorityOrDefault(raw string) entities.TaskPriority {
 	if priority, ok := parsePriority(raw); ok {
 		return priority
@@ -298,19 +427,18 @@ func toPriorityOrDefault(raw string) entities.TaskPriority {
 	return entities.TaskPriorityMedium
 }
 
+func normalizeStatus(raw string) entities.TaskStatus {
+	return entities.TaskStatus(strings.ToLower(strings.TrimSpace(raw)))
+}
+
+// parseStatus accepts any well-formed status key; statuses come from the
+// user's workflows.
 func parseStatus(raw string) (entities.TaskStatus, bool) {
-	switch strings.ToLower(strings.TrimSpace(raw)) {
-	case string(entities.TaskStatusPending):
-		return entities.TaskStatusPending, true
-	case string(entities.TaskStatusInProgress):
-		return entities.TaskStatusInProgress, true
-	case string(entities.TaskStatusCompleted):
-		return entities.TaskStatusCompleted, true
-	case string(entities.TaskStatusArchived):
-		return entities.TaskStatusArchived, true
-	default:
+	status := normalizeStatus(raw)
+	if !status.IsValid() {
 		return "", false
 	}
+	return status, true
 }
 
 func parsePriority(raw string) (entities.TaskPriority, bool) {
@@ -17,8 +17,8 @@ func TestCreateTaskRequest_ToInputDefaults(t *testing.T) {
 	if input.UserID != 10 {
 		t.Fatalf("unexpected user id: %d", input.UserID)
 	}
-	if input.Status != entities.TaskStatusPending {
-		t.Fatalf("expected default status pending")
+	if input.Status != "" {
+		t.Fatalf("expected no status so the workflow's first status applies, got %q", input.Status)
 	}
 	if input.Priority != entities.TaskPriorityMedium {
 		t.Fatalf("expected default priority medium")
@@ -102,6 +102,22 @@ func TestTaskFilterRequest_ToFilter(t *testing.T) {
 	}
 }
 
+func TestTaskFilterRequest_CustomFields(t *testing.T) {
+	req := TaskFilterRequest{
+		SortField:    " Points ",
+		SortOrder:    "desc",
+		CustomFields: map[string]string{"points": "5", "Env": "prod"},
+	}
+
+	filter := req.ToFilter()
+	if filter.SortField != "points" || !filter.SortDescending {
+		t.Fatalf("unexpected sort: %q desc=%v", filter.SortField, filter.SortDescending)
+	}
+	if len(filter.CustomFields) != 2 || filter.CustomFields[0].Key != "env" || filter.CustomFields[1].Value != "5" {
+		t.Fatalf("unexpected custom field filters: %+v", filter.CustomFields)
+	}
+}
+
 func TestResponses(t *testing.T) {
 	now := time.Now()
 	category := entities.Category{ID: 2, Name: "Work", CreatedAt: now, UpdatedAt: now}
@@ -140,8 +156,11 @@ func TestHelperFunctions(t *testing.T) {
 	if status, ok := parseStatus(" archived "); !ok || status != entities.TaskStatusArchived {
 		t.Fatalf("parseStatus failed")
 	}
-	if _, ok := parseStatus("invalid"); ok {
-		t.Fatalf("parseStatus should fail for invalid")
+	if status, ok := parseStatus("In_Review"); !ok || status != "in_review" {
+		t.Fatalf("parseStatus should accept custom workflow statuses")
+	}
+	if _, ok := parseStatus("not valid!"); ok {
+		t.Fatalf("parseStatus should fail for malformed keys")
 	}
 
 	if priority, ok := parsePriority("LOW"); !ok || priority != entities.TaskPriorityLow {
@@ -151,9 +170,6 @@ func TestHelperFunctions(t *testing.T) {
 		t.Fatalf("parsePriority should fail for invalid")
 	}
 
-	if toStatusOrDefault("unknown") != entities.TaskStatusPending {
-		t.Fatalf("expected default pending status")
-	}
 	if toPriorityOrDefault("unknown") != entities.TaskPriorityMedium {
 		t.Fatalf("expected default medium priority")
 	}
@@ -190,3 +206,23 @@ func TestCreateCategoryRequest_ToInput(t *testing.T) {
 		t.Fatalf("unexpected category input: %+v", input)
 	}
 }
+
+func TestBoardRequestToFilter(t *testing.T) {
+	categoryID := int64(3)
+	filter := BoardRequest{
+		Statuses:   "pending, in_progress,not valid!",
+		Priority:   "HIGH",
+		CategoryID: &categoryID,
+		Limit:      50,
+	}.ToFilter()
+
+	if len(filter.Statuses) != 2 || filter.Statuses[1] != entities.TaskStatusInProgress {
+		t.Fatalf("unexpected statuses: %v", filter.Statuses)
+	}
+	if len(filter.Priorities) != 1 || filter.Priorities[0] != entities.TaskPriorityHigh {
+		t.Fatalf("unexpected priorities: %v", filter.Priorities)
+	}
+	if filter.CategoryID == nil || *filter.CategoryID != 3 || filter.Limit != 50 {
+		t.Fatalf("unexpected filter: %+v", filter)
+	}
+}
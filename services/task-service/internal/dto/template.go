@@ -0,0 +1,133 @@
+package dto
+
+import (
+	"strings"
+	"time"
+
+	"todoapp/services/task-service/internal/domain/entities"
+	"todoapp/services/task-service/internal/ports"
+)
+
+// TemplateRequest is used both to create and to replace a template.
+type TemplateRequest struct {
+	Name        string                `json:"name" binding:"required,min=1,max=100"`
+	Title       string                `json:"title" binding:"required,min=1,max=200"`
+	Description string                `json:"description" binding:"omitempty,max=2000"`
+	Priority    string                `json:"priority" binding:"omitempty,oneof=low medium high"`
+	CategoryID  *int64                `json:"categoryId" binding:"omitempty,gte=1"`
+	DueOffset   string                `json:"dueOffset" binding:"omitempty,max=16"`
+	Items       []TemplateItemRequest `json:"items" binding:"omitempty,max=50,dive"`
+}
+
+type TemplateItemRequest struct {
+	Title       string `json:"title" binding:"required,min=1,max=200"`
+	Description string `json:"description" binding:"omitempty,max=2000"`
+	Priority    string `json:"priority" binding:"omitempty,oneof=low medium high"`
+	DueOffset   string `json:"dueOffset" binding:"omitempty,max=16"`
+}
+
+type InstantiateTemplateRequest struct {
+	Variables  map[string]string `json:"variables"`
+	CategoryID *int64            `json:"categoryId" binding:"omitempty,gte=1"`
+}
+
+type TemplateResponse struct {
+	ID          int64                  `json:"id"`
+	Name        string                 `json:"name"`
+	Title       string                 `json:"title"`
+	Description string                 `json:"description"`
+	Priority    string                 `json:"priority"`
+	CategoryID  *int64                 `json:"categoryId,omitempty"`
+	DueOffset   string                 `json:"dueOffset,omitempty"`
+	Items       []TemplateItemResponse `json:"items"`
+	CreatedAt   time.Time              `json:"createdAt"`
+	UpdatedAt   time.Time              `json:"updatedAt"`
+}
+
+type TemplateItemResponse struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Priority    string `json:"priority,omitempty"`
+	DueOffset   string `json:"dueOffset,omitempty"`
+}
+
+func (r TemplateRequest) toTemplateInput() ports.TemplateInput {
+	items := make([]entities.TemplateItem, 0, len(r.Items))
+	for _, item := range r.Items {
+		items = append(items, entities.TemplateItem{
+			Title:       strings.TrimSpace(item.Title),
+			Description: strings.TrimSpace(item.Description),
+			Priority:    entities.TaskPriority(item.Priority),
+			DueOffset:   strings.TrimSpace(item.DueOffset),
+		})
+	}
+
+	return ports.TemplateInput{
+		Name:        strings.TrimSpace(r.Name),
+		Title:       strings.TrimSpace(r.Title),
+		Description: strings.TrimSpace(r.Description),
+		Priority:    entities.TaskPriority(r.Priority),
+		CategoryID:  r.CategoryID,
+		DueOffset:   strings.TrimSpace(r.DueOffset),
+		Items:       items,
+	}
+}
+
+func (r TemplateRequest) ToCreateInput(userID int64) ports.CreateTemplateInput {
+	return ports.CreateTemplateInput{
+		UserID:        userID,
+		TemplateInput: r.toTemplateInput(),
+	}
+}
+
+func (r TemplateRequest) ToUpdateInput(userID, templateID int64) ports.UpdateTemplateInput {
+	return ports.UpdateTemplateInput{
+		UserID:        userID,
+		TemplateID:    templateID,
+		TemplateInput: r.toTemplateInput(),
+	}
+}
+
+func (r InstantiateTemplateRequest) ToInput(userID, templateID int64) ports.InstantiateTemplateInput {
+	return ports.InstantiateTemplateInput{
+		UserID:     userID,
+		TemplateID: templateID,
+		Variables:  r.Variables,
+		CategoryID: r.CategoryID,
+	}
+}
+
+func NewTemplateResponse(template entities.TaskTemplate) TemplateResponse {
+	items := make([]TemplateItemResponse, 0, len(template.Items))
+	for _, item := range template.Items {
+		items = append(items, TemplateItemResponse{
+			Title:       item.Title,
+			Description: item.Description,
+			Priority:    string(item.Priority),
+			DueOffset:   item.DueOffset,
+		})
+	}
+
+	return TemplateResponse{
+		ID:          template.ID,
+		Name:        template.Name,
+		Title:       template.Title,
+		Description: template.Description,
+		Priority:    string(template.Priority),
+		CategoryID:  template.CategoryID,
+		DueOffset:   template.DueOffset,
+		Items:       items,
+		CreatedAt:   template.CreatedAt,
+		UpdatedAt:   template.UpdatedAt,
+	}
+}
+
+func NewTemplateResponses(templates []entities.TaskTemplate) []TemplateResponse {
+	result := make([]TemplateResponse, 0, len(templates))
+
+	for _, template := range templates {
+		result = append(result, NewTemplateResponse(template))
+	}
+
+	return result
+}
@@ -0,0 +1,156 @@
+package dto
+
+import (
+	"strings"
+	"time"
+
+	"todoapp/services/task-service/internal/domain/entities"
+	"todoapp/services/task-service/internal/ports"
+)
+
+type StartTimerRequest struct {
+	Note string `json:"note" binding:"omitempty,max=500"`
+}
+
+// AddTimeEntryRequest records time spent without a timer. StartedAt is an
+// RFC3339 timestamp.
+type AddTimeEntryRequest struct {
+	StartedAt       string `json:"startedAt" binding:"required"`
+	DurationSeconds int64  `json:"durationSeconds" binding:"required,gte=1,lte=86400"`
+	Note            string `json:"note" binding:"omitempty,max=500"`
+}
+
+// TimeReportRequest takes inclusive YYYY-MM-DD dates.
+type TimeReportRequest struct {
+	From string `form:"from" binding:"omitempty,datetime=2006-01-02"`
+	To   string `form:"to" binding:"omitempty,datetime=2006-01-02"`
+}
+
+type TimeEntryResponse struct {
+	ID              int64      `json:"id"`
+	TaskID          int64      `json:"taskId"`
+	TaskTitle       string     `json:"taskTitle,omitempty"`
+	StartedAt       time.Time  `json:"startedAt"`
+	EndedAt         *time.Time `json:"endedAt,omitempty"`
+	DurationSeconds int64      `json:"durationSeconds"`
+	Running         bool       `json:"running"`
+	Note            string     `json:"note,omitempty"`
+	CreatedAt       time.Time  `json:"createdAt"`
+}
+
+type TimeReportResponse struct {
+	From         string                       `json:"from"`
+	To           string                       `json:"to"`
+	TotalSeconds int64                        `json:"totalSeconds"`
+	ByDay        []TimeReportDayResponse      `json:"byDay"`
+	ByCategory   []TimeReportCategoryResponse `json:"byCategory"`
+	ByTask       []TimeReportTaskResponse     `json:"byTask"`
+}
+
+type TimeReportDayResponse struct {
+	Date    string `json:"date"`
+	Seconds int64  `json:"seconds"`
+}
+
+type TimeReportCategoryResponse struct {
+	CategoryID *int64 `json:"categoryId"`
+	Name       string `json:"name"`
+	Seconds    int64  `json:"seconds"`
+}
+
+type TimeReportTaskResponse struct {
+	TaskID          int64  `json:"taskId"`
+	Title           string `json:"title"`
+	CategoryID      *int64 `json:"categoryId,omitempty"`
+	EstimateMinutes *int   `json:"estimateMinutes,omitempty"`
+	Seconds         int64  `json:"seconds"`
+}
+
+// ToInput returns false when StartedAt is not a valid timestamp.
+func (r AddTimeEntryRequest) ToInput(userID, taskID int64) (ports.AddTimeEntryInput, bool) {
+	startedAt, ok := parseFlexibleTime(strings.TrimSpace(r.StartedAt))
+	if !ok {
+		return ports.AddTimeEntryInput{}, false
+	}
+
+	return ports.AddTimeEntryInput{
+		UserID:    userID,
+		TaskID:    taskID,
+		StartedAt: startedAt,
+		Duration:  time.Duration(r.DurationSeconds) * time.Second,
+		Note:      strings.TrimSpace(r.Note),
+	}, true
+}
+
+func (r TimeReportRequest) ToInput(userID int64) ports.TimeReportInput {
+	input := ports.TimeReportInput{UserID: userID}
+	if parsed, err := time.Parse(time.DateOnly, r.From); err == nil {
+		input.From = parsed
+	}
+	if parsed, err := time.Parse(time.DateOnly, r.To); err == nil {
+		input.To = parsed
+	}
+	return input
+}
+
+func NewTimeEntryResponse(entry entities.TimeEntry) TimeEntryResponse {
+	return TimeEntryResponse{
+		ID:              entry.ID,
+		TaskID:          entry.TaskID,
+		TaskTitle:       entry.TaskTitle,
+		StartedAt:       entry.StartedAt,
+		EndedAt:         entry.EndedAt,
+		DurationSeconds: int64(entry.Duration / time.Second),
+		Running:         entry.Running(),
+		Note:            entry.Note,
+		CreatedAt:       entry.CreatedAt,
+	}
+}
+
+func NewTimeEntryResponses(entries []entities.TimeEntry) []TimeEntryResponse {
+	result := make([]TimeEntryResponse, 0, len(entries))
+
+	for _, entry := range entries {
+		result = append(result, NewTimeEntryResponse(entry))
+	}
+
+	return result
+}
+
+func NewTimeReportResponse(report entities.TimeReport) TimeReportResponse {
+	response := TimeReportResponse{
+		From:         report.From.Format(time.DateOnly),
+		To:           report.To.Format(time.DateOnly),
+		TotalSeconds: int64(report.Total / time.Second),
+		ByDay:        make([]TimeReportDayResponse, 0, len(report.ByDay)),
+		ByCategory:   make([]TimeReportCategoryResponse, 0, len(report.ByCategory)),
+		ByTask:       make([]TimeReportTaskResponse, 0, len(report.ByTask)),
+	}
+
+	for _, day := range report.ByDay {
+		response.ByDay = append(response.ByDay, TimeReportDayResponse{
+			Date:    day.Date.Format(time.DateOnly),
+			Seconds: int64(day.Duration / time.Second),
+		})
+	}
+
+	for _, category := range report.ByCategory {
+		response.ByCategory = append(response.ByCategory, TimeReportCategoryResponse{
+			CategoryID: category.CategoryID,
+			Name:       category.Name,
+			Seconds:    int64(category.Duration / time.Second),
+		})
+	}
+
+	for _, task := range report.ByTask {
+		response.ByTask = append(response.ByTask, TimeReportTaskResponse{
+			TaskID:          task.TaskID,
+			Title:           task.Title,
+			CategoryID:      task.CategoryID,
+			EstimateMinutes: task.EstimateMinutes,
+			Seconds:         int64(task.Duration / time.Second),
+		})
+	}
+
+	return response
+}
@@ -0,0 +1,129 @@
+package dto
+
+import (
+	"strings"
+	"time"
+
+	"todoapp/services/task-service/internal/domain/entities"
+	"todoapp/services/task-service/internal/ports"
+)
+
+// ViewRequest is used both to create and to replace a saved view.
+type ViewRequest struct {
+	Name   string            `json:"name" binding:"required,min=1,max=100"`
+	Filter ViewFilterRequest `json:"filter"`
+}
+
+// ViewFilterRequest mirrors the task list query parameters. DueFrom and DueTo
+// accept date expressions such as "today", "+7d" or "endOfWeek".
+type ViewFilterRequest struct {
+	Statuses             []string `json:"statuses" binding:"omitempty,max=20,dive,max=50"`
+	Priorities           []string `json:"priorities" binding:"omitempty,dive,oneof=low medium high"`
+	CategoryID           *int64   `json:"categoryId" binding:"omitempty,gte=1"`
+	IncludeSubcategories bool     `json:"includeSubcategories"`
+	WithoutCategory      bool     `json:"withoutCategory"`
+	Search               string   `json:"search" binding:"omitempty,max=200"`
+	DueFrom              string   `json:"dueFrom" binding:"omitempty,max=32"`
+	DueTo                string   `json:"dueTo" binding:"omitempty,max=32"`
+}
+
+type ViewTasksRequest struct {
+	Limit  int `form:"limit,default=20"`
+	Offset int `form:"offset,default=0"`
+}
+
+type ViewResponse struct {
+	ID        int64              `json:"id"`
+	Name      string             `json:"name"`
+	Filter    ViewFilterResponse `json:"filter"`
+	CreatedAt time.Time          `json:"createdAt"`
+	UpdatedAt time.Time          `json:"updatedAt"`
+}
+
+type ViewFilterResponse struct {
+	Statuses             []string `json:"statuses"`
+	Priorities           []string `json:"priorities"`
+	CategoryID           *int64   `json:"categoryId,omitempty"`
+	IncludeSubcategories bool     `json:"includeSubcategories"`
+	WithoutCategory      bool     `json:"withoutCategory"`
+	Search               string   `json:"search,omitempty"`
+	DueFrom              string   `json:"dueFrom,omitempty"`
+	DueTo                string   `json:"dueTo,omitempty"`
+}
+
+func (r ViewRequest) toFilter() entities.ViewFilter {
+	filter := entities.ViewFilter{
+		CategoryID:           r.Filter.CategoryID,
+		IncludeSubcategories: r.Filter.IncludeSubcategories,
+		WithoutCategory:      r.Filter.WithoutCategory,
+		Search:               strings.TrimSpace(r.Filter.Search),
+		DueFrom:              strings.TrimSpace(r.Filter.DueFrom),
+		DueTo:                strings.TrimSpace(r.Filter.DueTo),
+	}
+	for _, status := range r.Filter.Statuses {
+		filter.Statuses = append(filter.Statuses, normalizeStatus(status))
+	}
+	for _, priority := range r.Filter.Priorities {
+		filter.Priorities = append(filter.Priorities, entities.TaskPriority(priority))
+	}
+	return filter
+}
+
+func (r ViewRequest) ToCreateInput(userID int64) ports.CreateViewInput {
+	return ports.CreateViewInput{
+		UserID: userID,
+		Name:   strings.TrimSpace(r.Name),
+		Filter: r.toFilter(),
+	}
+}
+
+func (r ViewRequest) ToUpdateInput(userID, viewID int64) ports.UpdateViewInput {
+	return ports.UpdateViewInput{
+		UserID: userID,
+		ViewID: viewID,
+		Name:   strings.TrimSpace(r.Name),
+		Filter: r.toFilter(),
+	}
+}
+
+// Pagination returns the clamped limit and offset.
+func (r ViewTasksRequest) Pagination() (int, int) {
+	return clampLimit(r.Limit), clampOffset(r.Offset)
+}
+
+func NewViewResponse(view entities.SavedView) ViewResponse {
+	filter := ViewFilterResponse{
+		Statuses:             make([]string, 0, len(view.Filter.Statuses)),
+		Priorities:           make([]string, 0, len(view.Filter.Priorities)),
+		CategoryID:           view.Filter.CategoryID,
+		IncludeSubcategories: view.Filter.IncludeSubcategories,
+		WithoutCategory:      view.Filter.WithoutCategory,
+		Search:               view.Filter.Search,
+		DueFrom:              view.Filter.DueFrom,
+		DueTo:                view.Filter.DueTo,
+	}
+	for _, status := range view.Filter.Statuses {
+		filter.Statuses = append(filter.Statuses, string(status))
+	}
+	for _, priority := range view.Filter.Priorities {
+		filter.Priorities = append(filter.Priorities, string(priority))
+	}
+
+	return ViewResponse{
+		ID:        view.ID,
+		Name:      view.Name,
+		Filter:    filter,
+		CreatedAt: view.CreatedAt,
+		UpdatedAt: view.UpdatedAt,
+	}
+}
+
+func NewViewResponses(views []entities.SavedView) []ViewResponse {
+	result := make([]ViewResponse, 0, len(views))
+
+	for _, view := range views {
+		result = append(result, NewViewResponse(view))
+	}
+
+	return result
+}
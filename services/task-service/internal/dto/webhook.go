@@ -0,0 +1,151 @@
+package dto
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"todoapp/pkg/events"
+	"todoapp/services/task-service/internal/domain/entities"
+	"todoapp/services/task-service/internal/ports"
+)
+
+type CreateWebhookRequest struct {
+	URL string `json:"url" binding:"required,max=2048"`
+	// EventTypes limits the events sent; omitted or empty means all of them.
+	EventTypes []string `json:"eventTypes" binding:"omitempty,dive,oneof=task.created task.updated task.completed task.deleted task.commented"`
+}
+
+// UpdateWebhookRequest changes only the fields that are present. An empty
+// eventTypes list subscribes to all events.
+type UpdateWebhookRequest struct {
+	URL        *string  `json:"url" binding:"omitempty,max=2048"`
+	EventTypes []string `json:"eventTypes" binding:"omitempty,dive,oneof=task.created task.updated task.completed task.deleted task.commented"`
+	Active     *bool    `json:"active"`
+}
+
+type WebhookResponse struct {
+	ID                  int64      `json:"id"`
+	URL                 string     `json:"url"`
+	EventTypes          []string   `json:"eventTypes"`
+	Active              bool       `json:"active"`
+	ConsecutiveFailures int        `json:"consecutiveFailures"`
+	DisabledAt          *time.Time `json:"disabledAt,omitempty"`
+	CreatedAt           time.Time  `json:"createdAt"`
+	UpdatedAt           time.Time  `json:"updatedAt"`
+}
+
+// CreateWebhookResponse is the only response that includes the secret.
+type CreateWebhookResponse struct {
+	WebhookResponse
+	Secret string `json:"secret"`
+}
+
+type WebhookDeliveryResponse struct {
+	ID            int64           `json:"id"`
+	EventID       string          `json:"eventId"`
+	EventType     string          `json:"eventType"`
+	Status        string          `json:"status"`
+	Attempts      int             `json:"attempts"`
+	ResponseCode  *int            `json:"responseCode,omitempty"`
+	LastError     string          `json:"lastError,omitempty"`
+	NextAttemptAt *time.Time      `json:"nextAttemptAt,omitempty"`
+	Payload       json.RawMessage `json:"payload"`
+	CreatedAt     time.Time       `json:"createdAt"`
+	CompletedAt   *time.Time      `json:"completedAt,omitempty"`
+}
+
+func (r CreateWebhookRequest) ToInput(userID int64) ports.CreateWebhookInput {
+	return ports.CreateWebhookInput{
+		UserID:     userID,
+		URL:        strings.TrimSpace(r.URL),
+		EventTypes: toEventTypes(r.EventTypes),
+	}
+}
+
+func (r UpdateWebhookRequest) ToInput(userID, webhookID int64) ports.UpdateWebhookInput {
+	return ports.UpdateWebhookInput{
+		UserID:     userID,
+		WebhookID:  webhookID,
+		URL:        r.URL,
+		EventTypes: toEventTypes(r.EventTypes),
+		Active:     r.Active,
+	}
+}
+
+// toEventTypes keeps a nil list nil so updates can tell "unchanged" from "all".
+func toEventTypes(values []string) []events.TaskEventType {
+	if values == nil {
+		return nil
+	}
+	eventTypes := make([]events.TaskEventType, 0, len(values))
+	for _, value := range values {
+		eventTypes = append(eventTypes, events.TaskEventType(value))
+	}
+	return eventTypes
+}
+
+func NewWebhookResponse(webhook entities.Webhook) WebhookResponse {
+	eventTypes := make([]string, 0, len(webhook.EventTypes))
+	for _, eventType := range webhook.EventTypes {
+		eventTypes = append(eventTypes, string(eventType))
+	}
+
+	return WebhookResponse{
+		ID:                  webhook.ID,
+		URL:                 webhook.URL,
+		EventTypes:          eventTypes,
+		Active:              webhook.Active,
+		ConsecutiveFailures: webhook.ConsecutiveFailures,
+		DisabledAt:          webhook.DisabledAt,
+		CreatedAt:           webhook.CreatedAt,
+		UpdatedAt:           webhook.UpdatedAt,
+	}
+}
+
+func NewWebhookResponses(webhooks []entities.Webhook) []WebhookResponse {
+	result := make([]WebhookResponse, 0, len(webhooks))
+
+	for _, webhook := range webhooks {
+		result = append(result, NewWebhookResponse(webhook))
+	}
+
+	return result
+}
+
+func NewCreateWebhookResponse(webhook entities.Webhook) CreateWebhookResponse {
+	return CreateWebhookResponse{
+		WebhookResponse: NewWebhookResponse(webhook),
+		Secret:          webhook.Secret,
+	}
+}
+
+func NewWebhookDeliveryResponse(delivery entities.WebhookDelivery) WebhookDeliveryResponse {
+	response := WebhookDeliveryResponse{
+		ID:           delivery.ID,
+		EventID:      delivery.EventID,
+		EventType:    string(delivery.EventType),
+		Status:       string(delivery.Status),
+		Attempts:     delivery.Attempts,
+		ResponseCode: delivery.ResponseCode,
+		LastError:    delivery.LastError,
+		Payload:      json.RawMessage(delivery.Payload),
+		CreatedAt:    delivery.CreatedAt,
+		CompletedAt:  delivery.CompletedAt,
+	}
+	if delivery.Status == entities.WebhookDeliveryPending {
+		next := delivery.NextAttemptAt
+		response.NextAttemptAt = &next
+	}
+	return response
+}
+
+func NewWebhookDeliveryResponses(deliveries []entities.WebhookDelivery) []WebhookDeliveryResponse {
+	result := make([]WebhookDeliveryResponse, 0, len(deliveries))
+
+	for _, delivery := range deliveries {
+		result = append(result, NewWebhookDeliveryResponse(delivery))
+	}
+
+	return result
+}
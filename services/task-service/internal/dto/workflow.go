@@ -0,0 +1,140 @@
+package dto
+
+import (
+	"strings"
+	"time"
+
+	"todoapp/services/task-service/internal/domain/entities"
+	"todoapp/services/task-service/internal/ports"
+)
+
+// WorkflowRequest is used both to create and to replace a workflow.
+type WorkflowRequest struct {
+	Name        string                      `json:"name" binding:"required,min=1,max=100"`
+	CategoryID  *int64                      `json:"categoryId" binding:"omitempty,gte=1"`
+	Statuses    []WorkflowStatusRequest     `json:"statuses" binding:"required,min=1,max=20,dive"`
+	Transitions []WorkflowTransitionRequest `json:"transitions" binding:"omitempty,max=400,dive"`
+}
+
+type WorkflowStatusRequest struct {
+	Key       string `json:"key" binding:"required,max=50"`
+	Name      string `json:"name" binding:"omitempty,max=100"`
+	Completed bool   `json:"completed"`
+}
+
+type WorkflowTransitionRequest struct {
+	From string `json:"from" binding:"required,max=50"`
+	To   string `json:"to" binding:"required,max=50"`
+}
+
+type EffectiveWorkflowRequest struct {
+	CategoryID *int64 `form:"categoryId" binding:"omitempty,gte=1"`
+}
+
+type WorkflowResponse struct {
+	ID          int64                        `json:"id"`
+	Name        string                       `json:"name"`
+	CategoryID  *int64                       `json:"categoryId,omitempty"`
+	BuiltIn     bool                         `json:"builtIn,omitempty"`
+	Statuses    []WorkflowStatusResponse     `json:"statuses"`
+	Transitions []WorkflowTransitionResponse `json:"transitions"`
+	CreatedAt   *time.Time                   `json:"createdAt,omitempty"`
+	UpdatedAt   *time.Time                   `json:"updatedAt,omitempty"`
+}
+
+type WorkflowStatusResponse struct {
+	Key       string `json:"key"`
+	Name      string `json:"name"`
+	Completed bool   `json:"completed"`
+}
+
+type WorkflowTransitionResponse struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+func (r WorkflowRequest) toWorkflowInput() ports.WorkflowInput {
+	statuses := make([]entities.WorkflowStatus, 0, len(r.Statuses))
+	for _, status := range r.Statuses {
+		statuses = append(statuses, entities.WorkflowStatus{
+			Key:       normalizeStatus(status.Key),
+			Name:      strings.TrimSpace(status.Name),
+			Completed: status.Completed,
+		})
+	}
+
+	transitions := make([]entities.WorkflowTransition, 0, len(r.Transitions))
+	for _, transition := range r.Transitions {
+		transitions = append(transitions, entities.WorkflowTransition{
+			From: normalizeStatus(transition.From),
+			To:   normalizeStatus(transition.To),
+		})
+	}
+
+	return ports.WorkflowInput{
+		Name:        strings.TrimSpace(r.Name),
+		CategoryID:  r.CategoryID,
+		Statuses:    statuses,
+		Transitions: transitions,
+	}
+}
+
+func (r WorkflowRequest) ToCreateInput(userID int64) ports.CreateWorkflowInput {
+	return ports.CreateWorkflowInput{
+		UserID:        userID,
+		WorkflowInput: r.toWorkflowInput(),
+	}
+}
+
+func (r WorkflowRequest) ToUpdateInput(userID, workflowID int64) ports.UpdateWorkflowInput {
+	return ports.UpdateWorkflowInput{
+		UserID:        userID,
+		WorkflowID:    workflowID,
+		WorkflowInput: r.toWorkflowInput(),
+	}
+}
+
+// NewWorkflowResponse marks the built-in workflow, which has no id and no
+// timestamps.
+func NewWorkflowResponse(workflow entities.Workflow) WorkflowResponse {
+	response := WorkflowResponse{
+		ID:          workflow.ID,
+		Name:        workflow.Name,
+		CategoryID:  workflow.CategoryID,
+		BuiltIn:     workflow.IsDefault(),
+		Statuses:    make([]WorkflowStatusResponse, 0, len(workflow.Statuses)),
+		Transitions: make([]WorkflowTransitionResponse, 0, len(workflow.Transitions)),
+	}
+
+	for _, status := range workflow.Statuses {
+		response.Statuses = append(response.Statuses, WorkflowStatusResponse{
+			Key:       string(status.Key),
+			Name:      status.Name,
+			Completed: status.Completed,
+		})
+	}
+	for _, transition := range workflow.Transitions {
+		response.Transitions = append(response.Transitions, WorkflowTransitionResponse{
+			From: string(transition.From),
+			To:   string(transition.To),
+		})
+	}
+
+	if !workflow.IsDefault() {
+		createdAt, updatedAt := workflow.CreatedAt, workflow.UpdatedAt
+		response.CreatedAt = &createdAt
+		response.UpdatedAt = &updatedAt
+	}
+
+	return response
+}
+
+func NewWorkflowResponses(workflows []entities.Workflow) []WorkflowResponse {
+	result := make([]WorkflowResponse, 0, len(workflows))
+
+	for _, workflow := range workflows {
+		result = append(result, NewWorkflowResponse(workflow))
+	}
+
+	return result
+}
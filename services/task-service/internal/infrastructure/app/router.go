@@ -2,20 +2,62 @@ package app
 
 import (
 	"fmt"
+	"log/slog"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 
+	"todoapp/pkg/health"
+	"todoapp/pkg/logging"
+	"todoapp/pkg/metrics"
+	"todoapp/pkg/ratelimit"
+	"todoapp/pkg/requestid"
+	"todoapp/pkg/tracing"
+	"todoapp/services/task-service/internal/adapters/clients/degraded"
+	dbadapter "todoapp/services/task-service/internal/adapters/database"
+	attachmentshttp "todoapp/services/task-service/internal/adapters/http/attachments"
+	customfieldshttp "todoapp/services/task-service/internal/adapters/http/customfields"
 	exporthttp "todoapp/services/task-service/internal/adapters/http/export"
 	middlewarehttp "todoapp/services/task-service/internal/adapters/http/middleware"
+	quickaddhttp "todoapp/services/task-service/internal/adapters/http/quickadd"
+	streamhttp "todoapp/services/task-service/internal/adapters/http/stream"
 	taskshttp "todoapp/services/task-service/internal/adapters/http/tasks"
+	templateshttp "todoapp/services/task-service/internal/adapters/http/templates"
+	timetrackinghttp "todoapp/services/task-service/internal/adapters/http/timetracking"
+	viewshttp "todoapp/services/task-service/internal/adapters/http/views"
+	webhookshttp "todoapp/services/task-service/internal/adapters/http/webhooks"
+	workflowshttp "todoapp/services/task-service/internal/adapters/http/workflows"
 	"todoapp/services/task-service/internal/ports"
 )
 
 type HTTPDeps struct {
-	TaskService ports.TaskService
-	TokenMgr    ports.TokenManager
-	ServiceName string
+	TaskService       ports.TaskService
+	AttachmentService ports.AttachmentService
+	// MaxAttachmentSize caps attachment upload bodies; it should match the
+	// attachment service's MaxFileSize.
+	MaxAttachmentSize   int64
+	TemplateService     ports.TemplateService
+	QuickAddService     ports.QuickAddService
+	ViewService         ports.ViewService
+	TimeTrackingService ports.TimeTrackingService
+	WebhookService      ports.WebhookService
+	WorkflowService     ports.WorkflowService
+	CustomFieldService  ports.CustomFieldService
+	ChangeStream        ports.ChangeStream
+	TokenMgr            ports.TokenManager
+	// TokenValidator, when set, rejects tokens revoked before they expire.
+	TokenValidator ports.TokenValidator
+	RateLimiter    ratelimit.Limiter
+	ServiceName    string
+	// TrustedProxies are the addresses and CIDRs allowed to report the client
+	// address in X-Forwarded-For; the peer address is used when empty.
+	TrustedProxies []string
+	// Readiness runs the checks behind /health/ready; none when nil.
+	Readiness *health.Checker
+	// Logger receives the request log; slog.Default() when nil.
+	Logger *slog.Logger
 }
 
 func NewRouter(deps HTTPDeps) (*gin.Engine, error) {
@@ -23,23 +65,88 @@ func NewRouter(deps HTTPDeps) (*gin.Engine, error) {
 		return nil, err
 	}
 
+	logger := deps.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+
 	router := gin.New()
-	router.Use(gin.Logger(), gin.Recovery())
+	if err := router.SetTrustedProxies(deps.TrustedProxies); err != nil {
+		return nil, fmt.Errorf("trusted proxies: %w", err)
+	}
+	router.Use(tracing.Middleware(deps.ServiceName), requestid.Middleware(), logging.Middleware(logger), metrics.Middleware(), gin.Recovery(), readOnlyRequests(), taskSpanAttributes())
 
-	router.GET("/health", healthHandler(deps.ServiceName))
-	router.HEAD("/health", healthHandler(deps.ServiceName))
+	serviceName := deps.ServiceName
+	if serviceName == "" {
+		serviceName = "task-service"
+	}
+	health.RegisterRoutes(router, serviceName, deps.Readiness)
+	router.GET(metrics.Path, gin.WrapH(metrics.Handler()))
 
-	security := middlewarehttp.New(deps.TokenMgr)
+	var securityOpts []middlewarehttp.Option
+	if deps.TokenValidator != nil {
+		securityOpts = append(securityOpts, middlewarehttp.WithTokenValidator(deps.TokenValidator))
+	}
+	security := middlewarehttp.New(deps.TokenMgr, securityOpts...)
+	rateLimit := ratelimit.Middleware(deps.RateLimiter, middlewarehttp.RateLimitKey)
 
 	protected := router.Group("")
-	protected.Use(security.JWT())
+	protected.Use(security.JWT(), rateLimit)
 
 	taskHandler := taskshttp.New(deps.TaskService)
 	taskHandler.RegisterRoutes(protected)
 
-	exportHandler := exporthttp.New(deps.TaskService)
+	exportHandler := exporthttp.New(deps.TaskService, deps.ViewService)
 	exportHandler.RegisterRoutes(protected)
 
+	if deps.AttachmentService != nil {
+		attachmentHandler := attachmentshttp.New(deps.AttachmentService, deps.MaxAttachmentSize)
+		attachmentHandler.RegisterRoutes(protected)
+	}
+
+	if deps.QuickAddService != nil {
+		quickAddHandler := quickaddhttp.New(deps.QuickAddService)
+		quickAddHandler.RegisterRoutes(protected)
+	}
+
+	if deps.TemplateService != nil {
+		templateHandler := templateshttp.New(deps.TemplateService)
+		templateHandler.RegisterRoutes(protected)
+	}
+
+	if deps.ViewService != nil {
+		viewHandler := viewshttp.New(deps.ViewService)
+		viewHandler.RegisterRoutes(protected)
+	}
+
+	if deps.TimeTrackingService != nil {
+		timeTrackingHandler := timetrackinghttp.New(deps.TimeTrackingService)
+		timeTrackingHandler.RegisterRoutes(protected)
+	}
+
+	if deps.WebhookService != nil {
+		webhookHandler := webhookshttp.New(deps.WebhookService)
+		webhookHandler.RegisterRoutes(protected)
+	}
+
+	if deps.WorkflowService != nil {
+		workflowHandler := workflowshttp.New(deps.WorkflowService)
+		workflowHandler.RegisterRoutes(protected)
+	}
+
+	if deps.CustomFieldService != nil {
+		customFieldHandler := customfieldshttp.New(deps.CustomFieldService)
+		customFieldHandler.RegisterRoutes(protected)
+	}
+
+	if deps.ChangeStream != nil {
+		streaming := router.Group("")
+		streaming.Use(security.JWTOrQueryToken(), rateLimit)
+
+		streamHandler := streamhttp.New(deps.ChangeStream)
+		streamHandler.RegisterRoutes(streaming)
+	}
+
 	return router, nil
 }
 
@@ -49,25 +156,35 @@ func validateHTTPDeps(deps HTTPDeps) error {
 		return fmt.Errorf("task service is required")
 	case deps.TokenMgr == nil:
 		return fmt.Errorf("token manager is required")
+	case deps.AttachmentService != nil && deps.MaxAttachmentSize <= 0:
+		return fmt.Errorf("max attachment size is required")
 	default:
 		return nil
 	}
 }
 
-func healthHandler(serviceName string) gin.HandlerFunc {
-	if serviceName == "" {
-		serviceName = "task-service"
+// readOnlyRequests lets GET and HEAD requests read from the replica and
+// proceed while user-service is unavailable. Other requests may write, so
+// they read from the primary and see their changes, and need a verified user.
+func readOnlyRequests() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if ctx.Request.Method == http.MethodGet || ctx.Request.Method == http.MethodHead {
+			reqCtx := dbadapter.WithReplicaReads(ctx.Request.Context())
+			ctx.Request = ctx.Request.WithContext(degraded.WithReadOnly(reqCtx))
+		}
+		ctx.Next()
 	}
+}
 
+// taskSpanAttributes tags the request span with the task of /tasks/:id
+// routes.
+func taskSpanAttributes() gin.HandlerFunc {
 	return func(ctx *gin.Context) {
-		if ctx.Request.Method == http.MethodHead {
-			ctx.Status(http.StatusOK)
-			return
+		if strings.HasPrefix(ctx.FullPath(), "/tasks/:id") {
+			if taskID, err := strconv.ParseInt(ctx.Param("id"), 10, 64); err == nil {
+				tracing.SetTaskID(ctx.Request.Context(), taskID)
+			}
 		}
-
-		ctx.JSON(http.StatusOK, gin.H{
-			"status":  "ok",
-			"service": serviceName,
-		})
+		ctx.Next()
 	}
 }
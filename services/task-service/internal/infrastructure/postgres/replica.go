@@ -0,0 +1,29 @@
+package postgres
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"todoapp/pkg/pgreplica"
+	"todoapp/services/task-service/internal/infrastructure/config"
+)
+
+// NewReplicaPool connects to the replica configured in cfg. It returns nil
+// without error when no replica is configured, so callers read from the
+// primary alone.
+func NewReplicaPool(ctx context.Context, cfg config.Config, primary *pgxpool.Pool, logger *slog.Logger) (*pgreplica.Pool, error) {
+	if cfg.ReplicaURL() == "" {
+		return nil, nil
+	}
+
+	return pgreplica.New(ctx, pgreplica.Config{
+		URL:             cfg.ReplicaURL(),
+		MaxConns:        cfg.Postgres.MaxConns,
+		MinConns:        cfg.Postgres.MinConns,
+		MaxConnLifetime: cfg.Postgres.MaxLifetime,
+		MaxLag:          cfg.Postgres.ReplicaMaxLag,
+		CheckInterval:   cfg.Postgres.ReplicaCheckInterval,
+	}, primary, logger)
+}
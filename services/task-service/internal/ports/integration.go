@@ -6,21 +6,63 @@ import (
 
 	"todoapp/pkg/events"
 	analyticsv1 "todoapp/pkg/proto/analytics/v1"
+	"todoapp/services/task-service/internal/domain/entities"
 )
 
 type AnalyticsEvent struct {
+	// ID lets analytics-service drop redelivered events.
+	ID         string
 	Type       analyticsv1.TaskEventType
 	UserID     int64
 	TaskID     int64
 	Status     string
 	Priority   string
 	OccurredAt time.Time
+	// Duration is set for TASK_EVENT_TYPE_TIME_TRACKED events.
+	Duration time.Duration
 }
 
 type AnalyticsTracker interface {
 	TrackTaskEvent(ctx context.Context, event AnalyticsEvent) error
 }
 
+// AnalyticsBatchTracker sends several events in one call; either all of them
+// are accepted or the call fails.
+type AnalyticsBatchTracker interface {
+	BatchTrackTaskEvents(ctx context.Context, events []AnalyticsEvent) error
+}
+
 type TaskEventPublisher interface {
 	Publish(ctx context.Context, event events.TaskEvent) error
 }
+
+// WebhookDispatcher queues task events for the user's webhooks.
+type WebhookDispatcher interface {
+	DispatchTaskEvent(ctx context.Context, event events.TaskEvent) error
+}
+
+// WebhookRequest is a delivery ready to be signed and sent.
+type WebhookRequest struct {
+	URL        string
+	Secret     string
+	DeliveryID int64
+	EventType  events.TaskEventType
+	Payload    []byte
+	Timestamp  time.Time
+}
+
+type WebhookSender interface {
+	// Send posts the signed payload and returns the response status code. The
+	// error is set only when no response was received.
+	Send(ctx context.Context, request WebhookRequest) (int, error)
+}
+
+// ChangeListener receives the change events recorded by any replica.
+type ChangeListener interface {
+	// Listen calls fn for each recorded event until ctx is cancelled or the
+	// connection fails. With afterID > 0 it first replays the stored events
+	// recorded after that ID, covering the time the listener was down, and
+	// those with a lower ID that committed after it. An event is delivered
+	// once.
+	Listen(ctx context.Context, afterID int64, fn func(entities.ChangeEvent)) error
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"todoapp/pkg/events"
 	"todoapp/services/task-service/internal/domain/entities"
 )
 
@@ -11,11 +12,31 @@ type TaskFilter struct {
 	Statuses   []entities.TaskStatus
 	Priorities []entities.TaskPriority
 	CategoryID *int64
-	Search     string
-	DueFrom    *time.Time
-	DueTo      *time.Time
-	Limit      int
-	Offset     int
+	// IncludeSubcategories extends CategoryID to all of its descendants.
+	IncludeSubcategories bool
+	// WithoutCategory matches only uncategorized tasks.
+	WithoutCategory bool
+	// OrderByPosition sorts by board position instead of due date.
+	OrderByPosition bool
+	Search          string
+	DueFrom         *time.Time
+	DueTo           *time.Time
+	// CustomFields keeps tasks whose custom field values match every filter.
+	CustomFields []CustomFieldFilter
+	// SortField orders tasks by the value of the custom field with this key,
+	// tasks without a value last. It takes precedence over OrderByPosition.
+	SortField      string
+	SortDescending bool
+	Limit          int
+	Offset         int
+}
+
+// CustomFieldFilter matches one custom field. Callers pass the raw value from
+// the request; TaskService replaces it with the typed value stored on tasks.
+// A multi_select filter matches tasks whose values include it.
+type CustomFieldFilter struct {
+	Key   string
+	Value any
 }
 
 type TaskRepository interface {
@@ -24,12 +45,248 @@ type TaskRepository interface {
 	SoftDeleteTask(ctx context.Context, userID, taskID int64, deletedAt time.Time) error
 	GetTask(ctx context.Context, userID, taskID int64) (*entities.Task, error)
 	ListTasks(ctx context.Context, userID int64, filter TaskFilter) ([]entities.Task, error)
+	// MoveTask persists the status and position of a task.
+	MoveTask(ctx context.Context, task *entities.Task) error
+	// AdjacentTaskPosition returns the position of the nearest task in the
+	// status column strictly after (or before) position, ignoring excludeTaskID.
+	// It returns nil when there is no such task.
+	AdjacentTaskPosition(ctx context.Context, userID int64, status entities.TaskStatus, position float64, after bool, excludeTaskID int64) (*float64, error)
+	// LockTaskColumn locks the tasks of a status column until the transaction
+	// ends, so moves into the column are placed one at a time.
+	LockTaskColumn(ctx context.Context, userID int64, status entities.TaskStatus) error
+	// RebalanceTaskPositions renumbers a status column with evenly spaced
+	// positions, keeping the current order.
+	RebalanceTaskPositions(ctx context.Context, userID int64, status entities.TaskStatus) error
 
 	CreateCategory(ctx context.Context, category *entities.Category) error
 	ListCategories(ctx context.Context, userID int64) ([]entities.Category, error)
+	// LockCategories locks the user's categories until the transaction
+	// ends, so parent changes are checked and written one at a time.
+	LockCategories(ctx context.Context, userID int64) error
 	GetCategory(ctx context.Context, userID, categoryID int64) (*entities.Category, error)
-	DeleteCategory(ctx context.Context, userID, categoryID int64) error
+	UpdateCategory(ctx context.Context, category *entities.Category) error
+	ReorderCategories(ctx context.Context, userID int64, categoryIDs []int64) error
+	DeleteCategory(ctx context.Context, userID, categoryID int64, mode entities.CategoryDeleteMode) error
 
 	CreateComment(ctx context.Context, comment *entities.TaskComment) error
 	ListComments(ctx context.Context, userID, taskID int64) ([]entities.TaskComment, error)
 }
+
+type AttachmentRepository interface {
+	// CreateAttachment stores the attachment unless the user's attachments
+	// would then take more than quota bytes, in which case it returns
+	// ErrQuotaExceeded. Uploads of the same user are checked one at a time, so
+	// concurrent ones cannot exceed the quota together.
+	CreateAttachment(ctx context.Context, attachment *entities.Attachment, quota int64) error
+	GetAttachment(ctx context.Context, userID, taskID, attachmentID int64) (*entities.Attachment, error)
+	ListAttachments(ctx context.Context, userID, taskID int64) ([]entities.Attachment, error)
+	DeleteAttachment(ctx context.Context, userID, taskID, attachmentID int64) error
+	// DeleteTaskAttachments removes all attachment rows of a task and returns them
+	// so the caller can release the underlying blobs.
+	DeleteTaskAttachments(ctx context.Context, userID, taskID int64) ([]entities.Attachment, error)
+	UserStorageUsage(ctx context.Context, userID int64) (int64, error)
+}
+
+type TemplateRepository interface {
+	CreateTemplate(ctx context.Context, template *entities.TaskTemplate) error
+	GetTemplate(ctx context.Context, userID, templateID int64) (*entities.TaskTemplate, error)
+	ListTemplates(ctx context.Context, userID int64) ([]entities.TaskTemplate, error)
+	UpdateTemplate(ctx context.Context, template *entities.TaskTemplate) error
+	DeleteTemplate(ctx context.Context, userID, templateID int64) error
+}
+
+// WorkflowResolver finds the workflow that applies to a task.
+type WorkflowResolver interface {
+	// ResolveWorkflow returns the workflow of the category or of its closest
+	// ancestor that has one, falling back to the user's default workflow. It
+	// returns nil when the user has defined neither.
+	ResolveWorkflow(ctx context.Context, userID int64, categoryID *int64) (*entities.Workflow, error)
+}
+
+type WorkflowRepository interface {
+	WorkflowResolver
+	// CreateWorkflow fails with domain.ErrCategoryNotFound when the category
+	// does not belong to the user.
+	CreateWorkflow(ctx context.Context, workflow *entities.Workflow) error
+	GetWorkflow(ctx context.Context, userID, workflowID int64) (*entities.Workflow, error)
+	ListWorkflows(ctx context.Context, userID int64) ([]entities.Workflow, error)
+	// UpdateWorkflow replaces the name, category, statuses and transitions.
+	UpdateWorkflow(ctx context.Context, workflow *entities.Workflow) error
+	DeleteWorkflow(ctx context.Context, userID, workflowID int64) error
+}
+
+// CustomFieldLister reads the custom field definitions of a user.
+type CustomFieldLister interface {
+	ListCustomFields(ctx context.Context, userID int64) ([]entities.CustomField, error)
+}
+
+type CustomFieldRepository interface {
+	CustomFieldLister
+	CreateCustomField(ctx context.Context, field *entities.CustomField) error
+	GetCustomField(ctx context.Context, userID, fieldID int64) (*entities.CustomField, error)
+	// UpdateCustomField changes the name and options of a field.
+	UpdateCustomField(ctx context.Context, field *entities.CustomField) error
+	// DeleteCustomField also removes the field's values from the user's tasks.
+	DeleteCustomField(ctx context.Context, userID, fieldID int64) error
+}
+
+type ViewRepository interface {
+	CreateView(ctx context.Context, view *entities.SavedView) error
+	GetView(ctx context.Context, userID, viewID int64) (*entities.SavedView, error)
+	ListViews(ctx context.Context, userID int64) ([]entities.SavedView, error)
+	UpdateView(ctx context.Context, view *entities.SavedView) error
+	DeleteView(ctx context.Context, userID, viewID int64) error
+}
+
+// TimeEntryFilter narrows time entry listings. From and To bound StartedAt,
+// To being exclusive.
+type TimeEntryFilter struct {
+	TaskID *int64
+	From   *time.Time
+	To     *time.Time
+}
+
+type TimeEntryRepository interface {
+	// CreateTimeEntry stores a finished entry or, when EndedAt is nil, starts a
+	// timer. Starting a second timer fails with domain.ErrTimerRunning.
+	CreateTimeEntry(ctx context.Context, entry *entities.TimeEntry) error
+	GetRunningTimeEntry(ctx context.Context, userID int64) (*entities.TimeEntry, error)
+	// StopTimeEntry persists EndedAt and Duration of a running entry.
+	StopTimeEntry(ctx context.Context, entry *entities.TimeEntry) error
+	ListTimeEntries(ctx context.Context, userID int64, filter TimeEntryFilter) ([]entities.TimeEntry, error)
+	DeleteTimeEntry(ctx context.Context, userID, taskID, entryID int64) error
+}
+
+// OverdueRepository tracks which overdue tasks have been announced. A task is
+// announced once per due date.
+type OverdueRepository interface {
+	// ListOverdueTasks returns open tasks due before now that have not been
+	// announced for their current due date, oldest due date first.
+	ListOverdueTasks(ctx context.Context, now time.Time, limit int) ([]entities.Task, error)
+	// ClaimOverdueTask records the announcement. It returns false when the task
+	// was already claimed for this due date, e.g. by another instance.
+	ClaimOverdueTask(ctx context.Context, taskID int64, dueDate time.Time) (bool, error)
+	// ReleaseOverdueTask undoes a claim whose event could not be published.
+	ReleaseOverdueTask(ctx context.Context, taskID int64, dueDate time.Time) error
+}
+
+// TransactionManager makes the repository calls inside fn atomic.
+type TransactionManager interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+// OutboxMessage is a task event waiting in the outbox to be relayed.
+type OutboxMessage struct {
+	ID       int64
+	Event    events.TaskEvent
+	Attempts int
+	// RequestID is the request that produced the event, if any.
+	RequestID string
+	// TraceParent is the W3C trace context of that request, if any.
+	TraceParent string
+}
+
+// OutboxRepository is the relay side of the transactional outbox. Events are
+// written through the TaskEventPublisher implemented by the same adapter.
+type OutboxRepository interface {
+	// FetchPendingOutbox locks up to limit unsent messages that are due at now.
+	// Only the oldest unsent message of each task is returned, so events of a
+	// task are relayed in order. Call it inside a transaction.
+	FetchPendingOutbox(ctx context.Context, now time.Time, limit int) ([]OutboxMessage, error)
+	MarkOutboxSent(ctx context.Context, id int64, sentAt time.Time) error
+	// MarkOutboxFailed counts a failed attempt and postpones the message.
+	MarkOutboxFailed(ctx context.Context, id int64, nextAttemptAt time.Time, lastError string) error
+	// PurgeSentOutbox deletes messages sent before the given time.
+	PurgeSentOutbox(ctx context.Context, before time.Time) (int64, error)
+	OutboxBacklog(ctx context.Context) (OutboxBacklog, error)
+}
+
+// OutboxBacklog describes the messages not relayed yet.
+type OutboxBacklog struct {
+	Pending int64
+	// Oldest is when the oldest pending message was written, zero when
+	// nothing is pending.
+	Oldest time.Time
+}
+
+// AnalyticsOutboxMessage is an analytics event waiting to be sent.
+type AnalyticsOutboxMessage struct {
+	ID       int64
+	Event    AnalyticsEvent
+	Attempts int
+}
+
+// AnalyticsOutboxRepository is the relay side of the analytics outbox. Events
+// are written through the AnalyticsTracker implemented by the same adapter.
+type AnalyticsOutboxRepository interface {
+	// FetchPendingAnalytics locks up to limit messages that are due at now.
+	// Call it inside a transaction.
+	FetchPendingAnalytics(ctx context.Context, now time.Time, limit int) ([]AnalyticsOutboxMessage, error)
+	DeleteAnalytics(ctx context.Context, ids []int64) error
+	// MarkAnalyticsFailed counts a failed attempt and postpones the messages.
+	MarkAnalyticsFailed(ctx context.Context, ids []int64, nextAttemptAt time.Time, lastError string) error
+}
+
+// DueWebhookDelivery is a leased delivery together with its target.
+type DueWebhookDelivery struct {
+	Delivery entities.WebhookDelivery
+	URL      string
+	Secret   string
+}
+
+// WebhookAttempt is the outcome of sending a delivery once.
+type WebhookAttempt struct {
+	DeliveryID   int64
+	WebhookID    int64
+	At           time.Time
+	ResponseCode *int
+	Error        string
+	Succeeded    bool
+	// NextAttemptAt schedules a retry of a failed attempt; nil gives up.
+	NextAttemptAt *time.Time
+	// Test attempts leave the webhook's failure count alone.
+	Test bool
+}
+
+type WebhookRepository interface {
+	CreateWebhook(ctx context.Context, webhook *entities.Webhook) error
+	CountWebhooks(ctx context.Context, userID int64) (int, error)
+	ListWebhooks(ctx context.Context, userID int64) ([]entities.Webhook, error)
+	GetWebhook(ctx context.Context, userID, webhookID int64) (*entities.Webhook, error)
+	// UpdateWebhook saves the URL, event types, active flag, failure count and
+	// disabled time.
+	UpdateWebhook(ctx context.Context, webhook *entities.Webhook) error
+	DeleteWebhook(ctx context.Context, userID, webhookID int64) error
+	// ListWebhookDeliveries returns the latest deliveries, newest first.
+	ListWebhookDeliveries(ctx context.Context, webhookID int64, limit int) ([]entities.WebhookDelivery, error)
+
+	// EnqueueWebhookDeliveries creates a pending delivery of the event for every
+	// active webhook of the user subscribed to its type and returns how many
+	// were created.
+	EnqueueWebhookDeliveries(ctx context.Context, userID int64, event events.TaskEvent, payload []byte) (int, error)
+	CreateWebhookDelivery(ctx context.Context, delivery *entities.WebhookDelivery) error
+	// LeaseDueWebhookDeliveries picks up to limit pending deliveries of active
+	// webhooks that are due at now and postpones them to leaseUntil, so other
+	// workers skip them and they are retried if this one dies.
+	LeaseDueWebhookDeliveries(ctx context.Context, now, leaseUntil time.Time, limit int) ([]DueWebhookDelivery, error)
+	// RecordWebhookAttempt updates the delivery and the webhook's failure
+	// count, disabling the webhook when the count reaches disableAfter. It
+	// reports whether the webhook was disabled.
+	RecordWebhookAttempt(ctx context.Context, attempt WebhookAttempt, disableAfter int) (bool, error)
+	// PurgeWebhookDeliveries deletes finished deliveries completed before the
+	// given time.
+	PurgeWebhookDeliveries(ctx context.Context, before time.Time) (int64, error)
+}
+
+// ChangeRecorder stores change events for the real-time stream and announces
+// them to every replica once the surrounding transaction commits.
+type ChangeRecorder interface {
+	// RecordChange sets the event ID and CreatedAt.
+	RecordChange(ctx context.Context, event *entities.ChangeEvent) error
+}
+
+type ChangeEventRepository interface {
+	ChangeRecorder
+	// PurgeChanges deletes events recorded before the given time.
+	PurgeChanges(ctx context.Context, before time.Time) (int64, error)
+}
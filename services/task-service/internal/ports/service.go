@@ -2,8 +2,10 @@ package ports
 
 import (
 	"context"
+	"io"
 	"time"
 
+	"todoapp/pkg/events"
 	"todoapp/services/task-service/internal/domain/entities"
 )
 
@@ -11,10 +13,15 @@ type CreateTaskInput struct {
 	UserID      int64
 	Title       string
 	Description string
-	Status      entities.TaskStatus
-	Priority    entities.TaskPriority
-	DueDate     *time.Time
-	CategoryID  *int64
+	// Status defaults to the first status of the task's workflow when empty.
+	Status     entities.TaskStatus
+	Priority   entities.TaskPriority
+	DueDate    *time.Time
+	CategoryID *int64
+	// EstimateMinutes is the expected effort, nil when not estimated.
+	EstimateMinutes *int
+	// CustomFields are checked against the user's custom field definitions.
+	CustomFields entities.CustomFieldValues
 }
 
 type UpdateTaskInput struct {
@@ -28,6 +35,12 @@ type UpdateTaskInput struct {
 	ClearDueDate  bool
 	CategoryID    *int64
 	ClearCategory bool
+	// EstimateMinutes replaces the estimate; ClearEstimate removes it.
+	EstimateMinutes *int
+	ClearEstimate   bool
+	// CustomFields sets the listed values and keeps the others; a nil value
+	// removes the field from the task.
+	CustomFields entities.CustomFieldValues
 }
 
 type AddCommentInput struct {
@@ -37,8 +50,171 @@ type AddCommentInput struct {
 }
 
 type CreateCategoryInput struct {
+	UserID   int64
+	ParentID *int64
+	Name     string
+	Color    string
+	Icon     string
+}
+
+type UpdateCategoryInput struct {
+	UserID      int64
+	CategoryID  int64
+	Name        *string
+	Color       *string
+	Icon        *string
+	ParentID    *int64
+	ClearParent bool
+}
+
+// TemplateInput holds the editable fields of a task template.
+type TemplateInput struct {
+	Name        string
+	Title       string
+	Description string
+	Priority    entities.TaskPriority
+	CategoryID  *int64
+	DueOffset   string
+	Items       []entities.TemplateItem
+}
+
+type CreateTemplateInput struct {
+	UserID int64
+	TemplateInput
+}
+
+// UpdateTemplateInput replaces all editable fields of a template.
+type UpdateTemplateInput struct {
+	UserID     int64
+	TemplateID int64
+	TemplateInput
+}
+
+type CreateCustomFieldInput struct {
+	UserID  int64
+	Key     string
+	Name    string
+	Type    entities.CustomFieldType
+	Options []string
+}
+
+// UpdateCustomFieldInput changes the name and, for select fields, the
+// options. Nil fields are left unchanged.
+type UpdateCustomFieldInput struct {
+	UserID  int64
+	FieldID int64
+	Name    *string
+	Options []string
+}
+
+// WorkflowInput holds the editable fields of a workflow.
+type WorkflowInput struct {
+	Name string
+	// CategoryID scopes the workflow to a category; nil makes it the user's
+	// default workflow.
+	CategoryID  *int64
+	Statuses    []entities.WorkflowStatus
+	Transitions []entities.WorkflowTransition
+}
+
+type CreateWorkflowInput struct {
+	UserID int64
+	WorkflowInput
+}
+
+// UpdateWorkflowInput replaces all editable fields of a workflow.
+type UpdateWorkflowInput struct {
+	UserID     int64
+	WorkflowID int64
+	WorkflowInput
+}
+
+type InstantiateTemplateInput struct {
+	UserID     int64
+	TemplateID int64
+	// Variables are substituted into {{name}} placeholders.
+	Variables map[string]string
+	// CategoryID overrides the template's default category.
+	CategoryID *int64
+}
+
+// MoveTaskInput places a task in a status column. AfterID is the task that
+// should end up directly above it and BeforeID the one directly below; when
+// both are nil the task is appended to the end of the column.
+type MoveTaskInput struct {
+	UserID   int64
+	TaskID   int64
+	Status   entities.TaskStatus
+	AfterID  *int64
+	BeforeID *int64
+}
+
+type CreateViewInput struct {
+	UserID int64
+	Name   string
+	Filter entities.ViewFilter
+}
+
+// UpdateViewInput replaces the name and filter of a view.
+type UpdateViewInput struct {
 	UserID int64
+	ViewID int64
 	Name   string
+	Filter entities.ViewFilter
+}
+
+// QuickAddResult describes how a quick-add line was interpreted together with
+// the task created from it.
+type QuickAddResult struct {
+	Input CreateTaskInput
+	// CategoryName is the category referenced with '#', empty when none matched.
+	CategoryName string
+	// HasTime reports whether the due date includes an explicit time of day.
+	HasTime bool
+	Task    *entities.Task
+}
+
+type AddTimeEntryInput struct {
+	UserID    int64
+	TaskID    int64
+	StartedAt time.Time
+	Duration  time.Duration
+	Note      string
+}
+
+// TimeReportInput selects whole days in the user's timezone; From and To are
+// inclusive and only their dates are used.
+type TimeReportInput struct {
+	UserID int64
+	From   time.Time
+	To     time.Time
+}
+
+type CreateWebhookInput struct {
+	UserID int64
+	URL    string
+	// EventTypes limits the events sent; empty means all of them.
+	EventTypes []events.TaskEventType
+}
+
+type UpdateWebhookInput struct {
+	UserID    int64
+	WebhookID int64
+	URL       *string
+	// EventTypes replaces the subscription when non-nil; an empty slice
+	// subscribes to all events.
+	EventTypes []events.TaskEventType
+	// Active re-enables or pauses the webhook. Re-enabling resets its failure
+	// count.
+	Active *bool
+}
+
+type UploadAttachmentInput struct {
+	UserID   int64
+	TaskID   int64
+	FileName string
+	Size     int64
+	Content  io.Reader
 }
 
 type TaskService interface {
@@ -48,15 +224,146 @@ type TaskService interface {
 	DeleteTask(ctx context.Context, userID, taskID int64) error
 	GetTask(ctx context.Context, userID, taskID int64) (*entities.Task, error)
 	ListTasks(ctx context.Context, userID int64, filter TaskFilter) ([]entities.Task, error)
+	MoveTask(ctx context.Context, input MoveTaskInput) (*entities.Task, error)
+	// GetBoard returns one column per status in filter.Statuses (the
+	// statuses of the effective workflow by default, without archived) with
+	// tasks in position order.
+	// filter.Limit applies to each column.
+	GetBoard(ctx context.Context, userID int64, filter TaskFilter) ([]entities.BoardColumn, error)
 
 	// ExportTasks exports all user tasks in the specified format.
 	// Returns the file content, filename, and any error.
 	ExportTasks(ctx context.Context, userID int64, format entities.ExportFormat) ([]byte, string, error)
 
 	CreateCategory(ctx context.Context, input CreateCategoryInput) (*entities.Category, error)
+	GetCategory(ctx context.Context, userID, categoryID int64) (*entities.Category, error)
 	ListCategories(ctx context.Context, userID int64) ([]entities.Category, error)
-	DeleteCategory(ctx context.Context, userID, categoryID int64) error
+	// ListCategoryTree returns top-level categories with their descendants
+	// nested in Children.
+	ListCategoryTree(ctx context.Context, userID int64) ([]entities.Category, error)
+	UpdateCategory(ctx context.Context, input UpdateCategoryInput) (*entities.Category, error)
+	// ReorderCategories assigns positions following the order of categoryIDs,
+	// which must list every category of the user exactly once.
+	ReorderCategories(ctx context.Context, userID int64, categoryIDs []int64) ([]entities.Category, error)
+	DeleteCategory(ctx context.Context, userID, categoryID int64, mode entities.CategoryDeleteMode) error
 
 	AddComment(ctx context.Context, input AddCommentInput) (*entities.TaskComment, error)
 	ListComments(ctx context.Context, userID, taskID int64) ([]entities.TaskComment, error)
 }
+
+type WebhookService interface {
+	// CreateWebhook registers a webhook with a new secret. The secret is only
+	// returned here.
+	CreateWebhook(ctx context.Context, input CreateWebhookInput) (*entities.Webhook, error)
+	ListWebhooks(ctx context.Context, userID int64) ([]entities.Webhook, error)
+	GetWebhook(ctx context.Context, userID, webhookID int64) (*entities.Webhook, error)
+	UpdateWebhook(ctx context.Context, input UpdateWebhookInput) (*entities.Webhook, error)
+	DeleteWebhook(ctx context.Context, userID, webhookID int64) error
+	// ListWebhookDeliveries returns the delivery log of a webhook, newest first.
+	ListWebhookDeliveries(ctx context.Context, userID, webhookID int64) ([]entities.WebhookDelivery, error)
+	// SendTestEvent sends a webhook.test event right away, without retries, and
+	// returns the recorded delivery.
+	SendTestEvent(ctx context.Context, userID, webhookID int64) (*entities.WebhookDelivery, error)
+}
+
+// ChangeSubscription delivers the change events of one user.
+type ChangeSubscription interface {
+	Events() <-chan entities.ChangeEvent
+	// Done is closed when the subscription ends, either through Close or
+	// because the subscriber fell behind. A client that was dropped should
+	// reconnect and resume from its last event.
+	Done() <-chan struct{}
+	Close()
+}
+
+type ChangeStream interface {
+	// Subscribe starts streaming the user's changes. With lastEventID > 0 the
+	// buffered events after it are returned for replay; resumed is false when
+	// that event is no longer buffered and the client has to reload its state.
+	Subscribe(userID, lastEventID int64) (sub ChangeSubscription, replay []entities.ChangeEvent, resumed bool)
+}
+
+type AttachmentService interface {
+	UploadAttachment(ctx context.Context, input UploadAttachmentInput) (*entities.Attachment, error)
+	ListAttachments(ctx context.Context, userID, taskID int64) ([]entities.Attachment, error)
+	// OpenAttachment returns attachment metadata together with a reader over its
+	// content. The caller must close the reader.
+	OpenAttachment(ctx context.Context, userID, taskID, attachmentID int64) (*entities.Attachment, io.ReadCloser, error)
+	DeleteAttachment(ctx context.Context, userID, taskID, attachmentID int64) error
+	AttachmentPurger
+}
+
+// AttachmentPurger releases every attachment of a task, both metadata and blobs.
+type AttachmentPurger interface {
+	PurgeTaskAttachments(ctx context.Context, userID, taskID int64) error
+}
+
+type TemplateService interface {
+	CreateTemplate(ctx context.Context, input CreateTemplateInput) (*entities.TaskTemplate, error)
+	GetTemplate(ctx context.Context, userID, templateID int64) (*entities.TaskTemplate, error)
+	ListTemplates(ctx context.Context, userID int64) ([]entities.TaskTemplate, error)
+	UpdateTemplate(ctx context.Context, input UpdateTemplateInput) (*entities.TaskTemplate, error)
+	DeleteTemplate(ctx context.Context, userID, templateID int64) error
+	// InstantiateTemplate creates the template task followed by one task per
+	// item and returns them in that order.
+	InstantiateTemplate(ctx context.Context, input InstantiateTemplateInput) ([]entities.Task, error)
+}
+
+type WorkflowService interface {
+	CreateWorkflow(ctx context.Context, input CreateWorkflowInput) (*entities.Workflow, error)
+	GetWorkflow(ctx context.Context, userID, workflowID int64) (*entities.Workflow, error)
+	ListWorkflows(ctx context.Context, userID int64) ([]entities.Workflow, error)
+	UpdateWorkflow(ctx context.Context, input UpdateWorkflowInput) (*entities.Workflow, error)
+	DeleteWorkflow(ctx context.Context, userID, workflowID int64) error
+	// EffectiveWorkflow returns the workflow that applies to tasks in the
+	// category, or to uncategorized tasks when categoryID is nil. Users without
+	// a matching workflow get the built-in one.
+	EffectiveWorkflow(ctx context.Context, userID int64, categoryID *int64) (*entities.Workflow, error)
+}
+
+type CustomFieldService interface {
+	CreateCustomField(ctx context.Context, input CreateCustomFieldInput) (*entities.CustomField, error)
+	GetCustomField(ctx context.Context, userID, fieldID int64) (*entities.CustomField, error)
+	ListCustomFields(ctx context.Context, userID int64) ([]entities.CustomField, error)
+	UpdateCustomField(ctx context.Context, input UpdateCustomFieldInput) (*entities.CustomField, error)
+	DeleteCustomField(ctx context.Context, userID, fieldID int64) error
+}
+
+type TimeTrackingService interface {
+	// StartTimer starts a timer on a task. A timer already running for the
+	// user is stopped first, so at most one timer runs at a time.
+	StartTimer(ctx context.Context, userID, taskID int64, note string) (*entities.TimeEntry, error)
+	StopTimer(ctx context.Context, userID int64) (*entities.TimeEntry, error)
+	// RunningTimer returns domain.ErrNoRunningTimer when no timer is running.
+	RunningTimer(ctx context.Context, userID int64) (*entities.TimeEntry, error)
+	AddTimeEntry(ctx context.Context, input AddTimeEntryInput) (*entities.TimeEntry, error)
+	ListTimeEntries(ctx context.Context, userID, taskID int64) ([]entities.TimeEntry, error)
+	DeleteTimeEntry(ctx context.Context, userID, taskID, entryID int64) error
+	TimeReport(ctx context.Context, input TimeReportInput) (*entities.TimeReport, error)
+	TimerStopper
+}
+
+// TimerStopper stops the running timer of a user if it belongs to the task.
+type TimerStopper interface {
+	StopTaskTimer(ctx context.Context, userID, taskID int64) error
+}
+
+type QuickAddService interface {
+	// QuickAddTask parses a single line of free text into task fields and
+	// creates the task.
+	QuickAddTask(ctx context.Context, userID int64, text string) (*QuickAddResult, error)
+}
+
+type ViewService interface {
+	CreateView(ctx context.Context, input CreateViewInput) (*entities.SavedView, error)
+	GetView(ctx context.Context, userID, viewID int64) (*entities.SavedView, error)
+	ListViews(ctx context.Context, userID int64) ([]entities.SavedView, error)
+	UpdateView(ctx context.Context, input UpdateViewInput) (*entities.SavedView, error)
+	DeleteView(ctx context.Context, userID, viewID int64) error
+	// ListViewTasks resolves the view's filter at call time and returns the
+	// matching tasks.
+	ListViewTasks(ctx context.Context, userID, viewID int64, limit, offset int) ([]entities.Task, error)
+	// ExportView exports every task matching the view in the given format.
+	// Returns the file content, filename, and any error.
+	ExportView(ctx context.Context, userID, viewID int64, format entities.ExportFormat) ([]byte, string, error)
+}
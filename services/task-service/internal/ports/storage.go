@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"context"
+	"io"
+)
+
+// BlobStore persists opaque binary objects addressed by key.
+type BlobStore interface {
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
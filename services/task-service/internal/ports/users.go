@@ -9,9 +9,34 @@ type UserInfo struct {
 	Name   string
 	Role   string
 	Active bool
+	// Timezone is the IANA zone name from the user's preferences, empty when unset.
+	Timezone string
 }
 
 // UserDirectory exposes the operations required from the user-service.
 type UserDirectory interface {
 	GetUser(ctx context.Context, userID int64) (*UserInfo, error)
 }
+
+// TokenValidator asks user-service whether an access token that passed the
+// local signature and expiry checks has been revoked since.
+type TokenValidator interface {
+	ValidateAccessToken(ctx context.Context, token string, claims *TokenClaims) error
+}
+
+// UserCache drops what is cached about a user when user-service reports a
+// change.
+type UserCache interface {
+	InvalidateUser(userID int64)
+	// InvalidateAll is used when change events may have been missed.
+	InvalidateAll()
+}
+
+// SessionCloser ends the open streams of a user whose tokens may have been
+// revoked, so the client has to present a token again to reconnect.
+type SessionCloser interface {
+	CloseUser(userID int64)
+	// CloseSubscriptions ends every stream; it is used when revocations may
+	// have been missed.
+	CloseSubscriptions()
+}
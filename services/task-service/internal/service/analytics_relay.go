@@ -0,0 +1,172 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"todoapp/pkg/logging"
+	"todoapp/services/task-service/internal/ports"
+)
+
+const (
+	defaultAnalyticsInterval  = 5 * time.Second
+	defaultAnalyticsBatchSize = 200
+	defaultAnalyticsSendLimit = 10 * time.Second
+)
+
+// AnalyticsRelay sends buffered analytics events to analytics-service in
+// batches, so requests do not wait on it and nothing is lost while it is
+// unavailable. A batch is deleted only after analytics-service accepted it;
+// events carry IDs, so a batch resent after a crash is counted once.
+type AnalyticsRelay struct {
+	outbox      ports.AnalyticsOutboxRepository
+	tx          ports.TransactionManager
+	tracker     ports.AnalyticsBatchTracker
+	interval    time.Duration
+	batchSize   int
+	baseBackoff time.Duration
+	maxBackoff  time.Duration
+	now         func() time.Time
+	logger      *slog.Logger
+}
+
+type AnalyticsRelayOption func(*AnalyticsRelay)
+
+func NewAnalyticsRelay(outbox ports.AnalyticsOutboxRepository, tx ports.TransactionManager, tracker ports.AnalyticsBatchTracker, opts ...AnalyticsRelayOption) *AnalyticsRelay {
+	relay := &AnalyticsRelay{
+		outbox:      outbox,
+		tx:          tx,
+		tracker:     tracker,
+		interval:    defaultAnalyticsInterval,
+		batchSize:   defaultAnalyticsBatchSize,
+		baseBackoff: defaultOutboxBaseBackoff,
+		maxBackoff:  defaultOutboxMaxBackoff,
+		now:         time.Now,
+		logger:      logging.Discard(),
+	}
+	for _, opt := range opts {
+		opt(relay)
+	}
+	return relay
+}
+
+func WithAnalyticsInterval(interval time.Duration) AnalyticsRelayOption {
+	return func(r *AnalyticsRelay) {
+		if interval > 0 {
+			r.interval = interval
+		}
+	}
+}
+
+// WithAnalyticsBatchSize sets how many events go into one call. It must not
+// exceed the limit of analytics-service.
+func WithAnalyticsBatchSize(size int) AnalyticsRelayOption {
+	return func(r *AnalyticsRelay) {
+		if size > 0 {
+			r.batchSize = size
+		}
+	}
+}
+
+// WithAnalyticsBackoff sets the delay after the first failed attempt and its
+// cap.
+func WithAnalyticsBackoff(base, maxDelay time.Duration) AnalyticsRelayOption {
+	return func(r *AnalyticsRelay) {
+		if base > 0 {
+			r.baseBackoff = base
+		}
+		if maxDelay >= r.baseBackoff {
+			r.maxBackoff = maxDelay
+		}
+	}
+}
+
+func WithAnalyticsClock(now func() time.Time) AnalyticsRelayOption {
+	return func(r *AnalyticsRelay) {
+		if now != nil {
+			r.now = now
+		}
+	}
+}
+
+func WithAnalyticsLogger(logger *slog.Logger) AnalyticsRelayOption {
+	return func(r *AnalyticsRelay) {
+		if logger != nil {
+			r.logger = logger
+		}
+	}
+}
+
+// Run sends pending events on every interval until ctx is cancelled.
+func (r *AnalyticsRelay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := r.Relay(ctx); err != nil && ctx.Err() == nil {
+			r.logger.ErrorContext(ctx, "analytics relay failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Relay sends batches until no due event is left and returns how many events
+// were sent. A rejected batch is postponed with exponential backoff and ends
+// the run; storage errors end it immediately.
+func (r *AnalyticsRelay) Relay(ctx context.Context) (int, error) {
+	sent := 0
+
+	for {
+		var (
+			fetched int
+			failed  bool
+		)
+
+		err := r.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+			messages, err := r.outbox.FetchPendingAnalytics(ctx, r.now(), r.batchSize)
+			if err != nil || len(messages) == 0 {
+				return err
+			}
+			fetched = len(messages)
+
+			ids := make([]int64, 0, len(messages))
+			batch := make([]ports.AnalyticsEvent, 0, len(messages))
+			attempts := 0
+			for _, message := range messages {
+				ids = append(ids, message.ID)
+				batch = append(batch, message.Event)
+				attempts = max(attempts, message.Attempts)
+			}
+
+			sendCtx, cancel := context.WithTimeout(ctx, defaultAnalyticsSendLimit)
+			err = r.tracker.BatchTrackTaskEvents(sendCtx, batch)
+			cancel()
+
+			if err != nil {
+				failed = true
+				next := r.now().Add(retryBackoff(r.baseBackoff, r.maxBackoff, attempts))
+				r.logger.WarnContext(ctx, "analytics batch failed", "events", len(batch), "attempt", attempts+1, "error", err)
+				return r.outbox.MarkAnalyticsFailed(ctx, ids, next, err.Error())
+			}
+
+			if err := r.outbox.DeleteAnalytics(ctx, ids); err != nil {
+				return err
+			}
+			sent += len(batch)
+			return nil
+		})
+		if err != nil {
+			return sent, err
+		}
+
+		if fetched < r.batchSize || failed {
+			return sent, nil
+		}
+	}
+}
@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	analyticsv1 "todoapp/pkg/proto/analytics/v1"
+	"todoapp/services/task-service/internal/domain/entities"
+	"todoapp/services/task-service/internal/ports"
+)
+
+type analyticsOutboxStub struct {
+	pending []ports.AnalyticsOutboxMessage
+	retryAt map[int64]time.Time
+}
+
+func (r *analyticsOutboxStub) FetchPendingAnalytics(ctx context.Context, now time.Time, limit int) ([]ports.AnalyticsOutboxMessage, error) {
+	var result []ports.AnalyticsOutboxMessage
+	for _, message := range r.pending {
+		if next, ok := r.retryAt[message.ID]; ok && next.After(now) {
+			continue
+		}
+		result = append(result, message)
+		if len(result) == limit {
+			break
+		}
+	}
+	return result, nil
+}
+
+func (r *analyticsOutboxStub) DeleteAnalytics(ctx context.Context, ids []int64) error {
+	deleted := map[int64]bool{}
+	for _, id := range ids {
+		deleted[id] = true
+	}
+	kept := r.pending[:0]
+	for _, message := range r.pending {
+		if !deleted[message.ID] {
+			kept = append(kept, message)
+		}
+	}
+	r.pending = kept
+	return nil
+}
+
+func (r *analyticsOutboxStub) MarkAnalyticsFailed(ctx context.Context, ids []int64, nextAttemptAt time.Time, lastError string) error {
+	for _, id := range ids {
+		r.retryAt[id] = nextAttemptAt
+		for i := range r.pending {
+			if r.pending[i].ID == id {
+				r.pending[i].Attempts++
+			}
+		}
+	}
+	return nil
+}
+
+type batchTrackerStub struct {
+	batches [][]ports.AnalyticsEvent
+	err     error
+}
+
+func (t *batchTrackerStub) BatchTrackTaskEvents(ctx context.Context, events []ports.AnalyticsEvent) error {
+	if t.err != nil {
+		return t.err
+	}
+	t.batches = append(t.batches, events)
+	return nil
+}
+
+func analyticsMessages(n int) []ports.AnalyticsOutboxMessage {
+	messages := make([]ports.AnalyticsOutboxMessage, 0, n)
+	for i := 1; i <= n; i++ {
+		messages = append(messages, ports.AnalyticsOutboxMessage{
+			ID:    int64(i),
+			Event: ports.AnalyticsEvent{Type: analyticsv1.TaskEventType_TASK_EVENT_TYPE_CREATED, UserID: 1, TaskID: int64(i)},
+		})
+	}
+	return messages
+}
+
+func TestAnalyticsRelay_SendsInBatches(t *testing.T) {
+	repo := &analyticsOutboxStub{pending: analyticsMessages(5), retryAt: map[int64]time.Time{}}
+	tracker := &batchTrackerStub{}
+	relay := NewAnalyticsRelay(repo, &txManagerStub{}, tracker, WithAnalyticsBatchSize(2))
+
+	sent, err := relay.Relay(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sent != 5 || len(tracker.batches) != 3 {
+		t.Fatalf("expected 5 events in 3 batches, got %d in %d", sent, len(tracker.batches))
+	}
+	if len(repo.pending) != 0 {
+		t.Fatalf("expected sent events to be deleted, %d left", len(repo.pending))
+	}
+}
+
+func TestAnalyticsRelay_KeepsEventsWhileUnavailable(t *testing.T) {
+	now := time.Date(2024, 12, 4, 10, 0, 0, 0, time.UTC)
+	repo := &analyticsOutboxStub{pending: analyticsMessages(3), retryAt: map[int64]time.Time{}}
+	tracker := &batchTrackerStub{err: errors.New("unavailable")}
+	relay := NewAnalyticsRelay(repo, &txManagerStub{}, tracker,
+		WithAnalyticsBackoff(time.Second, time.Minute),
+		WithAnalyticsClock(func() time.Time { return now }),
+	)
+
+	if sent, err := relay.Relay(context.Background()); err != nil || sent != 0 {
+		t.Fatalf("expected nothing sent, got %d, %v", sent, err)
+	}
+	if len(repo.pending) != 3 || !repo.retryAt[1].Equal(now.Add(time.Second)) {
+		t.Fatalf("expected events to be kept and postponed, got %+v", repo.retryAt)
+	}
+
+	tracker.err = nil
+	now = now.Add(time.Second)
+	if sent, err := relay.Relay(context.Background()); err != nil || sent != 3 {
+		t.Fatalf("expected retry to send all events, got %d, %v", sent, err)
+	}
+}
+
+func TestCreateTask_AnalyticsRecordedInTransaction(t *testing.T) {
+	tx := &txManagerStub{}
+	svc := NewTaskService(
+		&repoMock{},
+		WithAnalyticsTracker(analyticsStub{err: errors.New("outbox insert failed")}),
+		WithTransactionManager(tx),
+	)
+
+	_, err := svc.CreateTask(context.Background(), ports.CreateTaskInput{
+		UserID:   1,
+		Title:    "Task",
+		Status:   entities.TaskStatusPending,
+		Priority: entities.TaskPriorityMedium,
+	})
+	if err == nil {
+		t.Fatalf("expected the analytics outbox error to fail the request")
+	}
+	if tx.rollbacks != 1 {
+		t.Fatalf("expected the transaction to roll back, got %d rollbacks", tx.rollbacks)
+	}
+}
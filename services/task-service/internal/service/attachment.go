@@ -0,0 +1,264 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"todoapp/pkg/logging"
+	"todoapp/services/task-service/internal/domain"
+	"todoapp/services/task-service/internal/domain/entities"
+	"todoapp/services/task-service/internal/ports"
+)
+
+const (
+	// sniffLen is the number of leading bytes inspected by http.DetectContentType.
+	sniffLen          = 512
+	maxFileNameLength = 255
+)
+
+// AttachmentLimits bounds what users may upload.
+type AttachmentLimits struct {
+	MaxFileSize         int64
+	UserQuota           int64
+	AllowedContentTypes []string
+}
+
+// DefaultAttachmentLimits allows screenshots, PDFs and plain text up to 10 MiB
+// per file and 100 MiB per user.
+var DefaultAttachmentLimits = AttachmentLimits{
+	MaxFileSize: 10 << 20,
+	UserQuota:   100 << 20,
+	AllowedContentTypes: []string{
+		"image/png",
+		"image/jpeg",
+		"image/gif",
+		"image/webp",
+		"application/pdf",
+		"text/plain",
+	},
+}
+
+type AttachmentService struct {
+	tasks       ports.TaskRepository
+	attachments ports.AttachmentRepository
+	blobs       ports.BlobStore
+	users       ports.UserDirectory
+	limits      AttachmentLimits
+	logger      *slog.Logger
+}
+
+type AttachmentServiceOption func(*AttachmentService)
+
+var _ ports.AttachmentService = (*AttachmentService)(nil)
+
+func NewAttachmentService(tasks ports.TaskRepository, attachments ports.AttachmentRepository, blobs ports.BlobStore, opts ...AttachmentServiceOption) *AttachmentService {
+	svc := &AttachmentService{
+		tasks:       tasks,
+		attachments: attachments,
+		blobs:       blobs,
+		limits:      DefaultAttachmentLimits,
+		logger:      logging.Discard(),
+	}
+	for _, opt := range opts {
+		opt(svc)
+	}
+	return svc
+}
+
+func WithAttachmentLimits(limits AttachmentLimits) AttachmentServiceOption {
+	return func(s *AttachmentService) {
+		if limits.MaxFileSize > 0 {
+			s.limits.MaxFileSize = limits.MaxFileSize
+		}
+		if limits.UserQuota > 0 {
+			s.limits.UserQuota = limits.UserQuota
+		}
+		if len(limits.AllowedContentTypes) > 0 {
+			s.limits.AllowedContentTypes = limits.AllowedContentTypes
+		}
+	}
+}
+
+func WithAttachmentUserDirectory(users ports.UserDirectory) AttachmentServiceOption {
+	return func(s *AttachmentService) {
+		s.users = users
+	}
+}
+
+func WithAttachmentLogger(logger *slog.Logger) AttachmentServiceOption {
+	return func(s *AttachmentService) {
+		if logger != nil {
+			s.logger = logger
+		}
+	}
+}
+
+func (s *AttachmentService) UploadAttachment(ctx context.Context, input ports.UploadAttachmentInput) (*entities.Attachment, error) {
+	if _, err := ensureActiveUser(ctx, s.users, input.UserID); err != nil {
+		return nil, err
+	}
+
+	if input.Content == nil || input.Size <= 0 {
+		return nil, domain.ErrValidationFailed.WithMessage("file is empty")
+	}
+
+	if input.Size > s.limits.MaxFileSize {
+		return nil, domain.ErrFileTooLarge.WithDetails(fmt.Sprintf("limit is %d bytes", s.limits.MaxFileSize))
+	}
+
+	if _, err := s.tasks.GetTask(ctx, input.UserID, input.TaskID); err != nil {
+		return nil, err
+	}
+
+	// Checked again when the attachment is stored; this saves uploading a
+	// file that cannot fit.
+	used, err := s.attachments.UserStorageUsage(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if used+input.Size > s.limits.UserQuota {
+		return nil, domain.ErrQuotaExceeded.WithDetails(fmt.Sprintf("quota is %d bytes, %d bytes used", s.limits.UserQuota, used))
+	}
+
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(input.Content, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return nil, err
+	}
+	head = head[:n]
+
+	contentType, ok := s.detectContentType(head)
+	if !ok {
+		return nil, domain.ErrUnsupportedFileType.WithDetails(contentType)
+	}
+
+	attachment := &entities.Attachment{
+		TaskID:      input.TaskID,
+		UserID:      input.UserID,
+		FileName:    sanitizeFileName(input.FileName),
+		ContentType: contentType,
+		SizeBytes:   input.Size,
+		StorageKey:  fmt.Sprintf("users/%d/tasks/%d/%s", input.UserID, input.TaskID, uuid.NewString()),
+	}
+
+	body := io.LimitReader(io.MultiReader(bytes.NewReader(head), input.Content), input.Size)
+	if err := s.blobs.Put(ctx, attachment.StorageKey, body, input.Size, contentType); err != nil {
+		return nil, err
+	}
+
+	if err := s.attachments.CreateAttachment(ctx, attachment, s.limits.UserQuota); err != nil {
+		s.deleteBlob(ctx, attachment.StorageKey)
+		return nil, err
+	}
+
+	return attachment, nil
+}
+
+func (s *AttachmentService) ListAttachments(ctx context.Context, userID, taskID int64) ([]entities.Attachment, error) {
+	if _, err := ensureActiveUser(ctx, s.users, userID); err != nil {
+		return nil, err
+	}
+	if _, err := s.tasks.GetTask(ctx, userID, taskID); err != nil {
+		return nil, err
+	}
+	return s.attachments.ListAttachments(ctx, userID, taskID)
+}
+
+func (s *AttachmentService) OpenAttachment(ctx context.Context, userID, taskID, attachmentID int64) (*entities.Attachment, io.ReadCloser, error) {
+	if _, err := ensureActiveUser(ctx, s.users, userID); err != nil {
+		return nil, nil, err
+	}
+
+	attachment, err := s.attachments.GetAttachment(ctx, userID, taskID, attachmentID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	body, err := s.blobs.Get(ctx, attachment.StorageKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return attachment, body, nil
+}
+
+func (s *AttachmentService) DeleteAttachment(ctx context.Context, userID, taskID, attachmentID int64) error {
+	if _, err := ensureActiveUser(ctx, s.users, userID); err != nil {
+		return err
+	}
+
+	attachment, err := s.attachments.GetAttachment(ctx, userID, taskID, attachmentID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.attachments.DeleteAttachment(ctx, userID, taskID, attachmentID); err != nil {
+		return err
+	}
+
+	s.deleteBlob(ctx, attachment.StorageKey)
+	return nil
+}
+
+func (s *AttachmentService) PurgeTaskAttachments(ctx context.Context, userID, taskID int64) error {
+	removed, err := s.attachments.DeleteTaskAttachments(ctx, userID, taskID)
+	if err != nil {
+		return err
+	}
+
+	for _, attachment := range removed {
+		s.deleteBlob(ctx, attachment.StorageKey)
+	}
+
+	return nil
+}
+
+// detectContentType sniffs the leading bytes of a file and reports whether the
+// detected media type is allowed. The client-supplied type is never trusted.
+func (s *AttachmentService) detectContentType(head []byte) (string, bool) {
+	detected := http.DetectContentType(head)
+	mediaType, _, err := mime.ParseMediaType(detected)
+	if err != nil {
+		return detected, false
+	}
+
+	for _, allowed := range s.limits.AllowedContentTypes {
+		if strings.EqualFold(mediaType, allowed) {
+			return mediaType, true
+		}
+	}
+
+	return mediaType, false
+}
+
+// deleteBlob removes a blob on a best-effort basis; orphaned blobs are only
+// wasted space, so failures are logged rather than returned.
+func (s *AttachmentService) deleteBlob(ctx context.Context, key string) {
+	if err := s.blobs.Delete(ctx, key); err != nil {
+		s.logger.WarnContext(ctx, "attachment blob cleanup failed", "blob_key", key, "error", err)
+	}
+}
+
+func sanitizeFileName(name string) string {
+	name = strings.TrimSpace(filepath.Base(strings.ReplaceAll(name, "\\", "/")))
+	if name == "" || name == "." || name == "/" {
+		return "attachment"
+	}
+
+	for len(name) > maxFileNameLength {
+		_, size := utf8.DecodeLastRuneInString(name)
+		name = name[:len(name)-size]
+	}
+
+	return name
+}
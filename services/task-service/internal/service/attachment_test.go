@@ -0,0 +1,205 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"todoapp/services/task-service/internal/domain"
+	"todoapp/services/task-service/internal/domain/entities"
+	"todoapp/services/task-service/internal/ports"
+)
+
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+type attachmentRepoStub struct {
+	created   *entities.Attachment
+	createErr error
+	stored    *entities.Attachment
+	usage     int64
+	// usageAtCreate, when set, is the usage CreateAttachment sees, as after
+	// a concurrent upload.
+	usageAtCreate int64
+	removed       []entities.Attachment
+	deleted       bool
+}
+
+func (r *attachmentRepoStub) CreateAttachment(ctx context.Context, attachment *entities.Attachment, quota int64) error {
+	if r.usageAtCreate+attachment.SizeBytes > quota {
+		return domain.ErrQuotaExceeded
+	}
+	r.created = attachment
+	attachment.ID = 7
+	return r.createErr
+}
+
+func (r *attachmentRepoStub) GetAttachment(ctx context.Context, userID, taskID, attachmentID int64) (*entities.Attachment, error) {
+	if r.stored == nil {
+		return nil, domain.ErrAttachmentNotFound
+	}
+	return r.stored, nil
+}
+
+func (r *attachmentRepoStub) ListAttachments(ctx context.Context, userID, taskID int64) ([]entities.Attachment, error) {
+	return nil, nil
+}
+
+func (r *attachmentRepoStub) DeleteAttachment(ctx context.Context, userID, taskID, attachmentID int64) error {
+	r.deleted = true
+	return nil
+}
+
+func (r *attachmentRepoStub) DeleteTaskAttachments(ctx context.Context, userID, taskID int64) ([]entities.Attachment, error) {
+	return r.removed, nil
+}
+
+func (r *attachmentRepoStub) UserStorageUsage(ctx context.Context, userID int64) (int64, error) {
+	return r.usage, nil
+}
+
+type blobStoreStub struct {
+	objects map[string][]byte
+	deleted []string
+}
+
+func newBlobStoreStub() *blobStoreStub {
+	return &blobStoreStub{objects: map[string][]byte{}}
+}
+
+func (b *blobStoreStub) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	b.objects[key] = data
+	return nil
+}
+
+func (b *blobStoreStub) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	data, ok := b.objects[key]
+	if !ok {
+		return nil, domain.ErrAttachmentNotFound
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (b *blobStoreStub) Delete(ctx context.Context, key string) error {
+	b.deleted = append(b.deleted, key)
+	delete(b.objects, key)
+	return nil
+}
+
+func TestUploadAttachmentSuccess(t *testing.T) {
+	attachments := &attachmentRepoStub{}
+	blobs := newBlobStoreStub()
+	svc := NewAttachmentService(&repoMock{}, attachments, blobs)
+
+	content := append(append([]byte{}, pngHeader...), bytes.Repeat([]byte{0}, 1024)...)
+	attachment, err := svc.UploadAttachment(context.Background(), ports.UploadAttachmentInput{
+		UserID:   1,
+		TaskID:   2,
+		FileName: "../../screens/shot.png",
+		Size:     int64(len(content)),
+		Content:  bytes.NewReader(content),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if attachment.ContentType != "image/png" || attachment.FileName != "shot.png" {
+		t.Fatalf("unexpected attachment metadata: %+v", attachment)
+	}
+	if !bytes.Equal(blobs.objects[attachment.StorageKey], content) {
+		t.Fatalf("stored blob does not match uploaded content")
+	}
+}
+
+func TestUploadAttachmentRejections(t *testing.T) {
+	tests := []struct {
+		name    string
+		usage   int64
+		content []byte
+		size    int64
+		want    error
+	}{
+		{name: "too large", content: pngHeader, size: 11 << 20, want: domain.ErrFileTooLarge},
+		{name: "quota exceeded", usage: 100 << 20, content: pngHeader, size: int64(len(pngHeader)), want: domain.ErrQuotaExceeded},
+		{name: "unsupported type", content: []byte("MZ\x90\x00\x03\x00\x00\x00"), size: 8, want: domain.ErrUnsupportedFileType},
+		{name: "empty", content: nil, size: 0, want: domain.ErrValidationFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			blobs := newBlobStoreStub()
+			svc := NewAttachmentService(&repoMock{}, &attachmentRepoStub{usage: tt.usage}, blobs)
+
+			_, err := svc.UploadAttachment(context.Background(), ports.UploadAttachmentInput{
+				UserID:  1,
+				TaskID:  2,
+				Size:    tt.size,
+				Content: bytes.NewReader(tt.content),
+			})
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, err)
+			}
+			if len(blobs.objects) != 0 {
+				t.Fatalf("rejected upload must not be stored")
+			}
+		})
+	}
+}
+
+func TestUploadAttachmentRemovesBlobWhenMetadataFails(t *testing.T) {
+	blobs := newBlobStoreStub()
+	svc := NewAttachmentService(&repoMock{}, &attachmentRepoStub{createErr: errors.New("db down")}, blobs)
+
+	_, err := svc.UploadAttachment(context.Background(), ports.UploadAttachmentInput{
+		UserID:  1,
+		TaskID:  2,
+		Size:    int64(len(pngHeader)),
+		Content: bytes.NewReader(pngHeader),
+	})
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+	if len(blobs.objects) != 0 || len(blobs.deleted) != 1 {
+		t.Fatalf("orphaned blob was not removed: %+v", blobs)
+	}
+}
+
+func TestUploadAttachmentQuotaCheckedOnCreate(t *testing.T) {
+	blobs := newBlobStoreStub()
+	svc := NewAttachmentService(&repoMock{}, &attachmentRepoStub{usageAtCreate: 100 << 20}, blobs)
+
+	_, err := svc.UploadAttachment(context.Background(), ports.UploadAttachmentInput{
+		UserID:  1,
+		TaskID:  2,
+		Size:    int64(len(pngHeader)),
+		Content: bytes.NewReader(pngHeader),
+	})
+	if !errors.Is(err, domain.ErrQuotaExceeded) {
+		t.Fatalf("expected %v, got %v", domain.ErrQuotaExceeded, err)
+	}
+	if len(blobs.objects) != 0 || len(blobs.deleted) != 1 {
+		t.Fatalf("blob over quota was not removed: %+v", blobs)
+	}
+}
+
+func TestDeleteTaskPurgesAttachments(t *testing.T) {
+	attachments := &attachmentRepoStub{removed: []entities.Attachment{
+		{ID: 1, StorageKey: "users/1/tasks/1/a"},
+		{ID: 2, StorageKey: "users/1/tasks/1/b"},
+	}}
+	blobs := newBlobStoreStub()
+	purger := NewAttachmentService(&repoMock{}, attachments, blobs)
+
+	svc := NewTaskService(&repoMock{storedTask: &entities.Task{ID: 1, UserID: 1}}, WithAttachmentPurger(purger))
+	if err := svc.DeleteTask(context.Background(), 1, 1); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(blobs.deleted) != 2 {
+		t.Fatalf("expected both blobs to be deleted, got %v", blobs.deleted)
+	}
+}
@@ -0,0 +1,219 @@
+package service
+
+import (
+	"context"
+	"math"
+
+	"todoapp/services/task-service/internal/domain"
+	"todoapp/services/task-service/internal/domain/entities"
+	"todoapp/services/task-service/internal/ports"
+)
+
+const (
+	// minPositionGap is the smallest gap between neighbours that is still
+	// split; below it the column is rebalanced first. With a step of 1024 this
+	// allows about 30 consecutive moves into the same gap.
+	minPositionGap = 1e-6
+
+	maxBoardColumnTasks = 500
+)
+
+// MoveTask places a task between two neighbours of the target column. Only the
+// moved task is written unless the gap between the neighbours is exhausted, in
+// which case the column is rebalanced first, in the same transaction.
+func (s *TaskService) MoveTask(ctx context.Context, input ports.MoveTaskInput) (*entities.Task, error) {
+	user, err := s.ensureUser(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if (input.AfterID != nil && *input.AfterID == input.TaskID) || (input.BeforeID != nil && *input.BeforeID == input.TaskID) {
+		return nil, domain.ErrValidationFailed.WithMessage("a task cannot be its own neighbour")
+	}
+
+	task, err := s.repo.GetTask(ctx, input.UserID, input.TaskID)
+	if err != nil {
+		return nil, err
+	}
+
+	workflow, err := s.workflowFor(ctx, input.UserID, task.CategoryID)
+	if err != nil {
+		return nil, err
+	}
+	completed, err := s.applyStatus(workflow, task, input.Status)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.inTransaction(ctx, func(ctx context.Context) error {
+		// The neighbours are read under the column lock, so concurrent moves
+		// do not place tasks by stale positions, and a failed move undoes
+		// the rebalance.
+		if err := s.repo.LockTaskColumn(ctx, input.UserID, input.Status); err != nil {
+			return err
+		}
+
+		after, before, err := s.moveBounds(ctx, input)
+		if err != nil {
+			return err
+		}
+
+		position, ok := positionBetween(after, before)
+		if !ok {
+			if err := s.repo.RebalanceTaskPositions(ctx, input.UserID, input.Status); err != nil {
+				return err
+			}
+			if after, before, err = s.moveBounds(ctx, input); err != nil {
+				return err
+			}
+			if position, ok = positionBetween(after, before); !ok {
+				return domain.ErrPositionExhausted
+			}
+		}
+
+		task.Position = position
+		if err := s.repo.MoveTask(ctx, task); err != nil {
+			return err
+		}
+		return s.recordStatusChange(ctx, task, user, completed)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if completed {
+		s.afterCompleted(ctx, task)
+	}
+
+	return task, nil
+}
+
+func (s *TaskService) GetBoard(ctx context.Context, userID int64, filter ports.TaskFilter) ([]entities.BoardColumn, error) {
+	if _, err := s.ensureUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	statuses := filter.Statuses
+	if len(statuses) == 0 {
+		workflow, err := s.workflowFor(ctx, userID, filter.CategoryID)
+		if err != nil {
+			return nil, err
+		}
+		statuses = boardStatuses(workflow)
+	}
+	for _, status := range statuses {
+		if !status.IsValid() {
+			return nil, domain.ErrInvalidTaskStatus.WithMessage("unsupported status: " + string(status))
+		}
+	}
+
+	if err := s.resolveCustomFieldFilter(ctx, userID, &filter); err != nil {
+		return nil, err
+	}
+
+	if filter.Limit <= 0 {
+		filter.Limit = 100
+	}
+	if filter.Limit > maxBoardColumnTasks {
+		filter.Limit = maxBoardColumnTasks
+	}
+	filter.Offset = 0
+	filter.OrderByPosition = true
+
+	columns := make([]entities.BoardColumn, 0, len(statuses))
+	for _, status := range statuses {
+		filter.Statuses = []entities.TaskStatus{status}
+
+		tasks, err := s.repo.ListTasks(ctx, userID, filter)
+		if err != nil {
+			return nil, err
+		}
+		if tasks == nil {
+			tasks = []entities.Task{}
+		}
+
+		columns = append(columns, entities.BoardColumn{Status: status, Tasks: tasks})
+	}
+
+	return columns, nil
+}
+
+// boardStatuses returns the default columns of a board: the statuses of the
+// workflow apart from archived, which is left off unless requested.
+func boardStatuses(workflow *entities.Workflow) []entities.TaskStatus {
+	statuses := make([]entities.TaskStatus, 0, len(workflow.Statuses))
+	for _, status := range workflow.Statuses {
+		if status.Key != entities.TaskStatusArchived {
+			statuses = append(statuses, status.Key)
+		}
+	}
+	return statuses
+}
+
+// moveBounds returns the positions the moved task must fall between. A nil
+// bound means the column is open on that side. When only one neighbour is
+// given the other bound is looked up, so a stale client cannot skip over
+// tasks it did not know about.
+func (s *TaskService) moveBounds(ctx context.Context, input ports.MoveTaskInput) (*float64, *float64, error) {
+	after, err := s.neighbourPosition(ctx, input, input.AfterID)
+	if err != nil {
+		return nil, nil, err
+	}
+	before, err := s.neighbourPosition(ctx, input, input.BeforeID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	switch {
+	case after != nil && before != nil:
+		if *after >= *before {
+			return nil, nil, domain.ErrValidationFailed.WithMessage("afterId must be above beforeId in the column")
+		}
+	case after != nil:
+		before, err = s.repo.AdjacentTaskPosition(ctx, input.UserID, input.Status, *after, true, input.TaskID)
+	case before != nil:
+		after, err = s.repo.AdjacentTaskPosition(ctx, input.UserID, input.Status, *before, false, input.TaskID)
+	default:
+		// Append: the last task of the column is the one below +Inf.
+		after, err = s.repo.AdjacentTaskPosition(ctx, input.UserID, input.Status, math.Inf(1), false, input.TaskID)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return after, before, nil
+}
+
+func (s *TaskService) neighbourPosition(ctx context.Context, input ports.MoveTaskInput, neighbourID *int64) (*float64, error) {
+	if neighbourID == nil {
+		return nil, nil
+	}
+
+	neighbour, err := s.repo.GetTask(ctx, input.UserID, *neighbourID)
+	if err != nil {
+		return nil, err
+	}
+	if neighbour.Status != input.Status {
+		return nil, domain.ErrValidationFailed.WithMessage("neighbour task is not in the target column")
+	}
+
+	position := neighbour.Position
+	return &position, nil
+}
+
+// positionBetween picks a position strictly between after and before. It
+// reports false when the gap is too small to split.
+func positionBetween(after, before *float64) (float64, bool) {
+	switch {
+	case after == nil && before == nil:
+		return entities.TaskPositionStep, true
+	case before == nil:
+		return *after + entities.TaskPositionStep, true
+	case after == nil:
+		return *before - entities.TaskPositionStep, true
+	}
+
+	if *before-*after < minPositionGap {
+		return 0, false
+	}
+	return *after + (*before-*after)/2, true
+}
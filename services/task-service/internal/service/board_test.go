@@ -0,0 +1,318 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"testing"
+
+	"todoapp/services/task-service/internal/domain"
+	"todoapp/services/task-service/internal/domain/entities"
+	"todoapp/services/task-service/internal/ports"
+)
+
+// boardRepoStub keeps tasks in memory so moves and rebalancing can be checked
+// against real column order.
+type boardRepoStub struct {
+	ports.TaskRepository
+	tasks      map[int64]*entities.Task
+	moves      int
+	rebalances int
+	locks      int
+	moveErr    error
+}
+
+func newBoardRepoStub(tasks ...entities.Task) *boardRepoStub {
+	repo := &boardRepoStub{tasks: map[int64]*entities.Task{}}
+	for i := range tasks {
+		task := tasks[i]
+		repo.tasks[task.ID] = &task
+	}
+	return repo
+}
+
+func (r *boardRepoStub) GetTask(ctx context.Context, userID, taskID int64) (*entities.Task, error) {
+	task, ok := r.tasks[taskID]
+	if !ok {
+		return nil, domain.ErrTaskNotFound
+	}
+	copied := *task
+	return &copied, nil
+}
+
+func (r *boardRepoStub) MoveTask(ctx context.Context, task *entities.Task) error {
+	r.moves++
+	if r.moveErr != nil {
+		return r.moveErr
+	}
+	copied := *task
+	r.tasks[task.ID] = &copied
+	return nil
+}
+
+func (r *boardRepoStub) AdjacentTaskPosition(ctx context.Context, userID int64, status entities.TaskStatus, position float64, after bool, excludeTaskID int64) (*float64, error) {
+	var found *float64
+	for _, task := range r.column(status) {
+		if task.ID == excludeTaskID {
+			continue
+		}
+		p := task.Position
+		switch {
+		case after && p > position && (found == nil || p < *found):
+			found = &p
+		case !after && p < position && (found == nil || p > *found):
+			found = &p
+		}
+	}
+	return found, nil
+}
+
+func (r *boardRepoStub) LockTaskColumn(ctx context.Context, userID int64, status entities.TaskStatus) error {
+	r.locks++
+	return nil
+}
+
+func (r *boardRepoStub) RebalanceTaskPositions(ctx context.Context, userID int64, status entities.TaskStatus) error {
+	r.rebalances++
+	for i, task := range r.column(status) {
+		r.tasks[task.ID].Position = float64((i + 1) * entities.TaskPositionStep)
+	}
+	return nil
+}
+
+func (r *boardRepoStub) ListTasks(ctx context.Context, userID int64, filter ports.TaskFilter) ([]entities.Task, error) {
+	return r.column(filter.Statuses[0]), nil
+}
+
+func (r *boardRepoStub) column(status entities.TaskStatus) []entities.Task {
+	var tasks []entities.Task
+	for _, task := range r.tasks {
+		if task.Status == status {
+			tasks = append(tasks, *task)
+		}
+	}
+	sort.Slice(tasks, func(i, j int) bool {
+		if tasks[i].Position == tasks[j].Position {
+			return tasks[i].ID < tasks[j].ID
+		}
+		return tasks[i].Position < tasks[j].Position
+	})
+	return tasks
+}
+
+func (r *boardRepoStub) order(status entities.TaskStatus) []int64 {
+	var ids []int64
+	for _, task := range r.column(status) {
+		ids = append(ids, task.ID)
+	}
+	return ids
+}
+
+func pendingTask(id int64, position float64) entities.Task {
+	return entities.Task{ID: id, UserID: 42, Status: entities.TaskStatusPending, Position: position}
+}
+
+func int64Ptr(v int64) *int64 { return &v }
+
+func assertOrder(t *testing.T, got []int64, want ...int64) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("expected order %v, got %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("expected order %v, got %v", want, got)
+		}
+	}
+}
+
+func TestMoveTask(t *testing.T) {
+	tests := []struct {
+		name  string
+		input ports.MoveTaskInput
+		want  []int64
+	}{
+		{
+			name:  "between neighbours",
+			input: ports.MoveTaskInput{TaskID: 3, AfterID: int64Ptr(1), BeforeID: int64Ptr(2)},
+			want:  []int64{1, 3, 2},
+		},
+		{
+			name:  "to top with before only",
+			input: ports.MoveTaskInput{TaskID: 3, BeforeID: int64Ptr(1)},
+			want:  []int64{3, 1, 2},
+		},
+		{
+			name:  "after only does not skip the next task",
+			input: ports.MoveTaskInput{TaskID: 3, AfterID: int64Ptr(1)},
+			want:  []int64{1, 3, 2},
+		},
+		{
+			name:  "append without neighbours",
+			input: ports.MoveTaskInput{TaskID: 1},
+			want:  []int64{2, 3, 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newBoardRepoStub(pendingTask(1, 1024), pendingTask(2, 2048), pendingTask(3, 3072))
+			svc := NewTaskService(repo)
+
+			tt.input.UserID = 42
+			tt.input.Status = entities.TaskStatusPending
+			if _, err := svc.MoveTask(context.Background(), tt.input); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			assertOrder(t, repo.order(entities.TaskStatusPending), tt.want...)
+			if repo.moves != 1 || repo.rebalances != 0 {
+				t.Fatalf("expected a single-row move, got moves=%d rebalances=%d", repo.moves, repo.rebalances)
+			}
+		})
+	}
+}
+
+func TestMoveTaskAcrossColumns(t *testing.T) {
+	done := entities.Task{ID: 4, UserID: 42, Status: entities.TaskStatusCompleted, Position: 1024}
+	repo := newBoardRepoStub(pendingTask(1, 1024), pendingTask(2, 2048), done)
+	svc := NewTaskService(repo)
+
+	task, err := svc.MoveTask(context.Background(), ports.MoveTaskInput{
+		UserID:   42,
+		TaskID:   1,
+		Status:   entities.TaskStatusCompleted,
+		BeforeID: int64Ptr(4),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if task.Status != entities.TaskStatusCompleted {
+		t.Fatalf("expected status to change, got %s", task.Status)
+	}
+	assertOrder(t, repo.order(entities.TaskStatusCompleted), 1, 4)
+	assertOrder(t, repo.order(entities.TaskStatusPending), 2)
+}
+
+func TestMoveTaskRebalancesExhaustedGap(t *testing.T) {
+	repo := newBoardRepoStub(pendingTask(1, 1024), pendingTask(2, 1024+minPositionGap/2), pendingTask(3, 4096))
+	svc := NewTaskService(repo)
+
+	_, err := svc.MoveTask(context.Background(), ports.MoveTaskInput{
+		UserID:   42,
+		TaskID:   3,
+		Status:   entities.TaskStatusPending,
+		AfterID:  int64Ptr(1),
+		BeforeID: int64Ptr(2),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if repo.rebalances != 1 {
+		t.Fatalf("expected one rebalance, got %d", repo.rebalances)
+	}
+	assertOrder(t, repo.order(entities.TaskStatusPending), 1, 3, 2)
+}
+
+func TestMoveTaskRebalancesInTheMoveTransaction(t *testing.T) {
+	repo := newBoardRepoStub(pendingTask(1, 1024), pendingTask(2, 1024+minPositionGap/2), pendingTask(3, 4096))
+	repo.moveErr = errors.New("move failed")
+	tx := &txManagerStub{}
+	svc := NewTaskService(repo, WithTransactionManager(tx))
+
+	_, err := svc.MoveTask(context.Background(), ports.MoveTaskInput{
+		UserID:   42,
+		TaskID:   3,
+		Status:   entities.TaskStatusPending,
+		AfterID:  int64Ptr(1),
+		BeforeID: int64Ptr(2),
+	})
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+
+	if repo.locks != 1 || repo.rebalances != 1 {
+		t.Fatalf("expected the column to be locked and rebalanced, got locks=%d rebalances=%d", repo.locks, repo.rebalances)
+	}
+	if tx.calls != 1 || tx.rollbacks != 1 {
+		t.Fatalf("expected the rebalance to roll back with the move, got %d calls and %d rollbacks", tx.calls, tx.rollbacks)
+	}
+}
+
+func TestMoveTaskRepeatedlyIntoSameGap(t *testing.T) {
+	repo := newBoardRepoStub(pendingTask(1, 1024), pendingTask(2, 2048))
+	svc := NewTaskService(repo)
+
+	// Keep inserting directly below task 1 until the gap has to be rebalanced.
+	for id := int64(10); id < 80; id++ {
+		repo.tasks[id] = &entities.Task{ID: id, UserID: 42, Status: entities.TaskStatusArchived}
+		if _, err := svc.MoveTask(context.Background(), ports.MoveTaskInput{
+			UserID:  42,
+			TaskID:  id,
+			Status:  entities.TaskStatusPending,
+			AfterID: int64Ptr(1),
+		}); err != nil {
+			t.Fatalf("move %d: unexpected error: %v", id, err)
+		}
+	}
+
+	order := repo.order(entities.TaskStatusPending)
+	if order[0] != 1 || order[len(order)-1] != 2 || order[1] != 79 || order[len(order)-2] != 10 {
+		t.Fatalf("unexpected order: %v", order)
+	}
+	if repo.rebalances == 0 {
+		t.Fatalf("expected at least one rebalance")
+	}
+}
+
+func TestMoveTaskValidation(t *testing.T) {
+	repo := newBoardRepoStub(pendingTask(1, 1024), pendingTask(2, 2048),
+		entities.Task{ID: 3, UserID: 42, Status: entities.TaskStatusCompleted, Position: 1024})
+	svc := NewTaskService(repo)
+
+	tests := []struct {
+		name  string
+		input ports.MoveTaskInput
+	}{
+		{"neighbour in another column", ports.MoveTaskInput{TaskID: 1, Status: entities.TaskStatusPending, AfterID: int64Ptr(3)}},
+		{"neighbours out of order", ports.MoveTaskInput{TaskID: 3, Status: entities.TaskStatusPending, AfterID: int64Ptr(2), BeforeID: int64Ptr(1)}},
+		{"own neighbour", ports.MoveTaskInput{TaskID: 1, Status: entities.TaskStatusPending, AfterID: int64Ptr(1)}},
+		{"unknown status", ports.MoveTaskInput{TaskID: 1, Status: "done"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.input.UserID = 42
+			_, err := svc.MoveTask(context.Background(), tt.input)
+			if !errors.Is(err, domain.ErrValidationFailed) && !errors.Is(err, domain.ErrInvalidTaskStatus) {
+				t.Fatalf("expected validation error, got %v", err)
+			}
+			if repo.moves != 0 {
+				t.Fatalf("task must not be moved")
+			}
+		})
+	}
+}
+
+func TestGetBoard(t *testing.T) {
+	repo := newBoardRepoStub(pendingTask(1, 2048), pendingTask(2, 1024),
+		entities.Task{ID: 3, UserID: 42, Status: entities.TaskStatusInProgress, Position: 1024})
+	svc := NewTaskService(repo)
+
+	columns, err := svc.GetBoard(context.Background(), 42, ports.TaskFilter{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(columns) != 3 {
+		t.Fatalf("expected 3 default columns, got %d", len(columns))
+	}
+	if columns[0].Status != entities.TaskStatusPending || len(columns[0].Tasks) != 2 || columns[0].Tasks[0].ID != 2 {
+		t.Fatalf("unexpected pending column: %+v", columns[0])
+	}
+	if columns[2].Status != entities.TaskStatusCompleted || columns[2].Tasks == nil {
+		t.Fatalf("expected empty completed column, got %+v", columns[2])
+	}
+}
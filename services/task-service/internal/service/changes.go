@@ -0,0 +1,296 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"todoapp/pkg/logging"
+	"todoapp/services/task-service/internal/domain/entities"
+	"todoapp/services/task-service/internal/ports"
+)
+
+const (
+	defaultChangeBufferSize   = 100
+	defaultChangeBufferTTL    = 10 * time.Minute
+	defaultChangeRetention    = time.Hour
+	changeSubscriberQueue     = 64
+	changeMaintenanceInterval = time.Minute
+	changeListenBaseBackoff   = time.Second
+	changeListenMaxBackoff    = 30 * time.Second
+)
+
+// ChangeHub fans change events out to the subscribers of this replica. Every
+// replica listens to the events recorded by all of them, so each keeps the
+// same bounded replay buffer per user and a client can resume on any replica
+// with the ID of the last event it saw.
+type ChangeHub struct {
+	listener   ports.ChangeListener
+	changes    ports.ChangeEventRepository
+	bufferSize int
+	bufferTTL  time.Duration
+	retention  time.Duration
+	now        func() time.Time
+	logger     *slog.Logger
+
+	mu     sync.Mutex
+	users  map[int64]*userChanges
+	lastID int64
+}
+
+type userChanges struct {
+	buffer      []entities.ChangeEvent
+	subscribers map[*changeSubscription]struct{}
+	lastEventAt time.Time
+}
+
+type ChangeHubOption func(*ChangeHub)
+
+var (
+	_ ports.ChangeStream  = (*ChangeHub)(nil)
+	_ ports.SessionCloser = (*ChangeHub)(nil)
+)
+
+func NewChangeHub(listener ports.ChangeListener, changes ports.ChangeEventRepository, opts ...ChangeHubOption) *ChangeHub {
+	hub := &ChangeHub{
+		listener:   listener,
+		changes:    changes,
+		bufferSize: defaultChangeBufferSize,
+		bufferTTL:  defaultChangeBufferTTL,
+		retention:  defaultChangeRetention,
+		now:        time.Now,
+		logger:     logging.Discard(),
+		users:      make(map[int64]*userChanges),
+	}
+	for _, opt := range opts {
+		opt(hub)
+	}
+	return hub
+}
+
+// WithChangeBuffer sets how many events are kept per user for resuming and how
+// long a user's buffer is kept after their last event when nobody subscribes.
+func WithChangeBuffer(size int, ttl time.Duration) ChangeHubOption {
+	return func(h *ChangeHub) {
+		if size > 0 {
+			h.bufferSize = size
+		}
+		if ttl > 0 {
+			h.bufferTTL = ttl
+		}
+	}
+}
+
+func WithChangeClock(now func() time.Time) ChangeHubOption {
+	return func(h *ChangeHub) {
+		if now != nil {
+			h.now = now
+		}
+	}
+}
+
+func WithChangeLogger(logger *slog.Logger) ChangeHubOption {
+	return func(h *ChangeHub) {
+		if logger != nil {
+			h.logger = logger
+		}
+	}
+}
+
+// Run listens for change events until ctx is cancelled, reconnecting with
+// backoff, and periodically drops idle buffers and purges stored events.
+func (h *ChangeHub) Run(ctx context.Context) {
+	var wg sync.WaitGroup
+	wg.Go(func() {
+		h.maintain(ctx)
+	})
+	defer wg.Wait()
+
+	failures := 0
+	for {
+		h.mu.Lock()
+		afterID := h.lastID
+		h.mu.Unlock()
+
+		err := h.listener.Listen(ctx, afterID, h.Publish)
+		if ctx.Err() != nil {
+			return
+		}
+		h.logger.ErrorContext(ctx, "change listener stopped", "error", err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(retryBackoff(changeListenBaseBackoff, changeListenMaxBackoff, failures)):
+		}
+		failures++
+	}
+}
+
+func (h *ChangeHub) maintain(ctx context.Context) {
+	ticker := time.NewTicker(changeMaintenanceInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		now := h.now()
+		h.dropIdleBuffers(now)
+
+		if purged, err := h.changes.PurgeChanges(ctx, now.Add(-h.retention)); err != nil {
+			h.logger.ErrorContext(ctx, "change purge failed", "error", err)
+		} else if purged > 0 {
+			h.logger.InfoContext(ctx, "change stream purged", "purged", purged)
+		}
+	}
+}
+
+func (h *ChangeHub) dropIdleBuffers(now time.Time) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for userID, user := range h.users {
+		if len(user.subscribers) == 0 && now.Sub(user.lastEventAt) > h.bufferTTL {
+			delete(h.users, userID)
+		}
+	}
+}
+
+// Publish buffers an event and hands it to the user's subscribers. Events
+// already buffered are ignored. A subscriber whose queue is full is dropped
+// rather than slowing everyone down; it can resume from its last event.
+func (h *ChangeHub) Publish(event entities.ChangeEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	user := h.user(event.UserID)
+	for _, buffered := range user.buffer {
+		if buffered.ID == event.ID {
+			return
+		}
+	}
+
+	user.buffer = append(user.buffer, event)
+	if len(user.buffer) > h.bufferSize {
+		user.buffer = append(user.buffer[:0], user.buffer[len(user.buffer)-h.bufferSize:]...)
+	}
+	user.lastEventAt = h.now()
+	h.lastID = max(h.lastID, event.ID)
+
+	for sub := range user.subscribers {
+		select {
+		case sub.events <- event:
+		default:
+			delete(user.subscribers, sub)
+			sub.end()
+		}
+	}
+}
+
+func (h *ChangeHub) Subscribe(userID, lastEventID int64) (ports.ChangeSubscription, []entities.ChangeEvent, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	user := h.user(userID)
+	sub := &changeSubscription{
+		hub:    h,
+		userID: userID,
+		events: make(chan entities.ChangeEvent, changeSubscriberQueue),
+		done:   make(chan struct{}),
+	}
+	user.subscribers[sub] = struct{}{}
+
+	if lastEventID <= 0 {
+		return sub, nil, true
+	}
+
+	for i, event := range user.buffer {
+		if event.ID == lastEventID {
+			replay := make([]entities.ChangeEvent, len(user.buffer)-i-1)
+			copy(replay, user.buffer[i+1:])
+			return sub, replay, true
+		}
+	}
+
+	return sub, nil, false
+}
+
+// CloseSubscriptions ends every subscription, e.g. on shutdown.
+func (h *ChangeHub) CloseSubscriptions() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, user := range h.users {
+		for sub := range user.subscribers {
+			delete(user.subscribers, sub)
+			sub.end()
+		}
+	}
+}
+
+// CloseUser ends the user's subscriptions, e.g. when their tokens are
+// revoked. A client whose token is still valid reconnects and resumes from
+// its last event.
+func (h *ChangeHub) CloseUser(userID int64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	user, ok := h.users[userID]
+	if !ok {
+		return
+	}
+	for sub := range user.subscribers {
+		delete(user.subscribers, sub)
+		sub.end()
+	}
+}
+
+func (h *ChangeHub) user(userID int64) *userChanges {
+	user, ok := h.users[userID]
+	if !ok {
+		user = &userChanges{subscribers: make(map[*changeSubscription]struct{})}
+		h.users[userID] = user
+	}
+	return user
+}
+
+func (h *ChangeHub) unsubscribe(sub *changeSubscription) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if user, ok := h.users[sub.userID]; ok {
+		delete(user.subscribers, sub)
+	}
+	sub.end()
+}
+
+type changeSubscription struct {
+	hub    *ChangeHub
+	userID int64
+	events chan entities.ChangeEvent
+	done   chan struct{}
+	once   sync.Once
+}
+
+func (s *changeSubscription) Events() <-chan entities.ChangeEvent {
+	return s.events
+}
+
+func (s *changeSubscription) Done() <-chan struct{} {
+	return s.done
+}
+
+func (s *changeSubscription) Close() {
+	s.hub.unsubscribe(s)
+}
+
+func (s *changeSubscription) end() {
+	s.once.Do(func() {
+		close(s.done)
+	})
+}
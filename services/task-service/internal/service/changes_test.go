@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"todoapp/services/task-service/internal/domain/entities"
+	"todoapp/services/task-service/internal/ports"
+)
+
+type changeRepoStub struct {
+	recorded []entities.ChangeEvent
+	purged   []time.Time
+}
+
+func (r *changeRepoStub) RecordChange(ctx context.Context, event *entities.ChangeEvent) error {
+	event.ID = int64(len(r.recorded) + 1)
+	r.recorded = append(r.recorded, *event)
+	return nil
+}
+
+func (r *changeRepoStub) PurgeChanges(ctx context.Context, before time.Time) (int64, error) {
+	r.purged = append(r.purged, before)
+	return 0, nil
+}
+
+// changeListenerStub delivers its events once and then waits for ctx.
+type changeListenerStub struct {
+	events  []entities.ChangeEvent
+	afterID chan int64
+}
+
+func (l *changeListenerStub) Listen(ctx context.Context, afterID int64, fn func(entities.ChangeEvent)) error {
+	l.afterID <- afterID
+	for _, event := range l.events {
+		if event.ID > afterID {
+			fn(event)
+		}
+	}
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func changeEvent(id, userID int64) entities.ChangeEvent {
+	return entities.ChangeEvent{
+		ID:     id,
+		UserID: userID,
+		Type:   entities.ChangeTaskUpdated,
+		Task:   &entities.Task{ID: id, UserID: userID},
+	}
+}
+
+func receiveChange(t *testing.T, sub ports.ChangeSubscription) entities.ChangeEvent {
+	t.Helper()
+	select {
+	case event := <-sub.Events():
+		return event
+	case <-time.After(time.Second):
+		t.Fatal("expected a change event")
+		return entities.ChangeEvent{}
+	}
+}
+
+func TestChangeHub_DeliversToTheUsersSubscribers(t *testing.T) {
+	hub := NewChangeHub(&changeListenerStub{}, &changeRepoStub{})
+
+	mine, _, _ := hub.Subscribe(42, 0)
+	defer mine.Close()
+	other, _, _ := hub.Subscribe(7, 0)
+	defer other.Close()
+
+	hub.Publish(changeEvent(1, 42))
+
+	if event := receiveChange(t, mine); event.ID != 1 {
+		t.Fatalf("unexpected event: %+v", event)
+	}
+	select {
+	case event := <-other.Events():
+		t.Fatalf("other user received %+v", event)
+	default:
+	}
+}
+
+func TestChangeHub_ResumesFromLastEventID(t *testing.T) {
+	hub := NewChangeHub(&changeListenerStub{}, &changeRepoStub{}, WithChangeBuffer(3, time.Minute))
+	for id := int64(1); id <= 5; id++ {
+		hub.Publish(changeEvent(id, 42))
+	}
+	// Seen twice, e.g. after a listener reconnect.
+	hub.Publish(changeEvent(5, 42))
+
+	sub, replay, resumed := hub.Subscribe(42, 3)
+	defer sub.Close()
+	if !resumed || len(replay) != 2 || replay[0].ID != 4 || replay[1].ID != 5 {
+		t.Fatalf("unexpected replay: resumed=%v %+v", resumed, replay)
+	}
+
+	// Event 2 fell out of the buffer, so the client has to reload.
+	sub2, replay, resumed := hub.Subscribe(42, 2)
+	defer sub2.Close()
+	if resumed || len(replay) != 0 {
+		t.Fatalf("expected a reset, got resumed=%v %+v", resumed, replay)
+	}
+}
+
+func TestChangeHub_DropsSlowSubscriber(t *testing.T) {
+	hub := NewChangeHub(&changeListenerStub{}, &changeRepoStub{}, WithChangeBuffer(changeSubscriberQueue*2, time.Minute))
+	sub, _, _ := hub.Subscribe(42, 0)
+	defer sub.Close()
+
+	for id := int64(1); id <= changeSubscriberQueue+1; id++ {
+		hub.Publish(changeEvent(id, 42))
+	}
+
+	select {
+	case <-sub.Done():
+	default:
+		t.Fatal("expected the subscription to end")
+	}
+}
+
+func TestChangeHub_CloseSubscriptions(t *testing.T) {
+	hub := NewChangeHub(&changeListenerStub{}, &changeRepoStub{})
+	sub, _, _ := hub.Subscribe(42, 0)
+
+	hub.CloseSubscriptions()
+	sub.Close()
+
+	select {
+	case <-sub.Done():
+	default:
+		t.Fatal("expected the subscription to end")
+	}
+}
+
+func TestChangeHub_CloseUser(t *testing.T) {
+	hub := NewChangeHub(&changeListenerStub{}, &changeRepoStub{})
+	revoked, _, _ := hub.Subscribe(42, 0)
+	other, _, _ := hub.Subscribe(7, 0)
+	defer other.Close()
+
+	hub.CloseUser(42)
+
+	select {
+	case <-revoked.Done():
+	default:
+		t.Fatal("expected the user's subscription to end")
+	}
+	select {
+	case <-other.Done():
+		t.Fatal("expected other users' subscriptions to stay open")
+	default:
+	}
+}
+
+func TestChangeHub_RunPublishesListenedEvents(t *testing.T) {
+	listener := &changeListenerStub{
+		events:  []entities.ChangeEvent{changeEvent(1, 42), changeEvent(2, 42)},
+		afterID: make(chan int64, 1),
+	}
+	hub := NewChangeHub(listener, &changeRepoStub{})
+	sub, _, _ := hub.Subscribe(42, 0)
+	defer sub.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		hub.Run(ctx)
+	}()
+
+	if afterID := <-listener.afterID; afterID != 0 {
+		t.Fatalf("expected to listen from the start, got %d", afterID)
+	}
+	if first, second := receiveChange(t, sub), receiveChange(t, sub); first.ID != 1 || second.ID != 2 {
+		t.Fatalf("unexpected events: %d, %d", first.ID, second.ID)
+	}
+
+	cancel()
+	<-done
+}
+
+func TestTaskService_RecordsChanges(t *testing.T) {
+	changes := &changeRepoStub{}
+	tasks := &repoMock{storedTask: &entities.Task{ID: 7, UserID: 42, Title: "Report", Status: entities.TaskStatusPending, Priority: entities.TaskPriorityMedium}}
+	svc := NewTaskService(tasks, WithChangeRecorder(changes), WithTransactionManager(&txManagerStub{}))
+
+	if _, err := svc.CreateCategory(context.Background(), ports.CreateCategoryInput{UserID: 42, Name: "Work"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := svc.AddComment(context.Background(), ports.AddCommentInput{UserID: 42, TaskID: 7, Content: "Done"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := svc.DeleteTask(context.Background(), 42, 7); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(changes.recorded) != 3 {
+		t.Fatalf("expected 3 changes, got %+v", changes.recorded)
+	}
+	if event := changes.recorded[0]; event.Type != entities.ChangeCategoryCreated || event.UserID != 42 || event.Category == nil || event.Category.Name != "Work" {
+		t.Fatalf("unexpected category change: %+v", event)
+	}
+	if event := changes.recorded[1]; event.Type != entities.ChangeCommentCreated || event.Comment == nil || event.Comment.TaskID != 7 {
+		t.Fatalf("unexpected comment change: %+v", event)
+	}
+	if event := changes.recorded[2]; event.Type != entities.ChangeTaskDeleted || event.Task == nil || event.Task.ID != 7 {
+		t.Fatalf("unexpected delete change: %+v", event)
+	}
+}
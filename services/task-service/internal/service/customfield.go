@@ -0,0 +1,367 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"todoapp/services/task-service/internal/domain"
+	"todoapp/services/task-service/internal/domain/entities"
+	"todoapp/services/task-service/internal/ports"
+)
+
+const (
+	maxCustomFields         = 50
+	maxCustomFieldOptions   = 50
+	maxCustomFieldName      = 100
+	maxCustomFieldOption    = 100
+	maxCustomFieldTextValue = 1000
+	maxCustomFieldURLValue  = 2000
+)
+
+var customFieldKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
+
+// CustomFieldService manages the users' custom field definitions. Values are
+// validated against them by TaskService.
+type CustomFieldService struct {
+	fields ports.CustomFieldRepository
+	users  ports.UserDirectory
+}
+
+type CustomFieldServiceOption func(*CustomFieldService)
+
+var _ ports.CustomFieldService = (*CustomFieldService)(nil)
+
+func NewCustomFieldService(fields ports.CustomFieldRepository, opts ...CustomFieldServiceOption) *CustomFieldService {
+	svc := &CustomFieldService{fields: fields}
+	for _, opt := range opts {
+		opt(svc)
+	}
+	return svc
+}
+
+func WithCustomFieldUserDirectory(users ports.UserDirectory) CustomFieldServiceOption {
+	return func(s *CustomFieldService) {
+		s.users = users
+	}
+}
+
+func (s *CustomFieldService) CreateCustomField(ctx context.Context, input ports.CreateCustomFieldInput) (*entities.CustomField, error) {
+	if _, err := ensureActiveUser(ctx, s.users, input.UserID); err != nil {
+		return nil, err
+	}
+
+	key := strings.ToLower(strings.TrimSpace(input.Key))
+	if !customFieldKeyPattern.MatchString(key) {
+		return nil, domain.ErrValidationFailed.WithMessage("custom field key must start with a letter and contain only lower-case letters, digits and underscores")
+	}
+	if !input.Type.IsValid() {
+		return nil, domain.ErrValidationFailed.WithMessage("unsupported custom field type: " + string(input.Type))
+	}
+
+	field := &entities.CustomField{
+		UserID: input.UserID,
+		Key:    key,
+		Type:   input.Type,
+	}
+	if err := setCustomFieldName(field, input.Name); err != nil {
+		return nil, err
+	}
+	if err := setCustomFieldOptions(field, input.Options); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.fields.ListCustomFields(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if len(existing) >= maxCustomFields {
+		return nil, domain.ErrValidationFailed.WithMessage(fmt.Sprintf("at most %d custom fields are allowed", maxCustomFields))
+	}
+
+	if err := s.fields.CreateCustomField(ctx, field); err != nil {
+		return nil, err
+	}
+
+	return field, nil
+}
+
+func (s *CustomFieldService) GetCustomField(ctx context.Context, userID, fieldID int64) (*entities.CustomField, error) {
+	if _, err := ensureActiveUser(ctx, s.users, userID); err != nil {
+		return nil, err
+	}
+	return s.fields.GetCustomField(ctx, userID, fieldID)
+}
+
+func (s *CustomFieldService) ListCustomFields(ctx context.Context, userID int64) ([]entities.CustomField, error) {
+	if _, err := ensureActiveUser(ctx, s.users, userID); err != nil {
+		return nil, err
+	}
+	return s.fields.ListCustomFields(ctx, userID)
+}
+
+// UpdateCustomField renames a field or replaces its options. Tasks keep values
+// of removed options until the field is set again.
+func (s *CustomFieldService) UpdateCustomField(ctx context.Context, input ports.UpdateCustomFieldInput) (*entities.CustomField, error) {
+	if _, err := ensureActiveUser(ctx, s.users, input.UserID); err != nil {
+		return nil, err
+	}
+
+	field, err := s.fields.GetCustomField(ctx, input.UserID, input.FieldID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Name != nil {
+		if err := setCustomFieldName(field, *input.Name); err != nil {
+			return nil, err
+		}
+	}
+	if input.Options != nil {
+		if err := setCustomFieldOptions(field, input.Options); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.fields.UpdateCustomField(ctx, field); err != nil {
+		return nil, err
+	}
+
+	return field, nil
+}
+
+func (s *CustomFieldService) DeleteCustomField(ctx context.Context, userID, fieldID int64) error {
+	if _, err := ensureActiveUser(ctx, s.users, userID); err != nil {
+		return err
+	}
+	return s.fields.DeleteCustomField(ctx, userID, fieldID)
+}
+
+func setCustomFieldName(field *entities.CustomField, name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return domain.ErrValidationFailed.WithMessage("custom field name is required")
+	}
+	if len(name) > maxCustomFieldName {
+		return domain.ErrValidationFailed.WithMessage(fmt.Sprintf("custom field name must be at most %d characters", maxCustomFieldName))
+	}
+	field.Name = name
+	return nil
+}
+
+// setCustomFieldOptions requires options for select fields and rejects them
+// for every other type.
+func setCustomFieldOptions(field *entities.CustomField, options []string) error {
+	if !field.Type.HasOptions() {
+		if len(options) > 0 {
+			return domain.ErrValidationFailed.WithMessage("only select fields have options")
+		}
+		field.Options = nil
+		return nil
+	}
+
+	if len(options) == 0 || len(options) > maxCustomFieldOptions {
+		return domain.ErrValidationFailed.WithMessage(fmt.Sprintf("select fields need between 1 and %d options", maxCustomFieldOptions))
+	}
+
+	normalized := make([]string, 0, len(options))
+	for _, option := range options {
+		option = strings.TrimSpace(option)
+		if option == "" || len(option) > maxCustomFieldOption {
+			return domain.ErrValidationFailed.WithMessage(fmt.Sprintf("options must be between 1 and %d characters", maxCustomFieldOption))
+		}
+		if slices.Contains(normalized, option) {
+			return domain.ErrValidationFailed.WithMessage(fmt.Sprintf("option %q is listed twice", option))
+		}
+		normalized = append(normalized, option)
+	}
+
+	field.Options = normalized
+	return nil
+}
+
+// normalizeCustomFieldValue checks a value decoded from JSON against its field
+// and returns the form stored on tasks. A nil result removes the value: it is
+// returned for null, empty text and an empty multi_select.
+func normalizeCustomFieldValue(field entities.CustomField, value any) (any, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	invalid := func(expected string) error {
+		return domain.ErrValidationFailed.WithMessage(fmt.Sprintf("custom field %q expects %s", field.Key, expected))
+	}
+
+	switch field.Type {
+	case entities.CustomFieldText:
+		text, ok := value.(string)
+		if !ok {
+			return nil, invalid("text")
+		}
+		text = strings.TrimSpace(text)
+		if len(text) > maxCustomFieldTextValue {
+			return nil, invalid(fmt.Sprintf("at most %d characters", maxCustomFieldTextValue))
+		}
+		if text == "" {
+			return nil, nil
+		}
+		return text, nil
+
+	case entities.CustomFieldNumber:
+		number, ok := customFieldNumber(value)
+		if !ok {
+			return nil, invalid("a number")
+		}
+		return number, nil
+
+	case entities.CustomFieldDate:
+		text, ok := value.(string)
+		if !ok {
+			return nil, invalid("a date (YYYY-MM-DD)")
+		}
+		date, ok := parseCustomFieldDate(text)
+		if !ok {
+			return nil, invalid("a date (YYYY-MM-DD)")
+		}
+		return date, nil
+
+	case entities.CustomFieldSelect:
+		option, ok := value.(string)
+		if !ok || !slices.Contains(field.Options, strings.TrimSpace(option)) {
+			return nil, invalid("one of its options")
+		}
+		return strings.TrimSpace(option), nil
+
+	case entities.CustomFieldMultiSelect:
+		items, ok := value.([]any)
+		if !ok {
+			if list, isList := value.([]string); isList {
+				for _, item := range list {
+					items = append(items, item)
+				}
+				ok = true
+			}
+		}
+		if !ok {
+			return nil, invalid("a list of its options")
+		}
+		selected := make([]string, 0, len(items))
+		for _, item := range items {
+			option, ok := item.(string)
+			if !ok || !slices.Contains(field.Options, strings.TrimSpace(option)) {
+				return nil, invalid("a list of its options")
+			}
+			if option = strings.TrimSpace(option); !slices.Contains(selected, option) {
+				selected = append(selected, option)
+			}
+		}
+		if len(selected) == 0 {
+			return nil, nil
+		}
+		return selected, nil
+
+	case entities.CustomFieldCheckbox:
+		checked, ok := value.(bool)
+		if !ok {
+			return nil, invalid("true or false")
+		}
+		return checked, nil
+
+	case entities.CustomFieldURL:
+		text, ok := value.(string)
+		if !ok {
+			return nil, invalid("an http(s) URL")
+		}
+		text = strings.TrimSpace(text)
+		if text == "" {
+			return nil, nil
+		}
+		parsed, err := url.Parse(text)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" || len(text) > maxCustomFieldURLValue {
+			return nil, invalid("an http(s) URL")
+		}
+		return text, nil
+
+	default:
+		return nil, invalid("a supported type")
+	}
+}
+
+// parseCustomFieldFilter turns a filter value from a query string into the
+// value stored on tasks.
+func parseCustomFieldFilter(field entities.CustomField, raw string) (any, error) {
+	raw = strings.TrimSpace(raw)
+
+	invalid := func(expected string) error {
+		return domain.ErrValidationFailed.WithMessage(fmt.Sprintf("filter on custom field %q expects %s", field.Key, expected))
+	}
+
+	switch field.Type {
+	case entities.CustomFieldNumber:
+		number, err := strconv.ParseFloat(raw, 64)
+		if err != nil || math.IsInf(number, 0) || math.IsNaN(number) {
+			return nil, invalid("a number")
+		}
+		return number, nil
+	case entities.CustomFieldDate:
+		date, ok := parseCustomFieldDate(raw)
+		if !ok {
+			return nil, invalid("a date (YYYY-MM-DD)")
+		}
+		return date, nil
+	case entities.CustomFieldCheckbox:
+		checked, err := strconv.ParseBool(raw)
+		if err != nil {
+			return nil, invalid("true or false")
+		}
+		return checked, nil
+	case entities.CustomFieldMultiSelect:
+		return []string{raw}, nil
+	default:
+		return raw, nil
+	}
+}
+
+func customFieldNumber(value any) (float64, bool) {
+	var number float64
+	switch v := value.(type) {
+	case float64:
+		number = v
+	case int:
+		number = float64(v)
+	case int64:
+		number = float64(v)
+	case json.Number:
+		parsed, err := v.Float64()
+		if err != nil {
+			return 0, false
+		}
+		number = parsed
+	default:
+		return 0, false
+	}
+	if math.IsInf(number, 0) || math.IsNaN(number) {
+		return 0, false
+	}
+	return number, true
+}
+
+// parseCustomFieldDate accepts a date or an RFC 3339 timestamp and keeps the
+// date part.
+func parseCustomFieldDate(text string) (string, bool) {
+	text = strings.TrimSpace(text)
+	if date, err := time.Parse(entities.CustomFieldDateLayout, text); err == nil {
+		return date.Format(entities.CustomFieldDateLayout), true
+	}
+	if timestamp, err := time.Parse(time.RFC3339, text); err == nil {
+		return timestamp.Format(entities.CustomFieldDateLayout), true
+	}
+	return "", false
+}
@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"todoapp/services/task-service/internal/domain"
+	"todoapp/services/task-service/internal/domain/entities"
+	"todoapp/services/task-service/internal/ports"
+)
+
+type customFieldRepoStub struct {
+	ports.CustomFieldRepository
+	fields  []entities.CustomField
+	created *entities.CustomField
+}
+
+func (r *customFieldRepoStub) ListCustomFields(ctx context.Context, userID int64) ([]entities.CustomField, error) {
+	return r.fields, nil
+}
+
+func (r *customFieldRepoStub) CreateCustomField(ctx context.Context, field *entities.CustomField) error {
+	field.ID = 1
+	r.created = field
+	return nil
+}
+
+func sampleCustomFields() []entities.CustomField {
+	return []entities.CustomField{
+		{ID: 1, Key: "points", Name: "Story points", Type: entities.CustomFieldNumber},
+		{ID: 2, Key: "env", Name: "Environment", Type: entities.CustomFieldMultiSelect, Options: []string{"dev", "prod"}},
+		{ID: 3, Key: "customer", Name: "Customer", Type: entities.CustomFieldText},
+		{ID: 4, Key: "billable", Name: "Billable", Type: entities.CustomFieldCheckbox},
+	}
+}
+
+func TestCreateCustomField(t *testing.T) {
+	tests := []struct {
+		name  string
+		input ports.CreateCustomFieldInput
+		err   error
+	}{
+		{name: "bad key", input: ports.CreateCustomFieldInput{Key: "1st", Name: "First", Type: entities.CustomFieldText}, err: domain.ErrValidationFailed},
+		{name: "unknown type", input: ports.CreateCustomFieldInput{Key: "color", Name: "Color", Type: "color"}, err: domain.ErrValidationFailed},
+		{name: "options on text", input: ports.CreateCustomFieldInput{Key: "note", Name: "Note", Type: entities.CustomFieldText, Options: []string{"a"}}, err: domain.ErrValidationFailed},
+		{name: "select without options", input: ports.CreateCustomFieldInput{Key: "env", Name: "Env", Type: entities.CustomFieldSelect}, err: domain.ErrValidationFailed},
+		{name: "duplicate option", input: ports.CreateCustomFieldInput{Key: "env", Name: "Env", Type: entities.CustomFieldSelect, Options: []string{"dev", " dev "}}, err: domain.ErrValidationFailed},
+		{name: "valid", input: ports.CreateCustomFieldInput{Key: " Env ", Name: " Env ", Type: entities.CustomFieldSelect, Options: []string{"dev", "prod"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &customFieldRepoStub{}
+			svc := NewCustomFieldService(repo)
+
+			tt.input.UserID = 1
+			field, err := svc.CreateCustomField(context.Background(), tt.input)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("expected %v, got %v", tt.err, err)
+			}
+			if tt.err == nil && (repo.created == nil || field.Key != "env" || field.Name != "Env") {
+				t.Fatalf("field not normalized: %+v", field)
+			}
+		})
+	}
+}
+
+func TestNormalizeCustomFieldValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		field   entities.CustomField
+		value   any
+		want    any
+		wantErr bool
+	}{
+		{name: "text", field: entities.CustomField{Type: entities.CustomFieldText}, value: " Acme ", want: "Acme"},
+		{name: "empty text clears", field: entities.CustomField{Type: entities.CustomFieldText}, value: " ", want: nil},
+		{name: "number", field: entities.CustomField{Type: entities.CustomFieldNumber}, value: float64(5), want: float64(5)},
+		{name: "number as text", field: entities.CustomField{Type: entities.CustomFieldNumber}, value: "5", wantErr: true},
+		{name: "date", field: entities.CustomField{Type: entities.CustomFieldDate}, value: "2024-12-20", want: "2024-12-20"},
+		{name: "timestamp keeps date", field: entities.CustomField{Type: entities.CustomFieldDate}, value: "2024-12-20T08:00:00Z", want: "2024-12-20"},
+		{name: "bad date", field: entities.CustomField{Type: entities.CustomFieldDate}, value: "20/12/2024", wantErr: true},
+		{name: "select", field: entities.CustomField{Type: entities.CustomFieldSelect, Options: []string{"dev"}}, value: "dev", want: "dev"},
+		{name: "unknown option", field: entities.CustomField{Type: entities.CustomFieldSelect, Options: []string{"dev"}}, value: "qa", wantErr: true},
+		{name: "multi select", field: entities.CustomField{Type: entities.CustomFieldMultiSelect, Options: []string{"dev", "prod"}}, value: []any{"prod", "dev", "prod"}, want: []string{"prod", "dev"}},
+		{name: "checkbox", field: entities.CustomField{Type: entities.CustomFieldCheckbox}, value: true, want: true},
+		{name: "url", field: entities.CustomField{Type: entities.CustomFieldURL}, value: "https://example.com/a", want: "https://example.com/a"},
+		{name: "url without scheme", field: entities.CustomField{Type: entities.CustomFieldURL}, value: "example.com", wantErr: true},
+		{name: "null clears", field: entities.CustomField{Type: entities.CustomFieldNumber}, value: nil, want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := normalizeCustomFieldValue(tt.field, tt.value)
+			if tt.wantErr {
+				if !errors.Is(err, domain.ErrValidationFailed) {
+					t.Fatalf("expected validation error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("expected %#v, got %#v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestTaskCustomFields(t *testing.T) {
+	repo := &repoMock{}
+	svc := NewTaskService(repo, WithCustomFieldLister(&customFieldRepoStub{fields: sampleCustomFields()}))
+
+	task, err := svc.CreateTask(context.Background(), ports.CreateTaskInput{
+		UserID:       1,
+		Title:        "t",
+		Priority:     entities.TaskPriorityMedium,
+		CustomFields: entities.CustomFieldValues{"points": float64(3), "customer": " Acme "},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if task.CustomFields["points"] != float64(3) || task.CustomFields["customer"] != "Acme" {
+		t.Fatalf("unexpected custom fields: %+v", task.CustomFields)
+	}
+
+	repo.storedTask = task
+	updated, err := svc.UpdateTask(context.Background(), ports.UpdateTaskInput{
+		UserID:       1,
+		TaskID:       task.ID,
+		CustomFields: entities.CustomFieldValues{"customer": nil, "env": []any{"prod"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := entities.CustomFieldValues{"points": float64(3), "env": []string{"prod"}}
+	if !reflect.DeepEqual(updated.CustomFields, want) {
+		t.Fatalf("expected %+v, got %+v", want, updated.CustomFields)
+	}
+
+	_, err = svc.CreateTask(context.Background(), ports.CreateTaskInput{
+		UserID:       1,
+		Title:        "t",
+		Priority:     entities.TaskPriorityMedium,
+		CustomFields: entities.CustomFieldValues{"unknown": "x"},
+	})
+	if !errors.Is(err, domain.ErrValidationFailed) {
+		t.Fatalf("expected unknown field to be rejected, got %v", err)
+	}
+}
+
+func TestListTasksResolvesCustomFieldFilter(t *testing.T) {
+	repo := &repoMock{}
+	svc := NewTaskService(repo, WithCustomFieldLister(&customFieldRepoStub{fields: sampleCustomFields()}))
+
+	_, err := svc.ListTasks(context.Background(), 1, ports.TaskFilter{
+		CustomFields: []ports.CustomFieldFilter{
+			{Key: "billable", Value: "false"},
+			{Key: "env", Value: "prod"},
+			{Key: "points", Value: "5"},
+		},
+		SortField: "points",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []ports.CustomFieldFilter{
+		{Key: "billable", Value: false},
+		{Key: "env", Value: []string{"prod"}},
+		{Key: "points", Value: float64(5)},
+	}
+	if !reflect.DeepEqual(repo.listFilter.CustomFields, want) {
+		t.Fatalf("expected %+v, got %+v", want, repo.listFilter.CustomFields)
+	}
+
+	tests := []ports.TaskFilter{
+		{CustomFields: []ports.CustomFieldFilter{{Key: "points", Value: "many"}}},
+		{CustomFields: []ports.CustomFieldFilter{{Key: "unknown", Value: "x"}}},
+		{SortField: "unknown"},
+	}
+	for _, filter := range tests {
+		if _, err := svc.ListTasks(context.Background(), 1, filter); !errors.Is(err, domain.ErrValidationFailed) {
+			t.Fatalf("expected validation error for %+v, got %v", filter, err)
+		}
+	}
+}
@@ -0,0 +1,26 @@
+package service
+
+import "time"
+
+// offsetUnitPattern matches the unit of the relative offsets accepted by
+// template due dates and saved view filters: m for minutes, h for hours, d for
+// days, w for weeks and mo for months.
+const offsetUnitPattern = `mo|[mhdw]`
+
+// addOffset moves t by amount units of offsetUnitPattern. Day, week and month
+// offsets keep the wall-clock time across DST changes.
+func addOffset(t time.Time, amount int, unit string) time.Time {
+	switch unit {
+	case "m":
+		return t.Add(time.Duration(amount) * time.Minute)
+	case "h":
+		return t.Add(time.Duration(amount) * time.Hour)
+	case "d":
+		return t.AddDate(0, 0, amount)
+	case "w":
+		return t.AddDate(0, 0, 7*amount)
+	case "mo":
+		return t.AddDate(0, amount, 0)
+	}
+	return t
+}
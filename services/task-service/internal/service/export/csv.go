@@ -4,17 +4,21 @@ import (
 	"bytes"
 	"encoding/csv"
 	"strconv"
+	"strings"
 	"time"
 
 	"todoapp/services/task-service/internal/domain/entities"
 )
 
 // CSVFormatter formats tasks as CSV.
-type CSVFormatter struct{}
+type CSVFormatter struct {
+	fields []entities.CustomField
+}
 
-// NewCSVFormatter creates a new CSV formatter.
-func NewCSVFormatter() *CSVFormatter {
-	return &CSVFormatter{}
+// NewCSVFormatter creates a new CSV formatter. Each custom field becomes an
+// extra column named after the field.
+func NewCSVFormatter(fields ...entities.CustomField) *CSVFormatter {
+	return &CSVFormatter{fields: fields}
 }
 
 // Format converts tasks to CSV format.
@@ -28,7 +32,10 @@ func (f *CSVFormatter) Format(tasks []entities.Task) ([]byte, error) {
 	writer := csv.NewWriter(&buf)
 
 	// Write header
-	header := []string{"ID", "Title", "Description", "Status", "Priority", "DueDate", "Category", "CreatedAt", "UpdatedAt"}
+	header := []string{"ID", "Title", "Description", "Status", "Priority", "DueDate", "Category", "CreatedAt", "UpdatedAt", "CategoryColor", "EstimateMinutes", "TrackedMinutes"}
+	for _, field := range f.fields {
+		header = append(header, field.Name)
+	}
 	if err := writer.Write(header); err != nil {
 		return nil, err
 	}
@@ -56,11 +63,18 @@ func (f *CSVFormatter) taskToRow(task entities.Task) []string {
 	}
 
 	category := ""
+	categoryColor := ""
 	if task.Category != nil {
 		category = task.Category.Name
+		categoryColor = task.Category.Color
+	}
+
+	estimate := ""
+	if task.EstimateMinutes != nil {
+		estimate = strconv.Itoa(*task.EstimateMinutes)
 	}
 
-	return []string{
+	row := []string{
 		strconv.FormatInt(task.ID, 10),
 		task.Title,
 		task.Description,
@@ -70,5 +84,14 @@ func (f *CSVFormatter) taskToRow(task entities.Task) []string {
 		category,
 		task.CreatedAt.Format(time.RFC3339),
 		task.UpdatedAt.Format(time.RFC3339),
+		categoryColor,
+		estimate,
+		strconv.FormatInt(int64(task.TrackedTime.Round(time.Minute)/time.Minute), 10),
 	}
+
+	for _, field := range f.fields {
+		row = append(row, strings.Join(customFieldValues(task.CustomFields[field.Key]), ", "))
+	}
+
+	return row
 }
@@ -49,7 +49,7 @@ func TestCSVFormatter_Format_SingleTask(t *testing.T) {
 			Status:      entities.TaskStatusPending,
 			Priority:    entities.TaskPriorityHigh,
 			DueDate:     &dueDate,
-			Category:    &entities.Category{Name: "Work"},
+			Category:    &entities.Category{Name: "Work", Color: "#3498db"},
 			CreatedAt:   now,
 			UpdatedAt:   now,
 		},
@@ -94,6 +94,9 @@ func TestCSVFormatter_Format_SingleTask(t *testing.T) {
 	if row[6] != "Work" {
 		t.Errorf("expected Category='Work', got %s", row[6])
 	}
+	if row[9] != "#3498db" {
+		t.Errorf("expected CategoryColor='#3498db', got %s", row[9])
+	}
 }
 
 func TestCSVFormatter_Format_MultipleTasks(t *testing.T) {
@@ -222,3 +225,70 @@ func TestCSVFormatter_Format_NilDueDateAndCategory(t *testing.T) {
 		t.Errorf("expected empty category, got: %s", row[6])
 	}
 }
+
+func TestCSVFormatter_Format_TimeTracking(t *testing.T) {
+	formatter := NewCSVFormatter()
+	now := time.Now()
+	estimate := 90
+
+	tasks := []entities.Task{
+		{ID: 1, Title: "Estimated", Status: entities.TaskStatusPending, Priority: entities.TaskPriorityLow, EstimateMinutes: &estimate, TrackedTime: 45*time.Minute + 40*time.Second, CreatedAt: now, UpdatedAt: now},
+		{ID: 2, Title: "Untracked", Status: entities.TaskStatusPending, Priority: entities.TaskPriorityLow, CreatedAt: now, UpdatedAt: now},
+	}
+
+	data, err := formatter.Format(tasks)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	records, err := csv.NewReader(strings.NewReader(string(data[3:]))).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to parse CSV: %v", err)
+	}
+
+	header := records[0]
+	if header[10] != "EstimateMinutes" || header[11] != "TrackedMinutes" {
+		t.Fatalf("unexpected header: %v", header)
+	}
+	if records[1][10] != "90" || records[1][11] != "46" {
+		t.Errorf("expected estimate 90 and 46 tracked minutes, got %v", records[1][10:])
+	}
+	if records[2][10] != "" || records[2][11] != "0" {
+		t.Errorf("expected empty estimate and 0 tracked minutes, got %v", records[2][10:])
+	}
+}
+
+func TestCSVFormatter_Format_CustomFields(t *testing.T) {
+	formatter := NewCSVFormatter(
+		entities.CustomField{Key: "points", Name: "Story points", Type: entities.CustomFieldNumber},
+		entities.CustomField{Key: "env", Name: "Environment", Type: entities.CustomFieldMultiSelect},
+	)
+
+	tasks := []entities.Task{
+		{ID: 1, Title: "With values", CustomFields: entities.CustomFieldValues{"points": 2.5, "env": []any{"dev", "prod"}}},
+		{ID: 2, Title: "Without values"},
+	}
+
+	data, err := formatter.Format(tasks)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	records, err := csv.NewReader(bytes.NewReader(data[3:])).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to parse CSV: %v", err)
+	}
+
+	header := records[0]
+	if header[len(header)-2] != "Story points" || header[len(header)-1] != "Environment" {
+		t.Fatalf("expected custom field columns, got %v", header)
+	}
+	row := records[1]
+	if row[len(row)-2] != "2.5" || row[len(row)-1] != "dev, prod" {
+		t.Fatalf("unexpected custom field values: %v", row)
+	}
+	row = records[2]
+	if row[len(row)-2] != "" || row[len(row)-1] != "" {
+		t.Fatalf("expected empty custom field values, got %v", row)
+	}
+}
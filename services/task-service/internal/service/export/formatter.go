@@ -1,6 +1,8 @@
 package export
 
 import (
+	"strconv"
+
 	"todoapp/services/task-service/internal/domain/entities"
 )
 
@@ -10,14 +12,40 @@ type Formatter interface {
 	Format(tasks []entities.Task) ([]byte, error)
 }
 
-// NewFormatter creates a new formatter for the specified export format.
-func NewFormatter(format entities.ExportFormat) (Formatter, error) {
+// NewFormatter creates a new formatter for the specified export format. The
+// values of the given custom fields are exported along with each task.
+func NewFormatter(format entities.ExportFormat, fields ...entities.CustomField) (Formatter, error) {
 	switch format {
 	case entities.ExportFormatCSV:
-		return NewCSVFormatter(), nil
+		return NewCSVFormatter(fields...), nil
 	case entities.ExportFormatICal:
-		return NewICalFormatter(), nil
+		return NewICalFormatter(fields...), nil
 	default:
 		return nil, ErrUnsupportedFormat
 	}
 }
+
+// customFieldValues returns the text form of a custom field value, one
+// entry per selected option for multi_select fields.
+func customFieldValues(value any) []string {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case string:
+		return []string{v}
+	case float64:
+		return []string{strconv.FormatFloat(v, 'f', -1, 64)}
+	case bool:
+		return []string{strconv.FormatBool(v)}
+	case []string:
+		return v
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			values = append(values, customFieldValues(item)...)
+		}
+		return values
+	default:
+		return nil
+	}
+}
@@ -11,11 +11,14 @@ import (
 )
 
 // ICalFormatter formats tasks as iCalendar (RFC 5545) VTODO components.
-type ICalFormatter struct{}
+type ICalFormatter struct {
+	fields []entities.CustomField
+}
 
-// NewICalFormatter creates a new iCal formatter.
-func NewICalFormatter() *ICalFormatter {
-	return &ICalFormatter{}
+// NewICalFormatter creates a new iCal formatter. Custom field values are
+// written as X-TODOAPP-FIELD-<KEY> properties.
+func NewICalFormatter(fields ...entities.CustomField) *ICalFormatter {
+	return &ICalFormatter{fields: fields}
 }
 
 // Format converts tasks to iCalendar format with VTODO components.
@@ -68,12 +71,34 @@ func (f *ICalFormatter) writeVTodo(buf *bytes.Buffer, task entities.Task) {
 	// PRIORITY - 1=high, 5=medium, 9=low
 	buf.WriteString(fmt.Sprintf("PRIORITY:%d\r\n", mapPriority(task.Priority)))
 
-	// STATUS - NEEDS-ACTION, IN-PROCESS, COMPLETED
-	buf.WriteString(fmt.Sprintf("STATUS:%s\r\n", mapStatus(task.Status)))
+	// STATUS - NEEDS-ACTION, IN-PROCESS, COMPLETED. Custom workflow statuses
+	// are only known to be done through the completion time.
+	status := mapStatus(task.Status)
+	if task.CompletedAt != nil {
+		status = "COMPLETED"
+		buf.WriteString(fmt.Sprintf("COMPLETED:%s\r\n", formatICalTime(*task.CompletedAt)))
+	}
+	buf.WriteString(fmt.Sprintf("STATUS:%s\r\n", status))
 
-	// CATEGORIES - category name if set
+	// CATEGORIES - category name if set, with its color as a vendor extension
 	if task.Category != nil {
 		buf.WriteString(fmt.Sprintf("CATEGORIES:%s\r\n", escapeICalText(task.Category.Name)))
+		if task.Category.Color != "" {
+			buf.WriteString(fmt.Sprintf("X-TODOAPP-CATEGORY-COLOR:%s\r\n", escapeICalText(task.Category.Color)))
+		}
+	}
+
+	// X-TODOAPP-FIELD-* - custom field values; multi_select values are a list
+	for _, field := range f.fields {
+		values := customFieldValues(task.CustomFields[field.Key])
+		if len(values) == 0 {
+			continue
+		}
+		escaped := make([]string, 0, len(values))
+		for _, value := range values {
+			escaped = append(escaped, escapeICalText(value))
+		}
+		buf.WriteString(fmt.Sprintf("%s:%s\r\n", customFieldProperty(field), strings.Join(escaped, ",")))
 	}
 
 	// LAST-MODIFIED
@@ -85,6 +110,12 @@ func (f *ICalFormatter) writeVTodo(buf *bytes.Buffer, task entities.Task) {
 	buf.WriteString("END:VTODO\r\n")
 }
 
+// customFieldProperty returns the property name of a custom field. Keys only
+// hold letters, digits and underscores, and property names use dashes.
+func customFieldProperty(field entities.CustomField) string {
+	return "X-TODOAPP-FIELD-" + strings.ToUpper(strings.ReplaceAll(field.Key, "_", "-"))
+}
+
 // formatICalTime formats a time.Time to iCalendar format (UTC).
 func formatICalTime(t time.Time) string {
 	return t.UTC().Format("20060102T150405Z")
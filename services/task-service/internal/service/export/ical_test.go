@@ -51,7 +51,7 @@ func TestICalFormatter_Format_SingleTask(t *testing.T) {
 			Status:      entities.TaskStatusPending,
 			Priority:    entities.TaskPriorityHigh,
 			DueDate:     &dueDate,
-			Category:    &entities.Category{Name: "Work"},
+			Category:    &entities.Category{Name: "Work", Color: "#3498db"},
 			CreatedAt:   now,
 			UpdatedAt:   now,
 		},
@@ -91,6 +91,9 @@ func TestICalFormatter_Format_SingleTask(t *testing.T) {
 	if !strings.Contains(content, "CATEGORIES:Work") {
 		t.Error("expected CATEGORIES")
 	}
+	if !strings.Contains(content, "X-TODOAPP-CATEGORY-COLOR:#3498db") {
+		t.Error("expected category color")
+	}
 
 	// Check dates
 	if !strings.Contains(content, "DTSTAMP:20241210T100000Z") {
@@ -280,3 +283,30 @@ func TestICalFormatter_EmptyDescription(t *testing.T) {
 		t.Error("expected no DESCRIPTION field for empty description")
 	}
 }
+
+func TestICalFormatter_Format_CustomFields(t *testing.T) {
+	formatter := NewICalFormatter(
+		entities.CustomField{Key: "story_points", Type: entities.CustomFieldNumber},
+		entities.CustomField{Key: "env", Type: entities.CustomFieldMultiSelect},
+		entities.CustomField{Key: "customer", Type: entities.CustomFieldText},
+	)
+
+	tasks := []entities.Task{
+		{ID: 1, Title: "Task", CustomFields: entities.CustomFieldValues{"story_points": float64(3), "env": []any{"dev", "a,b"}}},
+	}
+
+	data, err := formatter.Format(tasks)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	content := string(data)
+	for _, want := range []string{"X-TODOAPP-FIELD-STORY-POINTS:3\r\n", "X-TODOAPP-FIELD-ENV:dev,a\\,b\r\n"} {
+		if !strings.Contains(content, want) {
+			t.Errorf("expected %q in %q", want, content)
+		}
+	}
+	if strings.Contains(content, "X-TODOAPP-FIELD-CUSTOMER") {
+		t.Error("expected no property for a field without a value")
+	}
+}
@@ -0,0 +1,28 @@
+package service
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	tasksCreated = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "tasks_created_total",
+		Help: "Tasks created, from any source.",
+	})
+
+	tasksCompleted = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "tasks_completed_total",
+		Help: "Tasks moved to a completed status.",
+	})
+
+	outboxPending = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "outbox_pending_messages",
+		Help: "Task events written to the outbox and not relayed to RabbitMQ yet.",
+	})
+
+	outboxLag = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "outbox_lag_seconds",
+		Help: "Age of the oldest task event not relayed to RabbitMQ yet.",
+	})
+)
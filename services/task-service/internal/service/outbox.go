@@ -0,0 +1,243 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"todoapp/pkg/logging"
+	"todoapp/pkg/requestid"
+	"todoapp/pkg/tracing"
+	"todoapp/services/task-service/internal/ports"
+)
+
+const (
+	defaultOutboxInterval     = time.Second
+	defaultOutboxBatchSize    = 100
+	defaultOutboxBaseBackoff  = time.Second
+	defaultOutboxMaxBackoff   = 5 * time.Minute
+	defaultOutboxRetention    = 7 * 24 * time.Hour
+	defaultOutboxPublishLimit = 5 * time.Second
+	outboxPurgeInterval       = time.Hour
+)
+
+// OutboxRelay publishes outbox messages to the broker. Messages are locked,
+// published and marked as sent in one transaction; a crash before the commit
+// republishes them, so delivery is at-least-once and consumers should dedupe by
+// event ID. Failed messages are retried with exponential backoff and hold back
+// later events of the same task.
+type OutboxRelay struct {
+	outbox      ports.OutboxRepository
+	tx          ports.TransactionManager
+	publisher   ports.TaskEventPublisher
+	interval    time.Duration
+	batchSize   int
+	baseBackoff time.Duration
+	maxBackoff  time.Duration
+	retention   time.Duration
+	now         func() time.Time
+	logger      *slog.Logger
+}
+
+type OutboxRelayOption func(*OutboxRelay)
+
+func NewOutboxRelay(outbox ports.OutboxRepository, tx ports.TransactionManager, publisher ports.TaskEventPublisher, opts ...OutboxRelayOption) *OutboxRelay {
+	relay := &OutboxRelay{
+		outbox:      outbox,
+		tx:          tx,
+		publisher:   publisher,
+		interval:    defaultOutboxInterval,
+		batchSize:   defaultOutboxBatchSize,
+		baseBackoff: defaultOutboxBaseBackoff,
+		maxBackoff:  defaultOutboxMaxBackoff,
+		retention:   defaultOutboxRetention,
+		now:         time.Now,
+		logger:      logging.Discard(),
+	}
+	for _, opt := range opts {
+		opt(relay)
+	}
+	return relay
+}
+
+func WithOutboxInterval(interval time.Duration) OutboxRelayOption {
+	return func(r *OutboxRelay) {
+		if interval > 0 {
+			r.interval = interval
+		}
+	}
+}
+
+func WithOutboxBatchSize(size int) OutboxRelayOption {
+	return func(r *OutboxRelay) {
+		if size > 0 {
+			r.batchSize = size
+		}
+	}
+}
+
+// WithOutboxBackoff sets the delay after the first failed attempt and its cap.
+func WithOutboxBackoff(base, maxDelay time.Duration) OutboxRelayOption {
+	return func(r *OutboxRelay) {
+		if base > 0 {
+			r.baseBackoff = base
+		}
+		if maxDelay >= r.baseBackoff {
+			r.maxBackoff = maxDelay
+		}
+	}
+}
+
+// WithOutboxRetention sets how long sent messages are kept.
+func WithOutboxRetention(retention time.Duration) OutboxRelayOption {
+	return func(r *OutboxRelay) {
+		if retention > 0 {
+			r.retention = retention
+		}
+	}
+}
+
+func WithOutboxClock(now func() time.Time) OutboxRelayOption {
+	return func(r *OutboxRelay) {
+		if now != nil {
+			r.now = now
+		}
+	}
+}
+
+func WithOutboxLogger(logger *slog.Logger) OutboxRelayOption {
+	return func(r *OutboxRelay) {
+		if logger != nil {
+			r.logger = logger
+		}
+	}
+}
+
+// Run relays pending messages on every interval until ctx is cancelled and
+// purges old sent messages once an hour.
+func (r *OutboxRelay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.interval)
+	defer ticker.Stop()
+
+	var lastPurge time.Time
+
+	for {
+		if _, err := r.Relay(ctx); err != nil && ctx.Err() == nil {
+			r.logger.ErrorContext(ctx, "outbox relay failed", "error", err)
+		}
+		r.recordBacklog(ctx)
+
+		if now := r.now(); now.Sub(lastPurge) >= outboxPurgeInterval {
+			lastPurge = now
+			if purged, err := r.outbox.PurgeSentOutbox(ctx, now.Add(-r.retention)); err != nil {
+				r.logger.ErrorContext(ctx, "outbox purge failed", "error", err)
+			} else if purged > 0 {
+				r.logger.InfoContext(ctx, "outbox purged", "purged", purged)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Relay publishes batches until no due message is left and returns how many
+// messages were sent. A publish failure is recorded on its message and ends
+// the run after the current batch; storage errors end it immediately.
+func (r *OutboxRelay) Relay(ctx context.Context) (int, error) {
+	sent := 0
+
+	for {
+		var fetched, failed int
+
+		err := r.tx.WithinTransaction(ctx, func(ctx context.Context) error {
+			messages, err := r.outbox.FetchPendingOutbox(ctx, r.now(), r.batchSize)
+			if err != nil {
+				return err
+			}
+			fetched = len(messages)
+
+			for _, message := range messages {
+				ok, err := r.deliver(ctx, message)
+				if err != nil {
+					return err
+				}
+				if ok {
+					sent++
+				} else {
+					failed++
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return sent, err
+		}
+
+		// A batch holds at most one message per task, so keep going until
+		// nothing is due rather than stopping at a short batch.
+		if fetched == 0 || failed > 0 {
+			return sent, nil
+		}
+	}
+}
+
+// recordBacklog updates the outbox metrics. The lag is the age of the oldest
+// message not relayed yet, zero when the outbox is drained.
+func (r *OutboxRelay) recordBacklog(ctx context.Context) {
+	backlog, err := r.outbox.OutboxBacklog(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			r.logger.WarnContext(ctx, "outbox backlog check failed", "error", err)
+		}
+		return
+	}
+
+	lag := time.Duration(0)
+	if !backlog.Oldest.IsZero() {
+		lag = max(r.now().Sub(backlog.Oldest), 0)
+	}
+	outboxPending.Set(float64(backlog.Pending))
+	outboxLag.Set(lag.Seconds())
+}
+
+func (r *OutboxRelay) deliver(ctx context.Context, message ports.OutboxMessage) (bool, error) {
+	ctx = requestid.WithContext(ctx, message.RequestID)
+	ctx = tracing.WithTraceParent(ctx, message.TraceParent)
+	publishCtx, cancel := context.WithTimeout(ctx, defaultOutboxPublishLimit)
+	err := r.publisher.Publish(publishCtx, message.Event)
+	cancel()
+
+	if err != nil {
+		next := r.now().Add(r.backoff(message.Attempts))
+		r.logger.WarnContext(ctx, "outbox publish failed",
+			"outbox_id", message.ID,
+			"event_type", message.Event.Type,
+			"task_id", message.Event.TaskID,
+			"attempt", message.Attempts+1,
+			"error", err,
+		)
+		return false, r.outbox.MarkOutboxFailed(ctx, message.ID, next, err.Error())
+	}
+
+	return true, r.outbox.MarkOutboxSent(ctx, message.ID, r.now())
+}
+
+func (r *OutboxRelay) backoff(attempts int) time.Duration {
+	return retryBackoff(r.baseBackoff, r.maxBackoff, attempts)
+}
+
+// retryBackoff doubles base with every failed attempt up to maxDelay.
+func retryBackoff(base, maxDelay time.Duration, attempts int) time.Duration {
+	delay := base
+	for i := 0; i < attempts; i++ {
+		delay *= 2
+		if delay >= maxDelay {
+			return maxDelay
+		}
+	}
+	return delay
+}
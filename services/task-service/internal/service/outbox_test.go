@@ -0,0 +1,277 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"go.opentelemetry.io/otel/trace"
+
+	"todoapp/pkg/events"
+	"todoapp/pkg/requestid"
+	"todoapp/services/task-service/internal/domain/entities"
+	"todoapp/services/task-service/internal/ports"
+)
+
+// txManagerStub runs fn directly and counts rollbacks, i.e. failed runs.
+type txManagerStub struct {
+	calls     int
+	rollbacks int
+}
+
+func (m *txManagerStub) WithinTransaction(ctx context.Context, fn func(context.Context) error) error {
+	m.calls++
+	if err := fn(ctx); err != nil {
+		m.rollbacks++
+		return err
+	}
+	return nil
+}
+
+type outboxRepoStub struct {
+	pending []ports.OutboxMessage
+	sent    []int64
+	failed  map[int64]time.Time
+}
+
+func (r *outboxRepoStub) FetchPendingOutbox(ctx context.Context, now time.Time, limit int) ([]ports.OutboxMessage, error) {
+	var (
+		result  []ports.OutboxMessage
+		blocked = map[int64]bool{}
+	)
+	for _, message := range r.pending {
+		taskID := message.Event.TaskID
+		if blocked[taskID] {
+			continue
+		}
+		blocked[taskID] = true
+		if next, ok := r.failed[message.ID]; ok && next.After(now) {
+			continue
+		}
+		result = append(result, message)
+		if len(result) == limit {
+			break
+		}
+	}
+	return result, nil
+}
+
+func (r *outboxRepoStub) MarkOutboxSent(ctx context.Context, id int64, sentAt time.Time) error {
+	r.sent = append(r.sent, id)
+	for i, message := range r.pending {
+		if message.ID == id {
+			r.pending = append(r.pending[:i], r.pending[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+func (r *outboxRepoStub) MarkOutboxFailed(ctx context.Context, id int64, nextAttemptAt time.Time, lastError string) error {
+	r.failed[id] = nextAttemptAt
+	for i := range r.pending {
+		if r.pending[i].ID == id {
+			r.pending[i].Attempts++
+		}
+	}
+	return nil
+}
+
+func (r *outboxRepoStub) OutboxBacklog(ctx context.Context) (ports.OutboxBacklog, error) {
+	return ports.OutboxBacklog{Pending: int64(len(r.pending))}, nil
+}
+
+func (r *outboxRepoStub) PurgeSentOutbox(ctx context.Context, before time.Time) (int64, error) {
+	return 0, nil
+}
+
+// flakyPublisher fails every event of the listed tasks.
+type flakyPublisher struct {
+	failTasks  map[int64]bool
+	published  []string
+	requestIDs []string
+	traceIDs   []string
+}
+
+func (p *flakyPublisher) Publish(ctx context.Context, event events.TaskEvent) error {
+	if p.failTasks[event.TaskID] {
+		return errors.New("broker unavailable")
+	}
+	p.published = append(p.published, event.ID)
+	p.requestIDs = append(p.requestIDs, requestid.FromContext(ctx))
+	p.traceIDs = append(p.traceIDs, trace.SpanContextFromContext(ctx).TraceID().String())
+	return nil
+}
+
+func outboxMessage(id, taskID int64) ports.OutboxMessage {
+	return ports.OutboxMessage{ID: id, Event: events.TaskEvent{ID: "e" + string(rune('0'+id)), TaskID: taskID}}
+}
+
+func TestOutboxRelay_PublishesInOrderPerTask(t *testing.T) {
+	now := time.Date(2024, 12, 4, 10, 0, 0, 0, time.UTC)
+	repo := &outboxRepoStub{
+		pending: []ports.OutboxMessage{outboxMessage(1, 10), outboxMessage(2, 10), outboxMessage(3, 20)},
+		failed:  map[int64]time.Time{},
+	}
+	publisher := &flakyPublisher{}
+	relay := NewOutboxRelay(repo, &txManagerStub{}, publisher,
+		WithOutboxBatchSize(2),
+		WithOutboxClock(func() time.Time { return now }),
+	)
+
+	sent, err := relay.Relay(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sent != 3 {
+		t.Fatalf("expected 3 messages sent, got %d", sent)
+	}
+	want := []string{"e1", "e3", "e2"}
+	for i, id := range want {
+		if publisher.published[i] != id {
+			t.Fatalf("expected order %v, got %v", want, publisher.published)
+		}
+	}
+}
+
+func TestOutboxRelay_FailureHoldsBackTaskAndBacksOff(t *testing.T) {
+	now := time.Date(2024, 12, 4, 10, 0, 0, 0, time.UTC)
+	repo := &outboxRepoStub{
+		pending: []ports.OutboxMessage{outboxMessage(1, 10), outboxMessage(2, 10), outboxMessage(3, 20)},
+		failed:  map[int64]time.Time{},
+	}
+	publisher := &flakyPublisher{failTasks: map[int64]bool{10: true}}
+	relay := NewOutboxRelay(repo, &txManagerStub{}, publisher,
+		WithOutboxBackoff(time.Second, time.Minute),
+		WithOutboxClock(func() time.Time { return now }),
+	)
+
+	sent, err := relay.Relay(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sent != 1 || len(publisher.published) != 1 || publisher.published[0] != "e3" {
+		t.Fatalf("expected only task 20 to be published, got %v", publisher.published)
+	}
+	if next := repo.failed[1]; !next.Equal(now.Add(time.Second)) {
+		t.Fatalf("expected first retry after 1s, got %v", next)
+	}
+
+	// The broker recovers; message 1 is due only after its backoff.
+	publisher.failTasks = nil
+	if sent, _ := relay.Relay(context.Background()); sent != 0 {
+		t.Fatalf("expected nothing before the backoff elapsed, got %d", sent)
+	}
+
+	now = now.Add(time.Second)
+	if sent, _ := relay.Relay(context.Background()); sent != 2 {
+		t.Fatalf("expected both task 10 messages after the backoff, got %d", sent)
+	}
+	if publisher.published[1] != "e1" || publisher.published[2] != "e2" {
+		t.Fatalf("expected task 10 in order, got %v", publisher.published)
+	}
+}
+
+func TestOutboxRelay_Backoff(t *testing.T) {
+	relay := NewOutboxRelay(nil, nil, nil, WithOutboxBackoff(time.Second, 10*time.Second))
+
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{0, time.Second},
+		{1, 2 * time.Second},
+		{3, 8 * time.Second},
+		{4, 10 * time.Second},
+		{60, 10 * time.Second},
+	}
+	for _, tt := range tests {
+		if got := relay.backoff(tt.attempts); got != tt.want {
+			t.Fatalf("backoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestCreateTask_OutboxFailureRollsBack(t *testing.T) {
+	tx := &txManagerStub{}
+	svc := NewTaskService(
+		&repoMock{},
+		WithUserDirectory(userDirStub{user: &ports.UserInfo{ID: 1, Email: "a@b.c", Active: true}}),
+		WithEventPublisher(publisherStub{err: errors.New("outbox insert failed")}),
+		WithTransactionManager(tx),
+	)
+
+	_, err := svc.CreateTask(context.Background(), ports.CreateTaskInput{
+		UserID:   1,
+		Title:    "Task",
+		Status:   entities.TaskStatusPending,
+		Priority: entities.TaskPriorityMedium,
+	})
+	if err == nil {
+		t.Fatalf("expected the outbox error to fail the request")
+	}
+	if tx.rollbacks != 1 {
+		t.Fatalf("expected the transaction to roll back, got %d rollbacks", tx.rollbacks)
+	}
+}
+
+func TestOutboxRelay_PublishesWithRequestID(t *testing.T) {
+	message := outboxMessage(1, 10)
+	message.RequestID = "req-1"
+	repo := &outboxRepoStub{pending: []ports.OutboxMessage{message}, failed: map[int64]time.Time{}}
+	publisher := &flakyPublisher{}
+	relay := NewOutboxRelay(repo, &txManagerStub{}, publisher)
+
+	if _, err := relay.Relay(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(publisher.requestIDs) != 1 || publisher.requestIDs[0] != "req-1" {
+		t.Fatalf("expected the stored request ID to be published, got %v", publisher.requestIDs)
+	}
+}
+
+func TestOutboxRelay_PublishesInStoredTrace(t *testing.T) {
+	message := outboxMessage(1, 10)
+	message.TraceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	repo := &outboxRepoStub{pending: []ports.OutboxMessage{message}, failed: map[int64]time.Time{}}
+	publisher := &flakyPublisher{}
+	relay := NewOutboxRelay(repo, &txManagerStub{}, publisher)
+
+	if _, err := relay.Relay(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(publisher.traceIDs) != 1 || publisher.traceIDs[0] != "4bf92f3577b34da6a3ce929d0e0e4736" {
+		t.Fatalf("expected the event to be published in the stored trace, got %v", publisher.traceIDs)
+	}
+}
+
+type backlogRepoStub struct {
+	outboxRepoStub
+	backlog ports.OutboxBacklog
+}
+
+func (r *backlogRepoStub) OutboxBacklog(ctx context.Context) (ports.OutboxBacklog, error) {
+	return r.backlog, nil
+}
+
+func TestOutboxRelay_RecordsBacklog(t *testing.T) {
+	now := time.Date(2024, 12, 4, 10, 0, 0, 0, time.UTC)
+	repo := &backlogRepoStub{backlog: ports.OutboxBacklog{Pending: 3, Oldest: now.Add(-90 * time.Second)}}
+	relay := NewOutboxRelay(repo, &txManagerStub{}, &flakyPublisher{}, WithOutboxClock(func() time.Time { return now }))
+
+	relay.recordBacklog(context.Background())
+	if got := testutil.ToFloat64(outboxPending); got != 3 {
+		t.Fatalf("expected 3 pending messages, got %v", got)
+	}
+	if got := testutil.ToFloat64(outboxLag); got != 90 {
+		t.Fatalf("expected 90s lag, got %v", got)
+	}
+
+	repo.backlog = ports.OutboxBacklog{}
+	relay.recordBacklog(context.Background())
+	if got := testutil.ToFloat64(outboxLag); got != 0 {
+		t.Fatalf("expected no lag once drained, got %v", got)
+	}
+}
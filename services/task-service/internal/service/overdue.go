@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"todoapp/pkg/events"
+	"todoapp/pkg/logging"
+	"todoapp/services/task-service/internal/domain"
+	"todoapp/services/task-service/internal/domain/entities"
+	"todoapp/services/task-service/internal/ports"
+)
+
+const (
+	defaultOverdueInterval  = time.Minute
+	defaultOverdueBatchSize = 100
+)
+
+// OverdueScanner periodically publishes task.overdue for open tasks past their
+// due date. A task is claimed in the database together with publishing its
+// event, so each due date is announced at most once across restarts and
+// instances; a failed publish gives the claim back for the next scan.
+type OverdueScanner struct {
+	repo      ports.OverdueRepository
+	users     ports.UserDirectory
+	publisher ports.TaskEventPublisher
+	tx        ports.TransactionManager
+	interval  time.Duration
+	batchSize int
+	now       func() time.Time
+	logger    *slog.Logger
+}
+
+type OverdueScannerOption func(*OverdueScanner)
+
+func NewOverdueScanner(repo ports.OverdueRepository, users ports.UserDirectory, publisher ports.TaskEventPublisher, opts ...OverdueScannerOption) *OverdueScanner {
+	scanner := &OverdueScanner{
+		repo:      repo,
+		users:     users,
+		publisher: publisher,
+		interval:  defaultOverdueInterval,
+		batchSize: defaultOverdueBatchSize,
+		now:       time.Now,
+		logger:    logging.Discard(),
+	}
+	for _, opt := range opts {
+		opt(scanner)
+	}
+	return scanner
+}
+
+func WithOverdueInterval(interval time.Duration) OverdueScannerOption {
+	return func(s *OverdueScanner) {
+		if interval > 0 {
+			s.interval = interval
+		}
+	}
+}
+
+func WithOverdueBatchSize(size int) OverdueScannerOption {
+	return func(s *OverdueScanner) {
+		if size > 0 {
+			s.batchSize = size
+		}
+	}
+}
+
+// WithOverdueTransactionManager claims a task and records its event in one
+// transaction. With the outbox as publisher every due date is then announced
+// exactly once.
+func WithOverdueTransactionManager(tx ports.TransactionManager) OverdueScannerOption {
+	return func(s *OverdueScanner) {
+		s.tx = tx
+	}
+}
+
+func WithOverdueClock(now func() time.Time) OverdueScannerOption {
+	return func(s *OverdueScanner) {
+		if now != nil {
+			s.now = now
+		}
+	}
+}
+
+func WithOverdueLogger(logger *slog.Logger) OverdueScannerOption {
+	return func(s *OverdueScanner) {
+		if logger != nil {
+			s.logger = logger
+		}
+	}
+}
+
+// Run scans immediately and then on every interval until ctx is cancelled.
+func (s *OverdueScanner) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		if published, err := s.Scan(ctx); err != nil {
+			s.logger.ErrorContext(ctx, "overdue scan failed", "published", published, "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Scan publishes events for all currently overdue tasks and returns how many
+// were published. It stops at the first publish or lookup failure.
+func (s *OverdueScanner) Scan(ctx context.Context) (int, error) {
+	published := 0
+
+	for {
+		tasks, err := s.repo.ListOverdueTasks(ctx, s.now(), s.batchSize)
+		if err != nil {
+			return published, err
+		}
+
+		for i := range tasks {
+			sent, err := s.announce(ctx, &tasks[i])
+			if err != nil {
+				return published, err
+			}
+			if sent {
+				published++
+			}
+		}
+
+		if len(tasks) < s.batchSize {
+			return published, nil
+		}
+	}
+}
+
+func (s *OverdueScanner) announce(ctx context.Context, task *entities.Task) (bool, error) {
+	dueDate := *task.DueDate
+
+	user, err := s.users.GetUser(ctx, task.UserID)
+	if err != nil && !errors.Is(err, domain.ErrUnknownUser) {
+		return false, err
+	}
+	// Unknown and inactive users and users without an email are only claimed:
+	// there is nobody to notify for this due date.
+	notify := err == nil && user.Active && user.Email != ""
+
+	var published bool
+
+	err = s.inTransaction(ctx, func(ctx context.Context) error {
+		claimed, err := s.repo.ClaimOverdueTask(ctx, task.ID, dueDate)
+		if err != nil || !claimed || !notify {
+			return err
+		}
+
+		if err := s.publisher.Publish(ctx, events.TaskEvent{
+			ID:          uuid.NewString(),
+			Type:        events.TaskEventOverdue,
+			TaskID:      task.ID,
+			UserID:      task.UserID,
+			Title:       task.Title,
+			Description: task.Description,
+			Status:      string(task.Status),
+			Priority:    string(task.Priority),
+			DueDate:     &dueDate,
+			UserEmail:   user.Email,
+			CreatedAt:   s.now(),
+		}); err != nil {
+			if s.tx == nil {
+				return s.release(ctx, task, err)
+			}
+			return err
+		}
+
+		published = true
+		return nil
+	})
+
+	return published, err
+}
+
+func (s *OverdueScanner) inTransaction(ctx context.Context, fn func(context.Context) error) error {
+	if s.tx == nil {
+		return fn(ctx)
+	}
+	return s.tx.WithinTransaction(ctx, fn)
+}
+
+func (s *OverdueScanner) release(ctx context.Context, task *entities.Task, cause error) error {
+	// Release even when the scan was cancelled, otherwise the due date would
+	// stay claimed without an event.
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), 3*time.Second)
+	defer cancel()
+
+	if err := s.repo.ReleaseOverdueTask(ctx, task.ID, *task.DueDate); err != nil {
+		s.logger.ErrorContext(ctx, "release overdue claim failed", "task_id", task.ID, "error", err)
+	}
+	return cause
+}
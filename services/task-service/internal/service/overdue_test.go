@@ -0,0 +1,154 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"todoapp/pkg/events"
+	"todoapp/services/task-service/internal/domain/entities"
+	"todoapp/services/task-service/internal/ports"
+)
+
+type overdueKey struct {
+	taskID  int64
+	dueDate time.Time
+}
+
+// overdueRepoStub mimics the claim table: listed tasks are filtered by claims.
+type overdueRepoStub struct {
+	tasks  []entities.Task
+	claims map[overdueKey]bool
+}
+
+func (r *overdueRepoStub) ListOverdueTasks(ctx context.Context, now time.Time, limit int) ([]entities.Task, error) {
+	var result []entities.Task
+	for _, task := range r.tasks {
+		if task.DueDate == nil || !task.DueDate.Before(now) || r.claims[overdueKey{task.ID, *task.DueDate}] {
+			continue
+		}
+		result = append(result, task)
+		if len(result) == limit {
+			break
+		}
+	}
+	return result, nil
+}
+
+func (r *overdueRepoStub) ClaimOverdueTask(ctx context.Context, taskID int64, dueDate time.Time) (bool, error) {
+	key := overdueKey{taskID, dueDate}
+	if r.claims[key] {
+		return false, nil
+	}
+	r.claims[key] = true
+	return true, nil
+}
+
+func (r *overdueRepoStub) ReleaseOverdueTask(ctx context.Context, taskID int64, dueDate time.Time) error {
+	delete(r.claims, overdueKey{taskID, dueDate})
+	return nil
+}
+
+type recordingPublisher struct {
+	events []events.TaskEvent
+	err    error
+}
+
+func (p *recordingPublisher) Publish(ctx context.Context, event events.TaskEvent) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.events = append(p.events, event)
+	return nil
+}
+
+func TestOverdueScanner_PublishesOncePerDueDate(t *testing.T) {
+	now := time.Date(2024, 12, 4, 10, 0, 0, 0, time.UTC)
+	due := now.Add(-time.Hour)
+	later := now.Add(time.Hour)
+
+	repo := &overdueRepoStub{
+		tasks: []entities.Task{
+			{ID: 1, UserID: 1, Title: "Overdue", DueDate: &due},
+			{ID: 2, UserID: 1, Title: "Not yet", DueDate: &later},
+			{ID: 3, UserID: 1, Title: "Also overdue", DueDate: &due},
+		},
+		claims: map[overdueKey]bool{},
+	}
+	publisher := &recordingPublisher{}
+	users := userDirStub{user: &ports.UserInfo{ID: 1, Email: "a@b.c", Active: true}}
+	scanner := NewOverdueScanner(repo, users, publisher,
+		WithOverdueClock(func() time.Time { return now }),
+		WithOverdueBatchSize(1),
+	)
+
+	published, err := scanner.Scan(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if published != 2 || len(publisher.events) != 2 {
+		t.Fatalf("expected 2 events, got %d", len(publisher.events))
+	}
+	event := publisher.events[0]
+	if event.Type != events.TaskEventOverdue || event.TaskID != 1 || event.UserEmail != "a@b.c" || !event.DueDate.Equal(due) {
+		t.Fatalf("unexpected event: %+v", event)
+	}
+
+	// A second scan, e.g. after a restart, finds nothing new.
+	if published, _ := scanner.Scan(context.Background()); published != 0 {
+		t.Fatalf("expected no duplicates, got %d", published)
+	}
+
+	// Moving the due date makes the task eligible again.
+	moved := now.Add(-time.Minute)
+	repo.tasks[0].DueDate = &moved
+	if published, _ := scanner.Scan(context.Background()); published != 1 {
+		t.Fatalf("expected the new due date to be announced, got %d", published)
+	}
+}
+
+func TestOverdueScanner_ReleasesClaimOnPublishFailure(t *testing.T) {
+	now := time.Date(2024, 12, 4, 10, 0, 0, 0, time.UTC)
+	due := now.Add(-time.Hour)
+
+	repo := &overdueRepoStub{
+		tasks:  []entities.Task{{ID: 1, UserID: 1, DueDate: &due}},
+		claims: map[overdueKey]bool{},
+	}
+	publisher := &recordingPublisher{err: errors.New("broker down")}
+	users := userDirStub{user: &ports.UserInfo{ID: 1, Email: "a@b.c", Active: true}}
+	scanner := NewOverdueScanner(repo, users, publisher, WithOverdueClock(func() time.Time { return now }))
+
+	if _, err := scanner.Scan(context.Background()); err == nil {
+		t.Fatalf("expected publish error")
+	}
+	if len(repo.claims) != 0 {
+		t.Fatalf("expected claim to be released")
+	}
+
+	publisher.err = nil
+	if published, err := scanner.Scan(context.Background()); err != nil || published != 1 {
+		t.Fatalf("expected retry to publish, got %d, %v", published, err)
+	}
+}
+
+func TestOverdueScanner_SkipsInactiveUsers(t *testing.T) {
+	now := time.Date(2024, 12, 4, 10, 0, 0, 0, time.UTC)
+	due := now.Add(-time.Hour)
+
+	repo := &overdueRepoStub{
+		tasks:  []entities.Task{{ID: 1, UserID: 1, DueDate: &due}},
+		claims: map[overdueKey]bool{},
+	}
+	publisher := &recordingPublisher{}
+	users := userDirStub{user: &ports.UserInfo{ID: 1, Email: "a@b.c", Active: false}}
+	scanner := NewOverdueScanner(repo, users, publisher, WithOverdueClock(func() time.Time { return now }))
+
+	if published, err := scanner.Scan(context.Background()); err != nil || published != 0 {
+		t.Fatalf("expected nothing published, got %d, %v", published, err)
+	}
+	if !repo.claims[overdueKey{1, due}] {
+		t.Fatalf("expected the task to stay claimed")
+	}
+}
@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"todoapp/services/task-service/internal/domain"
+	"todoapp/services/task-service/internal/domain/entities"
+	"todoapp/services/task-service/internal/ports"
+	"todoapp/services/task-service/internal/service/quickadd"
+)
+
+type QuickAddService struct {
+	repo  ports.TaskRepository
+	tasks ports.TaskService
+	users ports.UserDirectory
+	now   func() time.Time
+}
+
+type QuickAddServiceOption func(*QuickAddService)
+
+var _ ports.QuickAddService = (*QuickAddService)(nil)
+
+// NewQuickAddService creates a quick-add service. Parsed tasks are created
+// through tasks; repo is only used to resolve category names.
+func NewQuickAddService(repo ports.TaskRepository, tasks ports.TaskService, opts ...QuickAddServiceOption) *QuickAddService {
+	svc := &QuickAddService{
+		repo:  repo,
+		tasks: tasks,
+		now:   time.Now,
+	}
+	for _, opt := range opts {
+		opt(svc)
+	}
+	return svc
+}
+
+// WithQuickAddUserDirectory enables resolving dates in the user's timezone.
+func WithQuickAddUserDirectory(users ports.UserDirectory) QuickAddServiceOption {
+	return func(s *QuickAddService) {
+		s.users = users
+	}
+}
+
+func WithQuickAddClock(now func() time.Time) QuickAddServiceOption {
+	return func(s *QuickAddService) {
+		if now != nil {
+			s.now = now
+		}
+	}
+}
+
+func (s *QuickAddService) QuickAddTask(ctx context.Context, userID int64, text string) (*ports.QuickAddResult, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, domain.ErrValidationFailed.WithMessage("text is required")
+	}
+
+	user, err := ensureActiveUser(ctx, s.users, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed := quickadd.Parse(text, s.now().In(userLocation(user)))
+
+	input := ports.CreateTaskInput{
+		UserID:   userID,
+		Title:    parsed.Title,
+		Priority: parsed.Priority,
+	}
+	if parsed.DueDate != nil {
+		// Due dates are stored without a zone, as UTC wall-clock time.
+		due := parsed.DueDate.UTC()
+		input.DueDate = &due
+	}
+	if input.Priority == "" {
+		input.Priority = entities.TaskPriorityMedium
+	}
+
+	result := &ports.QuickAddResult{HasTime: parsed.HasTime}
+
+	if parsed.Category != "" {
+		category, err := s.findCategory(ctx, userID, parsed.Category)
+		if err != nil {
+			return nil, err
+		}
+		if category != nil {
+			input.CategoryID = &category.ID
+			result.CategoryName = category.Name
+		} else {
+			// Keep unknown tags visible instead of silently dropping them.
+			input.Title = strings.TrimSpace(input.Title + " #" + parsed.Category)
+		}
+	}
+
+	task, err := s.tasks.CreateTask(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Input = input
+	result.Task = task
+
+	return result, nil
+}
+
+// findCategory matches a category by name, ignoring case. Underscores in the
+// tag stand for spaces, so "#home_office" matches "Home office". Nil is
+// returned when no category matches.
+func (s *QuickAddService) findCategory(ctx context.Context, userID int64, name string) (*entities.Category, error) {
+	categories, err := s.repo.ListCategories(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	spaced := strings.ReplaceAll(name, "_", " ")
+	for i := range categories {
+		if strings.EqualFold(categories[i].Name, name) || strings.EqualFold(categories[i].Name, spaced) {
+			return &categories[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+// userLocation returns the user's preferred timezone, falling back to UTC
+// when it is unset or unknown.
+func userLocation(user *ports.UserInfo) *time.Location {
+	if user == nil || user.Timezone == "" {
+		return time.UTC
+	}
+	loc, err := time.LoadLocation(user.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
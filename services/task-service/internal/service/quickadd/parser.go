@@ -0,0 +1,537 @@
+// Package quickadd turns a single line of free text such as
+// "Call Anna tomorrow 17:00 !high #work" into task fields.
+//
+// English and Russian date phrases are understood. Recognised markers are
+// removed from the text; whatever remains becomes the task title.
+package quickadd
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"todoapp/services/task-service/internal/domain/entities"
+)
+
+// Date-only phrases resolve to the end of the day so a task due "tomorrow"
+// is not reported as overdue in the morning.
+const (
+	endOfDayHour   = 23
+	endOfDayMinute = 59
+)
+
+// Result is what Parse understood from a line.
+type Result struct {
+	Title   string
+	DueDate *time.Time
+	// HasTime reports whether the due date includes an explicit time of day.
+	HasTime bool
+	// Priority is empty when no priority marker was given.
+	Priority entities.TaskPriority
+	// Category is the name after '#', empty when none was given.
+	Category string
+}
+
+var (
+	clockPattern    = regexp.MustCompile(`^([01]?\d|2[0-3]):([0-5]\d)$`)
+	meridiemPattern = regexp.MustCompile(`^(1[0-2]|0?[1-9])(?::([0-5]\d))?(am|pm)$`)
+	isoDatePattern  = regexp.MustCompile(`^(\d{4})-(\d{2})-(\d{2})$`)
+	dotDatePattern  = regexp.MustCompile(`^(\d{1,2})\.(\d{1,2})(?:\.(\d{4}))?$`)
+	dayPattern      = regexp.MustCompile(`^(\d{1,2})(?:st|nd|rd|th)?$`)
+	numberPattern   = regexp.MustCompile(`^\d{1,3}$`)
+)
+
+var priorities = map[string]entities.TaskPriority{
+	"!high":    entities.TaskPriorityHigh,
+	"!h":       entities.TaskPriorityHigh,
+	"!1":       entities.TaskPriorityHigh,
+	"!!!":      entities.TaskPriorityHigh,
+	"!urgent":  entities.TaskPriorityHigh,
+	"!высокий": entities.TaskPriorityHigh,
+	"!срочно":  entities.TaskPriorityHigh,
+	"!medium":  entities.TaskPriorityMedium,
+	"!m":       entities.TaskPriorityMedium,
+	"!2":       entities.TaskPriorityMedium,
+	"!!":       entities.TaskPriorityMedium,
+	"!средний": entities.TaskPriorityMedium,
+	"!low":     entities.TaskPriorityLow,
+	"!l":       entities.TaskPriorityLow,
+	"!3":       entities.TaskPriorityLow,
+	"!низкий":  entities.TaskPriorityLow,
+	"!неважно": entities.TaskPriorityLow,
+}
+
+// prepositions may precede a date or time phrase and are dropped with it.
+var prepositions = map[string]bool{
+	"on": true, "at": true, "by": true,
+	"в": true, "во": true, "к": true, "на": true, "до": true,
+}
+
+var relativeDays = map[string]int{
+	"today":       0,
+	"tomorrow":    1,
+	"сегодня":     0,
+	"завтра":      1,
+	"послезавтра": 2,
+}
+
+var weekdays = map[string]time.Weekday{
+	"monday":      time.Monday,
+	"tuesday":     time.Tuesday,
+	"wednesday":   time.Wednesday,
+	"thursday":    time.Thursday,
+	"friday":      time.Friday,
+	"saturday":    time.Saturday,
+	"sunday":      time.Sunday,
+	"понедельник": time.Monday,
+	"вторник":     time.Tuesday,
+	"среда":       time.Wednesday,
+	"среду":       time.Wednesday,
+	"четверг":     time.Thursday,
+	"пятница":     time.Friday,
+	"пятницу":     time.Friday,
+	"суббота":     time.Saturday,
+	"субботу":     time.Saturday,
+	"воскресенье": time.Sunday,
+	// Dative forms follow "к": "к пятнице".
+	"понедельнику": time.Monday,
+	"вторнику":     time.Tuesday,
+	"среде":        time.Wednesday,
+	"четвергу":     time.Thursday,
+	"пятнице":      time.Friday,
+	"субботе":      time.Saturday,
+	"воскресенью":  time.Sunday,
+}
+
+// nextModifiers may precede a weekday ("next friday", "в следующую пятницу").
+var nextModifiers = map[string]bool{
+	"next": true, "следующий": true, "следующую": true, "следующее": true, "следующая": true,
+}
+
+var months = map[string]time.Month{
+	"jan": time.January, "january": time.January,
+	"feb": time.February, "february": time.February,
+	"mar": time.March, "march": time.March,
+	"apr": time.April, "april": time.April,
+	"may": time.May,
+	"jun": time.June, "june": time.June,
+	"jul": time.July, "july": time.July,
+	"aug": time.August, "august": time.August,
+	"sep": time.September, "sept": time.September, "september": time.September,
+	"oct": time.October, "october": time.October,
+	"nov": time.November, "november": time.November,
+	"dec": time.December, "december": time.December,
+	"января":   time.January,
+	"февраля":  time.February,
+	"марта":    time.March,
+	"апреля":   time.April,
+	"мая":      time.May,
+	"июня":     time.June,
+	"июля":     time.July,
+	"августа":  time.August,
+	"сентября": time.September,
+	"октября":  time.October,
+	"ноября":   time.November,
+	"декабря":  time.December,
+}
+
+type unit int
+
+const (
+	unitMinute unit = iota
+	unitHour
+	unitDay
+	unitWeek
+)
+
+var units = map[string]unit{
+	"min": unitMinute, "mins": unitMinute, "minute": unitMinute, "minutes": unitMinute,
+	"hour": unitHour, "hours": unitHour,
+	"day": unitDay, "days": unitDay,
+	"week": unitWeek, "weeks": unitWeek,
+	"минуту": unitMinute, "минуты": unitMinute, "минут": unitMinute,
+	"час": unitHour, "часа": unitHour, "часов": unitHour,
+	"день": unitDay, "дня": unitDay, "дней": unitDay,
+	"неделю": unitWeek, "недели": unitWeek, "недель": unitWeek,
+}
+
+// singularUnits may be used without a number: "через час", "через неделю".
+var singularUnits = map[string]bool{
+	"минуту": true, "час": true, "день": true, "неделю": true,
+}
+
+// partsOfDay follow an hour in Russian: "в 8 утра", "в 7 вечера".
+var partsOfDay = map[string]int{
+	"утра": 0, "ночи": 0, "дня": 12, "вечера": 12,
+}
+
+// timesOfDay are standalone words that set a time.
+var timesOfDay = map[string][2]int{
+	"noon":    {12, 0},
+	"полдень": {12, 0},
+	"утром":   {9, 0},
+	"днём":    {13, 0},
+	"днем":    {13, 0},
+	"вечером": {19, 0},
+}
+
+type parser struct {
+	now time.Time
+
+	date     *time.Time
+	exact    *time.Time
+	hour     int
+	minute   int
+	hasClock bool
+	result   Result
+}
+
+// Parse interprets text relative to now; dates are resolved in now's location.
+func Parse(text string, now time.Time) Result {
+	p := &parser{now: now}
+
+	words := strings.Fields(text)
+	norm := make([]string, len(words))
+	for i, word := range words {
+		norm[i] = normalize(word)
+	}
+
+	var title []string
+	for i := 0; i < len(words); {
+		if n := p.matchMarker(words[i], norm[i]); n > 0 {
+			i += n
+			continue
+		}
+		if n := p.matchPhrase(norm[i:]); n > 0 {
+			i += n
+			continue
+		}
+		if prepositions[norm[i]] && i+1 < len(words) {
+			if n := p.matchPhrase(norm[i+1:]); n > 0 {
+				i += n + 1
+				continue
+			}
+		}
+		title = append(title, words[i])
+		i++
+	}
+
+	p.result.Title = strings.Join(title, " ")
+	p.resolveDue()
+
+	return p.result
+}
+
+func normalize(word string) string {
+	return strings.TrimRight(strings.ToLower(word), ",;?")
+}
+
+// matchMarker handles !priority and #category tokens.
+func (p *parser) matchMarker(word, norm string) int {
+	if priority, ok := priorities[norm]; ok && p.result.Priority == "" {
+		p.result.Priority = priority
+		return 1
+	}
+
+	if strings.HasPrefix(word, "#") && p.result.Category == "" {
+		name := strings.TrimRight(strings.TrimPrefix(word, "#"), ",.;!?")
+		if name != "" {
+			p.result.Category = name
+			return 1
+		}
+	}
+
+	return 0
+}
+
+// matchPhrase tries every date and time phrase at the start of words and
+// returns the number of words consumed.
+func (p *parser) matchPhrase(words []string) int {
+	if len(words) == 0 {
+		return 0
+	}
+	if p.date == nil && p.exact == nil {
+		if n := p.matchDate(words); n > 0 {
+			return n
+		}
+		if n := p.matchRelative(words); n > 0 {
+			return n
+		}
+	}
+	if !p.hasClock && p.exact == nil {
+		if n := p.matchClock(words); n > 0 {
+			return n
+		}
+	}
+	return 0
+}
+
+func (p *parser) matchDate(words []string) int {
+	word := strings.TrimRight(words[0], ".")
+	today := time.Date(p.now.Year(), p.now.Month(), p.now.Day(), 0, 0, 0, 0, p.now.Location())
+
+	if days, ok := relativeDays[word]; ok {
+		p.setDate(today.AddDate(0, 0, days))
+		return 1
+	}
+
+	if word == "tonight" {
+		p.setDate(today)
+		p.setClock(20, 0)
+		return 1
+	}
+
+	if len(words) >= 3 && word == "day" && words[1] == "after" && strings.TrimRight(words[2], ".") == "tomorrow" {
+		p.setDate(today.AddDate(0, 0, 2))
+		return 3
+	}
+
+	if len(words) >= 2 && (word == "next" && strings.TrimRight(words[1], ".") == "week") {
+		p.setDate(nextWeekday(today, time.Monday))
+		return 2
+	}
+	if len(words) >= 2 && word == "следующей" && strings.TrimRight(words[1], ".") == "неделе" {
+		// The leading "на" is consumed as a preposition.
+		p.setDate(nextWeekday(today, time.Monday))
+		return 2
+	}
+
+	offset := 0
+	if nextModifiers[word] && len(words) > 1 {
+		offset = 1
+	}
+	if weekday, ok := weekdays[strings.TrimRight(words[offset], ".")]; ok {
+		p.setDate(nextWeekday(today, weekday))
+		return offset + 1
+	}
+
+	if m := isoDatePattern.FindStringSubmatch(word); m != nil {
+		if date, ok := makeDate(atoi(m[1]), atoi(m[2]), atoi(m[3]), p.now.Location()); ok {
+			p.setDate(date)
+			return 1
+		}
+	}
+
+	if m := dotDatePattern.FindStringSubmatch(word); m != nil {
+		year := atoi(m[3])
+		if date, ok := p.dayMonth(year, atoi(m[2]), atoi(m[1])); ok {
+			p.setDate(date)
+			return 1
+		}
+	}
+
+	if len(words) >= 2 {
+		// "31 dec", "31 декабря"
+		if m := dayPattern.FindStringSubmatch(word); m != nil {
+			if month, ok := months[strings.TrimRight(words[1], ".")]; ok {
+				if date, ok := p.dayMonth(0, int(month), atoi(m[1])); ok {
+					p.setDate(date)
+					return 2
+				}
+			}
+		}
+		// "dec 31"
+		if month, ok := months[word]; ok {
+			if m := dayPattern.FindStringSubmatch(strings.TrimRight(words[1], ".")); m != nil {
+				if date, ok := p.dayMonth(0, int(month), atoi(m[1])); ok {
+					p.setDate(date)
+					return 2
+				}
+			}
+		}
+	}
+
+	return 0
+}
+
+// matchRelative handles "in 3 days", "in an hour", "через 2 часа", "через неделю".
+func (p *parser) matchRelative(words []string) int {
+	if len(words) < 2 || (words[0] != "in" && words[0] != "через") {
+		return 0
+	}
+
+	amount, consumed := 1, 1
+	switch {
+	case numberPattern.MatchString(words[1]):
+		amount = atoi(words[1])
+		consumed = 2
+	case words[0] == "in" && (words[1] == "a" || words[1] == "an"):
+		consumed = 2
+	case words[0] == "через" && singularUnits[strings.TrimRight(words[1], ".")]:
+	default:
+		return 0
+	}
+
+	if consumed >= len(words) {
+		return 0
+	}
+	u, ok := units[strings.TrimRight(words[consumed], ".")]
+	if !ok {
+		return 0
+	}
+
+	switch u {
+	case unitMinute:
+		p.setExact(p.now.Add(time.Duration(amount) * time.Minute))
+	case unitHour:
+		p.setExact(p.now.Add(time.Duration(amount) * time.Hour))
+	case unitDay:
+		p.setDate(startOfDay(p.now).AddDate(0, 0, amount))
+	case unitWeek:
+		p.setDate(startOfDay(p.now).AddDate(0, 0, 7*amount))
+	}
+
+	return consumed + 1
+}
+
+// matchClock handles "17:00", "5pm", "5:30 pm", "noon" and "8 утра".
+func (p *parser) matchClock(words []string) int {
+	word := words[0]
+
+	if m := clockPattern.FindStringSubmatch(strings.TrimRight(word, ".")); m != nil {
+		hour, minute := atoi(m[1]), atoi(m[2])
+		if len(words) > 1 {
+			if shift, ok := partsOfDay[strings.TrimRight(words[1], ".")]; ok {
+				p.setClock(adjustHour(hour, shift), minute)
+				return 2
+			}
+		}
+		p.setClock(hour, minute)
+		return 1
+	}
+
+	if m := meridiemPattern.FindStringSubmatch(strings.TrimRight(word, ".")); m != nil {
+		p.setClock(meridiemHour(atoi(m[1]), m[3]), atoi(m[2]))
+		return 1
+	}
+
+	if len(words) > 1 {
+		next := strings.TrimRight(words[1], ".")
+		if m := meridiemPattern.FindStringSubmatch(word + next); m != nil && (next == "am" || next == "pm") {
+			p.setClock(meridiemHour(atoi(m[1]), m[3]), atoi(m[2]))
+			return 2
+		}
+		if shift, ok := partsOfDay[next]; ok && numberPattern.MatchString(word) {
+			if hour := atoi(word); hour >= 1 && hour <= 12 {
+				p.setClock(adjustHour(hour, shift), 0)
+				return 2
+			}
+		}
+	}
+
+	if clock, ok := timesOfDay[strings.TrimRight(word, ".")]; ok {
+		p.setClock(clock[0], clock[1])
+		return 1
+	}
+
+	return 0
+}
+
+func (p *parser) setDate(date time.Time) {
+	p.date = &date
+}
+
+func (p *parser) setExact(at time.Time) {
+	p.exact = &at
+}
+
+func (p *parser) setClock(hour, minute int) {
+	p.hour, p.minute, p.hasClock = hour, minute, true
+}
+
+func (p *parser) resolveDue() {
+	var due time.Time
+
+	switch {
+	case p.exact != nil:
+		due = *p.exact
+		p.result.HasTime = true
+	case p.date != nil && p.hasClock:
+		due = atClock(*p.date, p.hour, p.minute)
+		p.result.HasTime = true
+	case p.date != nil:
+		due = atClock(*p.date, endOfDayHour, endOfDayMinute)
+	case p.hasClock:
+		// A bare time means the next occurrence of that time.
+		due = atClock(startOfDay(p.now), p.hour, p.minute)
+		if !due.After(p.now) {
+			due = due.AddDate(0, 0, 1)
+		}
+		p.result.HasTime = true
+	default:
+		return
+	}
+
+	p.result.DueDate = &due
+}
+
+// dayMonth builds a date from day and month. Without a year the next
+// occurrence from today is used.
+func (p *parser) dayMonth(year, month, day int) (time.Time, bool) {
+	if year != 0 {
+		return makeDate(year, month, day, p.now.Location())
+	}
+
+	date, ok := makeDate(p.now.Year(), month, day, p.now.Location())
+	if !ok {
+		return time.Time{}, false
+	}
+	if date.Before(startOfDay(p.now)) {
+		return makeDate(p.now.Year()+1, month, day, p.now.Location())
+	}
+	return date, true
+}
+
+// makeDate rejects dates that time.Date would silently normalize, e.g. 31.02.
+func makeDate(year, month, day int, loc *time.Location) (time.Time, bool) {
+	if month < 1 || month > 12 || day < 1 {
+		return time.Time{}, false
+	}
+	date := time.Date(year, time.Month(month), day, 0, 0, 0, 0, loc)
+	if date.Day() != day || int(date.Month()) != month {
+		return time.Time{}, false
+	}
+	return date, true
+}
+
+// nextWeekday returns the next date after today falling on weekday.
+func nextWeekday(today time.Time, weekday time.Weekday) time.Time {
+	days := (int(weekday) - int(today.Weekday()) + 7) % 7
+	if days == 0 {
+		days = 7
+	}
+	return today.AddDate(0, 0, days)
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+func atClock(day time.Time, hour, minute int) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, day.Location())
+}
+
+func meridiemHour(hour int, meridiem string) int {
+	hour %= 12
+	if meridiem == "pm" {
+		hour += 12
+	}
+	return hour
+}
+
+func adjustHour(hour, shift int) int {
+	if hour == 12 && shift == 0 {
+		// "12 ночи" is midnight.
+		return 0
+	}
+	if hour < 12 {
+		return hour + shift
+	}
+	return hour
+}
+
+func atoi(raw string) int {
+	value, _ := strconv.Atoi(raw)
+	return value
+}
@@ -0,0 +1,75 @@
+package quickadd
+
+import (
+	"testing"
+	"time"
+
+	"todoapp/services/task-service/internal/domain/entities"
+)
+
+func TestParse(t *testing.T) {
+	msk := time.FixedZone("MSK", 3*60*60)
+	// Wednesday.
+	now := time.Date(2024, 12, 4, 10, 30, 0, 0, msk)
+	at := func(month time.Month, day, hour, minute int) *time.Time {
+		due := time.Date(2024, month, day, hour, minute, 0, 0, msk)
+		return &due
+	}
+
+	tests := []struct {
+		text     string
+		title    string
+		due      *time.Time
+		hasTime  bool
+		priority entities.TaskPriority
+		category string
+	}{
+		{text: "Call Anna tomorrow 17:00 !high #work", title: "Call Anna", due: at(12, 5, 17, 0), hasTime: true, priority: entities.TaskPriorityHigh, category: "work"},
+		{text: "Позвонить Анне завтра в 17:00 !высокий #работа", title: "Позвонить Анне", due: at(12, 5, 17, 0), hasTime: true, priority: entities.TaskPriorityHigh, category: "работа"},
+		{text: "Buy milk", title: "Buy milk"},
+		{text: "Submit report today", title: "Submit report", due: at(12, 4, 23, 59)},
+		{text: "Pay rent on friday at 5pm", title: "Pay rent", due: at(12, 6, 17, 0), hasTime: true},
+		{text: "Standup wednesday 9:30am", title: "Standup", due: at(12, 11, 9, 30), hasTime: true},
+		{text: "Plan sprint next week", title: "Plan sprint", due: at(12, 9, 23, 59)},
+		{text: "Check logs in 2 hours", title: "Check logs", due: at(12, 4, 12, 30), hasTime: true},
+		{text: "Renew passport in 3 days !low", title: "Renew passport", due: at(12, 7, 23, 59), priority: entities.TaskPriorityLow},
+		{text: "Release 31 dec", title: "Release", due: at(12, 31, 23, 59)},
+		{text: "Dentist 2024-12-20 8:15", title: "Dentist", due: at(12, 20, 8, 15), hasTime: true},
+		{text: "Отчёт к пятнице", title: "Отчёт", due: at(12, 6, 23, 59)},
+		{text: "Созвон послезавтра в 8 вечера", title: "Созвон", due: at(12, 6, 20, 0), hasTime: true},
+		{text: "Ретро в следующий понедельник", title: "Ретро", due: at(12, 9, 23, 59)},
+		{text: "Купить подарок через неделю", title: "Купить подарок", due: at(12, 11, 23, 59)},
+		{text: "Отправить счёт 15.12 утром", title: "Отправить счёт", due: at(12, 15, 9, 0), hasTime: true},
+		{text: "Coffee 9:00", title: "Coffee", due: at(12, 5, 9, 0), hasTime: true},
+		{text: "Lunch at noon", title: "Lunch", due: at(12, 4, 12, 0), hasTime: true},
+		{text: "Read chapter 5 of the book #reading #extra", title: "Read chapter 5 of the book #extra", category: "reading"},
+		{text: "Meet at the office", title: "Meet at the office"},
+		{text: "New year party 1 jan", title: "New year party", due: func() *time.Time { d := time.Date(2025, 1, 1, 23, 59, 0, 0, msk); return &d }()},
+		{text: "Invalid 31.02 date", title: "Invalid 31.02 date"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			result := Parse(tt.text, now)
+
+			if result.Title != tt.title {
+				t.Fatalf("title: expected %q, got %q", tt.title, result.Title)
+			}
+			if result.Priority != tt.priority {
+				t.Fatalf("priority: expected %q, got %q", tt.priority, result.Priority)
+			}
+			if result.Category != tt.category {
+				t.Fatalf("category: expected %q, got %q", tt.category, result.Category)
+			}
+			if result.HasTime != tt.hasTime {
+				t.Fatalf("hasTime: expected %v, got %v", tt.hasTime, result.HasTime)
+			}
+			switch {
+			case tt.due == nil && result.DueDate != nil:
+				t.Fatalf("expected no due date, got %v", result.DueDate)
+			case tt.due != nil && (result.DueDate == nil || !result.DueDate.Equal(*tt.due)):
+				t.Fatalf("due: expected %v, got %v", tt.due, result.DueDate)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"todoapp/services/task-service/internal/domain"
+	"todoapp/services/task-service/internal/domain/entities"
+	"todoapp/services/task-service/internal/ports"
+)
+
+func TestQuickAddTask(t *testing.T) {
+	// 22:30 UTC is already the next day in Moscow.
+	now := time.Date(2024, 12, 4, 22, 30, 0, 0, time.UTC)
+	repo := &repoMock{categories: []entities.Category{{ID: 4, Name: "Work"}, {ID: 5, Name: "Home office"}}}
+	tasks := &taskCreatorStub{}
+	svc := NewQuickAddService(repo, tasks,
+		WithQuickAddUserDirectory(userDirStub{user: &ports.UserInfo{ID: 1, Active: true, Timezone: "Europe/Moscow"}}),
+		WithQuickAddClock(func() time.Time { return now }),
+	)
+
+	result, err := svc.QuickAddTask(context.Background(), 1, "Call Anna tomorrow 17:00 !high #work")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	input := tasks.inputs[0]
+	if input.Title != "Call Anna" || input.Priority != entities.TaskPriorityHigh {
+		t.Fatalf("unexpected input: %+v", input)
+	}
+	if input.CategoryID == nil || *input.CategoryID != 4 || result.CategoryName != "Work" {
+		t.Fatalf("category not resolved: %+v", result)
+	}
+	want := time.Date(2024, 12, 6, 14, 0, 0, 0, time.UTC)
+	if input.DueDate == nil || !input.DueDate.Equal(want) || !result.HasTime {
+		t.Fatalf("expected due %v in user timezone, got %v", want, input.DueDate)
+	}
+	if result.Task == nil {
+		t.Fatalf("created task not returned")
+	}
+}
+
+// storedWallClock returns what a TIMESTAMP column keeps of t: its wall-clock
+// time, read back as UTC.
+func storedWallClock(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+}
+
+func TestQuickAddTaskStoresDueDateInUTC(t *testing.T) {
+	now := time.Date(2024, 12, 4, 9, 0, 0, 0, time.UTC)
+	tasks := &taskCreatorStub{}
+	svc := NewQuickAddService(&repoMock{}, tasks,
+		WithQuickAddUserDirectory(userDirStub{user: &ports.UserInfo{ID: 1, Active: true, Timezone: "Europe/Moscow"}}),
+		WithQuickAddClock(func() time.Time { return now }),
+	)
+
+	if _, err := svc.QuickAddTask(context.Background(), 1, "Call Anna tomorrow 17:00"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// 17:00 in Moscow is 14:00 UTC.
+	want := time.Date(2024, 12, 5, 14, 0, 0, 0, time.UTC)
+	if due := tasks.inputs[0].DueDate; due == nil || !storedWallClock(*due).Equal(want) {
+		t.Fatalf("expected stored due %v, got %v", want, due)
+	}
+}
+
+func TestQuickAddTaskCategories(t *testing.T) {
+	repo := &repoMock{categories: []entities.Category{{ID: 5, Name: "Home office"}}}
+
+	tests := []struct {
+		text       string
+		title      string
+		categoryID int64
+	}{
+		{text: "Fix printer #home_office", title: "Fix printer", categoryID: 5},
+		{text: "Fix printer #garage", title: "Fix printer #garage"},
+	}
+
+	for _, tt := range tests {
+		tasks := &taskCreatorStub{}
+		svc := NewQuickAddService(repo, tasks)
+
+		if _, err := svc.QuickAddTask(context.Background(), 1, tt.text); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		input := tasks.inputs[0]
+		if input.Title != tt.title || input.Priority != entities.TaskPriorityMedium {
+			t.Fatalf("unexpected input for %q: %+v", tt.text, input)
+		}
+		if (tt.categoryID == 0) != (input.CategoryID == nil) || (input.CategoryID != nil && *input.CategoryID != tt.categoryID) {
+			t.Fatalf("unexpected category for %q: %v", tt.text, input.CategoryID)
+		}
+	}
+}
+
+func TestQuickAddTaskRejectsEmptyText(t *testing.T) {
+	svc := NewQuickAddService(&repoMock{}, &taskCreatorStub{})
+
+	if _, err := svc.QuickAddTask(context.Background(), 1, "   "); !errors.Is(err, domain.ErrValidationFailed) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
@@ -2,28 +2,40 @@ package service
 
 import (
 	"context"
-	"io"
-	"log"
+	"fmt"
+	"log/slog"
+	"regexp"
 	"strings"
 	"time"
 
 	"github.com/google/uuid"
 
 	"todoapp/pkg/events"
+	"todoapp/pkg/logging"
 	analyticsv1 "todoapp/pkg/proto/analytics/v1"
+	"todoapp/pkg/tracing"
 	"todoapp/services/task-service/internal/domain"
 	"todoapp/services/task-service/internal/domain/entities"
 	"todoapp/services/task-service/internal/ports"
 	"todoapp/services/task-service/internal/service/export"
 )
 
+var hexColorPattern = regexp.MustCompile(`^#([0-9a-f]{3}|[0-9a-f]{6})$`)
+
 type TaskService struct {
 	repo      ports.TaskRepository
 	users     ports.UserDirectory
 	analytics ports.AnalyticsTracker
 	publisher ports.TaskEventPublisher
+	webhooks  ports.WebhookDispatcher
+	changes   ports.ChangeRecorder
+	workflows ports.WorkflowResolver
+	fields    ports.CustomFieldLister
+	purger    ports.AttachmentPurger
+	timers    ports.TimerStopper
+	tx        ports.TransactionManager
 	now       func() time.Time
-	logger    *log.Logger
+	logger    *slog.Logger
 }
 
 type TaskServiceOption func(*TaskService)
@@ -34,7 +46,7 @@ func NewTaskService(repo ports.TaskRepository, opts ...TaskServiceOption) *TaskS
 	svc := &TaskService{
 		repo:   repo,
 		now:    time.Now,
-		logger: log.New(io.Discard, "", 0),
+		logger: logging.Discard(),
 	}
 	for _, opt := range opts {
 		opt(svc)
@@ -60,7 +72,61 @@ func WithEventPublisher(publisher ports.TaskEventPublisher) TaskServiceOption {
 	}
 }
 
-func WithLogger(logger *log.Logger) TaskServiceOption {
+// WithWebhookDispatcher queues task events for the user's webhooks.
+func WithWebhookDispatcher(webhooks ports.WebhookDispatcher) TaskServiceOption {
+	return func(s *TaskService) {
+		s.webhooks = webhooks
+	}
+}
+
+// WithChangeRecorder records task, category and comment changes for the
+// real-time stream.
+func WithChangeRecorder(changes ports.ChangeRecorder) TaskServiceOption {
+	return func(s *TaskService) {
+		s.changes = changes
+	}
+}
+
+// WithWorkflowResolver applies the users' own status workflows. Without it
+// every task follows the built-in workflow.
+func WithWorkflowResolver(workflows ports.WorkflowResolver) TaskServiceOption {
+	return func(s *TaskService) {
+		s.workflows = workflows
+	}
+}
+
+// WithCustomFieldLister validates task custom fields against the user's
+// definitions. Without it tasks cannot have custom fields.
+func WithCustomFieldLister(fields ports.CustomFieldLister) TaskServiceOption {
+	return func(s *TaskService) {
+		s.fields = fields
+	}
+}
+
+// WithAttachmentPurger makes DeleteTask release the attachments of deleted tasks.
+func WithAttachmentPurger(purger ports.AttachmentPurger) TaskServiceOption {
+	return func(s *TaskService) {
+		s.purger = purger
+	}
+}
+
+// WithTimerStopper makes completing a task stop a timer running on it.
+func WithTimerStopper(timers ports.TimerStopper) TaskServiceOption {
+	return func(s *TaskService) {
+		s.timers = timers
+	}
+}
+
+// WithTransactionManager writes task changes and their notification events
+// atomically. The event publisher is then expected to be the outbox, and a
+// failure to record an event rolls the change back.
+func WithTransactionManager(tx ports.TransactionManager) TaskServiceOption {
+	return func(s *TaskService) {
+		s.tx = tx
+	}
+}
+
+func WithLogger(logger *slog.Logger) TaskServiceOption {
 	return func(s *TaskService) {
 		if logger != nil {
 			s.logger = logger
@@ -78,11 +144,11 @@ func (s *TaskService) CreateTask(ctx context.Context, input ports.CreateTaskInpu
 		return nil, err
 	}
 
-	if err := s.validateStatus(input.Status); err != nil {
+	if err := s.validatePriority(input.Priority); err != nil {
 		return nil, err
 	}
 
-	if err := s.validatePriority(input.Priority); err != nil {
+	if err := validateEstimate(input.EstimateMinutes); err != nil {
 		return nil, err
 	}
 
@@ -91,36 +157,74 @@ func (s *TaskService) CreateTask(ctx context.Context, input ports.CreateTaskInpu
 		return nil, err
 	}
 
-	task := &entities.Task{
-		UserID:      input.UserID,
-		Title:       strings.TrimSpace(input.Title),
-		Description: strings.TrimSpace(input.Description),
-		Status:      input.Status,
-		Priority:    input.Priority,
-		DueDate:     input.DueDate,
-		CategoryID:  input.CategoryID,
-		Category:    category,
+	workflow, err := s.workflowFor(ctx, input.UserID, input.CategoryID)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := s.repo.CreateTask(ctx, task); err != nil {
+	status := input.Status
+	if status == "" {
+		status = workflow.InitialStatus()
+	}
+
+	// A new task enters the workflow at its initial status, so creating it in
+	// another one is subject to the transitions like any later change.
+	task := &entities.Task{
+		Status:          workflow.InitialStatus(),
+		UserID:          input.UserID,
+		Title:           strings.TrimSpace(input.Title),
+		Description:     strings.TrimSpace(input.Description),
+		Priority:        input.Priority,
+		DueDate:         input.DueDate,
+		CategoryID:      input.CategoryID,
+		Category:        category,
+		EstimateMinutes: input.EstimateMinutes,
+	}
+	completed, err := s.applyStatus(workflow, task, status)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.applyCustomFields(ctx, task, input.CustomFields); err != nil {
 		return nil, err
 	}
 
-	s.trackAnalyticsEvent(ctx, ports.AnalyticsEvent{
-		Type:       analyticsv1.TaskEventType_TASK_EVENT_TYPE_CREATED,
-		UserID:     task.UserID,
-		TaskID:     task.ID,
-		Status:     string(task.Status),
-		Priority:   string(task.Priority),
-		OccurredAt: s.now(),
+	err = s.inTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.CreateTask(ctx, task); err != nil {
+			return err
+		}
+		tracing.SetTaskID(ctx, task.ID)
+		if err := s.recordChange(ctx, entities.ChangeEvent{Type: entities.ChangeTaskCreated, UserID: task.UserID, Task: task}); err != nil {
+			return err
+		}
+		if err := s.publishTaskNotification(ctx, events.TaskEventCreated, task, user); err != nil {
+			return err
+		}
+		if err := s.dispatchWebhooks(ctx, s.taskEvent(events.TaskEventCreated, task)); err != nil {
+			return err
+		}
+		if err := s.trackAnalyticsEvent(ctx, analyticsv1.TaskEventType_TASK_EVENT_TYPE_CREATED, task); err != nil {
+			return err
+		}
+		if completed {
+			return s.recordCompletion(ctx, task, user)
+		}
+		return nil
 	})
-	s.publishTaskNotification(ctx, events.TaskEventCreated, task, user)
+	if err != nil {
+		return nil, err
+	}
+	tasksCreated.Inc()
+	// No timer can be running on a task that did not exist.
+	if completed {
+		tasksCompleted.Inc()
+	}
 
 	return task, nil
 }
 
 func (s *TaskService) UpdateTask(ctx context.Context, input ports.UpdateTaskInput) (*entities.Task, error) {
-	if _, err := s.ensureUser(ctx, input.UserID); err != nil {
+	user, err := s.ensureUser(ctx, input.UserID)
+	if err != nil {
 		return nil, err
 	}
 
@@ -140,13 +244,6 @@ func (s *TaskService) UpdateTask(ctx context.Context, input ports.UpdateTaskInpu
 		task.Description = strings.TrimSpace(*input.Description)
 	}
 
-	if input.Status != nil {
-		task.Status = *input.Status
-		if err := s.validateStatus(task.Status); err != nil {
-			return nil, err
-		}
-	}
-
 	if input.Priority != nil {
 		task.Priority = *input.Priority
 		if err := s.validatePriority(task.Priority); err != nil {
@@ -160,6 +257,15 @@ func (s *TaskService) UpdateTask(ctx context.Context, input ports.UpdateTaskInpu
 		task.DueDate = nil
 	}
 
+	if input.EstimateMinutes != nil {
+		if err := validateEstimate(input.EstimateMinutes); err != nil {
+			return nil, err
+		}
+		task.EstimateMinutes = input.EstimateMinutes
+	} else if input.ClearEstimate {
+		task.EstimateMinutes = nil
+	}
+
 	if input.CategoryID != nil {
 		category, err := s.ensureCategory(ctx, input.UserID, input.CategoryID)
 		if err != nil {
@@ -172,10 +278,40 @@ func (s *TaskService) UpdateTask(ctx context.Context, input ports.UpdateTaskInpu
 		task.Category = nil
 	}
 
-	if err := s.repo.UpdateTask(ctx, task); err != nil {
+	// The status is checked against the workflow of the task's category
+	// after the change; a task moved to a category with another workflow may
+	// need a new status.
+	workflow, err := s.workflowFor(ctx, input.UserID, task.CategoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := task.Status
+	if input.Status != nil {
+		status = *input.Status
+	}
+	completed, err := s.applyStatus(workflow, task, status)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.applyCustomFields(ctx, task, input.CustomFields); err != nil {
 		return nil, err
 	}
 
+	err = s.inTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.UpdateTask(ctx, task); err != nil {
+			return err
+		}
+		return s.recordStatusChange(ctx, task, user, completed)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if completed {
+		s.afterCompleted(ctx, task)
+	}
+
 	return task, nil
 }
 
@@ -184,36 +320,75 @@ func (s *TaskService) UpdateTaskStatus(ctx context.Context, userID, taskID int64
 	if err != nil {
 		return nil, err
 	}
-	if err := s.validateStatus(status); err != nil {
+
+	task, err := s.repo.GetTask(ctx, userID, taskID)
+	if err != nil {
 		return nil, err
 	}
 
-	task, err := s.repo.GetTask(ctx, userID, taskID)
+	workflow, err := s.workflowFor(ctx, userID, task.CategoryID)
 	if err != nil {
 		return nil, err
 	}
 
-	task.Status = status
+	completed, err := s.applyStatus(workflow, task, status)
+	if err != nil {
+		return nil, err
+	}
 
-	if err := s.repo.UpdateTask(ctx, task); err != nil {
+	err = s.inTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.UpdateTask(ctx, task); err != nil {
+			return err
+		}
+		return s.recordStatusChange(ctx, task, user, completed)
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	if status == entities.TaskStatusCompleted {
-		s.trackAnalyticsEvent(ctx, ports.AnalyticsEvent{
-			Type:       analyticsv1.TaskEventType_TASK_EVENT_TYPE_COMPLETED,
-			UserID:     task.UserID,
-			TaskID:     task.ID,
-			Status:     string(task.Status),
-			Priority:   string(task.Priority),
-			OccurredAt: s.now(),
-		})
-		s.publishTaskNotification(ctx, events.TaskEventCompleted, task, user)
+	if completed {
+		s.afterCompleted(ctx, task)
 	}
 
 	return task, nil
 }
 
+// recordStatusChange writes the events of a status change as part of its
+// transaction: the completion events when the task was completed and a
+// task.updated webhook otherwise.
+func (s *TaskService) recordStatusChange(ctx context.Context, task *entities.Task, user *ports.UserInfo, completed bool) error {
+	if err := s.recordChange(ctx, entities.ChangeEvent{Type: entities.ChangeTaskUpdated, UserID: task.UserID, Task: task}); err != nil {
+		return err
+	}
+	if !completed {
+		return s.dispatchWebhooks(ctx, s.taskEvent(events.TaskEventUpdated, task))
+	}
+	return s.recordCompletion(ctx, task, user)
+}
+
+// recordCompletion writes the notification, webhook and analytics events of a
+// task entering a completed status.
+func (s *TaskService) recordCompletion(ctx context.Context, task *entities.Task, user *ports.UserInfo) error {
+	if err := s.publishTaskNotification(ctx, events.TaskEventCompleted, task, user); err != nil {
+		return err
+	}
+	if err := s.dispatchWebhooks(ctx, s.taskEvent(events.TaskEventCompleted, task)); err != nil {
+		return err
+	}
+	return s.trackAnalyticsEvent(ctx, analyticsv1.TaskEventType_TASK_EVENT_TYPE_COMPLETED, task)
+}
+
+// afterCompleted runs the side effects of completing a task that are not part
+// of its transaction.
+func (s *TaskService) afterCompleted(ctx context.Context, task *entities.Task) {
+	tasksCompleted.Inc()
+	if s.timers != nil {
+		if err := s.timers.StopTaskTimer(ctx, task.UserID, task.ID); err != nil {
+			s.logger.ErrorContext(ctx, "stop timer failed", "task_id", task.ID, "error", err)
+		}
+	}
+}
+
 func (s *TaskService) DeleteTask(ctx context.Context, userID, taskID int64) error {
 	user, err := s.ensureUser(ctx, userID)
 	if err != nil {
@@ -225,19 +400,30 @@ func (s *TaskService) DeleteTask(ctx context.Context, userID, taskID int64) erro
 		return err
 	}
 
-	if err := s.repo.SoftDeleteTask(ctx, userID, taskID, s.now()); err != nil {
+	err = s.inTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.SoftDeleteTask(ctx, userID, taskID, s.now()); err != nil {
+			return err
+		}
+		if err := s.recordChange(ctx, entities.ChangeEvent{Type: entities.ChangeTaskDeleted, UserID: userID, Task: &entities.Task{ID: taskID, UserID: userID}}); err != nil {
+			return err
+		}
+		if err := s.publishTaskNotification(ctx, events.TaskEventDeleted, task, user); err != nil {
+			return err
+		}
+		if err := s.dispatchWebhooks(ctx, s.taskEvent(events.TaskEventDeleted, task)); err != nil {
+			return err
+		}
+		return s.trackAnalyticsEvent(ctx, analyticsv1.TaskEventType_TASK_EVENT_TYPE_DELETED, task)
+	})
+	if err != nil {
 		return err
 	}
 
-	s.trackAnalyticsEvent(ctx, ports.AnalyticsEvent{
-		Type:       analyticsv1.TaskEventType_TASK_EVENT_TYPE_DELETED,
-		UserID:     task.UserID,
-		TaskID:     task.ID,
-		Status:     string(task.Status),
-		Priority:   string(task.Priority),
-		OccurredAt: s.now(),
-	})
-	s.publishTaskNotification(ctx, events.TaskEventDeleted, task, user)
+	if s.purger != nil {
+		if err := s.purger.PurgeTaskAttachments(ctx, userID, taskID); err != nil {
+			s.logger.ErrorContext(ctx, "attachment purge failed", "task_id", taskID, "error", err)
+		}
+	}
 
 	return nil
 }
@@ -259,6 +445,9 @@ func (s *TaskService) ListTasks(ctx context.Context, userID int64, filter ports.
 	if filter.Offset < 0 {
 		filter.Offset = 0
 	}
+	if err := s.resolveCustomFieldFilter(ctx, userID, &filter); err != nil {
+		return nil, err
+	}
 	return s.repo.ListTasks(ctx, userID, filter)
 }
 
@@ -270,12 +459,34 @@ func (s *TaskService) CreateCategory(ctx context.Context, input ports.CreateCate
 		return nil, domain.ErrValidationFailed.WithMessage("category name is required")
 	}
 
+	color := entities.DefaultCategoryColor
+	if strings.TrimSpace(input.Color) != "" {
+		normalized, err := normalizeColor(input.Color)
+		if err != nil {
+			return nil, err
+		}
+		color = normalized
+	}
+
+	if _, err := s.ensureCategory(ctx, input.UserID, input.ParentID); err != nil {
+		return nil, err
+	}
+
 	category := &entities.Category{
-		UserID: input.UserID,
-		Name:   strings.TrimSpace(input.Name),
+		UserID:   input.UserID,
+		ParentID: input.ParentID,
+		Name:     strings.TrimSpace(input.Name),
+		Color:    color,
+		Icon:     strings.TrimSpace(input.Icon),
 	}
 
-	if err := s.repo.CreateCategory(ctx, category); err != nil {
+	err := s.inTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.CreateCategory(ctx, category); err != nil {
+			return err
+		}
+		return s.recordChange(ctx, entities.ChangeEvent{Type: entities.ChangeCategoryCreated, UserID: category.UserID, Category: category})
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -289,11 +500,144 @@ func (s *TaskService) ListCategories(ctx context.Context, userID int64) ([]entit
 	return s.repo.ListCategories(ctx, userID)
 }
 
-func (s *TaskService) DeleteCategory(ctx context.Context, userID, categoryID int64) error {
+func (s *TaskService) GetCategory(ctx context.Context, userID, categoryID int64) (*entities.Category, error) {
+	if _, err := s.ensureUser(ctx, userID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetCategory(ctx, userID, categoryID)
+}
+
+func (s *TaskService) ListCategoryTree(ctx context.Context, userID int64) ([]entities.Category, error) {
+	categories, err := s.ListCategories(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return buildCategoryTree(categories), nil
+}
+
+func (s *TaskService) UpdateCategory(ctx context.Context, input ports.UpdateCategoryInput) (*entities.Category, error) {
+	if _, err := s.ensureUser(ctx, input.UserID); err != nil {
+		return nil, err
+	}
+
+	category, err := s.repo.GetCategory(ctx, input.UserID, input.CategoryID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Name != nil {
+		if strings.TrimSpace(*input.Name) == "" {
+			return nil, domain.ErrValidationFailed.WithMessage("category name is required")
+		}
+		category.Name = strings.TrimSpace(*input.Name)
+	}
+
+	if input.Color != nil {
+		color, err := normalizeColor(*input.Color)
+		if err != nil {
+			return nil, err
+		}
+		category.Color = color
+	}
+
+	if input.Icon != nil {
+		category.Icon = strings.TrimSpace(*input.Icon)
+	}
+
+	if input.ClearParent && input.ParentID == nil {
+		category.ParentID = nil
+	}
+
+	err = s.inTransaction(ctx, func(ctx context.Context) error {
+		if input.ParentID != nil {
+			// Two concurrent moves could each pass the check and form a
+			// cycle together, so they are checked under the lock.
+			if err := s.repo.LockCategories(ctx, input.UserID); err != nil {
+				return err
+			}
+			if err := s.ensureNotDescendant(ctx, input.UserID, category.ID, *input.ParentID); err != nil {
+				return err
+			}
+			category.ParentID = input.ParentID
+		}
+		if err := s.repo.UpdateCategory(ctx, category); err != nil {
+			return err
+		}
+		return s.recordChange(ctx, entities.ChangeEvent{Type: entities.ChangeCategoryUpdated, UserID: category.UserID, Category: category})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return category, nil
+}
+
+func (s *TaskService) ReorderCategories(ctx context.Context, userID int64, categoryIDs []int64) ([]entities.Category, error) {
+	if _, err := s.ensureUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.ListCategories(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(categoryIDs) != len(existing) {
+		return nil, domain.ErrValidationFailed.WithMessage("category order must list every category exactly once")
+	}
+
+	known := make(map[int64]bool, len(existing))
+	for _, category := range existing {
+		known[category.ID] = true
+	}
+	for _, id := range categoryIDs {
+		if !known[id] {
+			return nil, domain.ErrValidationFailed.WithMessage("category order must list every category exactly once")
+		}
+		delete(known, id)
+	}
+
+	var categories []entities.Category
+
+	err = s.inTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.ReorderCategories(ctx, userID, categoryIDs); err != nil {
+			return err
+		}
+		reordered, err := s.repo.ListCategories(ctx, userID)
+		if err != nil {
+			return err
+		}
+		for i := range reordered {
+			if err := s.recordChange(ctx, entities.ChangeEvent{Type: entities.ChangeCategoryUpdated, UserID: userID, Category: &reordered[i]}); err != nil {
+				return err
+			}
+		}
+		categories = reordered
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return categories, nil
+}
+
+func (s *TaskService) DeleteCategory(ctx context.Context, userID, categoryID int64, mode entities.CategoryDeleteMode) error {
 	if _, err := s.ensureUser(ctx, userID); err != nil {
 		return err
 	}
-	return s.repo.DeleteCategory(ctx, userID, categoryID)
+	if mode == "" {
+		mode = entities.CategoryDeleteRefuse
+	}
+	if !mode.IsValid() {
+		return domain.ErrValidationFailed.WithMessage("unsupported category delete mode")
+	}
+	return s.inTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.DeleteCategory(ctx, userID, categoryID, mode); err != nil {
+			return err
+		}
+		return s.recordChange(ctx, entities.ChangeEvent{Type: entities.ChangeCategoryDeleted, UserID: userID, Category: &entities.Category{ID: categoryID, UserID: userID}})
+	})
 }
 
 func (s *TaskService) AddComment(ctx context.Context, input ports.AddCommentInput) (*entities.TaskComment, error) {
@@ -304,7 +648,8 @@ func (s *TaskService) AddComment(ctx context.Context, input ports.AddCommentInpu
 		return nil, domain.ErrValidationFailed.WithMessage("comment cannot be empty")
 	}
 
-	if _, err := s.repo.GetTask(ctx, input.UserID, input.TaskID); err != nil {
+	task, err := s.repo.GetTask(ctx, input.UserID, input.TaskID)
+	if err != nil {
 		return nil, err
 	}
 
@@ -314,7 +659,18 @@ func (s *TaskService) AddComment(ctx context.Context, input ports.AddCommentInpu
 		Content: strings.TrimSpace(input.Content),
 	}
 
-	if err := s.repo.CreateComment(ctx, comment); err != nil {
+	err = s.inTransaction(ctx, func(ctx context.Context) error {
+		if err := s.repo.CreateComment(ctx, comment); err != nil {
+			return err
+		}
+		if err := s.recordChange(ctx, entities.ChangeEvent{Type: entities.ChangeCommentCreated, UserID: input.UserID, Comment: comment}); err != nil {
+			return err
+		}
+		event := s.taskEvent(events.TaskEventCommented, task)
+		event.Comment = &events.TaskEventComment{ID: comment.ID, Content: comment.Content}
+		return s.dispatchWebhooks(ctx, event)
+	})
+	if err != nil {
 		return nil, err
 	}
 
@@ -346,7 +702,12 @@ func (s *TaskService) ExportTasks(ctx context.Context, userID int64, format enti
 		return nil, "", err
 	}
 
-	formatter, err := export.NewFormatter(format)
+	fields, err := s.customFields(ctx, userID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	formatter, err := export.NewFormatter(format, fields...)
 	if err != nil {
 		return nil, "", domain.ErrValidationFailed.WithMessage(err.Error())
 	}
@@ -382,6 +743,35 @@ func (s *TaskService) ensureCategory(ctx context.Context, userID int64, category
 	return category, nil
 }
 
+// ensureNotDescendant rejects moving categoryID under parentID when parentID
+// is the category itself or one of its descendants.
+func (s *TaskService) ensureNotDescendant(ctx context.Context, userID, categoryID, parentID int64) error {
+	if parentID == categoryID {
+		return domain.ErrCategoryCycle
+	}
+
+	categories, err := s.repo.ListCategories(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	parents := make(map[int64]*int64, len(categories))
+	for _, category := range categories {
+		parents[category.ID] = category.ParentID
+	}
+	if _, ok := parents[parentID]; !ok {
+		return domain.ErrCategoryNotFound
+	}
+
+	for current, hops := parents[parentID], 0; current != nil && hops < len(parents); current, hops = parents[*current], hops+1 {
+		if *current == categoryID {
+			return domain.ErrCategoryCycle
+		}
+	}
+
+	return nil
+}
+
 func (s *TaskService) validateTitle(title string) error {
 	if strings.TrimSpace(title) == "" {
 		return domain.ErrValidationFailed.WithMessage("title is required")
@@ -389,16 +779,169 @@ func (s *TaskService) validateTitle(title string) error {
 	return nil
 }
 
-func (s *TaskService) validateStatus(status entities.TaskStatus) error {
-	switch status {
-	case entities.TaskStatusPending,
-		entities.TaskStatusInProgress,
-		entities.TaskStatusCompleted,
-		entities.TaskStatusArchived:
+// maxEstimateMinutes caps estimates at a thousand hours.
+const maxEstimateMinutes = 60000
+
+func validateEstimate(minutes *int) error {
+	if minutes == nil {
 		return nil
-	default:
-		return domain.ErrInvalidTaskStatus.WithMessage("unsupported status: " + string(status))
 	}
+	if *minutes <= 0 || *minutes > maxEstimateMinutes {
+		return domain.ErrValidationFailed.WithMessage("estimate must be between 1 and 60000 minutes")
+	}
+	return nil
+}
+
+// workflowFor returns the workflow that applies to tasks in the category,
+// falling back to the built-in one.
+func (s *TaskService) workflowFor(ctx context.Context, userID int64, categoryID *int64) (*entities.Workflow, error) {
+	if s.workflows == nil {
+		return entities.DefaultWorkflow(), nil
+	}
+
+	workflow, err := s.workflows.ResolveWorkflow(ctx, userID, categoryID)
+	if err != nil {
+		return nil, err
+	}
+	if workflow == nil {
+		return entities.DefaultWorkflow(), nil
+	}
+
+	return workflow, nil
+}
+
+func (s *TaskService) customFields(ctx context.Context, userID int64) ([]entities.CustomField, error) {
+	if s.fields == nil {
+		return nil, nil
+	}
+	return s.fields.ListCustomFields(ctx, userID)
+}
+
+func (s *TaskService) customFieldsByKey(ctx context.Context, userID int64) (map[string]entities.CustomField, error) {
+	fields, err := s.customFields(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	byKey := make(map[string]entities.CustomField, len(fields))
+	for _, field := range fields {
+		byKey[field.Key] = field
+	}
+	return byKey, nil
+}
+
+// applyCustomFields validates values against the user's field definitions
+// and merges them into the task; nil values remove a field.
+func (s *TaskService) applyCustomFields(ctx context.Context, task *entities.Task, values entities.CustomFieldValues) error {
+	if len(values) == 0 {
+		return nil
+	}
+
+	fields, err := s.customFieldsByKey(ctx, task.UserID)
+	if err != nil {
+		return err
+	}
+
+	for key, value := range values {
+		field, ok := fields[key]
+		if !ok {
+			return domain.ErrValidationFailed.WithMessage(fmt.Sprintf("unknown custom field %q", key))
+		}
+
+		normalized, err := normalizeCustomFieldValue(field, value)
+		if err != nil {
+			return err
+		}
+
+		if normalized == nil {
+			delete(task.CustomFields, key)
+			continue
+		}
+		if task.CustomFields == nil {
+			task.CustomFields = entities.CustomFieldValues{}
+		}
+		task.CustomFields[key] = normalized
+	}
+
+	return nil
+}
+
+// resolveCustomFieldFilter checks that filtered and sorted fields exist and
+// converts raw filter values into the stored form.
+func (s *TaskService) resolveCustomFieldFilter(ctx context.Context, userID int64, filter *ports.TaskFilter) error {
+	if len(filter.CustomFields) == 0 && filter.SortField == "" {
+		return nil
+	}
+
+	fields, err := s.customFieldsByKey(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if filter.SortField != "" {
+		if _, ok := fields[filter.SortField]; !ok {
+			return domain.ErrValidationFailed.WithMessage(fmt.Sprintf("unknown custom field %q", filter.SortField))
+		}
+	}
+
+	resolved := make([]ports.CustomFieldFilter, 0, len(filter.CustomFields))
+	for _, fieldFilter := range filter.CustomFields {
+		field, ok := fields[fieldFilter.Key]
+		if !ok {
+			return domain.ErrValidationFailed.WithMessage(fmt.Sprintf("unknown custom field %q", fieldFilter.Key))
+		}
+
+		if raw, ok := fieldFilter.Value.(string); ok {
+			value, err := parseCustomFieldFilter(field, raw)
+			if err != nil {
+				return err
+			}
+			fieldFilter.Value = value
+		}
+		resolved = append(resolved, fieldFilter)
+	}
+	filter.CustomFields = resolved
+
+	return nil
+}
+
+// applyStatus moves task to status if its workflow allows it and keeps
+// CompletedAt in step with the status. It reports whether the task was
+// completed by this change.
+func (s *TaskService) applyStatus(workflow *entities.Workflow, task *entities.Task, status entities.TaskStatus) (bool, error) {
+	if _, ok := workflow.Status(status); !ok {
+		return false, domain.ErrInvalidTaskStatus.WithMessage(fmt.Sprintf("status %q is not part of the %q workflow", status, workflow.Name))
+	}
+	if !workflow.Allows(task.Status, status) {
+		return false, domain.ErrInvalidTaskStatus.WithMessage(fmt.Sprintf("the %q workflow does not allow moving from %q to %q", workflow.Name, task.Status, status))
+	}
+
+	task.Status = status
+
+	if !workflow.IsCompleted(status) {
+		task.CompletedAt = nil
+		return false, nil
+	}
+	if task.CompletedAt != nil {
+		return false, nil
+	}
+
+	completedAt := s.now().UTC()
+	task.CompletedAt = &completedAt
+	return true, nil
+}
+
+// normalizeColor accepts #rgb and #rrggbb hex colors and returns the
+// lower-case six-digit form stored in the database.
+func normalizeColor(raw string) (string, error) {
+	color := strings.ToLower(strings.TrimSpace(raw))
+	if !hexColorPattern.MatchString(color) {
+		return "", domain.ErrValidationFailed.WithMessage("color must be a hex value like #3498db")
+	}
+	if len(color) == 4 {
+		color = string([]byte{'#', color[1], color[1], color[2], color[2], color[3], color[3]})
+	}
+	return color, nil
 }
 
 func (s *TaskService) validatePriority(priority entities.TaskPriority) error {
@@ -413,10 +956,14 @@ func (s *TaskService) validatePriority(priority entities.TaskPriority) error {
 }
 
 func (s *TaskService) ensureUser(ctx context.Context, userID int64) (*ports.UserInfo, error) {
-	if s.users == nil || userID == 0 {
+	return ensureActiveUser(ctx, s.users, userID)
+}
+
+func ensureActiveUser(ctx context.Context, users ports.UserDirectory, userID int64) (*ports.UserInfo, error) {
+	if users == nil || userID == 0 {
 		return nil, nil
 	}
-	user, err := s.users.GetUser(ctx, userID)
+	user, err := users.GetUser(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
@@ -426,46 +973,134 @@ func (s *TaskService) ensureUser(ctx context.Context, userID int64) (*ports.User
 	return user, nil
 }
 
-func (s *TaskService) trackAnalyticsEvent(ctx context.Context, event ports.AnalyticsEvent) {
+// trackAnalyticsEvent records an analytics event. Like notifications, it is
+// part of the transaction when a transaction manager is set and best effort
+// otherwise.
+func (s *TaskService) trackAnalyticsEvent(ctx context.Context, eventType analyticsv1.TaskEventType, task *entities.Task) error {
 	if s.analytics == nil {
-		return
+		return nil
 	}
-	if err := s.analytics.TrackTaskEvent(ctx, event); err != nil {
-		s.logError("analytics tracking failed: %v", err)
+
+	err := s.analytics.TrackTaskEvent(ctx, ports.AnalyticsEvent{
+		ID:         uuid.NewString(),
+		Type:       eventType,
+		UserID:     task.UserID,
+		TaskID:     task.ID,
+		Status:     string(task.Status),
+		Priority:   string(task.Priority),
+		OccurredAt: s.now(),
+	})
+	if err != nil {
+		if s.tx != nil {
+			return err
+		}
+		s.logger.ErrorContext(ctx, "analytics tracking failed", "task_id", task.ID, "error", err)
 	}
+	return nil
 }
 
-func (s *TaskService) publishTaskNotification(ctx context.Context, eventType events.TaskEventType, task *entities.Task, user *ports.UserInfo) {
+// publishTaskNotification hands a notification event to the publisher. Inside
+// a transaction the error is returned so the change rolls back; without a
+// transaction manager publishing is best effort.
+func (s *TaskService) publishTaskNotification(ctx context.Context, eventType events.TaskEventType, task *entities.Task, user *ports.UserInfo) error {
 	if s.publisher == nil || task == nil || user == nil || user.Email == "" {
-		return
+		return nil
+	}
+
+	payload := s.taskEvent(eventType, task)
+	payload.UserID = user.ID
+	payload.UserEmail = user.Email
+
+	if err := s.publisher.Publish(ctx, payload); err != nil {
+		if s.tx != nil {
+			return err
+		}
+		s.logger.ErrorContext(ctx, "notification publish failed", "task_id", task.ID, "error", err)
+	}
+	return nil
+}
+
+// dispatchWebhooks queues an event for the user's webhooks, with the same
+// transaction semantics as publishTaskNotification.
+func (s *TaskService) dispatchWebhooks(ctx context.Context, event events.TaskEvent) error {
+	if s.webhooks == nil {
+		return nil
+	}
+
+	if err := s.webhooks.DispatchTaskEvent(ctx, event); err != nil {
+		if s.tx != nil {
+			return err
+		}
+		s.logger.ErrorContext(ctx, "webhook dispatch failed", "task_id", event.TaskID, "error", err)
 	}
+	return nil
+}
+
+// recordChange stores a change for the real-time stream, with the same
+// transaction semantics as publishTaskNotification.
+func (s *TaskService) recordChange(ctx context.Context, event entities.ChangeEvent) error {
+	if s.changes == nil {
+		return nil
+	}
+
+	if err := s.changes.RecordChange(ctx, &event); err != nil {
+		if s.tx != nil {
+			return err
+		}
+		s.logger.ErrorContext(ctx, "change recording failed", "error", err)
+	}
+	return nil
+}
 
-	payload := events.TaskEvent{
+// taskEvent builds the event payload of a task. It carries no email; the
+// notification publisher adds it.
+func (s *TaskService) taskEvent(eventType events.TaskEventType, task *entities.Task) events.TaskEvent {
+	return events.TaskEvent{
 		ID:          uuid.NewString(),
 		Type:        eventType,
 		TaskID:      task.ID,
-		UserID:      user.ID,
+		UserID:      task.UserID,
 		Title:       task.Title,
 		Description: task.Description,
 		Status:      string(task.Status),
 		Priority:    string(task.Priority),
 		DueDate:     task.DueDate,
-		UserEmail:   user.Email,
 		CreatedAt:   s.now(),
 	}
+}
 
-	go func() {
-		ctx, cancel := context.WithTimeout(ctx, 3*time.Second)
-		defer cancel()
-		if err := s.publisher.Publish(ctx, payload); err != nil {
-			s.logError("notification publish failed: %v", err)
-		}
-	}()
+func (s *TaskService) inTransaction(ctx context.Context, fn func(context.Context) error) error {
+	if s.tx == nil {
+		return fn(ctx)
+	}
+	return s.tx.WithinTransaction(ctx, fn)
 }
 
-func (s *TaskService) logError(format string, args ...any) {
-	if s.logger == nil {
-		return
+// buildCategoryTree nests a flat, ordered category list by parent. Categories
+// whose parent is missing from the list are returned at the top level.
+func buildCategoryTree(categories []entities.Category) []entities.Category {
+	children := make(map[int64][]entities.Category, len(categories))
+	known := make(map[int64]bool, len(categories))
+	for _, category := range categories {
+		known[category.ID] = true
 	}
-	s.logger.Printf(format, args...)
+
+	var roots []entities.Category
+	for _, category := range categories {
+		if category.ParentID != nil && known[*category.ParentID] {
+			children[*category.ParentID] = append(children[*category.ParentID], category)
+			continue
+		}
+		roots = append(roots, category)
+	}
+
+	var attach func(nodes []entities.Category) []entities.Category
+	attach = func(nodes []entities.Category) []entities.Category {
+		for i := range nodes {
+			nodes[i].Children = attach(children[nodes[i].ID])
+		}
+		return nodes
+	}
+
+	return attach(roots)
 }
@@ -29,6 +29,9 @@ type repoMock struct {
 	categories   []entities.Category
 	categoryList error
 	deleteCatErr error
+	updatedCat   *entities.Category
+	reordered    []int64
+	deleteMode   entities.CategoryDeleteMode
 
 	comment      *entities.TaskComment
 	commentErr   error
@@ -70,6 +73,23 @@ func (r *repoMock) ListTasks(ctx context.Context, userID int64, filter ports.Tas
 	return r.listResult, r.listErr
 }
 
+func (r *repoMock) MoveTask(ctx context.Context, task *entities.Task) error {
+	r.storedTask = task
+	return r.updateErr
+}
+
+func (r *repoMock) AdjacentTaskPosition(ctx context.Context, userID int64, status entities.TaskStatus, position float64, after bool, excludeTaskID int64) (*float64, error) {
+	return nil, nil
+}
+
+func (r *repoMock) LockTaskColumn(ctx context.Context, userID int64, status entities.TaskStatus) error {
+	return nil
+}
+
+func (r *repoMock) RebalanceTaskPositions(ctx context.Context, userID int64, status entities.TaskStatus) error {
+	return nil
+}
+
 func (r *repoMock) CreateCategory(ctx context.Context, category *entities.Category) error {
 	r.category = category
 	category.ID = 2
@@ -82,11 +102,26 @@ func (r *repoMock) ListCategories(ctx context.Context, userID int64) ([]entities
 	return r.categories, r.categoryList
 }
 
+func (r *repoMock) LockCategories(ctx context.Context, userID int64) error {
+	return nil
+}
+
 func (r *repoMock) GetCategory(ctx context.Context, userID, categoryID int64) (*entities.Category, error) {
 	return r.category, r.categoryErr
 }
 
-func (r *repoMock) DeleteCategory(ctx context.Context, userID, categoryID int64) error {
+func (r *repoMock) UpdateCategory(ctx context.Context, category *entities.Category) error {
+	r.updatedCat = category
+	return nil
+}
+
+func (r *repoMock) ReorderCategories(ctx context.Context, userID int64, categoryIDs []int64) error {
+	r.reordered = categoryIDs
+	return nil
+}
+
+func (r *repoMock) DeleteCategory(ctx context.Context, userID, categoryID int64, mode entities.CategoryDeleteMode) error {
+	r.deleteMode = mode
 	return r.deleteCatErr
 }
 
@@ -337,3 +372,143 @@ func TestEnsureUserInactive(t *testing.T) {
 		t.Fatalf("expected forbidden error, got %v", err)
 	}
 }
+
+func TestCreateCategoryColor(t *testing.T) {
+	repo := &repoMock{}
+	svc := NewTaskService(repo)
+
+	category, err := svc.CreateCategory(context.Background(), ports.CreateCategoryInput{UserID: 1, Name: "Work"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if category.Color != entities.DefaultCategoryColor {
+		t.Fatalf("expected default color, got %q", category.Color)
+	}
+
+	if _, err := svc.CreateCategory(context.Background(), ports.CreateCategoryInput{UserID: 1, Name: "Work", Color: "red"}); !errors.Is(err, domain.ErrValidationFailed) {
+		t.Fatalf("expected validation error for non-hex color, got %v", err)
+	}
+}
+
+func TestUpdateCategory(t *testing.T) {
+	repo := &repoMock{category: &entities.Category{ID: 2, UserID: 1, Name: "Old", Color: entities.DefaultCategoryColor}}
+	svc := NewTaskService(repo)
+
+	name := " Home "
+	color := "#F0A"
+	icon := "house"
+	category, err := svc.UpdateCategory(context.Background(), ports.UpdateCategoryInput{
+		UserID:     1,
+		CategoryID: 2,
+		Name:       &name,
+		Color:      &color,
+		Icon:       &icon,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if category.Name != "Home" || category.Color != "#ff00aa" || category.Icon != "house" {
+		t.Fatalf("category not updated: %+v", category)
+	}
+	if repo.updatedCat != category {
+		t.Fatalf("category not persisted")
+	}
+
+	bad := "#12345g"
+	if _, err := svc.UpdateCategory(context.Background(), ports.UpdateCategoryInput{UserID: 1, CategoryID: 2, Color: &bad}); !errors.Is(err, domain.ErrValidationFailed) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
+
+func TestUpdateCategoryParentRejectsCycles(t *testing.T) {
+	area, project := int64(1), int64(2)
+	repo := &repoMock{
+		category: &entities.Category{ID: 1, UserID: 1, Name: "Area"},
+		categories: []entities.Category{
+			{ID: 1, Name: "Area"},
+			{ID: 2, Name: "Project", ParentID: &area},
+			{ID: 3, Name: "Sub", ParentID: &project},
+			{ID: 4, Name: "Other"},
+		},
+	}
+	svc := NewTaskService(repo)
+
+	for _, parentID := range []int64{1, 2, 3} {
+		parent := parentID
+		_, err := svc.UpdateCategory(context.Background(), ports.UpdateCategoryInput{UserID: 1, CategoryID: 1, ParentID: &parent})
+		if !errors.Is(err, domain.ErrCategoryCycle) {
+			t.Fatalf("parent %d: expected cycle error, got %v", parentID, err)
+		}
+	}
+
+	other := int64(4)
+	category, err := svc.UpdateCategory(context.Background(), ports.UpdateCategoryInput{UserID: 1, CategoryID: 1, ParentID: &other})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if category.ParentID == nil || *category.ParentID != 4 {
+		t.Fatalf("parent not updated: %+v", category)
+	}
+}
+
+func TestListCategoryTree(t *testing.T) {
+	area, missing := int64(1), int64(99)
+	repo := &repoMock{categories: []entities.Category{
+		{ID: 1, Name: "Work"},
+		{ID: 2, Name: "Project A", ParentID: &area},
+		{ID: 3, Name: "Home"},
+		{ID: 4, Name: "Project B", ParentID: &area},
+		{ID: 5, Name: "Orphan", ParentID: &missing},
+	}}
+	svc := NewTaskService(repo)
+
+	tree, err := svc.ListCategoryTree(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(tree) != 3 || tree[0].ID != 1 || tree[1].ID != 3 || tree[2].ID != 5 {
+		t.Fatalf("unexpected roots: %+v", tree)
+	}
+	if len(tree[0].Children) != 2 || tree[0].Children[0].ID != 2 || tree[0].Children[1].ID != 4 {
+		t.Fatalf("unexpected children: %+v", tree[0].Children)
+	}
+}
+
+func TestDeleteCategoryMode(t *testing.T) {
+	repo := &repoMock{}
+	svc := NewTaskService(repo)
+
+	if err := svc.DeleteCategory(context.Background(), 1, 2, ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if repo.deleteMode != entities.CategoryDeleteRefuse {
+		t.Fatalf("expected refuse by default, got %q", repo.deleteMode)
+	}
+
+	if err := svc.DeleteCategory(context.Background(), 1, 2, "orphan"); !errors.Is(err, domain.ErrValidationFailed) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+}
+
+func TestReorderCategories(t *testing.T) {
+	repo := &repoMock{categories: []entities.Category{{ID: 1}, {ID: 2}, {ID: 3}}}
+	svc := NewTaskService(repo)
+
+	if _, err := svc.ReorderCategories(context.Background(), 1, []int64{3, 1, 2}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(repo.reordered) != 3 || repo.reordered[0] != 3 {
+		t.Fatalf("order not forwarded: %v", repo.reordered)
+	}
+
+	invalid := [][]int64{
+		{1, 2},
+		{1, 2, 2},
+		{1, 2, 4},
+	}
+	for _, ids := range invalid {
+		if _, err := svc.ReorderCategories(context.Background(), 1, ids); !errors.Is(err, domain.ErrValidationFailed) {
+			t.Fatalf("expected validation error for %v, got %v", ids, err)
+		}
+	}
+}
@@ -0,0 +1,355 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"todoapp/services/task-service/internal/domain"
+	"todoapp/services/task-service/internal/domain/entities"
+	"todoapp/services/task-service/internal/ports"
+)
+
+const maxTemplateItems = 50
+
+var (
+	placeholderPattern = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+	dueOffsetPattern   = regexp.MustCompile(`^\+?(\d{1,4})(` + offsetUnitPattern + `)$`)
+)
+
+type TemplateService struct {
+	templates ports.TemplateRepository
+	repo      ports.TaskRepository
+	tasks     ports.TaskService
+	users     ports.UserDirectory
+	tx        ports.TransactionManager
+	now       func() time.Time
+}
+
+type TemplateServiceOption func(*TemplateService)
+
+var _ ports.TemplateService = (*TemplateService)(nil)
+
+// NewTemplateService creates a template service. Instantiated tasks are created
+// through tasks so they go through the same validation and events as any other
+// task; repo is only used to look up categories.
+func NewTemplateService(templates ports.TemplateRepository, repo ports.TaskRepository, tasks ports.TaskService, opts ...TemplateServiceOption) *TemplateService {
+	svc := &TemplateService{
+		templates: templates,
+		repo:      repo,
+		tasks:     tasks,
+		now:       time.Now,
+	}
+	for _, opt := range opts {
+		opt(svc)
+	}
+	return svc
+}
+
+func WithTemplateUserDirectory(users ports.UserDirectory) TemplateServiceOption {
+	return func(s *TemplateService) {
+		s.users = users
+	}
+}
+
+// WithTemplateTransactionManager creates the tasks of an instantiated template
+// in one transaction.
+func WithTemplateTransactionManager(tx ports.TransactionManager) TemplateServiceOption {
+	return func(s *TemplateService) {
+		s.tx = tx
+	}
+}
+
+func WithTemplateClock(now func() time.Time) TemplateServiceOption {
+	return func(s *TemplateService) {
+		if now != nil {
+			s.now = now
+		}
+	}
+}
+
+func (s *TemplateService) CreateTemplate(ctx context.Context, input ports.CreateTemplateInput) (*entities.TaskTemplate, error) {
+	if _, err := ensureActiveUser(ctx, s.users, input.UserID); err != nil {
+		return nil, err
+	}
+
+	template := &entities.TaskTemplate{UserID: input.UserID}
+	if err := s.apply(ctx, template, input.TemplateInput); err != nil {
+		return nil, err
+	}
+
+	if err := s.templates.CreateTemplate(ctx, template); err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+func (s *TemplateService) GetTemplate(ctx context.Context, userID, templateID int64) (*entities.TaskTemplate, error) {
+	if _, err := ensureActiveUser(ctx, s.users, userID); err != nil {
+		return nil, err
+	}
+	return s.templates.GetTemplate(ctx, userID, templateID)
+}
+
+func (s *TemplateService) ListTemplates(ctx context.Context, userID int64) ([]entities.TaskTemplate, error) {
+	if _, err := ensureActiveUser(ctx, s.users, userID); err != nil {
+		return nil, err
+	}
+	return s.templates.ListTemplates(ctx, userID)
+}
+
+func (s *TemplateService) UpdateTemplate(ctx context.Context, input ports.UpdateTemplateInput) (*entities.TaskTemplate, error) {
+	if _, err := ensureActiveUser(ctx, s.users, input.UserID); err != nil {
+		return nil, err
+	}
+
+	template, err := s.templates.GetTemplate(ctx, input.UserID, input.TemplateID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.apply(ctx, template, input.TemplateInput); err != nil {
+		return nil, err
+	}
+
+	if err := s.templates.UpdateTemplate(ctx, template); err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+func (s *TemplateService) DeleteTemplate(ctx context.Context, userID, templateID int64) error {
+	if _, err := ensureActiveUser(ctx, s.users, userID); err != nil {
+		return err
+	}
+	return s.templates.DeleteTemplate(ctx, userID, templateID)
+}
+
+// InstantiateTemplate resolves placeholders and due offsets for every task
+// before creating any of them, and creates them in one transaction, so
+// neither invalid input nor a failed insert leaves a partially created
+// checklist behind.
+func (s *TemplateService) InstantiateTemplate(ctx context.Context, input ports.InstantiateTemplateInput) ([]entities.Task, error) {
+	if _, err := ensureActiveUser(ctx, s.users, input.UserID); err != nil {
+		return nil, err
+	}
+
+	template, err := s.templates.GetTemplate(ctx, input.UserID, input.TemplateID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	variables := templateVariables(now, input.Variables)
+
+	categoryID := template.CategoryID
+	if input.CategoryID != nil {
+		categoryID = input.CategoryID
+	}
+
+	missing := map[string]bool{}
+	render := func(text string) string {
+		return renderPlaceholders(text, variables, missing)
+	}
+
+	dueDate, err := applyDueOffset(now, template.DueOffset)
+	if err != nil {
+		return nil, err
+	}
+
+	inputs := []ports.CreateTaskInput{{
+		UserID:      input.UserID,
+		Title:       render(template.Title),
+		Description: render(template.Description),
+		Priority:    template.Priority,
+		DueDate:     dueDate,
+		CategoryID:  categoryID,
+	}}
+
+	for _, item := range template.Items {
+		priority := item.Priority
+		if priority == "" {
+			priority = template.Priority
+		}
+
+		itemDue := dueDate
+		if item.DueOffset != "" {
+			if itemDue, err = applyDueOffset(now, item.DueOffset); err != nil {
+				return nil, err
+			}
+		}
+
+		inputs = append(inputs, ports.CreateTaskInput{
+			UserID:      input.UserID,
+			Title:       render(item.Title),
+			Description: render(item.Description),
+			Priority:    priority,
+			DueDate:     itemDue,
+			CategoryID:  categoryID,
+		})
+	}
+
+	if len(missing) > 0 {
+		names := make([]string, 0, len(missing))
+		for name := range missing {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return nil, domain.ErrValidationFailed.
+			WithMessage("template variables are missing").
+			WithDetails(strings.Join(names, ", "))
+	}
+
+	var tasks []entities.Task
+	create := func(ctx context.Context) error {
+		tasks = make([]entities.Task, 0, len(inputs))
+		for _, taskInput := range inputs {
+			task, err := s.tasks.CreateTask(ctx, taskInput)
+			if err != nil {
+				return err
+			}
+			tasks = append(tasks, *task)
+		}
+		return nil
+	}
+
+	if s.tx == nil {
+		err = create(ctx)
+	} else {
+		err = s.tx.WithinTransaction(ctx, create)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return tasks, nil
+}
+
+// apply validates input and copies it onto template.
+func (s *TemplateService) apply(ctx context.Context, template *entities.TaskTemplate, input ports.TemplateInput) error {
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return domain.ErrValidationFailed.WithMessage("template name is required")
+	}
+
+	title := strings.TrimSpace(input.Title)
+	if title == "" {
+		return domain.ErrValidationFailed.WithMessage("template title is required")
+	}
+
+	priority := input.Priority
+	if priority == "" {
+		priority = entities.TaskPriorityMedium
+	}
+	if err := validateTemplatePriority(priority); err != nil {
+		return err
+	}
+
+	if _, err := applyDueOffset(time.Time{}, input.DueOffset); err != nil {
+		return err
+	}
+
+	if len(input.Items) > maxTemplateItems {
+		return domain.ErrValidationFailed.WithMessage(fmt.Sprintf("a template may have at most %d items", maxTemplateItems))
+	}
+
+	items := make([]entities.TemplateItem, 0, len(input.Items))
+	for _, item := range input.Items {
+		item.Title = strings.TrimSpace(item.Title)
+		item.Description = strings.TrimSpace(item.Description)
+		item.DueOffset = strings.TrimSpace(item.DueOffset)
+
+		if item.Title == "" {
+			return domain.ErrValidationFailed.WithMessage("template item title is required")
+		}
+		if item.Priority != "" {
+			if err := validateTemplatePriority(item.Priority); err != nil {
+				return err
+			}
+		}
+		if _, err := applyDueOffset(time.Time{}, item.DueOffset); err != nil {
+			return err
+		}
+		items = append(items, item)
+	}
+
+	if input.CategoryID != nil {
+		if _, err := s.repo.GetCategory(ctx, template.UserID, *input.CategoryID); err != nil {
+			return err
+		}
+	}
+
+	template.Name = name
+	template.Title = title
+	template.Description = strings.TrimSpace(input.Description)
+	template.Priority = priority
+	template.CategoryID = input.CategoryID
+	template.DueOffset = strings.TrimSpace(input.DueOffset)
+	template.Items = items
+
+	return nil
+}
+
+func validateTemplatePriority(priority entities.TaskPriority) error {
+	switch priority {
+	case entities.TaskPriorityLow, entities.TaskPriorityMedium, entities.TaskPriorityHigh:
+		return nil
+	default:
+		return domain.ErrInvalidTaskPriority
+	}
+}
+
+// templateVariables returns the built-in variables overlaid with the
+// caller-provided ones.
+func templateVariables(now time.Time, provided map[string]string) map[string]string {
+	_, week := now.ISOWeek()
+
+	variables := map[string]string{
+		"date": now.Format("2006-01-02"),
+		"week": strconv.Itoa(week),
+		"year": strconv.Itoa(now.Year()),
+	}
+	for name, value := range provided {
+		variables[name] = value
+	}
+	return variables
+}
+
+// renderPlaceholders substitutes {{name}} placeholders and records names
+// without a value in missing.
+func renderPlaceholders(text string, variables map[string]string, missing map[string]bool) string {
+	return placeholderPattern.ReplaceAllStringFunc(text, func(match string) string {
+		name := placeholderPattern.FindStringSubmatch(match)[1]
+		value, ok := variables[name]
+		if !ok {
+			missing[name] = true
+			return match
+		}
+		return value
+	})
+}
+
+// applyDueOffset resolves offsets such as "+30m", "+4h", "+3d", "+2w" or
+// "+1mo" against base. An empty offset means no due date.
+func applyDueOffset(base time.Time, offset string) (*time.Time, error) {
+	offset = strings.TrimSpace(offset)
+	if offset == "" {
+		return nil, nil
+	}
+
+	match := dueOffsetPattern.FindStringSubmatch(offset)
+	if match == nil {
+		return nil, domain.ErrValidationFailed.WithMessage(fmt.Sprintf("invalid due offset %q, expected e.g. +3d", offset))
+	}
+
+	amount, _ := strconv.Atoi(match[1])
+	due := addOffset(base, amount, match[2])
+
+	return &due, nil
+}
@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"todoapp/services/task-service/internal/domain"
+	"todoapp/services/task-service/internal/domain/entities"
+	"todoapp/services/task-service/internal/ports"
+)
+
+type templateRepoStub struct {
+	stored  *entities.TaskTemplate
+	created *entities.TaskTemplate
+}
+
+func (r *templateRepoStub) CreateTemplate(ctx context.Context, template *entities.TaskTemplate) error {
+	r.created = template
+	template.ID = 5
+	return nil
+}
+
+func (r *templateRepoStub) GetTemplate(ctx context.Context, userID, templateID int64) (*entities.TaskTemplate, error) {
+	if r.stored == nil {
+		return nil, domain.ErrTemplateNotFound
+	}
+	return r.stored, nil
+}
+
+func (r *templateRepoStub) ListTemplates(ctx context.Context, userID int64) ([]entities.TaskTemplate, error) {
+	return nil, nil
+}
+
+func (r *templateRepoStub) UpdateTemplate(ctx context.Context, template *entities.TaskTemplate) error {
+	return nil
+}
+
+func (r *templateRepoStub) DeleteTemplate(ctx context.Context, userID, templateID int64) error {
+	return nil
+}
+
+// taskCreatorStub records CreateTask calls; other TaskService methods are not
+// used by the template service.
+type taskCreatorStub struct {
+	ports.TaskService
+	inputs []ports.CreateTaskInput
+	// failAt makes the nth call fail, counting from one.
+	failAt int
+}
+
+func (s *taskCreatorStub) CreateTask(ctx context.Context, input ports.CreateTaskInput) (*entities.Task, error) {
+	s.inputs = append(s.inputs, input)
+	if len(s.inputs) == s.failAt {
+		return nil, errors.New("insert failed")
+	}
+	return &entities.Task{ID: int64(len(s.inputs)), Title: input.Title, DueDate: input.DueDate}, nil
+}
+
+func TestCreateTemplateValidation(t *testing.T) {
+	tests := []struct {
+		name  string
+		input ports.TemplateInput
+		want  error
+	}{
+		{name: "missing title", input: ports.TemplateInput{Name: "n"}, want: domain.ErrValidationFailed},
+		{name: "bad offset", input: ports.TemplateInput{Name: "n", Title: "t", DueOffset: "tomorrow"}, want: domain.ErrValidationFailed},
+		{name: "bad priority", input: ports.TemplateInput{Name: "n", Title: "t", Priority: "urgent"}, want: domain.ErrInvalidTaskPriority},
+		{name: "empty item", input: ports.TemplateInput{Name: "n", Title: "t", Items: []entities.TemplateItem{{Title: " "}}}, want: domain.ErrValidationFailed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewTemplateService(&templateRepoStub{}, &repoMock{}, &taskCreatorStub{})
+			_, err := svc.CreateTemplate(context.Background(), ports.CreateTemplateInput{UserID: 1, TemplateInput: tt.input})
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, err)
+			}
+		})
+	}
+
+	repo := &templateRepoStub{}
+	svc := NewTemplateService(repo, &repoMock{}, &taskCreatorStub{})
+	template, err := svc.CreateTemplate(context.Background(), ports.CreateTemplateInput{
+		UserID:        1,
+		TemplateInput: ports.TemplateInput{Name: " Release ", Title: "Release {{version}}", DueOffset: "+3d"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if template.Name != "Release" || template.Priority != entities.TaskPriorityMedium || repo.created != template {
+		t.Fatalf("unexpected template: %+v", template)
+	}
+}
+
+func TestInstantiateTemplate(t *testing.T) {
+	now := time.Date(2024, 12, 2, 9, 0, 0, 0, time.UTC)
+	categoryID := int64(3)
+	templates := &templateRepoStub{stored: &entities.TaskTemplate{
+		ID:          5,
+		UserID:      1,
+		Title:       "Release {{version}}",
+		Description: "Week {{ week }} release",
+		Priority:    entities.TaskPriorityHigh,
+		CategoryID:  &categoryID,
+		DueOffset:   "+3d",
+		Items: []entities.TemplateItem{
+			{Title: "Tag {{version}}"},
+			{Title: "Announce", Priority: entities.TaskPriorityLow, DueOffset: "+4h"},
+		},
+	}}
+	tasks := &taskCreatorStub{}
+	svc := NewTemplateService(templates, &repoMock{}, tasks, WithTemplateClock(func() time.Time { return now }))
+
+	created, err := svc.InstantiateTemplate(context.Background(), ports.InstantiateTemplateInput{
+		UserID:     1,
+		TemplateID: 5,
+		Variables:  map[string]string{"version": "v1.2"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(created) != 3 || len(tasks.inputs) != 3 {
+		t.Fatalf("expected 3 tasks, got %d", len(created))
+	}
+
+	main, tag, announce := tasks.inputs[0], tasks.inputs[1], tasks.inputs[2]
+	if main.Title != "Release v1.2" || main.Description != "Week 49 release" {
+		t.Fatalf("placeholders not substituted: %+v", main)
+	}
+	if main.DueDate == nil || !main.DueDate.Equal(now.AddDate(0, 0, 3)) {
+		t.Fatalf("unexpected due date %v", main.DueDate)
+	}
+	if tag.Title != "Tag v1.2" || tag.Priority != entities.TaskPriorityHigh || !tag.DueDate.Equal(*main.DueDate) {
+		t.Fatalf("item did not inherit template defaults: %+v", tag)
+	}
+	if announce.Priority != entities.TaskPriorityLow || !announce.DueDate.Equal(now.Add(4*time.Hour)) {
+		t.Fatalf("item overrides not applied: %+v", announce)
+	}
+	for _, input := range tasks.inputs {
+		if input.CategoryID == nil || *input.CategoryID != categoryID {
+			t.Fatalf("category not applied: %+v", input)
+		}
+	}
+}
+
+func TestInstantiateTemplateMissingVariables(t *testing.T) {
+	templates := &templateRepoStub{stored: &entities.TaskTemplate{
+		Title: "Onboard {{name}}",
+		Items: []entities.TemplateItem{{Title: "Laptop for {{name}} in {{team}}"}},
+	}}
+	tasks := &taskCreatorStub{}
+	svc := NewTemplateService(templates, &repoMock{}, tasks)
+
+	_, err := svc.InstantiateTemplate(context.Background(), ports.InstantiateTemplateInput{UserID: 1, TemplateID: 5})
+	if !errors.Is(err, domain.ErrValidationFailed) {
+		t.Fatalf("expected validation error, got %v", err)
+	}
+	if len(tasks.inputs) != 0 {
+		t.Fatalf("no task may be created when variables are missing")
+	}
+}
+
+func TestInstantiateTemplateRollsBackOnFailure(t *testing.T) {
+	templates := &templateRepoStub{stored: &entities.TaskTemplate{
+		Title: "Release",
+		Items: []entities.TemplateItem{{Title: "Tag"}, {Title: "Announce"}},
+	}}
+	tasks := &taskCreatorStub{failAt: 2}
+	tx := &txManagerStub{}
+	svc := NewTemplateService(templates, &repoMock{}, tasks, WithTemplateTransactionManager(tx))
+
+	if _, err := svc.InstantiateTemplate(context.Background(), ports.InstantiateTemplateInput{UserID: 1, TemplateID: 5}); err == nil {
+		t.Fatalf("expected error")
+	}
+	if tx.calls != 1 || tx.rollbacks != 1 {
+		t.Fatalf("expected the tasks to be created in one rolled back transaction, got %d calls and %d rollbacks", tx.calls, tx.rollbacks)
+	}
+}
@@ -0,0 +1,451 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"todoapp/pkg/logging"
+	analyticsv1 "todoapp/pkg/proto/analytics/v1"
+	"todoapp/services/task-service/internal/domain"
+	"todoapp/services/task-service/internal/domain/entities"
+	"todoapp/services/task-service/internal/ports"
+)
+
+const (
+	// maxTimeEntryDuration bounds a single manual entry.
+	maxTimeEntryDuration = 24 * time.Hour
+	maxTimeEntryNote     = 500
+	// maxTimeReportDays keeps reports to roughly a year.
+	maxTimeReportDays = 366
+)
+
+type TimeTrackingService struct {
+	entries   ports.TimeEntryRepository
+	repo      ports.TaskRepository
+	users     ports.UserDirectory
+	analytics ports.AnalyticsTracker
+	tx        ports.TransactionManager
+	now       func() time.Time
+	logger    *slog.Logger
+}
+
+type TimeTrackingServiceOption func(*TimeTrackingService)
+
+var _ ports.TimeTrackingService = (*TimeTrackingService)(nil)
+
+func NewTimeTrackingService(entries ports.TimeEntryRepository, repo ports.TaskRepository, opts ...TimeTrackingServiceOption) *TimeTrackingService {
+	svc := &TimeTrackingService{
+		entries: entries,
+		repo:    repo,
+		now:     time.Now,
+		logger:  logging.Discard(),
+	}
+	for _, opt := range opts {
+		opt(svc)
+	}
+	return svc
+}
+
+// WithTimeTrackingUserDirectory enables the active-user check and builds
+// reports in the user's timezone.
+func WithTimeTrackingUserDirectory(users ports.UserDirectory) TimeTrackingServiceOption {
+	return func(s *TimeTrackingService) {
+		s.users = users
+	}
+}
+
+// WithTimeTrackingAnalytics reports tracked time to analytics-service.
+func WithTimeTrackingAnalytics(tracker ports.AnalyticsTracker) TimeTrackingServiceOption {
+	return func(s *TimeTrackingService) {
+		s.analytics = tracker
+	}
+}
+
+// WithTimeTrackingTransactionManager records tracked time for analytics in the
+// same transaction as the time entry.
+func WithTimeTrackingTransactionManager(tx ports.TransactionManager) TimeTrackingServiceOption {
+	return func(s *TimeTrackingService) {
+		s.tx = tx
+	}
+}
+
+func WithTimeTrackingClock(now func() time.Time) TimeTrackingServiceOption {
+	return func(s *TimeTrackingService) {
+		if now != nil {
+			s.now = now
+		}
+	}
+}
+
+func WithTimeTrackingLogger(logger *slog.Logger) TimeTrackingServiceOption {
+	return func(s *TimeTrackingService) {
+		if logger != nil {
+			s.logger = logger
+		}
+	}
+}
+
+func (s *TimeTrackingService) StartTimer(ctx context.Context, userID, taskID int64, note string) (*entities.TimeEntry, error) {
+	if _, err := ensureActiveUser(ctx, s.users, userID); err != nil {
+		return nil, err
+	}
+
+	note, err := normalizeTimeEntryNote(note)
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := s.repo.GetTask(ctx, userID, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &entities.TimeEntry{
+		UserID:              userID,
+		TaskID:              task.ID,
+		StartedAt:           s.now().UTC().Truncate(time.Second),
+		Note:                note,
+		TaskTitle:           task.Title,
+		TaskEstimateMinutes: task.EstimateMinutes,
+		CategoryID:          task.CategoryID,
+	}
+	if task.Category != nil {
+		entry.CategoryName = task.Category.Name
+	}
+
+	// The running timer is stopped in the same transaction, so a failed start
+	// leaves it running. Of two concurrent starts only one stops it; the
+	// other gets ErrTimerRunning.
+	err = s.inTransaction(ctx, func(ctx context.Context) error {
+		running, err := s.entries.GetRunningTimeEntry(ctx, userID)
+		switch {
+		case err == nil:
+			if err := s.stop(ctx, running); err != nil {
+				if errors.Is(err, domain.ErrNoRunningTimer) {
+					return domain.ErrTimerRunning
+				}
+				return err
+			}
+		case !errors.Is(err, domain.ErrNoRunningTimer):
+			return err
+		}
+		return s.entries.CreateTimeEntry(ctx, entry)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+func (s *TimeTrackingService) StopTimer(ctx context.Context, userID int64) (*entities.TimeEntry, error) {
+	if _, err := ensureActiveUser(ctx, s.users, userID); err != nil {
+		return nil, err
+	}
+
+	return s.stopRunning(ctx, userID)
+}
+
+// StopTaskTimer stops the user's running timer when it is on the given task.
+// It is a no-op otherwise.
+func (s *TimeTrackingService) StopTaskTimer(ctx context.Context, userID, taskID int64) error {
+	running, err := s.entries.GetRunningTimeEntry(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrNoRunningTimer) {
+			return nil
+		}
+		return err
+	}
+
+	if running.TaskID != taskID {
+		return nil
+	}
+
+	return s.stop(ctx, running)
+}
+
+func (s *TimeTrackingService) RunningTimer(ctx context.Context, userID int64) (*entities.TimeEntry, error) {
+	if _, err := ensureActiveUser(ctx, s.users, userID); err != nil {
+		return nil, err
+	}
+
+	return s.entries.GetRunningTimeEntry(ctx, userID)
+}
+
+func (s *TimeTrackingService) AddTimeEntry(ctx context.Context, input ports.AddTimeEntryInput) (*entities.TimeEntry, error) {
+	if _, err := ensureActiveUser(ctx, s.users, input.UserID); err != nil {
+		return nil, err
+	}
+
+	note, err := normalizeTimeEntryNote(input.Note)
+	if err != nil {
+		return nil, err
+	}
+
+	duration := input.Duration.Truncate(time.Second)
+	if duration <= 0 || duration > maxTimeEntryDuration {
+		return nil, domain.ErrValidationFailed.WithMessage("duration must be between 1 second and 24 hours")
+	}
+
+	if input.StartedAt.IsZero() {
+		return nil, domain.ErrValidationFailed.WithMessage("startedAt is required")
+	}
+
+	startedAt := input.StartedAt.UTC().Truncate(time.Second)
+	endedAt := startedAt.Add(duration)
+	if endedAt.After(s.now()) {
+		return nil, domain.ErrValidationFailed.WithMessage("time entry cannot end in the future")
+	}
+
+	task, err := s.repo.GetTask(ctx, input.UserID, input.TaskID)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &entities.TimeEntry{
+		UserID:              input.UserID,
+		TaskID:              task.ID,
+		StartedAt:           startedAt,
+		EndedAt:             &endedAt,
+		Duration:            duration,
+		Note:                note,
+		TaskTitle:           task.Title,
+		TaskEstimateMinutes: task.EstimateMinutes,
+		CategoryID:          task.CategoryID,
+	}
+	if task.Category != nil {
+		entry.CategoryName = task.Category.Name
+	}
+
+	err = s.inTransaction(ctx, func(ctx context.Context) error {
+		if err := s.entries.CreateTimeEntry(ctx, entry); err != nil {
+			return err
+		}
+		return s.trackTime(ctx, entry)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+func (s *TimeTrackingService) ListTimeEntries(ctx context.Context, userID, taskID int64) ([]entities.TimeEntry, error) {
+	if _, err := ensureActiveUser(ctx, s.users, userID); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.repo.GetTask(ctx, userID, taskID); err != nil {
+		return nil, err
+	}
+
+	return s.entries.ListTimeEntries(ctx, userID, ports.TimeEntryFilter{TaskID: &taskID})
+}
+
+func (s *TimeTrackingService) DeleteTimeEntry(ctx context.Context, userID, taskID, entryID int64) error {
+	if _, err := ensureActiveUser(ctx, s.users, userID); err != nil {
+		return err
+	}
+
+	return s.entries.DeleteTimeEntry(ctx, userID, taskID, entryID)
+}
+
+// TimeReport aggregates finished entries between two dates. Without dates it
+// covers the current week; a missing To covers seven days from From.
+func (s *TimeTrackingService) TimeReport(ctx context.Context, input ports.TimeReportInput) (*entities.TimeReport, error) {
+	user, err := ensureActiveUser(ctx, s.users, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	loc := userLocation(user)
+
+	var from, to time.Time
+	if input.From.IsZero() {
+		from = weekStart(s.now().In(loc))
+	} else {
+		from = dateIn(input.From, loc)
+	}
+	if input.To.IsZero() {
+		to = from.AddDate(0, 0, 6)
+	} else {
+		to = dateIn(input.To, loc)
+	}
+
+	if to.Before(from) {
+		return nil, domain.ErrValidationFailed.WithMessage("to must not be before from")
+	}
+
+	days := 0
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		days++
+		if days > maxTimeReportDays {
+			return nil, domain.ErrValidationFailed.WithMessage("time report range cannot exceed 366 days")
+		}
+	}
+
+	// Start times are stored as UTC wall-clock time, so the local day
+	// boundaries are passed in UTC.
+	start, end := from.UTC(), to.AddDate(0, 0, 1).UTC()
+	entries, err := s.entries.ListTimeEntries(ctx, input.UserID, ports.TimeEntryFilter{From: &start, To: &end})
+	if err != nil {
+		return nil, err
+	}
+
+	return buildTimeReport(from, to, loc, entries), nil
+}
+
+func (s *TimeTrackingService) stopRunning(ctx context.Context, userID int64) (*entities.TimeEntry, error) {
+	running, err := s.entries.GetRunningTimeEntry(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.stop(ctx, running); err != nil {
+		return nil, err
+	}
+
+	return running, nil
+}
+
+func (s *TimeTrackingService) stop(ctx context.Context, entry *entities.TimeEntry) error {
+	endedAt := s.now().UTC().Truncate(time.Second)
+	if endedAt.Before(entry.StartedAt) {
+		endedAt = entry.StartedAt
+	}
+
+	entry.EndedAt = &endedAt
+	entry.Duration = endedAt.Sub(entry.StartedAt)
+
+	return s.inTransaction(ctx, func(ctx context.Context) error {
+		if err := s.entries.StopTimeEntry(ctx, entry); err != nil {
+			return err
+		}
+		return s.trackTime(ctx, entry)
+	})
+}
+
+// trackTime reports tracked time to analytics. The error is returned only
+// inside a transaction, where it rolls back the time entry change.
+func (s *TimeTrackingService) trackTime(ctx context.Context, entry *entities.TimeEntry) error {
+	if s.analytics == nil || entry.Duration <= 0 {
+		return nil
+	}
+
+	err := s.analytics.TrackTaskEvent(ctx, ports.AnalyticsEvent{
+		ID:         uuid.NewString(),
+		Type:       analyticsv1.TaskEventType_TASK_EVENT_TYPE_TIME_TRACKED,
+		UserID:     entry.UserID,
+		TaskID:     entry.TaskID,
+		OccurredAt: *entry.EndedAt,
+		Duration:   entry.Duration,
+	})
+	if err != nil {
+		if s.tx != nil {
+			return err
+		}
+		s.logger.ErrorContext(ctx, "analytics tracking failed", "task_id", entry.TaskID, "error", err)
+	}
+	return nil
+}
+
+func (s *TimeTrackingService) inTransaction(ctx context.Context, fn func(context.Context) error) error {
+	if s.tx == nil {
+		return fn(ctx)
+	}
+	return s.tx.WithinTransaction(ctx, fn)
+}
+
+func buildTimeReport(from, to time.Time, loc *time.Location, entries []entities.TimeEntry) *entities.TimeReport {
+	report := &entities.TimeReport{From: from, To: to}
+
+	dayIndex := make(map[string]int)
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		dayIndex[day.Format(time.DateOnly)] = len(report.ByDay)
+		report.ByDay = append(report.ByDay, entities.TimeReportDay{Date: day})
+	}
+
+	categoryIndex := make(map[int64]int)
+	uncategorized := -1
+	taskIndex := make(map[int64]int)
+
+	for _, entry := range entries {
+		if entry.Running() || entry.Duration <= 0 {
+			continue
+		}
+
+		idx, ok := dayIndex[entry.StartedAt.In(loc).Format(time.DateOnly)]
+		if !ok {
+			continue
+		}
+		report.ByDay[idx].Duration += entry.Duration
+		report.Total += entry.Duration
+
+		if entry.CategoryID == nil {
+			if uncategorized < 0 {
+				uncategorized = len(report.ByCategory)
+				report.ByCategory = append(report.ByCategory, entities.TimeReportCategory{})
+			}
+			report.ByCategory[uncategorized].Duration += entry.Duration
+		} else {
+			i, ok := categoryIndex[*entry.CategoryID]
+			if !ok {
+				i = len(report.ByCategory)
+				categoryIndex[*entry.CategoryID] = i
+				report.ByCategory = append(report.ByCategory, entities.TimeReportCategory{
+					CategoryID: entry.CategoryID,
+					Name:       entry.CategoryName,
+				})
+			}
+			report.ByCategory[i].Duration += entry.Duration
+		}
+
+		i, ok := taskIndex[entry.TaskID]
+		if !ok {
+			i = len(report.ByTask)
+			taskIndex[entry.TaskID] = i
+			report.ByTask = append(report.ByTask, entities.TimeReportTask{
+				TaskID:          entry.TaskID,
+				Title:           entry.TaskTitle,
+				CategoryID:      entry.CategoryID,
+				EstimateMinutes: entry.TaskEstimateMinutes,
+			})
+		}
+		report.ByTask[i].Duration += entry.Duration
+	}
+
+	sort.SliceStable(report.ByCategory, func(i, j int) bool {
+		return report.ByCategory[i].Duration > report.ByCategory[j].Duration
+	})
+	sort.SliceStable(report.ByTask, func(i, j int) bool {
+		return report.ByTask[i].Duration > report.ByTask[j].Duration
+	})
+
+	return report
+}
+
+func normalizeTimeEntryNote(note string) (string, error) {
+	note = strings.TrimSpace(note)
+	if utf8.RuneCountInString(note) > maxTimeEntryNote {
+		return "", domain.ErrValidationFailed.WithMessage("note must be at most 500 characters")
+	}
+	return note, nil
+}
+
+// dateIn returns midnight of t's calendar date in loc.
+func dateIn(t time.Time, loc *time.Location) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+}
+
+func weekStart(t time.Time) time.Time {
+	day := dateIn(t, t.Location())
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
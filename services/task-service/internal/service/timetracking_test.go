@@ -0,0 +1,357 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"todoapp/pkg/events"
+	analyticsv1 "todoapp/pkg/proto/analytics/v1"
+	"todoapp/services/task-service/internal/domain"
+	"todoapp/services/task-service/internal/domain/entities"
+	"todoapp/services/task-service/internal/ports"
+)
+
+type timeEntryRepoStub struct {
+	running    *entities.TimeEntry
+	created    []*entities.TimeEntry
+	stopped    []*entities.TimeEntry
+	listed     []entities.TimeEntry
+	listFilter ports.TimeEntryFilter
+	// stopErr is returned by StopTimeEntry, e.g. when another request
+	// stopped the timer first.
+	stopErr error
+	// filterListed applies the list filter to listed like the database
+	// does, comparing stored wall-clock times.
+	filterListed bool
+}
+
+func (r *timeEntryRepoStub) CreateTimeEntry(ctx context.Context, entry *entities.TimeEntry) error {
+	if entry.EndedAt == nil {
+		if r.running != nil {
+			return domain.ErrTimerRunning
+		}
+		r.running = entry
+	}
+	entry.ID = int64(len(r.created) + 1)
+	r.created = append(r.created, entry)
+	return nil
+}
+
+func (r *timeEntryRepoStub) GetRunningTimeEntry(ctx context.Context, userID int64) (*entities.TimeEntry, error) {
+	if r.running == nil {
+		return nil, domain.ErrNoRunningTimer
+	}
+	return r.running, nil
+}
+
+func (r *timeEntryRepoStub) StopTimeEntry(ctx context.Context, entry *entities.TimeEntry) error {
+	if r.stopErr != nil {
+		return r.stopErr
+	}
+	if r.running == nil || r.running.ID != entry.ID {
+		return domain.ErrNoRunningTimer
+	}
+	r.running = nil
+	r.stopped = append(r.stopped, entry)
+	return nil
+}
+
+func (r *timeEntryRepoStub) ListTimeEntries(ctx context.Context, userID int64, filter ports.TimeEntryFilter) ([]entities.TimeEntry, error) {
+	r.listFilter = filter
+	if !r.filterListed {
+		return r.listed, nil
+	}
+
+	var listed []entities.TimeEntry
+	for _, entry := range r.listed {
+		startedAt := storedWallClock(entry.StartedAt)
+		if startedAt.Before(storedWallClock(*filter.From)) || !startedAt.Before(storedWallClock(*filter.To)) {
+			continue
+		}
+		listed = append(listed, entry)
+	}
+	return listed, nil
+}
+
+func (r *timeEntryRepoStub) DeleteTimeEntry(ctx context.Context, userID, taskID, entryID int64) error {
+	return nil
+}
+
+func TestTimeTracking_StartStopsRunningTimer(t *testing.T) {
+	now := time.Date(2024, 12, 4, 10, 0, 0, 0, time.UTC)
+	entries := &timeEntryRepoStub{}
+	analyticsCh := make(chan ports.AnalyticsEvent, 2)
+	svc := NewTimeTrackingService(entries, &repoMock{},
+		WithTimeTrackingAnalytics(analyticsStub{ch: analyticsCh}),
+		WithTimeTrackingClock(func() time.Time { return now }),
+	)
+
+	first, err := svc.StartTimer(context.Background(), 1, 10, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	now = now.Add(25 * time.Minute)
+	second, err := svc.StartTimer(context.Background(), 1, 20, " review ")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if first.Running() || first.Duration != 25*time.Minute {
+		t.Fatalf("expected first timer stopped after 25m, got %+v", first)
+	}
+	if !second.Running() || second.TaskID != 20 || second.Note != "review" {
+		t.Fatalf("unexpected second timer: %+v", second)
+	}
+
+	select {
+	case event := <-analyticsCh:
+		if event.Type != analyticsv1.TaskEventType_TASK_EVENT_TYPE_TIME_TRACKED || event.TaskID != 10 || event.Duration != 25*time.Minute {
+			t.Fatalf("unexpected analytics event: %+v", event)
+		}
+	default:
+		t.Fatalf("expected time tracked event")
+	}
+}
+
+func TestTimeTracking_StartConflictsWithConcurrentStart(t *testing.T) {
+	entries := &timeEntryRepoStub{
+		running: &entities.TimeEntry{ID: 1, UserID: 1, TaskID: 10, StartedAt: time.Now().Add(-time.Minute)},
+		stopErr: domain.ErrNoRunningTimer,
+	}
+	svc := NewTimeTrackingService(entries, &repoMock{})
+
+	if _, err := svc.StartTimer(context.Background(), 1, 20, ""); !errors.Is(err, domain.ErrTimerRunning) {
+		t.Fatalf("expected timer running conflict, got %v", err)
+	}
+	if len(entries.created) != 0 {
+		t.Fatalf("expected no timer to be created, got %+v", entries.created)
+	}
+}
+
+func TestTimeTracking_StopWithoutTimer(t *testing.T) {
+	svc := NewTimeTrackingService(&timeEntryRepoStub{}, &repoMock{})
+
+	if _, err := svc.StopTimer(context.Background(), 1); !errors.Is(err, domain.ErrNoRunningTimer) {
+		t.Fatalf("expected no running timer, got %v", err)
+	}
+}
+
+func TestTimeTracking_StopTaskTimer(t *testing.T) {
+	started := time.Date(2024, 12, 4, 10, 0, 0, 0, time.UTC)
+	entries := &timeEntryRepoStub{running: &entities.TimeEntry{ID: 1, UserID: 1, TaskID: 10, StartedAt: started}}
+	svc := NewTimeTrackingService(entries, &repoMock{},
+		WithTimeTrackingClock(func() time.Time { return started.Add(time.Hour) }),
+	)
+
+	if err := svc.StopTaskTimer(context.Background(), 1, 99); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if entries.running == nil {
+		t.Fatalf("timer of another task must keep running")
+	}
+
+	if err := svc.StopTaskTimer(context.Background(), 1, 10); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if entries.running != nil || len(entries.stopped) != 1 || entries.stopped[0].Duration != time.Hour {
+		t.Fatalf("expected timer stopped after 1h, got %+v", entries.stopped)
+	}
+
+	if err := svc.StopTaskTimer(context.Background(), 1, 10); err != nil {
+		t.Fatalf("expected no-op without a running timer, got %v", err)
+	}
+}
+
+func TestTimeTracking_AddTimeEntryValidation(t *testing.T) {
+	now := time.Date(2024, 12, 4, 10, 0, 0, 0, time.UTC)
+	svc := NewTimeTrackingService(&timeEntryRepoStub{}, &repoMock{},
+		WithTimeTrackingClock(func() time.Time { return now }),
+	)
+
+	tests := []struct {
+		name    string
+		input   ports.AddTimeEntryInput
+		wantErr bool
+	}{
+		{"valid", ports.AddTimeEntryInput{StartedAt: now.Add(-2 * time.Hour), Duration: time.Hour}, false},
+		{"zero duration", ports.AddTimeEntryInput{StartedAt: now.Add(-2 * time.Hour)}, true},
+		{"too long", ports.AddTimeEntryInput{StartedAt: now.Add(-48 * time.Hour), Duration: 25 * time.Hour}, true},
+		{"ends in future", ports.AddTimeEntryInput{StartedAt: now.Add(-time.Minute), Duration: time.Hour}, true},
+		{"missing start", ports.AddTimeEntryInput{Duration: time.Hour}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.input.UserID = 1
+			tt.input.TaskID = 10
+			entry, err := svc.AddTimeEntry(context.Background(), tt.input)
+			if tt.wantErr {
+				if !errors.Is(err, domain.ErrValidationFailed) {
+					t.Fatalf("expected validation error, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if entry.EndedAt == nil || !entry.EndedAt.Equal(now.Add(-time.Hour)) {
+				t.Fatalf("unexpected end: %v", entry.EndedAt)
+			}
+		})
+	}
+}
+
+func TestTimeTracking_TimeReport(t *testing.T) {
+	msk := time.FixedZone("MSK", 3*60*60)
+	// Wednesday.
+	now := time.Date(2024, 12, 4, 10, 0, 0, 0, msk)
+	work := int64(7)
+	ended := now
+
+	entries := &timeEntryRepoStub{listed: []entities.TimeEntry{
+		// 23:30 UTC on Monday is already Tuesday in Moscow.
+		{TaskID: 1, TaskTitle: "Write", CategoryID: &work, CategoryName: "Work", StartedAt: time.Date(2024, 12, 2, 23, 30, 0, 0, time.UTC), EndedAt: &ended, Duration: time.Hour},
+		{TaskID: 2, TaskTitle: "Read", StartedAt: time.Date(2024, 12, 2, 8, 0, 0, 0, time.UTC), EndedAt: &ended, Duration: 30 * time.Minute},
+		{TaskID: 1, TaskTitle: "Write", CategoryID: &work, CategoryName: "Work", StartedAt: time.Date(2024, 12, 4, 6, 0, 0, 0, time.UTC), EndedAt: &ended, Duration: 2 * time.Hour},
+		{TaskID: 3, TaskTitle: "Running", StartedAt: time.Date(2024, 12, 4, 6, 0, 0, 0, time.UTC)},
+	}}
+	svc := NewTimeTrackingService(entries, &repoMock{},
+		WithTimeTrackingUserDirectory(userDirStub{user: &ports.UserInfo{ID: 1, Active: true, Timezone: "Europe/Moscow"}}),
+		WithTimeTrackingClock(func() time.Time { return now }),
+	)
+
+	report, err := svc.TimeReport(context.Background(), ports.TimeReportInput{UserID: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := report.From.Format(time.DateOnly); got != "2024-12-02" {
+		t.Fatalf("expected report to start on Monday, got %s", got)
+	}
+	if len(report.ByDay) != 7 {
+		t.Fatalf("expected 7 days, got %d", len(report.ByDay))
+	}
+	if !entries.listFilter.To.Equal(time.Date(2024, 12, 9, 0, 0, 0, 0, report.From.Location())) {
+		t.Fatalf("unexpected filter end: %v", entries.listFilter.To)
+	}
+	if report.Total != 3*time.Hour+30*time.Minute {
+		t.Fatalf("unexpected total: %v", report.Total)
+	}
+	if report.ByDay[0].Duration != 30*time.Minute || report.ByDay[1].Duration != time.Hour || report.ByDay[2].Duration != 2*time.Hour {
+		t.Fatalf("unexpected days: %+v", report.ByDay[:3])
+	}
+	if len(report.ByCategory) != 2 || report.ByCategory[0].Name != "Work" || report.ByCategory[1].CategoryID != nil {
+		t.Fatalf("unexpected categories: %+v", report.ByCategory)
+	}
+	if len(report.ByTask) != 2 || report.ByTask[0].TaskID != 1 || report.ByTask[0].Duration != 3*time.Hour {
+		t.Fatalf("unexpected tasks: %+v", report.ByTask)
+	}
+}
+
+func TestTimeTracking_TimeReportAroundLocalMidnight(t *testing.T) {
+	now := time.Date(2024, 12, 4, 10, 0, 0, 0, time.UTC)
+	ended := now
+	entry := func(taskID int64, startedAt time.Time) entities.TimeEntry {
+		return entities.TimeEntry{TaskID: taskID, StartedAt: startedAt, EndedAt: &ended, Duration: time.Minute}
+	}
+
+	// The Moscow week of December 2 runs from 21:00 UTC on December 1 to
+	// 21:00 UTC on December 8.
+	entries := &timeEntryRepoStub{filterListed: true, listed: []entities.TimeEntry{
+		entry(1, time.Date(2024, 12, 1, 20, 50, 0, 0, time.UTC)),
+		entry(2, time.Date(2024, 12, 1, 21, 10, 0, 0, time.UTC)),
+		entry(3, time.Date(2024, 12, 8, 20, 50, 0, 0, time.UTC)),
+		entry(4, time.Date(2024, 12, 8, 21, 10, 0, 0, time.UTC)),
+	}}
+	svc := NewTimeTrackingService(entries, &repoMock{},
+		WithTimeTrackingUserDirectory(userDirStub{user: &ports.UserInfo{ID: 1, Active: true, Timezone: "Europe/Moscow"}}),
+		WithTimeTrackingClock(func() time.Time { return now }),
+	)
+
+	report, err := svc.TimeReport(context.Background(), ports.TimeReportInput{UserID: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if report.Total != 2*time.Minute {
+		t.Fatalf("expected the two entries inside the local week, got total %v", report.Total)
+	}
+	if report.ByDay[0].Duration != time.Minute || report.ByDay[6].Duration != time.Minute {
+		t.Fatalf("expected entries on the first and last day, got %+v", report.ByDay)
+	}
+	for _, task := range report.ByTask {
+		if task.TaskID != 2 && task.TaskID != 3 {
+			t.Fatalf("unexpected task outside the local week: %+v", report.ByTask)
+		}
+	}
+}
+
+func TestTimeTracking_TimeReportRange(t *testing.T) {
+	svc := NewTimeTrackingService(&timeEntryRepoStub{}, &repoMock{})
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	if _, err := svc.TimeReport(context.Background(), ports.TimeReportInput{UserID: 1, From: from, To: from.AddDate(0, 0, -1)}); !errors.Is(err, domain.ErrValidationFailed) {
+		t.Fatalf("expected validation error for inverted range, got %v", err)
+	}
+	if _, err := svc.TimeReport(context.Background(), ports.TimeReportInput{UserID: 1, From: from, To: from.AddDate(1, 1, 0)}); !errors.Is(err, domain.ErrValidationFailed) {
+		t.Fatalf("expected validation error for long range, got %v", err)
+	}
+}
+
+type timerStopperStub struct {
+	taskID int64
+}
+
+func (s *timerStopperStub) StopTaskTimer(ctx context.Context, userID, taskID int64) error {
+	s.taskID = taskID
+	return nil
+}
+
+func TestUpdateTaskStatus_StopsTimer(t *testing.T) {
+	repo := &repoMock{
+		storedTask: &entities.Task{ID: 5, UserID: 1, Status: entities.TaskStatusInProgress, Priority: entities.TaskPriorityHigh},
+	}
+	timers := &timerStopperStub{}
+	svc := NewTaskService(repo, WithTimerStopper(timers))
+
+	if _, err := svc.UpdateTaskStatus(context.Background(), 1, 5, entities.TaskStatusCompleted); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if timers.taskID != 5 {
+		t.Fatalf("expected timer of task 5 to be stopped, got %d", timers.taskID)
+	}
+}
+
+func TestUpdateTask_CompletingStopsTimerAndSendsEvents(t *testing.T) {
+	repo := &repoMock{
+		storedTask: &entities.Task{ID: 5, UserID: 1, Status: entities.TaskStatusInProgress, Priority: entities.TaskPriorityHigh},
+	}
+	timers := &timerStopperStub{}
+	publishCh := make(chan events.TaskEvent, 1)
+	analyticsCh := make(chan ports.AnalyticsEvent, 1)
+	svc := NewTaskService(
+		repo,
+		WithUserDirectory(userDirStub{user: &ports.UserInfo{ID: 1, Email: "a@b.c", Active: true}}),
+		WithEventPublisher(publisherStub{ch: publishCh}),
+		WithAnalyticsTracker(analyticsStub{ch: analyticsCh}),
+		WithTimerStopper(timers),
+	)
+
+	status := entities.TaskStatusCompleted
+	if _, err := svc.UpdateTask(context.Background(), ports.UpdateTaskInput{UserID: 1, TaskID: 5, Status: &status}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if timers.taskID != 5 {
+		t.Fatalf("expected timer of task 5 to be stopped, got %d", timers.taskID)
+	}
+	if len(publishCh) != 1 || (<-publishCh).Type != events.TaskEventCompleted {
+		t.Fatalf("expected a completion notification")
+	}
+	if len(analyticsCh) != 1 || (<-analyticsCh).Type != analyticsv1.TaskEventType_TASK_EVENT_TYPE_COMPLETED {
+		t.Fatalf("expected a completion analytics event")
+	}
+}
@@ -0,0 +1,347 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"todoapp/services/task-service/internal/domain"
+	"todoapp/services/task-service/internal/domain/entities"
+	"todoapp/services/task-service/internal/ports"
+	"todoapp/services/task-service/internal/service/export"
+)
+
+// maxViewExportTasks matches the cap used by ExportTasks.
+const maxViewExportTasks = 10000
+
+var dateExpressionPattern = regexp.MustCompile(`^([A-Za-z]+)?(?:([+-])(\d{1,4})(` + offsetUnitPattern + `))?$`)
+
+type ViewService struct {
+	views  ports.ViewRepository
+	repo   ports.TaskRepository
+	users  ports.UserDirectory
+	fields ports.CustomFieldLister
+	now    func() time.Time
+}
+
+type ViewServiceOption func(*ViewService)
+
+var _ ports.ViewService = (*ViewService)(nil)
+
+func NewViewService(views ports.ViewRepository, repo ports.TaskRepository, opts ...ViewServiceOption) *ViewService {
+	svc := &ViewService{
+		views: views,
+		repo:  repo,
+		now:   time.Now,
+	}
+	for _, opt := range opts {
+		opt(svc)
+	}
+	return svc
+}
+
+// WithViewUserDirectory enables the active-user check and resolves date
+// expressions in the user's timezone.
+func WithViewUserDirectory(users ports.UserDirectory) ViewServiceOption {
+	return func(s *ViewService) {
+		s.users = users
+	}
+}
+
+// WithViewCustomFields adds the user's custom fields to view exports.
+func WithViewCustomFields(fields ports.CustomFieldLister) ViewServiceOption {
+	return func(s *ViewService) {
+		s.fields = fields
+	}
+}
+
+func WithViewClock(now func() time.Time) ViewServiceOption {
+	return func(s *ViewService) {
+		if now != nil {
+			s.now = now
+		}
+	}
+}
+
+func (s *ViewService) CreateView(ctx context.Context, input ports.CreateViewInput) (*entities.SavedView, error) {
+	if _, err := ensureActiveUser(ctx, s.users, input.UserID); err != nil {
+		return nil, err
+	}
+
+	view := &entities.SavedView{UserID: input.UserID}
+	if err := s.apply(ctx, view, input.Name, input.Filter); err != nil {
+		return nil, err
+	}
+
+	if err := s.views.CreateView(ctx, view); err != nil {
+		return nil, err
+	}
+
+	return view, nil
+}
+
+func (s *ViewService) GetView(ctx context.Context, userID, viewID int64) (*entities.SavedView, error) {
+	if _, err := ensureActiveUser(ctx, s.users, userID); err != nil {
+		return nil, err
+	}
+	return s.views.GetView(ctx, userID, viewID)
+}
+
+func (s *ViewService) ListViews(ctx context.Context, userID int64) ([]entities.SavedView, error) {
+	if _, err := ensureActiveUser(ctx, s.users, userID); err != nil {
+		return nil, err
+	}
+	return s.views.ListViews(ctx, userID)
+}
+
+func (s *ViewService) UpdateView(ctx context.Context, input ports.UpdateViewInput) (*entities.SavedView, error) {
+	if _, err := ensureActiveUser(ctx, s.users, input.UserID); err != nil {
+		return nil, err
+	}
+
+	view, err := s.views.GetView(ctx, input.UserID, input.ViewID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.apply(ctx, view, input.Name, input.Filter); err != nil {
+		return nil, err
+	}
+
+	if err := s.views.UpdateView(ctx, view); err != nil {
+		return nil, err
+	}
+
+	return view, nil
+}
+
+func (s *ViewService) DeleteView(ctx context.Context, userID, viewID int64) error {
+	if _, err := ensureActiveUser(ctx, s.users, userID); err != nil {
+		return err
+	}
+	return s.views.DeleteView(ctx, userID, viewID)
+}
+
+func (s *ViewService) ListViewTasks(ctx context.Context, userID, viewID int64, limit, offset int) ([]entities.Task, error) {
+	filter, err := s.resolve(ctx, userID, viewID)
+	if err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 {
+		limit = 20
+	}
+	if offset < 0 {
+		offset = 0
+	}
+	filter.Limit = limit
+	filter.Offset = offset
+
+	return s.repo.ListTasks(ctx, userID, filter)
+}
+
+func (s *ViewService) ExportView(ctx context.Context, userID, viewID int64, format entities.ExportFormat) ([]byte, string, error) {
+	if !format.IsValid() {
+		return nil, "", domain.ErrValidationFailed.WithMessage("unsupported export format: " + format.String())
+	}
+
+	filter, err := s.resolve(ctx, userID, viewID)
+	if err != nil {
+		return nil, "", err
+	}
+	filter.Limit = maxViewExportTasks
+
+	tasks, err := s.repo.ListTasks(ctx, userID, filter)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var fields []entities.CustomField
+	if s.fields != nil {
+		fields, err = s.fields.ListCustomFields(ctx, userID)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	formatter, err := export.NewFormatter(format, fields...)
+	if err != nil {
+		return nil, "", domain.ErrValidationFailed.WithMessage(err.Error())
+	}
+
+	data, err := formatter.Format(tasks)
+	if err != nil {
+		return nil, "", err
+	}
+
+	filename := "tasks_" + s.now().Format("2006-01-02") + "." + format.FileExtension()
+	return data, filename, nil
+}
+
+// resolve loads a view and turns its stored filter into a task filter with
+// date expressions evaluated against the current time.
+func (s *ViewService) resolve(ctx context.Context, userID, viewID int64) (ports.TaskFilter, error) {
+	user, err := ensureActiveUser(ctx, s.users, userID)
+	if err != nil {
+		return ports.TaskFilter{}, err
+	}
+
+	view, err := s.views.GetView(ctx, userID, viewID)
+	if err != nil {
+		return ports.TaskFilter{}, err
+	}
+
+	now := s.now().In(userLocation(user))
+
+	dueFrom, err := resolveDateExpression(view.Filter.DueFrom, now)
+	if err != nil {
+		return ports.TaskFilter{}, err
+	}
+	dueTo, err := resolveDateExpression(view.Filter.DueTo, now)
+	if err != nil {
+		return ports.TaskFilter{}, err
+	}
+
+	return ports.TaskFilter{
+		Statuses:             view.Filter.Statuses,
+		Priorities:           view.Filter.Priorities,
+		CategoryID:           view.Filter.CategoryID,
+		IncludeSubcategories: view.Filter.IncludeSubcategories,
+		WithoutCategory:      view.Filter.WithoutCategory,
+		Search:               view.Filter.Search,
+		DueFrom:              inUTC(dueFrom),
+		DueTo:                inUTC(dueTo),
+	}, nil
+}
+
+// inUTC converts a bound resolved in the user's location to UTC, the zone due
+// dates are stored in.
+func inUTC(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+	utc := t.UTC()
+	return &utc
+}
+
+// apply validates name and filter and copies them onto view.
+func (s *ViewService) apply(ctx context.Context, view *entities.SavedView, name string, filter entities.ViewFilter) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return domain.ErrValidationFailed.WithMessage("view name is required")
+	}
+
+	for _, status := range filter.Statuses {
+		if !status.IsValid() {
+			return domain.ErrInvalidTaskStatus.WithMessage("unsupported status: " + string(status))
+		}
+	}
+	for _, priority := range filter.Priorities {
+		if err := validateTemplatePriority(priority); err != nil {
+			return err
+		}
+	}
+
+	if filter.WithoutCategory && filter.CategoryID != nil {
+		return domain.ErrValidationFailed.WithMessage("categoryId and withoutCategory are mutually exclusive")
+	}
+	if filter.CategoryID == nil {
+		filter.IncludeSubcategories = false
+	} else if _, err := s.repo.GetCategory(ctx, view.UserID, *filter.CategoryID); err != nil {
+		return err
+	}
+
+	filter.Search = strings.TrimSpace(filter.Search)
+	filter.DueFrom = strings.TrimSpace(filter.DueFrom)
+	filter.DueTo = strings.TrimSpace(filter.DueTo)
+
+	now := s.now()
+	if _, err := resolveDateExpression(filter.DueFrom, now); err != nil {
+		return err
+	}
+	if _, err := resolveDateExpression(filter.DueTo, now); err != nil {
+		return err
+	}
+
+	view.Name = name
+	view.Filter = filter
+
+	return nil
+}
+
+// resolveDateExpression evaluates a date expression relative to now, keeping
+// now's location. Supported forms are an anchor (now, today, tomorrow,
+// yesterday, endOfDay, startOfWeek, endOfWeek, startOfMonth, endOfMonth)
+// optionally followed by an offset such as +7d, -2w, +3h, +30m or +1mo;
+// a bare offset is relative to now. Absolute YYYY-MM-DD and RFC3339 values
+// are accepted as well. An empty expression means no bound.
+func resolveDateExpression(expr string, now time.Time) (*time.Time, error) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return nil, nil
+	}
+
+	if parsed, err := time.Parse(time.RFC3339, expr); err == nil {
+		return &parsed, nil
+	}
+	if parsed, err := time.ParseInLocation("2006-01-02", expr, now.Location()); err == nil {
+		return &parsed, nil
+	}
+
+	invalid := domain.ErrValidationFailed.WithMessage(fmt.Sprintf("invalid date expression %q, expected e.g. today, +7d or startOfWeek", expr))
+
+	match := dateExpressionPattern.FindStringSubmatch(expr)
+	if match == nil || (match[1] == "" && match[2] == "") {
+		return nil, invalid
+	}
+
+	year, month, day := now.Date()
+	startOfDay := time.Date(year, month, day, 0, 0, 0, 0, now.Location())
+	// Monday-based weeks.
+	weekday := (int(now.Weekday()) + 6) % 7
+	startOfWeek := startOfDay.AddDate(0, 0, -weekday)
+	startOfMonth := time.Date(year, month, 1, 0, 0, 0, 0, now.Location())
+
+	// End-of anchors are inclusive upper bounds, one microsecond before the
+	// next period starts, matching the timestamp precision of the database.
+	endOf := func(next time.Time) time.Time {
+		return next.Add(-time.Microsecond)
+	}
+
+	var resolved time.Time
+	switch strings.ToLower(match[1]) {
+	case "", "now":
+		resolved = now
+	case "today":
+		resolved = startOfDay
+	case "tomorrow":
+		resolved = startOfDay.AddDate(0, 0, 1)
+	case "yesterday":
+		resolved = startOfDay.AddDate(0, 0, -1)
+	case "endofday":
+		resolved = endOf(startOfDay.AddDate(0, 0, 1))
+	case "startofweek":
+		resolved = startOfWeek
+	case "endofweek":
+		resolved = endOf(startOfWeek.AddDate(0, 0, 7))
+	case "startofmonth":
+		resolved = startOfMonth
+	case "endofmonth":
+		resolved = endOf(startOfMonth.AddDate(0, 1, 0))
+	default:
+		return nil, invalid
+	}
+
+	if match[2] != "" {
+		amount, _ := strconv.Atoi(match[3])
+		if match[2] == "-" {
+			amount = -amount
+		}
+		resolved = addOffset(resolved, amount, match[4])
+	}
+
+	return &resolved, nil
+}
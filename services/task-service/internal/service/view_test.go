@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"todoapp/services/task-service/internal/domain"
+	"todoapp/services/task-service/internal/domain/entities"
+	"todoapp/services/task-service/internal/ports"
+)
+
+type viewRepoStub struct {
+	stored  *entities.SavedView
+	created *entities.SavedView
+}
+
+func (r *viewRepoStub) CreateView(ctx context.Context, view *entities.SavedView) error {
+	r.created = view
+	view.ID = 1
+	return nil
+}
+
+func (r *viewRepoStub) GetView(ctx context.Context, userID, viewID int64) (*entities.SavedView, error) {
+	if r.stored == nil {
+		return nil, domain.ErrViewNotFound
+	}
+	return r.stored, nil
+}
+
+func (r *viewRepoStub) ListViews(ctx context.Context, userID int64) ([]entities.SavedView, error) {
+	return nil, nil
+}
+
+func (r *viewRepoStub) UpdateView(ctx context.Context, view *entities.SavedView) error {
+	return nil
+}
+
+func (r *viewRepoStub) DeleteView(ctx context.Context, userID, viewID int64) error {
+	return nil
+}
+
+func TestResolveDateExpression(t *testing.T) {
+	msk := time.FixedZone("MSK", 3*60*60)
+	// Wednesday.
+	now := time.Date(2024, 12, 4, 10, 30, 0, 0, msk)
+	at := func(month time.Month, day, hour, minute int) time.Time {
+		return time.Date(2024, month, day, hour, minute, 0, 0, msk)
+	}
+	endOf := func(next time.Time) time.Time {
+		return next.Add(-time.Microsecond)
+	}
+
+	tests := []struct {
+		expr string
+		want time.Time
+	}{
+		{"now", now},
+		{"today", at(12, 4, 0, 0)},
+		{"tomorrow", at(12, 5, 0, 0)},
+		{"yesterday", at(12, 3, 0, 0)},
+		{"endOfDay", endOf(at(12, 5, 0, 0))},
+		{"startOfWeek", at(12, 2, 0, 0)},
+		{"endOfWeek", endOf(at(12, 9, 0, 0))},
+		{"startOfMonth", at(12, 1, 0, 0)},
+		{"endOfMonth", endOf(time.Date(2025, 1, 1, 0, 0, 0, 0, msk))},
+		{"+7d", at(12, 11, 10, 30)},
+		{"-3h", at(12, 4, 7, 30)},
+		{"today+7d", at(12, 11, 0, 0)},
+		{"startOfWeek+1w", at(12, 9, 0, 0)},
+		{"startofmonth-1mo", at(11, 1, 0, 0)},
+		{"now+30m", at(12, 4, 11, 0)},
+		{"2024-12-20", at(12, 20, 0, 0)},
+		{"2024-12-20T08:00:00Z", time.Date(2024, 12, 20, 8, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			got, err := resolveDateExpression(tt.expr, now)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got == nil || !got.Equal(tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+
+	if got, err := resolveDateExpression("", now); err != nil || got != nil {
+		t.Fatalf("expected no bound for empty expression, got %v, %v", got, err)
+	}
+
+	for _, expr := range []string{"someday", "+7x", "today+", "7d", "next week"} {
+		if _, err := resolveDateExpression(expr, now); !errors.Is(err, domain.ErrValidationFailed) {
+			t.Fatalf("%q: expected validation error, got %v", expr, err)
+		}
+	}
+}
+
+func TestListViewTasksResolvesInUserTimezone(t *testing.T) {
+	categoryID := int64(5)
+	views := &viewRepoStub{stored: &entities.SavedView{
+		ID:     1,
+		UserID: 42,
+		Name:   "Due this week in #work",
+		Filter: entities.ViewFilter{
+			Priorities:           []entities.TaskPriority{entities.TaskPriorityHigh},
+			CategoryID:           &categoryID,
+			IncludeSubcategories: true,
+			DueFrom:              "startOfWeek",
+			DueTo:                "endOfWeek",
+		},
+	}}
+	repo := &repoMock{}
+	users := userDirStub{user: &ports.UserInfo{ID: 42, Active: true, Timezone: "Asia/Tokyo"}}
+	// Sunday 20:00 UTC is already Monday in Tokyo.
+	now := time.Date(2024, 12, 8, 20, 0, 0, 0, time.UTC)
+
+	svc := NewViewService(views, repo, WithViewUserDirectory(users), WithViewClock(func() time.Time { return now }))
+
+	if _, err := svc.ListViewTasks(context.Background(), 42, 1, 0, -5); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	filter := repo.listFilter
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+	wantFrom := time.Date(2024, 12, 9, 0, 0, 0, 0, tokyo)
+	// The bounds are compared with the stored wall-clock time of due dates.
+	if filter.DueFrom == nil || !storedWallClock(*filter.DueFrom).Equal(wantFrom) {
+		t.Fatalf("expected dueFrom %v, got %v", wantFrom, filter.DueFrom)
+	}
+	if filter.DueTo == nil || !storedWallClock(*filter.DueTo).Equal(wantFrom.AddDate(0, 0, 7).Add(-time.Microsecond)) {
+		t.Fatalf("unexpected dueTo %v", filter.DueTo)
+	}
+	if filter.CategoryID == nil || *filter.CategoryID != categoryID || !filter.IncludeSubcategories {
+		t.Fatalf("category filter not applied: %+v", filter)
+	}
+	if len(filter.Priorities) != 1 || filter.Priorities[0] != entities.TaskPriorityHigh {
+		t.Fatalf("priority filter not applied: %+v", filter.Priorities)
+	}
+	if filter.Limit != 20 || filter.Offset != 0 {
+		t.Fatalf("unexpected pagination: limit=%d offset=%d", filter.Limit, filter.Offset)
+	}
+}
+
+func TestCreateViewValidation(t *testing.T) {
+	categoryID := int64(5)
+
+	tests := []struct {
+		name   string
+		input  ports.CreateViewInput
+		repo   *repoMock
+		wantOK bool
+	}{
+		{
+			name:   "no category",
+			input:  ports.CreateViewInput{UserID: 42, Name: "No category", Filter: entities.ViewFilter{WithoutCategory: true}},
+			repo:   &repoMock{},
+			wantOK: true,
+		},
+		{
+			name:  "missing name",
+			input: ports.CreateViewInput{UserID: 42, Name: "  "},
+			repo:  &repoMock{},
+		},
+		{
+			name:  "invalid date expression",
+			input: ports.CreateViewInput{UserID: 42, Name: "Soon", Filter: entities.ViewFilter{DueTo: "soonish"}},
+			repo:  &repoMock{},
+		},
+		{
+			name:  "category and without category",
+			input: ports.CreateViewInput{UserID: 42, Name: "Both", Filter: entities.ViewFilter{CategoryID: &categoryID, WithoutCategory: true}},
+			repo:  &repoMock{},
+		},
+		{
+			name:  "unknown category",
+			input: ports.CreateViewInput{UserID: 42, Name: "Work", Filter: entities.ViewFilter{CategoryID: &categoryID}},
+			repo:  &repoMock{categoryErr: domain.ErrCategoryNotFound},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			views := &viewRepoStub{}
+			svc := NewViewService(views, tt.repo)
+
+			_, err := svc.CreateView(context.Background(), tt.input)
+			if tt.wantOK {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected error")
+			}
+			if views.created != nil {
+				t.Fatalf("view must not be stored on error")
+			}
+		})
+	}
+}
@@ -0,0 +1,339 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"todoapp/pkg/events"
+	"todoapp/services/task-service/internal/domain"
+	"todoapp/services/task-service/internal/domain/entities"
+	"todoapp/services/task-service/internal/ports"
+)
+
+const (
+	maxWebhooksPerUser     = 10
+	maxWebhookURLLength    = 2048
+	webhookSecretBytes     = 32
+	webhookDeliveryLogSize = 50
+	webhookSendTimeout     = 10 * time.Second
+	// webhookTestLease keeps the worker away from a test delivery while it is
+	// being sent synchronously.
+	webhookTestLease = 5 * time.Minute
+)
+
+// webhookEventTypes are the task events users can subscribe to.
+var webhookEventTypes = map[events.TaskEventType]bool{
+	events.TaskEventCreated:   true,
+	events.TaskEventUpdated:   true,
+	events.TaskEventCompleted: true,
+	events.TaskEventDeleted:   true,
+	events.TaskEventCommented: true,
+}
+
+// WebhookService manages user webhooks and queues task events for them. It is
+// the TaskService's webhook dispatcher; WebhookWorker sends the queued
+// deliveries.
+type WebhookService struct {
+	webhooks ports.WebhookRepository
+	sender   ports.WebhookSender
+	users    ports.UserDirectory
+	now      func() time.Time
+}
+
+type WebhookServiceOption func(*WebhookService)
+
+var (
+	_ ports.WebhookService    = (*WebhookService)(nil)
+	_ ports.WebhookDispatcher = (*WebhookService)(nil)
+)
+
+func NewWebhookService(webhooks ports.WebhookRepository, sender ports.WebhookSender, opts ...WebhookServiceOption) *WebhookService {
+	svc := &WebhookService{
+		webhooks: webhooks,
+		sender:   sender,
+		now:      time.Now,
+	}
+	for _, opt := range opts {
+		opt(svc)
+	}
+	return svc
+}
+
+func WithWebhookUserDirectory(users ports.UserDirectory) WebhookServiceOption {
+	return func(s *WebhookService) {
+		s.users = users
+	}
+}
+
+func WithWebhookClock(now func() time.Time) WebhookServiceOption {
+	return func(s *WebhookService) {
+		if now != nil {
+			s.now = now
+		}
+	}
+}
+
+func (s *WebhookService) CreateWebhook(ctx context.Context, input ports.CreateWebhookInput) (*entities.Webhook, error) {
+	if _, err := ensureActiveUser(ctx, s.users, input.UserID); err != nil {
+		return nil, err
+	}
+
+	webhookURL, err := normalizeWebhookURL(input.URL)
+	if err != nil {
+		return nil, err
+	}
+	eventTypes, err := normalizeWebhookEventTypes(input.EventTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.webhooks.CountWebhooks(ctx, input.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if count >= maxWebhooksPerUser {
+		return nil, domain.ErrWebhookLimit
+	}
+
+	secret, err := newWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	webhook := &entities.Webhook{
+		UserID:     input.UserID,
+		URL:        webhookURL,
+		Secret:     secret,
+		EventTypes: eventTypes,
+		Active:     true,
+	}
+	if err := s.webhooks.CreateWebhook(ctx, webhook); err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+func (s *WebhookService) ListWebhooks(ctx context.Context, userID int64) ([]entities.Webhook, error) {
+	if _, err := ensureActiveUser(ctx, s.users, userID); err != nil {
+		return nil, err
+	}
+	return s.webhooks.ListWebhooks(ctx, userID)
+}
+
+func (s *WebhookService) GetWebhook(ctx context.Context, userID, webhookID int64) (*entities.Webhook, error) {
+	if _, err := ensureActiveUser(ctx, s.users, userID); err != nil {
+		return nil, err
+	}
+	return s.webhooks.GetWebhook(ctx, userID, webhookID)
+}
+
+func (s *WebhookService) UpdateWebhook(ctx context.Context, input ports.UpdateWebhookInput) (*entities.Webhook, error) {
+	if _, err := ensureActiveUser(ctx, s.users, input.UserID); err != nil {
+		return nil, err
+	}
+
+	webhook, err := s.webhooks.GetWebhook(ctx, input.UserID, input.WebhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.URL != nil {
+		webhookURL, err := normalizeWebhookURL(*input.URL)
+		if err != nil {
+			return nil, err
+		}
+		webhook.URL = webhookURL
+	}
+
+	if input.EventTypes != nil {
+		eventTypes, err := normalizeWebhookEventTypes(input.EventTypes)
+		if err != nil {
+			return nil, err
+		}
+		webhook.EventTypes = eventTypes
+	}
+
+	if input.Active != nil && *input.Active != webhook.Active {
+		webhook.Active = *input.Active
+		if webhook.Active {
+			webhook.ConsecutiveFailures = 0
+			webhook.DisabledAt = nil
+		}
+	}
+
+	if err := s.webhooks.UpdateWebhook(ctx, webhook); err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+func (s *WebhookService) DeleteWebhook(ctx context.Context, userID, webhookID int64) error {
+	if _, err := ensureActiveUser(ctx, s.users, userID); err != nil {
+		return err
+	}
+	return s.webhooks.DeleteWebhook(ctx, userID, webhookID)
+}
+
+func (s *WebhookService) ListWebhookDeliveries(ctx context.Context, userID, webhookID int64) ([]entities.WebhookDelivery, error) {
+	if _, err := ensureActiveUser(ctx, s.users, userID); err != nil {
+		return nil, err
+	}
+	if _, err := s.webhooks.GetWebhook(ctx, userID, webhookID); err != nil {
+		return nil, err
+	}
+	return s.webhooks.ListWebhookDeliveries(ctx, webhookID, webhookDeliveryLogSize)
+}
+
+func (s *WebhookService) SendTestEvent(ctx context.Context, userID, webhookID int64) (*entities.WebhookDelivery, error) {
+	if _, err := ensureActiveUser(ctx, s.users, userID); err != nil {
+		return nil, err
+	}
+
+	webhook, err := s.webhooks.GetWebhook(ctx, userID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	event := events.TaskEvent{
+		ID:        uuid.NewString(),
+		Type:      entities.WebhookTestEvent,
+		UserID:    userID,
+		CreatedAt: now,
+	}
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery := &entities.WebhookDelivery{
+		WebhookID:     webhook.ID,
+		EventID:       event.ID,
+		EventType:     event.Type,
+		Payload:       payload,
+		Status:        entities.WebhookDeliveryPending,
+		NextAttemptAt: now.Add(webhookTestLease),
+	}
+	if err := s.webhooks.CreateWebhookDelivery(ctx, delivery); err != nil {
+		return nil, err
+	}
+
+	attempt := sendWebhookDelivery(ctx, s.sender, webhook.URL, webhook.Secret, *delivery, s.now)
+	attempt.Test = true
+	if _, err := s.webhooks.RecordWebhookAttempt(ctx, attempt, 0); err != nil {
+		return nil, err
+	}
+
+	delivery.Attempts = 1
+	delivery.ResponseCode = attempt.ResponseCode
+	delivery.LastError = attempt.Error
+	delivery.CompletedAt = &attempt.At
+	delivery.Status = entities.WebhookDeliveryFailed
+	if attempt.Succeeded {
+		delivery.Status = entities.WebhookDeliverySucceeded
+	}
+
+	return delivery, nil
+}
+
+// DispatchTaskEvent queues the event for every active webhook of its user
+// that subscribes to it. It runs inside the task transaction, so deliveries
+// exist exactly when the change does.
+func (s *WebhookService) DispatchTaskEvent(ctx context.Context, event events.TaskEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	_, err = s.webhooks.EnqueueWebhookDeliveries(ctx, event.UserID, event, payload)
+	return err
+}
+
+// sendWebhookDelivery makes one attempt and describes its outcome. Only a 2xx
+// response counts as success.
+func sendWebhookDelivery(ctx context.Context, sender ports.WebhookSender, webhookURL, secret string, delivery entities.WebhookDelivery, now func() time.Time) ports.WebhookAttempt {
+	sendCtx, cancel := context.WithTimeout(ctx, webhookSendTimeout)
+	code, err := sender.Send(sendCtx, ports.WebhookRequest{
+		URL:        webhookURL,
+		Secret:     secret,
+		DeliveryID: delivery.ID,
+		EventType:  delivery.EventType,
+		Payload:    delivery.Payload,
+		Timestamp:  now(),
+	})
+	cancel()
+
+	attempt := ports.WebhookAttempt{
+		DeliveryID: delivery.ID,
+		WebhookID:  delivery.WebhookID,
+		At:         now(),
+	}
+
+	switch {
+	case err != nil:
+		attempt.Error = err.Error()
+	case code < 200 || code > 299:
+		attempt.ResponseCode = &code
+		attempt.Error = fmt.Sprintf("unexpected response status %d", code)
+	default:
+		attempt.ResponseCode = &code
+		attempt.Succeeded = true
+	}
+
+	return attempt
+}
+
+func normalizeWebhookURL(raw string) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", domain.ErrValidationFailed.WithMessage("webhook url is required")
+	}
+	if len(raw) > maxWebhookURLLength {
+		return "", domain.ErrValidationFailed.WithMessage("webhook url is too long")
+	}
+
+	parsed, err := url.Parse(raw)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return "", domain.ErrValidationFailed.WithMessage("webhook url must be an absolute http or https url")
+	}
+	if parsed.User != nil {
+		return "", domain.ErrValidationFailed.WithMessage("webhook url must not contain credentials")
+	}
+
+	return parsed.String(), nil
+}
+
+func normalizeWebhookEventTypes(eventTypes []events.TaskEventType) ([]events.TaskEventType, error) {
+	seen := make(map[events.TaskEventType]bool, len(eventTypes))
+	normalized := make([]events.TaskEventType, 0, len(eventTypes))
+
+	for _, eventType := range eventTypes {
+		if !webhookEventTypes[eventType] {
+			return nil, domain.ErrValidationFailed.WithMessage("unsupported webhook event type: " + string(eventType))
+		}
+		if seen[eventType] {
+			continue
+		}
+		seen[eventType] = true
+		normalized = append(normalized, eventType)
+	}
+
+	return normalized, nil
+}
+
+func newWebhookSecret() (string, error) {
+	buf := make([]byte, webhookSecretBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
@@ -0,0 +1,234 @@
+package service
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"todoapp/pkg/logging"
+	"todoapp/services/task-service/internal/ports"
+)
+
+const (
+	defaultWebhookInterval     = 5 * time.Second
+	defaultWebhookBatchSize    = 20
+	defaultWebhookBaseBackoff  = 30 * time.Second
+	defaultWebhookMaxBackoff   = time.Hour
+	defaultWebhookMaxAttempts  = 8
+	defaultWebhookDisableAfter = 20
+	defaultWebhookRetention    = 30 * 24 * time.Hour
+	// webhookLease must outlast sending a batch; a worker that crashes
+	// mid-batch leaves its deliveries to be retried once the lease expires.
+	webhookLease         = 2 * webhookSendTimeout
+	webhookPurgeInterval = time.Hour
+)
+
+// WebhookWorker sends queued webhook deliveries. Deliveries are leased before
+// they are sent, so several workers can run side by side; a lost lease sends
+// the delivery again, making delivery at-least-once. Failed deliveries are
+// retried with exponential backoff up to a maximum number of attempts, and a
+// webhook is disabled after too many failures in a row.
+type WebhookWorker struct {
+	webhooks     ports.WebhookRepository
+	sender       ports.WebhookSender
+	interval     time.Duration
+	batchSize    int
+	baseBackoff  time.Duration
+	maxBackoff   time.Duration
+	maxAttempts  int
+	disableAfter int
+	retention    time.Duration
+	now          func() time.Time
+	logger       *slog.Logger
+}
+
+type WebhookWorkerOption func(*WebhookWorker)
+
+func NewWebhookWorker(webhooks ports.WebhookRepository, sender ports.WebhookSender, opts ...WebhookWorkerOption) *WebhookWorker {
+	worker := &WebhookWorker{
+		webhooks:     webhooks,
+		sender:       sender,
+		interval:     defaultWebhookInterval,
+		batchSize:    defaultWebhookBatchSize,
+		baseBackoff:  defaultWebhookBaseBackoff,
+		maxBackoff:   defaultWebhookMaxBackoff,
+		maxAttempts:  defaultWebhookMaxAttempts,
+		disableAfter: defaultWebhookDisableAfter,
+		retention:    defaultWebhookRetention,
+		now:          time.Now,
+		logger:       logging.Discard(),
+	}
+	for _, opt := range opts {
+		opt(worker)
+	}
+	return worker
+}
+
+func WithWebhookInterval(interval time.Duration) WebhookWorkerOption {
+	return func(w *WebhookWorker) {
+		if interval > 0 {
+			w.interval = interval
+		}
+	}
+}
+
+func WithWebhookBatchSize(size int) WebhookWorkerOption {
+	return func(w *WebhookWorker) {
+		if size > 0 {
+			w.batchSize = size
+		}
+	}
+}
+
+// WithWebhookBackoff sets the delay after the first failed attempt and its cap.
+func WithWebhookBackoff(base, maxDelay time.Duration) WebhookWorkerOption {
+	return func(w *WebhookWorker) {
+		if base > 0 {
+			w.baseBackoff = base
+		}
+		if maxDelay >= w.baseBackoff {
+			w.maxBackoff = maxDelay
+		}
+	}
+}
+
+// WithWebhookMaxAttempts sets how many times a delivery is tried before it is
+// marked as failed.
+func WithWebhookMaxAttempts(attempts int) WebhookWorkerOption {
+	return func(w *WebhookWorker) {
+		if attempts > 0 {
+			w.maxAttempts = attempts
+		}
+	}
+}
+
+// WithWebhookDisableAfter sets how many failed attempts in a row disable a
+// webhook.
+func WithWebhookDisableAfter(failures int) WebhookWorkerOption {
+	return func(w *WebhookWorker) {
+		if failures > 0 {
+			w.disableAfter = failures
+		}
+	}
+}
+
+// WithWebhookRetention sets how long finished deliveries stay in the log.
+func WithWebhookRetention(retention time.Duration) WebhookWorkerOption {
+	return func(w *WebhookWorker) {
+		if retention > 0 {
+			w.retention = retention
+		}
+	}
+}
+
+func WithWebhookWorkerClock(now func() time.Time) WebhookWorkerOption {
+	return func(w *WebhookWorker) {
+		if now != nil {
+			w.now = now
+		}
+	}
+}
+
+func WithWebhookLogger(logger *slog.Logger) WebhookWorkerOption {
+	return func(w *WebhookWorker) {
+		if logger != nil {
+			w.logger = logger
+		}
+	}
+}
+
+// Run sends due deliveries on every interval until ctx is cancelled and purges
+// old finished deliveries once an hour.
+func (w *WebhookWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	var lastPurge time.Time
+
+	for {
+		if _, err := w.Deliver(ctx); err != nil && ctx.Err() == nil {
+			w.logger.ErrorContext(ctx, "webhook delivery failed", "error", err)
+		}
+
+		if now := w.now(); now.Sub(lastPurge) >= webhookPurgeInterval {
+			lastPurge = now
+			if purged, err := w.webhooks.PurgeWebhookDeliveries(ctx, now.Add(-w.retention)); err != nil {
+				w.logger.ErrorContext(ctx, "webhook delivery purge failed", "error", err)
+			} else if purged > 0 {
+				w.logger.InfoContext(ctx, "webhook deliveries purged", "purged", purged)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Deliver sends batches until no delivery is due and returns how many
+// attempts were made. The deliveries of a batch are sent concurrently so one
+// slow endpoint does not hold up the others.
+func (w *WebhookWorker) Deliver(ctx context.Context) (int, error) {
+	attempted := 0
+
+	for {
+		now := w.now()
+		due, err := w.webhooks.LeaseDueWebhookDeliveries(ctx, now, now.Add(webhookLease), w.batchSize)
+		if err != nil {
+			return attempted, err
+		}
+
+		var (
+			wg       sync.WaitGroup
+			mu       sync.Mutex
+			firstErr error
+		)
+		for _, item := range due {
+			wg.Go(func() {
+				if err := w.attempt(ctx, item); err != nil {
+					mu.Lock()
+					if firstErr == nil {
+						firstErr = err
+					}
+					mu.Unlock()
+				}
+			})
+		}
+		wg.Wait()
+
+		attempted += len(due)
+		if firstErr != nil {
+			return attempted, firstErr
+		}
+		// Leased deliveries are not due again, so a short batch means
+		// nothing is left.
+		if len(due) < w.batchSize {
+			return attempted, nil
+		}
+	}
+}
+
+func (w *WebhookWorker) attempt(ctx context.Context, item ports.DueWebhookDelivery) error {
+	delivery := item.Delivery
+	attempt := sendWebhookDelivery(ctx, w.sender, item.URL, item.Secret, delivery, w.now)
+
+	if !attempt.Succeeded {
+		w.logger.WarnContext(ctx, "webhook delivery attempt failed", "delivery_id", delivery.ID, "webhook_id", delivery.WebhookID, "event_type", delivery.EventType, "attempt", delivery.Attempts+1, "error", attempt.Error)
+		if delivery.Attempts+1 < w.maxAttempts {
+			next := attempt.At.Add(retryBackoff(w.baseBackoff, w.maxBackoff, delivery.Attempts))
+			attempt.NextAttemptAt = &next
+		}
+	}
+
+	disabled, err := w.webhooks.RecordWebhookAttempt(ctx, attempt, w.disableAfter)
+	if err != nil {
+		return err
+	}
+	if disabled {
+		w.logger.WarnContext(ctx, "webhook disabled after consecutive failed deliveries", "webhook_id", delivery.WebhookID, "failures", w.disableAfter)
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"todoapp/services/task-service/internal/domain"
+	"todoapp/services/task-service/internal/domain/entities"
+	"todoapp/services/task-service/internal/ports"
+)
+
+const (
+	maxWorkflowStatuses   = 20
+	maxWorkflowNameLength = 100
+)
+
+// WorkflowService manages the users' status workflows. Tasks are checked
+// against them by TaskService through the repository's ResolveWorkflow.
+type WorkflowService struct {
+	workflows ports.WorkflowRepository
+	users     ports.UserDirectory
+}
+
+type WorkflowServiceOption func(*WorkflowService)
+
+var _ ports.WorkflowService = (*WorkflowService)(nil)
+
+func NewWorkflowService(workflows ports.WorkflowRepository, opts ...WorkflowServiceOption) *WorkflowService {
+	svc := &WorkflowService{workflows: workflows}
+	for _, opt := range opts {
+		opt(svc)
+	}
+	return svc
+}
+
+func WithWorkflowUserDirectory(users ports.UserDirectory) WorkflowServiceOption {
+	return func(s *WorkflowService) {
+		s.users = users
+	}
+}
+
+func (s *WorkflowService) CreateWorkflow(ctx context.Context, input ports.CreateWorkflowInput) (*entities.Workflow, error) {
+	if _, err := ensureActiveUser(ctx, s.users, input.UserID); err != nil {
+		return nil, err
+	}
+
+	workflow := &entities.Workflow{UserID: input.UserID}
+	if err := applyWorkflowInput(workflow, input.WorkflowInput); err != nil {
+		return nil, err
+	}
+
+	if err := s.workflows.CreateWorkflow(ctx, workflow); err != nil {
+		return nil, err
+	}
+
+	return workflow, nil
+}
+
+func (s *WorkflowService) GetWorkflow(ctx context.Context, userID, workflowID int64) (*entities.Workflow, error) {
+	if _, err := ensureActiveUser(ctx, s.users, userID); err != nil {
+		return nil, err
+	}
+	return s.workflows.GetWorkflow(ctx, userID, workflowID)
+}
+
+func (s *WorkflowService) ListWorkflows(ctx context.Context, userID int64) ([]entities.Workflow, error) {
+	if _, err := ensureActiveUser(ctx, s.users, userID); err != nil {
+		return nil, err
+	}
+	return s.workflows.ListWorkflows(ctx, userID)
+}
+
+func (s *WorkflowService) UpdateWorkflow(ctx context.Context, input ports.UpdateWorkflowInput) (*entities.Workflow, error) {
+	if _, err := ensureActiveUser(ctx, s.users, input.UserID); err != nil {
+		return nil, err
+	}
+
+	workflow := &entities.Workflow{ID: input.WorkflowID, UserID: input.UserID}
+	if err := applyWorkflowInput(workflow, input.WorkflowInput); err != nil {
+		return nil, err
+	}
+
+	if err := s.workflows.UpdateWorkflow(ctx, workflow); err != nil {
+		return nil, err
+	}
+
+	return workflow, nil
+}
+
+func (s *WorkflowService) DeleteWorkflow(ctx context.Context, userID, workflowID int64) error {
+	if _, err := ensureActiveUser(ctx, s.users, userID); err != nil {
+		return err
+	}
+	return s.workflows.DeleteWorkflow(ctx, userID, workflowID)
+}
+
+func (s *WorkflowService) EffectiveWorkflow(ctx context.Context, userID int64, categoryID *int64) (*entities.Workflow, error) {
+	if _, err := ensureActiveUser(ctx, s.users, userID); err != nil {
+		return nil, err
+	}
+
+	workflow, err := s.workflows.ResolveWorkflow(ctx, userID, categoryID)
+	if err != nil {
+		return nil, err
+	}
+	if workflow == nil {
+		return entities.DefaultWorkflow(), nil
+	}
+
+	return workflow, nil
+}
+
+// applyWorkflowInput validates input and copies it onto workflow. Status keys
+// are normalized to lower case and a missing status name defaults to the key.
+func applyWorkflowInput(workflow *entities.Workflow, input ports.WorkflowInput) error {
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return domain.ErrValidationFailed.WithMessage("workflow name is required")
+	}
+	if len(name) > maxWorkflowNameLength {
+		return domain.ErrValidationFailed.WithMessage(fmt.Sprintf("workflow name must be at most %d characters", maxWorkflowNameLength))
+	}
+
+	if len(input.Statuses) == 0 || len(input.Statuses) > maxWorkflowStatuses {
+		return domain.ErrValidationFailed.WithMessage(fmt.Sprintf("a workflow needs between 1 and %d statuses", maxWorkflowStatuses))
+	}
+
+	statuses := make([]entities.WorkflowStatus, 0, len(input.Statuses))
+	seen := make(map[entities.TaskStatus]bool, len(input.Statuses))
+	hasCompleted := false
+	for _, status := range input.Statuses {
+		status.Key = normalizeStatusKey(status.Key)
+		status.Name = strings.TrimSpace(status.Name)
+
+		if !status.Key.IsValid() {
+			return domain.ErrInvalidTaskStatus.WithMessage(fmt.Sprintf("invalid status key %q: use lower-case letters, digits and underscores", status.Key))
+		}
+		if seen[status.Key] {
+			return domain.ErrValidationFailed.WithMessage(fmt.Sprintf("status %q is listed twice", status.Key))
+		}
+		seen[status.Key] = true
+
+		if status.Name == "" {
+			status.Name = string(status.Key)
+		}
+		hasCompleted = hasCompleted || status.Completed
+		statuses = append(statuses, status)
+	}
+
+	if statuses[0].Completed {
+		return domain.ErrValidationFailed.WithMessage("the first status is given to new tasks and cannot be a completed status")
+	}
+	if !hasCompleted {
+		return domain.ErrValidationFailed.WithMessage("a workflow needs at least one completed status")
+	}
+
+	transitions := make([]entities.WorkflowTransition, 0, len(input.Transitions))
+	seenTransitions := make(map[entities.WorkflowTransition]bool, len(input.Transitions))
+	for _, transition := range input.Transitions {
+		transition.From = normalizeStatusKey(transition.From)
+		transition.To = normalizeStatusKey(transition.To)
+
+		if !seen[transition.From] || !seen[transition.To] {
+			return domain.ErrValidationFailed.WithMessage(fmt.Sprintf("transition %q -> %q uses a status that is not part of the workflow", transition.From, transition.To))
+		}
+		if transition.From == transition.To || seenTransitions[transition] {
+			continue
+		}
+		seenTransitions[transition] = true
+		transitions = append(transitions, transition)
+	}
+
+	workflow.Name = name
+	workflow.CategoryID = input.CategoryID
+	workflow.Statuses = statuses
+	workflow.Transitions = transitions
+
+	return nil
+}
+
+func normalizeStatusKey(key entities.TaskStatus) entities.TaskStatus {
+	return entities.TaskStatus(strings.ToLower(strings.TrimSpace(string(key))))
+}
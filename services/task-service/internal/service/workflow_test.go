@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"todoapp/pkg/events"
+	"todoapp/services/task-service/internal/domain"
+	"todoapp/services/task-service/internal/domain/entities"
+	"todoapp/services/task-service/internal/ports"
+)
+
+type workflowRepoStub struct {
+	ports.WorkflowRepository
+	workflow *entities.Workflow
+	created  *entities.Workflow
+}
+
+func (r *workflowRepoStub) CreateWorkflow(ctx context.Context, workflow *entities.Workflow) error {
+	workflow.ID = 1
+	r.created = workflow
+	return nil
+}
+
+func (r *workflowRepoStub) ResolveWorkflow(ctx context.Context, userID int64, categoryID *int64) (*entities.Workflow, error) {
+	return r.workflow, nil
+}
+
+// reviewWorkflow only lets tasks reach done through review.
+func reviewWorkflow() *entities.Workflow {
+	return &entities.Workflow{
+		ID:   7,
+		Name: "Review",
+		Statuses: []entities.WorkflowStatus{
+			{Key: "todo", Name: "To do"},
+			{Key: "review", Name: "In review"},
+			{Key: "done", Name: "Done", Completed: true},
+		},
+		Transitions: []entities.WorkflowTransition{
+			{From: "todo", To: "review"},
+			{From: "review", To: "todo"},
+			{From: "review", To: "done"},
+		},
+	}
+}
+
+func TestCreateWorkflowValidation(t *testing.T) {
+	done := entities.WorkflowStatus{Key: "done", Completed: true}
+
+	tests := []struct {
+		name  string
+		input ports.WorkflowInput
+		err   error
+	}{
+		{name: "missing name", input: ports.WorkflowInput{Statuses: []entities.WorkflowStatus{{Key: "todo"}, done}}, err: domain.ErrValidationFailed},
+		{name: "no statuses", input: ports.WorkflowInput{Name: "W"}, err: domain.ErrValidationFailed},
+		{name: "bad key", input: ports.WorkflowInput{Name: "W", Statuses: []entities.WorkflowStatus{{Key: "to do"}, done}}, err: domain.ErrInvalidTaskStatus},
+		{name: "duplicate key", input: ports.WorkflowInput{Name: "W", Statuses: []entities.WorkflowStatus{{Key: "todo"}, {Key: "TODO"}, done}}, err: domain.ErrValidationFailed},
+		{name: "completed first", input: ports.WorkflowInput{Name: "W", Statuses: []entities.WorkflowStatus{done, {Key: "todo"}}}, err: domain.ErrValidationFailed},
+		{name: "nothing completes", input: ports.WorkflowInput{Name: "W", Statuses: []entities.WorkflowStatus{{Key: "todo"}}}, err: domain.ErrValidationFailed},
+		{
+			name: "unknown transition status",
+			input: ports.WorkflowInput{
+				Name:        "W",
+				Statuses:    []entities.WorkflowStatus{{Key: "todo"}, done},
+				Transitions: []entities.WorkflowTransition{{From: "todo", To: "review"}},
+			},
+			err: domain.ErrValidationFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := NewWorkflowService(&workflowRepoStub{})
+			_, err := svc.CreateWorkflow(context.Background(), ports.CreateWorkflowInput{UserID: 1, WorkflowInput: tt.input})
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("expected %v, got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+func TestCreateWorkflowNormalizes(t *testing.T) {
+	repo := &workflowRepoStub{}
+	svc := NewWorkflowService(repo)
+
+	workflow, err := svc.CreateWorkflow(context.Background(), ports.CreateWorkflowInput{
+		UserID: 1,
+		WorkflowInput: ports.WorkflowInput{
+			Name:     " Review ",
+			Statuses: []entities.WorkflowStatus{{Key: " Todo "}, {Key: "done", Name: "Done", Completed: true}},
+			Transitions: []entities.WorkflowTransition{
+				{From: "todo", To: "done"},
+				{From: "TODO", To: "done"},
+				{From: "done", To: "done"},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if repo.created == nil || workflow.Name != "Review" {
+		t.Fatalf("workflow not stored: %+v", workflow)
+	}
+	if workflow.Statuses[0].Key != "todo" || workflow.Statuses[0].Name != "todo" {
+		t.Fatalf("status not normalized: %+v", workflow.Statuses[0])
+	}
+	if len(workflow.Transitions) != 1 {
+		t.Fatalf("expected duplicate and self transitions to be dropped, got %+v", workflow.Transitions)
+	}
+}
+
+func TestEffectiveWorkflowFallsBackToDefault(t *testing.T) {
+	svc := NewWorkflowService(&workflowRepoStub{})
+
+	workflow, err := svc.EffectiveWorkflow(context.Background(), 1, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !workflow.IsDefault() || workflow.InitialStatus() != entities.TaskStatusPending {
+		t.Fatalf("expected the default workflow, got %+v", workflow)
+	}
+}
+
+func TestCreateTaskUsesInitialWorkflowStatus(t *testing.T) {
+	repo := &repoMock{}
+	svc := NewTaskService(repo, WithWorkflowResolver(&workflowRepoStub{workflow: reviewWorkflow()}))
+
+	task, err := svc.CreateTask(context.Background(), ports.CreateTaskInput{UserID: 1, Title: "t", Priority: entities.TaskPriorityMedium})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if task.Status != "todo" {
+		t.Fatalf("expected initial status todo, got %s", task.Status)
+	}
+
+	_, err = svc.CreateTask(context.Background(), ports.CreateTaskInput{UserID: 1, Title: "t", Status: entities.TaskStatusPending, Priority: entities.TaskPriorityMedium})
+	if !errors.Is(err, domain.ErrInvalidTaskStatus) {
+		t.Fatalf("expected status outside the workflow to be rejected, got %v", err)
+	}
+}
+
+func TestCreateTaskFollowsWorkflowTransitions(t *testing.T) {
+	repo := &repoMock{}
+	publishCh := make(chan events.TaskEvent, 2)
+	svc := NewTaskService(
+		repo,
+		WithUserDirectory(userDirStub{user: &ports.UserInfo{ID: 1, Email: "a@b.c", Active: true}}),
+		WithWorkflowResolver(&workflowRepoStub{workflow: reviewWorkflow()}),
+		WithEventPublisher(publisherStub{ch: publishCh}),
+	)
+
+	_, err := svc.CreateTask(context.Background(), ports.CreateTaskInput{UserID: 1, Title: "t", Status: "done", Priority: entities.TaskPriorityMedium})
+	if !errors.Is(err, domain.ErrInvalidTaskStatus) {
+		t.Fatalf("expected todo -> done to be rejected on creation, got %v", err)
+	}
+
+	workflow := reviewWorkflow()
+	workflow.Transitions = append(workflow.Transitions, entities.WorkflowTransition{From: "todo", To: "done"})
+	svc.workflows = &workflowRepoStub{workflow: workflow}
+
+	task, err := svc.CreateTask(context.Background(), ports.CreateTaskInput{UserID: 1, Title: "t", Status: "done", Priority: entities.TaskPriorityMedium})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if task.CompletedAt == nil {
+		t.Fatalf("expected completion time to be set")
+	}
+
+	var types []events.TaskEventType
+	for len(publishCh) > 0 {
+		types = append(types, (<-publishCh).Type)
+	}
+	if len(types) != 2 || types[0] != events.TaskEventCreated || types[1] != events.TaskEventCompleted {
+		t.Fatalf("expected created and completed events, got %v", types)
+	}
+}
+
+func TestUpdateTaskStatusFollowsWorkflow(t *testing.T) {
+	repo := &repoMock{storedTask: &entities.Task{ID: 1, UserID: 1, Status: "todo", Priority: entities.TaskPriorityMedium}}
+	publishCh := make(chan events.TaskEvent, 2)
+	svc := NewTaskService(
+		repo,
+		WithUserDirectory(userDirStub{user: &ports.UserInfo{ID: 1, Email: "a@b.c", Active: true}}),
+		WithWorkflowResolver(&workflowRepoStub{workflow: reviewWorkflow()}),
+		WithEventPublisher(publisherStub{ch: publishCh}),
+	)
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	svc.WithNow(func() time.Time { return now })
+
+	if _, err := svc.UpdateTaskStatus(context.Background(), 1, 1, "done"); !errors.Is(err, domain.ErrInvalidTaskStatus) {
+		t.Fatalf("expected todo -> done to be rejected, got %v", err)
+	}
+
+	if _, err := svc.UpdateTaskStatus(context.Background(), 1, 1, "review"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	task, err := svc.UpdateTaskStatus(context.Background(), 1, 1, "done")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if task.CompletedAt == nil || !task.CompletedAt.Equal(now) {
+		t.Fatalf("expected completion time to be set, got %v", task.CompletedAt)
+	}
+
+	var completed int
+	for len(publishCh) > 0 {
+		if event := <-publishCh; event.Type == events.TaskEventCompleted {
+			completed++
+		}
+	}
+	if completed != 1 {
+		t.Fatalf("expected one completion event, got %d", completed)
+	}
+}
+
+func TestGetBoardUsesWorkflowStatuses(t *testing.T) {
+	repo := newBoardRepoStub(entities.Task{ID: 1, UserID: 42, Status: "review", Position: 1024})
+	svc := NewTaskService(repo, WithWorkflowResolver(&workflowRepoStub{workflow: reviewWorkflow()}))
+
+	columns, err := svc.GetBoard(context.Background(), 42, ports.TaskFilter{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(columns) != 3 || columns[0].Status != "todo" || columns[1].Status != "review" || len(columns[1].Tasks) != 1 {
+		t.Fatalf("unexpected columns: %+v", columns)
+	}
+}
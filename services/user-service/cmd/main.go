@@ -3,7 +3,7 @@ package main
 import (
 	"context"
 	"errors"
-	"log"
+	"log/slog"
 	"net"
 	"net/http"
 	"os"
@@ -12,43 +12,117 @@ import (
 	"syscall"
 	"time"
 
+	"todoapp/migrations"
+	"todoapp/pkg/health"
+	"todoapp/pkg/logging"
+	"todoapp/pkg/metrics"
 	userv1 "todoapp/pkg/proto/user/v1"
+	"todoapp/pkg/ratelimit"
+	"todoapp/pkg/requestid"
+	"todoapp/pkg/tracing"
 	authadapter "todoapp/services/user-service/internal/adapters/auth"
 	dbadapter "todoapp/services/user-service/internal/adapters/database"
+	rabbitpublisher "todoapp/services/user-service/internal/adapters/events/rabbitmq"
 	usergrpc "todoapp/services/user-service/internal/adapters/grpc"
 	"todoapp/services/user-service/internal/infrastructure/app"
 	"todoapp/services/user-service/internal/infrastructure/config"
 	"todoapp/services/user-service/internal/infrastructure/posgres"
 	"todoapp/services/user-service/internal/service"
 
+	"github.com/redis/go-redis/v9"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
 func main() {
+	logger := logging.New("user-service")
+	slog.SetDefault(logger)
+
 	cfg, err := config.Load()
 	if err != nil {
-		log.Fatalf("failed to load config: %v", err)
+		logging.Fatal(logger, "failed to load config", err)
 	}
 
 	ctx := context.Background()
+
+	shutdownTracing, err := tracing.Setup(ctx, "user-service", tracing.Config{
+		Exporter:    cfg.Tracing.Exporter,
+		Endpoint:    cfg.Tracing.Endpoint,
+		Insecure:    cfg.Tracing.Insecure,
+		FilePath:    cfg.Tracing.FilePath,
+		SampleRatio: cfg.Tracing.SampleRatio,
+	})
+	if err != nil {
+		logging.Fatal(logger, "failed to set up tracing", err)
+	}
+	defer func() {
+		flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(flushCtx); err != nil {
+			logger.Error("failed to flush traces", "error", err)
+		}
+	}()
+
 	pool, err := posgres.NewPostgresPool(ctx, cfg)
 	if err != nil {
-		log.Fatalf("failed to connect to postgres: %v", err)
+		logging.Fatal(logger, "failed to connect to postgres", err)
 	}
 	defer pool.Close()
+	if err := metrics.RegisterPool(pool, "primary"); err != nil {
+		logging.Fatal(logger, "failed to register pool metrics", err)
+	}
 
 	repo := dbadapter.NewPostgresUserRepository(pool)
 	tokenManager := authadapter.NewJWTManager(cfg.JWT.AccessSecret, cfg.JWT.RefreshSecret, cfg.JWT.AccessTTL, cfg.JWT.RefreshTTL)
 	userService := service.NewUserService(repo, tokenManager)
+	userService.WithLogger(logger)
+	userService.WithTokenRevocations(dbadapter.NewPostgresTokenRevocationRepository(pool))
+
+	readiness := health.NewChecker("user-service")
+	readiness.Add("postgres", health.Postgres(pool, migrations.Version()))
+
+	if cfg.Rabbit.URL != "" {
+		publisher, err := rabbitpublisher.New(cfg.Rabbit.URL)
+		if err != nil {
+			logging.Fatal(logger, "failed to initialize rabbitmq publisher", err)
+		}
+		defer publisher.Close()
+		userService.WithEventPublisher(publisher)
+		readiness.AddOptional("rabbitmq", health.RabbitMQ(cfg.Rabbit.URL))
+	}
+
 	githubOAuth := authadapter.NewGitHubOAuth(cfg.GitHub.ClientID, cfg.GitHub.ClientSecret, cfg.GitHub.RedirectURL, cfg.GitHub.Scopes)
 
+	var redisClient redis.Scripter
+	if cfg.Redis.Addr != "" {
+		client := redis.NewClient(&redis.Options{
+			Addr:     cfg.Redis.Addr,
+			Password: cfg.Redis.Password,
+			DB:       cfg.Redis.DB,
+		})
+		defer client.Close()
+		redisClient = client
+	}
+
 	router, err := app.NewRouter(app.HTTPDeps{
 		UserService: userService,
-		TokenMgr:    tokenManager,
 		GitHubOAuth: githubOAuth,
+		AuthRateLimiter: ratelimit.New(redisClient, ratelimit.Rule{
+			Limit:  cfg.RateLimit.AuthRequests,
+			Window: cfg.RateLimit.Window,
+		}, ratelimit.WithPrefix("ratelimit:user-service:auth"), ratelimit.WithLogger(logger)),
+		RateLimiter: ratelimit.New(redisClient, ratelimit.Rule{
+			Limit:  cfg.RateLimit.Requests,
+			Window: cfg.RateLimit.Window,
+		}, ratelimit.WithPrefix("ratelimit:user-service"), ratelimit.WithLogger(logger)),
+		TrustedProxies: cfg.TrustedProxies,
+		Readiness:      readiness,
+		Logger:         logger,
 	})
 	if err != nil {
-		log.Fatalf("failed to initialize router: %v", err)
+		logging.Fatal(logger, "failed to initialize router", err)
 	}
 
 	httpServer := &http.Server{
@@ -58,12 +132,21 @@ func main() {
 
 	grpcListener, err := net.Listen("tcp", cfg.GRPCAddr)
 	if err != nil {
-		log.Fatalf("failed to listen on %s: %v", cfg.GRPCAddr, err)
+		logging.Fatal(logger, "failed to listen on "+cfg.GRPCAddr, err)
 	}
 	defer grpcListener.Close()
 
-	grpcServer := grpc.NewServer()
-	userv1.RegisterUserServiceServer(grpcServer, usergrpc.NewServer(userService, tokenManager))
+	grpcServer := grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(requestid.UnaryServerInterceptor(), metrics.UnaryServerInterceptor()),
+	)
+	userv1.RegisterUserServiceServer(grpcServer, usergrpc.NewServer(userService))
+	healthServer := grpchealth.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+
+	healthCtx, stopHealth := context.WithCancel(ctx)
+	defer stopHealth()
+	go readiness.Publish(healthCtx, healthServer, 10*time.Second, userv1.UserService_ServiceDesc.ServiceName)
 
 	var wg sync.WaitGroup
 	errCh := make(chan error, 2)
@@ -91,8 +174,10 @@ func main() {
 	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 	if err := httpServer.Shutdown(shutdownCtx); err != nil {
-		log.Printf("shutdown error: %v", err)
+		logger.Error("shutdown failed", "error", err)
 	}
+	stopHealth()
+	healthServer.Shutdown()
 	grpcServer.GracefulStop()
 
 	done := make(chan struct{})
@@ -103,7 +188,7 @@ func main() {
 
 	select {
 	case err := <-errCh:
-		log.Printf("server stopped with error: %v", err)
+		logger.Error("server stopped with error", "error", err)
 	case <-done:
 	}
 }
@@ -11,6 +11,13 @@ import (
 	"todoapp/services/user-service/internal/ports"
 )
 
+// Token times are written with a finer precision than they are issued at:
+// the library parses them as floats and truncates, which would otherwise lose
+// the last digit now and then.
+func init() {
+	jwt.TimePrecision = time.Microsecond
+}
+
 type tokenClaims struct {
 	UserID int64  `json:"uid"`
 	Email  string `json:"email"`
@@ -48,7 +55,7 @@ func (m *JWTManager) GenerateAccessToken(payload ports.TokenPayload) (string, ti
 		RegisteredClaims: jwt.RegisteredClaims{
 			Subject:   strconv.FormatInt(payload.UserID, 10),
 			ExpiresAt: jwt.NewNumericDate(expiresAt),
-			IssuedAt:  jwt.NewNumericDate(now),
+			IssuedAt:  jwt.NewNumericDate(now.Truncate(ports.TokenTimePrecision)),
 			ID:        uuid.NewString(),
 		},
 	}
@@ -69,7 +76,7 @@ func (m *JWTManager) GenerateRefreshToken(payload ports.TokenPayload, tokenID st
 		RegisteredClaims: jwt.RegisteredClaims{
 			Subject:   fmt.Sprintf("%d:%s", payload.UserID, tokenID),
 			ExpiresAt: jwt.NewNumericDate(expiresAt),
-			IssuedAt:  jwt.NewNumericDate(m.now()),
+			IssuedAt:  jwt.NewNumericDate(m.now().Truncate(ports.TokenTimePrecision)),
 			ID:        tokenID,
 		},
 	}
@@ -119,11 +126,21 @@ func (m *JWTManager) WithNow(now func() time.Time) {
 }
 
 func (m *JWTManager) toPortClaims(c *tokenClaims) *ports.TokenClaims {
+	// A token without iat counts as issued at the zero time, so any
+	// revocation of the user's tokens covers it.
+	var issuedAt time.Time
+	if c.RegisteredClaims.IssuedAt != nil {
+		// The fraction is parsed as a float and may come out a microsecond
+		// short of the time the token was issued at.
+		issuedAt = c.RegisteredClaims.IssuedAt.Round(ports.TokenTimePrecision)
+	}
+
 	return &ports.TokenClaims{
 		UserID:    c.UserID,
 		Email:     c.Email,
 		Role:      c.Role,
 		TokenID:   c.RegisteredClaims.ID,
+		IssuedAt:  issuedAt,
 		ExpiresAt: c.RegisteredClaims.ExpiresAt.Time,
 	}
 }
@@ -27,6 +27,9 @@ func TestGenerateAndParseTokens(t *testing.T) {
 	if claims.UserID != 10 || claims.Email != "user@example.com" {
 		t.Fatalf("unexpected claims")
 	}
+	if !claims.IssuedAt.Equal(fixed.Truncate(ports.TokenTimePrecision)) {
+		t.Fatalf("unexpected issued at: %v", claims.IssuedAt)
+	}
 
 	refresh, refreshExp, err := manager.GenerateRefreshToken(payload, "tid")
 	if err != nil {
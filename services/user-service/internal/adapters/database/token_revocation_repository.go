@@ -0,0 +1,82 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"todoapp/services/user-service/internal/domain"
+	"todoapp/services/user-service/internal/ports"
+)
+
+var _ ports.TokenRevocationRepository = (*PostgresTokenRevocationRepository)(nil)
+
+type PostgresTokenRevocationRepository struct {
+	pool Pool
+}
+
+func NewPostgresTokenRevocationRepository(pool Pool) *PostgresTokenRevocationRepository {
+	return &PostgresTokenRevocationRepository{pool: pool}
+}
+
+// RevokeUserTokens rejects the user's access tokens issued so far and ends
+// their sessions, so the refresh tokens cannot replace them.
+func (r *PostgresTokenRevocationRepository) RevokeUserTokens(ctx context.Context, userID int64, validAfter time.Time) error {
+	q := r.querier(ctx)
+	tag, err := q.Exec(ctx, `UPDATE user_service.users SET tokens_valid_after = $1 WHERE id = $2`, validAfter.UTC(), userID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return domain.ErrUserNotFound
+	}
+
+	_, err = q.Exec(ctx, `DELETE FROM user_service.user_sessions WHERE user_id = $1`, userID)
+	return err
+}
+
+// RevokeToken also drops revocations of tokens that have expired since, so
+// the table only holds tokens that would otherwise still be accepted.
+func (r *PostgresTokenRevocationRepository) RevokeToken(ctx context.Context, userID int64, tokenID string, expiresAt time.Time) error {
+	q := r.querier(ctx)
+	const query = `
+INSERT INTO user_service.revoked_tokens (token_id, user_id, expires_at)
+VALUES ($1,$2,$3)
+ON CONFLICT (token_id) DO NOTHING
+`
+	if _, err := q.Exec(ctx, query, tokenID, userID, expiresAt.UTC()); err != nil {
+		return err
+	}
+
+	_, err := q.Exec(ctx, `DELETE FROM user_service.revoked_tokens WHERE expires_at < $1`, time.Now().UTC())
+	return err
+}
+
+func (r *PostgresTokenRevocationRepository) GetTokenState(ctx context.Context, userID int64, tokenID string) (*ports.TokenState, error) {
+	q := r.querier(ctx)
+	const query = `
+SELECT
+    COALESCE(u.is_active, false),
+    u.tokens_valid_after,
+    EXISTS (SELECT 1 FROM user_service.revoked_tokens r WHERE r.token_id = $2)
+FROM user_service.users u
+WHERE u.id = $1
+`
+	var state ports.TokenState
+	if err := q.QueryRow(ctx, query, userID, tokenID).Scan(&state.UserActive, &state.TokensValidAfter, &state.TokenRevoked); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrUserNotFound
+		}
+		return nil, err
+	}
+	return &state, nil
+}
+
+func (r *PostgresTokenRevocationRepository) querier(ctx context.Context) querier {
+	if tx := TxFromContext(ctx); tx != nil {
+		return tx
+	}
+	return r.pool
+}
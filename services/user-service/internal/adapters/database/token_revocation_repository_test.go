@@ -0,0 +1,84 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	pgxmock "github.com/pashagolub/pgxmock/v2"
+	"github.com/stretchr/testify/require"
+
+	"todoapp/services/user-service/internal/domain"
+)
+
+func TestRevokeUserTokens(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	repo := NewPostgresTokenRevocationRepository(pool)
+	at := time.Date(2024, 12, 10, 9, 0, 0, 0, time.UTC)
+
+	pool.ExpectExec(`UPDATE user_service.users SET tokens_valid_after`).
+		WithArgs(at, int64(7)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	pool.ExpectExec(`DELETE FROM user_service.user_sessions WHERE user_id`).
+		WithArgs(int64(7)).
+		WillReturnResult(pgxmock.NewResult("DELETE", 2))
+
+	require.NoError(t, repo.RevokeUserTokens(context.Background(), 7, at))
+
+	pool.ExpectExec(`UPDATE user_service.users SET tokens_valid_after`).
+		WithArgs(at, int64(8)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+
+	require.Equal(t, domain.ErrUserNotFound, repo.RevokeUserTokens(context.Background(), 8, at))
+	require.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestRevokeToken(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	repo := NewPostgresTokenRevocationRepository(pool)
+	expires := time.Date(2024, 12, 10, 9, 15, 0, 0, time.UTC)
+
+	pool.ExpectExec(`INSERT INTO user_service.revoked_tokens`).
+		WithArgs("jti", int64(7), expires).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	pool.ExpectExec(`DELETE FROM user_service.revoked_tokens WHERE expires_at`).
+		WithArgs(pgxmock.AnyArg()).
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+
+	require.NoError(t, repo.RevokeToken(context.Background(), 7, "jti", expires))
+	require.NoError(t, pool.ExpectationsWereMet())
+}
+
+func TestGetTokenState(t *testing.T) {
+	pool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	defer pool.Close()
+
+	repo := NewPostgresTokenRevocationRepository(pool)
+	validAfter := time.Date(2024, 12, 10, 9, 0, 0, 0, time.UTC)
+
+	pool.ExpectQuery(`FROM user_service.users u`).
+		WithArgs(int64(7), "jti").
+		WillReturnRows(pgxmock.NewRows([]string{"is_active", "tokens_valid_after", "revoked"}).AddRow(true, &validAfter, true))
+
+	state, err := repo.GetTokenState(context.Background(), 7, "jti")
+	require.NoError(t, err)
+	require.True(t, state.UserActive)
+	require.True(t, state.TokenRevoked)
+	require.Equal(t, validAfter, *state.TokensValidAfter)
+
+	pool.ExpectQuery(`FROM user_service.users u`).
+		WithArgs(int64(8), "jti").
+		WillReturnError(pgx.ErrNoRows)
+
+	_, err = repo.GetTokenState(context.Background(), 8, "jti")
+	require.Equal(t, domain.ErrUserNotFound, err)
+	require.NoError(t, pool.ExpectationsWereMet())
+}
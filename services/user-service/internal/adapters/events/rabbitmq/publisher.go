@@ -0,0 +1,124 @@
+package rabbitmq
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+
+	"todoapp/pkg/events"
+	"todoapp/pkg/metrics"
+	"todoapp/pkg/requestid"
+	"todoapp/pkg/tracing"
+	"todoapp/services/user-service/internal/ports"
+)
+
+// Publisher sends user events to the fanout exchange shared by all
+// consumers. Events only invalidate caches, so they are not persisted and a
+// closed connection is re-established on the next publish.
+type Publisher struct {
+	url      string
+	exchange string
+
+	mu   sync.Mutex
+	conn *amqp.Connection
+	ch   *amqp.Channel
+}
+
+var _ ports.UserEventPublisher = (*Publisher)(nil)
+
+func New(url string) (*Publisher, error) {
+	if url == "" {
+		return nil, errors.New("rabbitmq: url is required")
+	}
+
+	p := &Publisher{url: url, exchange: events.UserEventsExchange}
+	if err := p.connect(); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+func (p *Publisher) PublishUserEvent(ctx context.Context, event events.UserEvent) (err error) {
+	ctx, span := tracing.StartPublish(ctx, p.exchange, tracing.UserID(event.UserID))
+	defer func() {
+		metrics.MessagePublished(p.exchange, err)
+		tracing.End(span, err)
+	}()
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.ch == nil || p.ch.IsClosed() {
+		if err := p.connect(); err != nil {
+			return err
+		}
+	}
+
+	return p.ch.PublishWithContext(ctx, p.exchange, "", false, false, amqp.Publishing{
+		Headers:     tracing.InjectHeaders(ctx, requestid.Headers(ctx)),
+		ContentType: "application/json",
+		MessageId:   event.ID,
+		Type:        string(event.Type),
+		Timestamp:   event.OccurredAt,
+		Body:        payload,
+	})
+}
+
+func (p *Publisher) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.closeLocked()
+}
+
+// connect replaces the current connection. It must be called with mu held or
+// before the publisher is shared.
+func (p *Publisher) connect() error {
+	_ = p.closeLocked()
+
+	conn, err := amqp.Dial(p.url)
+	if err != nil {
+		return err
+	}
+
+	ch, err := conn.Channel()
+	if err != nil {
+		conn.Close()
+		return err
+	}
+
+	if err := ch.ExchangeDeclare(p.exchange, amqp.ExchangeFanout, true, false, false, false, nil); err != nil {
+		ch.Close()
+		conn.Close()
+		return err
+	}
+
+	p.conn = conn
+	p.ch = ch
+
+	return nil
+}
+
+func (p *Publisher) closeLocked() error {
+	if p.ch != nil {
+		_ = p.ch.Close()
+		p.ch = nil
+	}
+	if p.conn != nil {
+		err := p.conn.Close()
+		p.conn = nil
+		if err != nil && !errors.Is(err, amqp.ErrClosed) {
+			return err
+		}
+	}
+	return nil
+}
@@ -16,11 +16,10 @@ type Server struct {
 	userv1.UnimplementedUserServiceServer
 
 	service ports.UserService
-	tokens  ports.TokenManager
 }
 
-func NewServer(service ports.UserService, tokens ports.TokenManager) *Server {
-	return &Server{service: service, tokens: tokens}
+func NewServer(service ports.UserService) *Server {
+	return &Server{service: service}
 }
 
 func (s *Server) GetUser(ctx context.Context, req *userv1.GetUserRequest) (*userv1.GetUserResponse, error) {
@@ -41,9 +40,11 @@ func (s *Server) ValidateToken(ctx context.Context, req *userv1.ValidateTokenReq
 		return nil, status.Error(codes.InvalidArgument, "access token is required")
 	}
 
-	claims, err := s.tokens.ParseAccessToken(req.GetAccessToken())
+	// Revoked tokens are reported as unauthenticated like invalid ones; the
+	// message tells them apart.
+	claims, err := s.service.ValidateAccessToken(ctx, req.GetAccessToken())
 	if err != nil {
-		return nil, status.Error(codes.Unauthenticated, "invalid access token")
+		return nil, mapToGRPCError(err)
 	}
 
 	return &userv1.ValidateTokenResponse{
@@ -61,6 +62,7 @@ func toProtoUser(user entities.User) *userv1.User {
 		Name:     user.Name,
 		Role:     user.Role,
 		IsActive: user.IsActive,
+		Timezone: user.Preferences.Timezone,
 	}
 }
 
@@ -12,11 +12,10 @@ import (
 
 type Handler struct {
 	service ports.UserService
-	tokens  ports.TokenManager
 }
 
-func New(service ports.UserService, tokens ports.TokenManager) *Handler {
-	return &Handler{service: service, tokens: tokens}
+func New(service ports.UserService) *Handler {
+	return &Handler{service: service}
 }
 
 func (h *Handler) RegisterRoutes(router gin.IRoutes) {
@@ -82,7 +81,8 @@ func (h *Handler) Logout(ctx *gin.Context) {
 		common.WriteValidationError(ctx, err)
 		return
 	}
-	if err := h.service.Logout(ctx.Request.Context(), request.RefreshToken); err != nil {
+	accessToken := common.ExtractBearerToken(ctx.GetHeader("Authorization"))
+	if err := h.service.Logout(ctx.Request.Context(), request.RefreshToken, accessToken); err != nil {
 		common.WriteDomainError(ctx, err)
 		return
 	}
@@ -95,9 +95,9 @@ func (h *Handler) Validate(ctx *gin.Context) {
 		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "MISSING_TOKEN"})
 		return
 	}
-	claims, err := h.tokens.ParseAccessToken(token)
+	claims, err := h.service.ValidateAccessToken(ctx.Request.Context(), token)
 	if err != nil {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "INVALID_TOKEN"})
+		common.WriteTokenError(ctx, err)
 		return
 	}
 	ctx.JSON(http.StatusOK, gin.H{
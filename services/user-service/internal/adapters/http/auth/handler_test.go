@@ -20,10 +20,9 @@ import (
 func TestRegister(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	mockSvc := NewMockUserService(t)
-	mockTokens := NewMockTokenManager(t)
 	result := &ports.AuthResult{User: entities.User{ID: 1, Email: "user@example.com"}}
 	mockSvc.On("Register", mockCtx(), ports.RegisterInput{Email: "user@example.com", Name: "User", Password: "password123"}).Return(result, nil)
-	handler := New(mockSvc, mockTokens)
+	handler := New(mockSvc)
 	router := gin.New()
 	handler.RegisterRoutes(router)
 	body := []byte(`{"email":"user@example.com","name":"User","password":"password123"}`)
@@ -38,9 +37,8 @@ func TestRegister(t *testing.T) {
 func TestLoginInvalidCredentials(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	mockSvc := NewMockUserService(t)
-	mockTokens := NewMockTokenManager(t)
 	mockSvc.On("Login", mockCtx(), ports.LoginInput{Email: "user@example.com", Password: "wrongpass"}).Return(nil, domain.ErrInvalidCredentials)
-	handler := New(mockSvc, mockTokens)
+	handler := New(mockSvc)
 	router := gin.New()
 	handler.RegisterRoutes(router)
 	body := []byte(`{"email":"user@example.com","password":"wrongpass"}`)
@@ -55,10 +53,9 @@ func TestLoginInvalidCredentials(t *testing.T) {
 func TestRefresh(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	mockSvc := NewMockUserService(t)
-	mockTokens := NewMockTokenManager(t)
 	tokens := &ports.AuthTokens{AccessToken: "a", RefreshToken: "r"}
 	mockSvc.On("RefreshToken", mockCtx(), "refresh").Return(tokens, nil)
-	handler := New(mockSvc, mockTokens)
+	handler := New(mockSvc)
 	router := gin.New()
 	handler.RegisterRoutes(router)
 	body := []byte(`{"refreshToken":"refresh"}`)
@@ -73,14 +70,14 @@ func TestRefresh(t *testing.T) {
 func TestLogout(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	mockSvc := NewMockUserService(t)
-	mockTokens := NewMockTokenManager(t)
-	mockSvc.On("Logout", mockCtx(), "refresh").Return(nil)
-	handler := New(mockSvc, mockTokens)
+	mockSvc.On("Logout", mockCtx(), "refresh", "access").Return(nil)
+	handler := New(mockSvc)
 	router := gin.New()
 	handler.RegisterRoutes(router)
 	body := []byte(`{"refreshToken":"refresh"}`)
 	req := httptest.NewRequest(http.MethodPost, "/auth/logout", bytes.NewReader(body))
 	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer access")
 	res := httptest.NewRecorder()
 	router.ServeHTTP(res, req)
 	assert.Equal(t, http.StatusNoContent, res.Code)
@@ -90,10 +87,9 @@ func TestLogout(t *testing.T) {
 func TestValidate(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	mockSvc := NewMockUserService(t)
-	mockTokens := NewMockTokenManager(t)
 	claims := &ports.TokenClaims{UserID: 1, Email: "user@example.com", Role: "user", ExpiresAt: time.Now().Add(time.Hour)}
-	mockTokens.On("ParseAccessToken", "token").Return(claims, nil)
-	handler := New(mockSvc, mockTokens)
+	mockSvc.On("ValidateAccessToken", mockCtx(), "token").Return(claims, nil)
+	handler := New(mockSvc)
 	router := gin.New()
 	handler.RegisterRoutes(router)
 	req := httptest.NewRequest(http.MethodPost, "/auth/validate", nil)
@@ -101,7 +97,22 @@ func TestValidate(t *testing.T) {
 	res := httptest.NewRecorder()
 	router.ServeHTTP(res, req)
 	assert.Equal(t, http.StatusOK, res.Code)
-	mockTokens.AssertExpectations(t)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestValidateRevokedToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mockSvc := NewMockUserService(t)
+	mockSvc.On("ValidateAccessToken", mockCtx(), "token").Return(nil, domain.ErrAccessTokenRevoked)
+	handler := New(mockSvc)
+	router := gin.New()
+	handler.RegisterRoutes(router)
+	req := httptest.NewRequest(http.MethodPost, "/auth/validate", nil)
+	req.Header.Set("Authorization", "Bearer token")
+	res := httptest.NewRecorder()
+	router.ServeHTTP(res, req)
+	assert.Equal(t, http.StatusUnauthorized, res.Code)
+	assert.Contains(t, res.Body.String(), "TOKEN_REVOKED")
 }
 
 func mockCtx() interface{} {
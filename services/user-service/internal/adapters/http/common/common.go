@@ -24,6 +24,19 @@ func WriteDomainError(ctx *gin.Context, err error) {
 	})
 }
 
+// WriteTokenError answers a request whose access token was not accepted.
+// Revoked tokens are reported as such, so clients know to sign in again.
+func WriteTokenError(ctx *gin.Context, err error) {
+	switch {
+	case errors.IsCode(err, errors.CodeTokenRevoked):
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "TOKEN_REVOKED"})
+	case errors.IsCode(err, errors.CodeTokenInvalid):
+		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "INVALID_TOKEN"})
+	default:
+		WriteDomainError(ctx, err)
+	}
+}
+
 func ExtractBearerToken(header string) string {
 	const prefix = "Bearer "
 	if header == "" {
@@ -13,11 +13,10 @@ import (
 
 type Handler struct {
 	service ports.UserService
-	tokens  ports.TokenManager
 }
 
-func New(service ports.UserService, tokens ports.TokenManager) *Handler {
-	return &Handler{service: service, tokens: tokens}
+func New(service ports.UserService) *Handler {
+	return &Handler{service: service}
 }
 
 func (h *Handler) RegisterRoutes(router gin.IRoutes) {
@@ -45,9 +44,9 @@ func (h *Handler) ValidateToken(ctx *gin.Context) {
 		common.WriteValidationError(ctx, err)
 		return
 	}
-	claims, err := h.tokens.ParseAccessToken(request.Token)
+	claims, err := h.service.ValidateAccessToken(ctx.Request.Context(), request.Token)
 	if err != nil {
-		ctx.JSON(http.StatusUnauthorized, gin.H{"error": "INVALID_TOKEN"})
+		common.WriteTokenError(ctx, err)
 		return
 	}
 	ctx.JSON(http.StatusOK, gin.H{
@@ -21,8 +21,7 @@ func TestGetUser(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	service := NewMockUserService(t)
 	service.On("GetProfile", mockCtx(), int64(1)).Return(&entities.User{ID: 1}, nil)
-	tokens := NewMockTokenManager(t)
-	handler := New(service, tokens)
+	handler := New(service)
 	router := gin.New()
 	handler.RegisterRoutes(router)
 	req := httptest.NewRequest(http.MethodGet, "/internal/users/1", nil)
@@ -35,10 +34,9 @@ func TestGetUser(t *testing.T) {
 func TestValidateToken(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	service := NewMockUserService(t)
-	tokens := NewMockTokenManager(t)
 	claims := &ports.TokenClaims{UserID: 1, Email: "user@example.com", Role: "user", ExpiresAt: time.Now()}
-	tokens.On("ParseAccessToken", "token").Return(claims, nil)
-	handler := New(service, tokens)
+	service.On("ValidateAccessToken", mockCtx(), "token").Return(claims, nil)
+	handler := New(service)
 	router := gin.New()
 	handler.RegisterRoutes(router)
 	body, _ := json.Marshal(map[string]any{"token": "token"})
@@ -47,7 +45,7 @@ func TestValidateToken(t *testing.T) {
 	res := httptest.NewRecorder()
 	router.ServeHTTP(res, req)
 	assert.Equal(t, http.StatusOK, res.Code)
-	tokens.AssertExpectations(t)
+	service.AssertExpectations(t)
 }
 
 func mockCtx() interface{} {
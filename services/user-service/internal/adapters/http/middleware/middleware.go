@@ -6,16 +6,20 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	"todoapp/pkg/logging"
+	"todoapp/pkg/ratelimit"
+	"todoapp/pkg/tracing"
+	"todoapp/services/user-service/internal/adapters/http/common"
 	"todoapp/services/user-service/internal/ports"
 )
 
 const ContextUserClaimsKey = "userClaims"
 
 type Middleware struct {
-	tokens ports.TokenManager
+	tokens ports.AccessTokenValidator
 }
 
-func New(tokens ports.TokenManager) *Middleware {
+func New(tokens ports.AccessTokenValidator) *Middleware {
 	return &Middleware{tokens: tokens}
 }
 
@@ -31,12 +35,15 @@ func (m *Middleware) JWT() gin.HandlerFunc {
 			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "INVALID_TOKEN"})
 			return
 		}
-		claims, err := m.tokens.ParseAccessToken(token)
+		claims, err := m.tokens.ValidateAccessToken(ctx.Request.Context(), token)
 		if err != nil {
-			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "INVALID_TOKEN"})
+			common.WriteTokenError(ctx, err)
+			ctx.Abort()
 			return
 		}
 		ctx.Set(ContextUserClaimsKey, claims)
+		ctx.Request = ctx.Request.WithContext(logging.WithUserID(ctx.Request.Context(), claims.UserID))
+		tracing.SetUserID(ctx.Request.Context(), claims.UserID)
 		ctx.Next()
 	}
 }
@@ -73,6 +80,16 @@ func CurrentUser(ctx *gin.Context) (*ports.TokenClaims, bool) {
 	return claims, ok
 }
 
+// RateLimitKey counts requests per user when the JWT middleware ran before
+// it, and per client IP otherwise.
+func RateLimitKey(ctx *gin.Context) string {
+	if claims, ok := CurrentUser(ctx); ok {
+		return ratelimit.UserKey(claims.UserID)
+	}
+
+	return ratelimit.ClientIP(ctx)
+}
+
 func extractBearerToken(header string) string {
 	const prefix = "Bearer "
 	if header == "" {
@@ -7,14 +7,16 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 
+	"todoapp/pkg/errors"
 	"todoapp/services/user-service/internal/ports"
 )
 
 func TestJWTMiddleware(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	mgr := NewMockTokenManager(t)
-	mgr.On("ParseAccessToken", "token").Return(&ports.TokenClaims{UserID: 1, Role: "admin"}, nil)
+	mgr := NewMockAccessTokenValidator(t)
+	mgr.On("ValidateAccessToken", mock.Anything, "token").Return(&ports.TokenClaims{UserID: 1, Role: "admin"}, nil)
 	m := New(mgr)
 	router := gin.New()
 	router.Use(m.JWT())
@@ -27,9 +29,24 @@ func TestJWTMiddleware(t *testing.T) {
 	mgr.AssertExpectations(t)
 }
 
+func TestJWTMiddlewareRejectsRevokedToken(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mgr := NewMockAccessTokenValidator(t)
+	mgr.On("ValidateAccessToken", mock.Anything, "token").Return(nil, errors.ErrTokenRevoked)
+	router := gin.New()
+	router.Use(New(mgr).JWT())
+	router.GET("/protected", func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+	req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+	req.Header.Set("Authorization", "Bearer token")
+	res := httptest.NewRecorder()
+	router.ServeHTTP(res, req)
+	assert.Equal(t, http.StatusUnauthorized, res.Code)
+	assert.Contains(t, res.Body.String(), "TOKEN_REVOKED")
+}
+
 func TestRequireRoles(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	m := New(NewMockTokenManager(t))
+	m := New(NewMockAccessTokenValidator(t))
 	router := gin.New()
 	router.Use(func(ctx *gin.Context) {
 		ctx.Set(ContextUserClaimsKey, &ports.TokenClaims{Role: "admin"})
@@ -44,7 +61,7 @@ func TestRequireRoles(t *testing.T) {
 
 func TestRequireRolesForbidden(t *testing.T) {
 	gin.SetMode(gin.TestMode)
-	m := New(NewMockTokenManager(t))
+	m := New(NewMockAccessTokenValidator(t))
 	router := gin.New()
 	router.Use(func(ctx *gin.Context) {
 		ctx.Set(ContextUserClaimsKey, &ports.TokenClaims{Role: "user"})
@@ -0,0 +1,59 @@
+// Code generated by mockery v2.53.5. DO NOT EDIT.
+
+package middleware
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+	ports "todoapp/services/user-service/internal/ports"
+)
+
+// MockAccessTokenValidator is an autogenerated mock type for the AccessTokenValidator type
+type MockAccessTokenValidator struct {
+	mock.Mock
+}
+
+// ValidateAccessToken provides a mock function with given fields: ctx, token
+func (_m *MockAccessTokenValidator) ValidateAccessToken(ctx context.Context, token string) (*ports.TokenClaims, error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ValidateAccessToken")
+	}
+
+	var r0 *ports.TokenClaims
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*ports.TokenClaims, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *ports.TokenClaims); ok {
+		r0 = rf(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ports.TokenClaims)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewMockAccessTokenValidator creates a new instance of MockAccessTokenValidator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockAccessTokenValidator(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockAccessTokenValidator {
+	mock := &MockAccessTokenValidator{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -166,17 +166,17 @@ func (_m *MockUserService) Login(ctx context.Context, input ports.LoginInput) (*
 	return r0, r1
 }
 
-// Logout provides a mock function with given fields: ctx, refreshToken
-func (_m *MockUserService) Logout(ctx context.Context, refreshToken string) error {
-	ret := _m.Called(ctx, refreshToken)
+// Logout provides a mock function with given fields: ctx, refreshToken, accessToken
+func (_m *MockUserService) Logout(ctx context.Context, refreshToken string, accessToken string) error {
+	ret := _m.Called(ctx, refreshToken, accessToken)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Logout")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = rf(ctx, refreshToken)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, refreshToken, accessToken)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -364,6 +364,36 @@ func (_m *MockUserService) UpdateUserStatus(ctx context.Context, userID int64, i
 	return r0, r1
 }
 
+// ValidateAccessToken provides a mock function with given fields: ctx, token
+func (_m *MockUserService) ValidateAccessToken(ctx context.Context, token string) (*ports.TokenClaims, error) {
+	ret := _m.Called(ctx, token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ValidateAccessToken")
+	}
+
+	var r0 *ports.TokenClaims
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*ports.TokenClaims, error)); ok {
+		return rf(ctx, token)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *ports.TokenClaims); ok {
+		r0 = rf(ctx, token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*ports.TokenClaims)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewMockUserService creates a new instance of MockUserService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockUserService(t interface {
@@ -10,6 +10,8 @@ var (
 	ErrUserLocked             = errors.ErrUserLocked
 	ErrRefreshTokenRevoked    = errors.ErrTokenRevoked
 	ErrRefreshTokenMismatch   = errors.ErrTokenInvalid.WithMessage("refresh token mismatch")
+	ErrAccessTokenInvalid     = errors.ErrTokenInvalid.WithMessage("invalid access token")
+	ErrAccessTokenRevoked     = errors.ErrTokenRevoked.WithMessage("access token has been revoked")
 	ErrPasswordTooWeak        = errors.ErrPasswordTooWeak
 	ErrInsufficientPrivileges = errors.ErrInsufficientPrivileges
 	ErrDataIntegrityViolation = errors.ErrConflict.WithMessage("data integrity violation")
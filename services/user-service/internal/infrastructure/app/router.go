@@ -2,11 +2,17 @@ package app
 
 import (
 	"fmt"
-	"net/http"
+	"log/slog"
 
 	"github.com/gin-gonic/gin"
 
+	"todoapp/pkg/health"
+	"todoapp/pkg/logging"
+	"todoapp/pkg/metrics"
+	"todoapp/pkg/ratelimit"
+	"todoapp/pkg/requestid"
 	"todoapp/pkg/swagger"
+	"todoapp/pkg/tracing"
 	authadapter "todoapp/services/user-service/internal/adapters/auth"
 	adminhttp "todoapp/services/user-service/internal/adapters/http/admin"
 	authhttp "todoapp/services/user-service/internal/adapters/http/auth"
@@ -19,9 +25,19 @@ import (
 
 type HTTPDeps struct {
 	UserService ports.UserService
-	TokenMgr    ports.TokenManager
 	GitHubOAuth *authadapter.GitHubOAuth
 	SwaggerSpec string
+	// AuthRateLimiter limits /auth/* requests per client IP and RateLimiter
+	// the other public endpoints per user. A nil limiter disables the limit.
+	AuthRateLimiter ratelimit.Limiter
+	RateLimiter     ratelimit.Limiter
+	// TrustedProxies are the addresses and CIDRs allowed to report the client
+	// address in X-Forwarded-For; the peer address is used when empty.
+	TrustedProxies []string
+	// Readiness runs the checks behind /health/ready; none when nil.
+	Readiness *health.Checker
+	// Logger receives the request log; slog.Default() when nil.
+	Logger *slog.Logger
 }
 
 func NewRouter(deps HTTPDeps) (*gin.Engine, error) {
@@ -30,31 +46,41 @@ func NewRouter(deps HTTPDeps) (*gin.Engine, error) {
 	}
 
 	router := gin.New()
-	router.Use(gin.Recovery(), gin.Logger())
+	if err := router.SetTrustedProxies(deps.TrustedProxies); err != nil {
+		return nil, fmt.Errorf("trusted proxies: %w", err)
+	}
+	logger := deps.Logger
+	if logger == nil {
+		logger = slog.Default()
+	}
+	router.Use(tracing.Middleware("user-service"), requestid.Middleware(), logging.Middleware(logger), metrics.Middleware(), gin.Recovery())
 
-	router.GET("/health", healthHandler)
-	router.HEAD("/health", healthHandler)
+	health.RegisterRoutes(router, "user-service", deps.Readiness)
+	router.GET(metrics.Path, gin.WrapH(metrics.Handler()))
 
 	if deps.SwaggerSpec != "" {
 		swagger.RegisterRoutes(router, deps.SwaggerSpec)
 	}
 
-	security := middlewarehttp.New(deps.TokenMgr)
+	security := middlewarehttp.New(deps.UserService)
+
+	public := router.Group("")
+	public.Use(ratelimit.Middleware(deps.AuthRateLimiter, ratelimit.ClientIP))
 
 	protected := router.Group("")
-	protected.Use(security.JWT())
+	protected.Use(security.JWT(), ratelimit.Middleware(deps.RateLimiter, middlewarehttp.RateLimitKey))
 
 	adminGroup := protected.Group("")
 	adminGroup.Use(security.RequireRoles("admin"))
 
-	authHandler := authhttp.New(deps.UserService, deps.TokenMgr)
-	internalHandler := internalhttp.New(deps.UserService, deps.TokenMgr)
+	authHandler := authhttp.New(deps.UserService)
+	internalHandler := internalhttp.New(deps.UserService)
 	githubHandler := githubhttp.New(deps.GitHubOAuth, deps.UserService)
 	profileHandler := profilehttp.New(deps.UserService)
 	adminHandler := adminhttp.New(deps.UserService)
 
-	githubHandler.RegisterRoutes(router)
-	authHandler.RegisterRoutes(router)
+	githubHandler.RegisterRoutes(public)
+	authHandler.RegisterRoutes(public)
 	internalHandler.RegisterRoutes(router)
 	profileHandler.RegisterRoutes(protected)
 	adminHandler.RegisterRoutes(adminGroup)
@@ -66,23 +92,9 @@ func validateHTTPDeps(deps HTTPDeps) error {
 	switch {
 	case deps.UserService == nil:
 		return fmt.Errorf("user service is required")
-	case deps.TokenMgr == nil:
-		return fmt.Errorf("token manager is required")
 	case deps.GitHubOAuth == nil:
 		return fmt.Errorf("github oauth provider is required")
 	default:
 		return nil
 	}
 }
-
-func healthHandler(c *gin.Context) {
-	if c.Request.Method == http.MethodHead {
-		c.Status(http.StatusOK)
-		return
-	}
-
-	c.JSON(http.StatusOK, gin.H{
-		"status":  "ok",
-		"service": "user-service",
-	})
-}
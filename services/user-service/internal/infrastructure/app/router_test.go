@@ -0,0 +1,47 @@
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"todoapp/pkg/ratelimit"
+	authadapter "todoapp/services/user-service/internal/adapters/auth"
+	"todoapp/services/user-service/internal/ports"
+)
+
+// userServiceStub is never called: the requests below fail validation or the
+// rate limit first.
+type userServiceStub struct {
+	ports.UserService
+}
+
+func TestAuthRateLimitIgnoresSpoofedForwardedFor(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router, err := NewRouter(HTTPDeps{
+		UserService:     userServiceStub{},
+		GitHubOAuth:     authadapter.NewGitHubOAuth("id", "secret", "http://localhost/callback", nil),
+		AuthRateLimiter: ratelimit.NewMemoryLimiter(ratelimit.Rule{Limit: 1, Window: time.Minute}),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	codes := make([]int, 0, 2)
+	for _, forwardedFor := range []string{"203.0.113.1", "203.0.113.2"} {
+		req := httptest.NewRequest(http.MethodPost, "/auth/login", nil)
+		req.RemoteAddr = "198.51.100.7:40000"
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+		res := httptest.NewRecorder()
+		router.ServeHTTP(res, req)
+		codes = append(codes, res.Code)
+	}
+
+	if codes[0] == http.StatusTooManyRequests || codes[1] != http.StatusTooManyRequests {
+		t.Fatalf("expected the second request to be limited despite a new X-Forwarded-For, got %v", codes)
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/jackc/pgx/v5/pgxpool"
 
+	"todoapp/pkg/tracing"
 	"todoapp/services/user-service/internal/infrastructure/config"
 )
 
@@ -24,5 +25,7 @@ func NewPostgresPool(ctx context.Context, cfg config.Config) (*pgxpool.Pool, err
 		poolCfg.MaxConnLifetime = cfg.Postgres.MaxLifetime
 	}
 
+	poolCfg.ConnConfig.Tracer = tracing.QueryTracer{}
+
 	return pgxpool.NewWithConfig(ctx, poolCfg)
 }
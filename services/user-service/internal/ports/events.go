@@ -0,0 +1,13 @@
+package ports
+
+import (
+	"context"
+
+	"todoapp/pkg/events"
+)
+
+// UserEventPublisher tells other services that a user changed, so they can
+// drop cached copies of the user.
+type UserEventPublisher interface {
+	PublishUserEvent(ctx context.Context, event events.UserEvent) error
+}
@@ -34,7 +34,9 @@ type UserService interface {
 	Register(ctx context.Context, input RegisterInput) (*AuthResult, error)
 	Login(ctx context.Context, input LoginInput) (*AuthResult, error)
 	RefreshToken(ctx context.Context, refreshToken string) (*AuthTokens, error)
-	Logout(ctx context.Context, refreshToken string) error
+	// Logout ends the session of refreshToken and revokes accessToken, if
+	// given, so it stops working before it expires.
+	Logout(ctx context.Context, refreshToken, accessToken string) error
 	GitHubLogin(ctx context.Context, input OAuthLoginInput) (*AuthResult, error)
 	GetProfile(ctx context.Context, userID int64) (*entities.User, error)
 	UpdateProfile(ctx context.Context, userID int64, input UpdateProfileInput) (*entities.User, error)
@@ -43,6 +45,7 @@ type UserService interface {
 	ListUsers(ctx context.Context, limit, offset int) ([]entities.User, error)
 	UpdateUserRole(ctx context.Context, userID int64, role string) (*entities.User, error)
 	UpdateUserStatus(ctx context.Context, userID int64, isActive bool) (*entities.User, error)
+	ValidateAccessToken(ctx context.Context, token string) (*TokenClaims, error)
 }
 
 type OAuthLoginInput struct {
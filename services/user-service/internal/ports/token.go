@@ -1,6 +1,9 @@
 package ports
 
-import "time"
+import (
+	"context"
+	"time"
+)
 
 type TokenPayload struct {
 	UserID int64
@@ -13,6 +16,7 @@ type TokenClaims struct {
 	Email     string
 	Role      string
 	TokenID   string
+	IssuedAt  time.Time
 	ExpiresAt time.Time
 }
 
@@ -22,3 +26,32 @@ type TokenManager interface {
 	ParseAccessToken(token string) (*TokenClaims, error)
 	ParseRefreshToken(token string) (*TokenClaims, error)
 }
+
+// AccessTokenValidator checks an access token's signature and expiry and
+// that it has not been revoked since it was issued.
+type AccessTokenValidator interface {
+	ValidateAccessToken(ctx context.Context, token string) (*TokenClaims, error)
+}
+
+// TokenTimePrecision is the precision of the issue times of tokens and of the
+// revocation times they are compared with. Whole seconds, the JWT default,
+// cannot tell a token issued right after a revocation from one issued right
+// before it, so a user reactivated and logging in within the second would be
+// locked out until the token expires.
+const TokenTimePrecision = time.Millisecond
+
+// TokenState is what decides whether a user's access token is still valid.
+type TokenState struct {
+	UserActive bool
+	// TokensValidAfter, when set, revokes every access token issued at or
+	// before it.
+	TokensValidAfter *time.Time
+	// TokenRevoked reports whether the token itself was revoked.
+	TokenRevoked bool
+}
+
+type TokenRevocationRepository interface {
+	RevokeUserTokens(ctx context.Context, userID int64, validAfter time.Time) error
+	RevokeToken(ctx context.Context, userID int64, tokenID string, expiresAt time.Time) error
+	GetTokenState(ctx context.Context, userID int64, tokenID string) (*TokenState, error)
+}
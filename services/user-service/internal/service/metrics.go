@@ -0,0 +1,29 @@
+package service
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"todoapp/pkg/metrics"
+)
+
+const (
+	authMethodPassword = "password"
+	authMethodGitHub   = "github"
+)
+
+var (
+	registrations = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "user_registrations_total",
+		Help: "Accounts created, by sign-up method.",
+	}, []string{"method"})
+
+	logins = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "user_logins_total",
+		Help: "Login attempts, by method and result.",
+	}, []string{"method", "result"})
+)
+
+func recordLogin(method string, err error) {
+	logins.WithLabelValues(method, metrics.Result(err)).Inc()
+}
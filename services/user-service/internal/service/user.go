@@ -3,8 +3,11 @@ package service
 import (
 	"context"
 	"errors"
+	"log/slog"
 	"time"
 
+	"todoapp/pkg/events"
+	"todoapp/pkg/logging"
 	"todoapp/services/user-service/internal/domain"
 	"todoapp/services/user-service/internal/domain/entities"
 	"todoapp/services/user-service/internal/ports"
@@ -18,9 +21,12 @@ const (
 )
 
 type UserService struct {
-	repo   ports.UserRepository
-	tokens ports.TokenManager
-	now    func() time.Time
+	repo        ports.UserRepository
+	tokens      ports.TokenManager
+	revocations ports.TokenRevocationRepository
+	events      ports.UserEventPublisher
+	now         func() time.Time
+	logger      *slog.Logger
 }
 
 func NewUserService(repo ports.UserRepository, tokens ports.TokenManager) *UserService {
@@ -28,6 +34,7 @@ func NewUserService(repo ports.UserRepository, tokens ports.TokenManager) *UserS
 		repo:   repo,
 		tokens: tokens,
 		now:    time.Now,
+		logger: logging.Discard(),
 	}
 }
 
@@ -60,6 +67,7 @@ func (s *UserService) Register(ctx context.Context, input ports.RegisterInput) (
 	}); err != nil {
 		return nil, err
 	}
+	registrations.WithLabelValues(authMethodPassword).Inc()
 
 	return &ports.AuthResult{
 		User:   *user,
@@ -67,7 +75,9 @@ func (s *UserService) Register(ctx context.Context, input ports.RegisterInput) (
 	}, nil
 }
 
-func (s *UserService) Login(ctx context.Context, input ports.LoginInput) (*ports.AuthResult, error) {
+func (s *UserService) Login(ctx context.Context, input ports.LoginInput) (_ *ports.AuthResult, err error) {
+	defer func() { recordLogin(authMethodPassword, err) }()
+
 	user, err := s.repo.GetByEmail(ctx, input.Email)
 	if err != nil {
 		return nil, err
@@ -126,17 +136,48 @@ func (s *UserService) RefreshToken(ctx context.Context, refreshToken string) (*p
 	return tokens, nil
 }
 
-func (s *UserService) Logout(ctx context.Context, refreshToken string) error {
-	if refreshToken == "" {
-		return nil
+func (s *UserService) Logout(ctx context.Context, refreshToken, accessToken string) error {
+	if refreshToken != "" {
+		if err := s.repo.DeleteSession(ctx, refreshToken); err != nil && err != domain.ErrRefreshTokenRevoked {
+			return err
+		}
 	}
-	if err := s.repo.DeleteSession(ctx, refreshToken); err != nil && err != domain.ErrRefreshTokenRevoked {
-		return err
+	return s.revokeAccessToken(ctx, accessToken)
+}
+
+// ValidateAccessToken accepts a token only while its user is active, it was
+// issued after the user's tokens were last revoked and it was not revoked
+// itself.
+func (s *UserService) ValidateAccessToken(ctx context.Context, token string) (*ports.TokenClaims, error) {
+	claims, err := s.tokens.ParseAccessToken(token)
+	if err != nil {
+		return nil, domain.ErrAccessTokenInvalid
 	}
-	return nil
+	if s.revocations == nil {
+		return claims, nil
+	}
+
+	state, err := s.revocations.GetTokenState(ctx, claims.UserID, claims.TokenID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil, domain.ErrAccessTokenRevoked
+		}
+		return nil, err
+	}
+
+	switch {
+	case !state.UserActive, state.TokenRevoked:
+		return nil, domain.ErrAccessTokenRevoked
+	case state.TokensValidAfter != nil && !claims.IssuedAt.After(*state.TokensValidAfter):
+		return nil, domain.ErrAccessTokenRevoked
+	}
+
+	return claims, nil
 }
 
-func (s *UserService) GitHubLogin(ctx context.Context, input ports.OAuthLoginInput) (*ports.AuthResult, error) {
+func (s *UserService) GitHubLogin(ctx context.Context, input ports.OAuthLoginInput) (_ *ports.AuthResult, err error) {
+	defer func() { recordLogin(authMethodGitHub, err) }()
+
 	user, err := s.findOrCreateGitHubUser(ctx, input)
 	if err != nil {
 		return nil, err
@@ -165,6 +206,8 @@ func (s *UserService) UpdateProfile(ctx context.Context, userID int64, input por
 		return nil, err
 	}
 
+	s.publishUserEvent(ctx, events.UserEventUpdated, user.ID)
+
 	return user, nil
 }
 
@@ -185,6 +228,8 @@ func (s *UserService) UpdatePreferences(ctx context.Context, userID int64, input
 		return nil, err
 	}
 
+	s.publishUserEvent(ctx, events.UserEventUpdated, userID)
+
 	return current, nil
 }
 
@@ -204,6 +249,8 @@ func (s *UserService) UpdateUserRole(ctx context.Context, userID int64, role str
 		return nil, err
 	}
 
+	s.publishUserEvent(ctx, events.UserEventRoleChanged, user.ID)
+
 	return user, nil
 }
 
@@ -215,10 +262,22 @@ func (s *UserService) UpdateUserStatus(ctx context.Context, userID int64, isActi
 
 	user.IsActive = isActive
 
-	if err := s.repo.Update(ctx, user); err != nil {
+	// Deactivation also revokes the tokens issued so far, so reactivating
+	// the user does not bring old sessions back.
+	if err := s.repo.WithTransaction(ctx, func(txCtx context.Context) error {
+		if err := s.repo.Update(txCtx, user); err != nil {
+			return err
+		}
+		if isActive || s.revocations == nil {
+			return nil
+		}
+		return s.revocations.RevokeUserTokens(txCtx, user.ID, s.now().Truncate(ports.TokenTimePrecision))
+	}); err != nil {
 		return nil, err
 	}
 
+	s.publishUserEvent(ctx, events.UserEventStatusChanged, user.ID)
+
 	return user, nil
 }
 
@@ -226,6 +285,62 @@ func (s *UserService) WithNow(now func() time.Time) {
 	s.now = now
 }
 
+// WithTokenRevocations lets access tokens be revoked before they expire.
+// Without it, ValidateAccessToken only checks signature and expiry.
+func (s *UserService) WithTokenRevocations(revocations ports.TokenRevocationRepository) {
+	s.revocations = revocations
+}
+
+func (s *UserService) WithLogger(logger *slog.Logger) {
+	if logger != nil {
+		s.logger = logger
+	}
+}
+
+// WithEventPublisher announces profile, preference, role and status changes
+// to other services.
+func (s *UserService) WithEventPublisher(publisher ports.UserEventPublisher) {
+	s.events = publisher
+}
+
+// revokeAccessToken ends token before it expires. Tokens that no longer
+// parse are rejected anyway and need no revocation.
+func (s *UserService) revokeAccessToken(ctx context.Context, token string) error {
+	if s.revocations == nil || token == "" {
+		return nil
+	}
+
+	claims, err := s.tokens.ParseAccessToken(token)
+	if err != nil || claims.TokenID == "" {
+		return nil
+	}
+
+	if err := s.revocations.RevokeToken(ctx, claims.UserID, claims.TokenID, claims.ExpiresAt); err != nil {
+		return err
+	}
+
+	s.publishUserEvent(ctx, events.UserEventTokensRevoked, claims.UserID)
+	return nil
+}
+
+// publishUserEvent runs after the change is stored, so a failure is only
+// logged: consumers' caches expire on their own.
+func (s *UserService) publishUserEvent(ctx context.Context, eventType events.UserEventType, userID int64) {
+	if s.events == nil {
+		return
+	}
+
+	event := events.UserEvent{
+		ID:         uuid.NewString(),
+		Type:       eventType,
+		UserID:     userID,
+		OccurredAt: s.now().UTC(),
+	}
+	if err := s.events.PublishUserEvent(ctx, event); err != nil {
+		s.logger.WarnContext(ctx, "failed to publish user event", "event_type", eventType, "user_id", userID, "error", err)
+	}
+}
+
 func (s *UserService) createUserWithPassword(email, name, password string) (*entities.User, error) {
 	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	if err != nil {
@@ -339,6 +454,7 @@ func (s *UserService) findOrCreateGitHubUser(ctx context.Context, input ports.OA
 		if err := s.repo.Update(ctx, user); err != nil {
 			return nil, err
 		}
+		s.publishUserEvent(ctx, events.UserEventUpdated, user.ID)
 	}
 
 	return user, nil
@@ -363,6 +479,7 @@ func (s *UserService) createGitHubUser(ctx context.Context, input ports.OAuthLog
 	if err := s.repo.Create(ctx, user); err != nil {
 		return nil, err
 	}
+	registrations.WithLabelValues(authMethodGitHub).Inc()
 
 	return user, nil
 }
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"golang.org/x/crypto/bcrypt"
+	"todoapp/pkg/events"
 	"todoapp/services/user-service/internal/domain/entities"
 
 	"todoapp/services/user-service/internal/domain"
@@ -446,3 +447,196 @@ func TestUpdateUserStatus(t *testing.T) {
 		t.Fatalf("status not updated")
 	}
 }
+
+type eventPublisherStub struct {
+	events []events.UserEvent
+	err    error
+}
+
+func (p *eventPublisherStub) PublishUserEvent(ctx context.Context, event events.UserEvent) error {
+	p.events = append(p.events, event)
+	return p.err
+}
+
+func TestUpdateUserStatusPublishesEvent(t *testing.T) {
+	repo := &repoStub{userByID: &entities.User{ID: 7, IsActive: true}}
+	publisher := &eventPublisherStub{err: errors.New("broker down")}
+	svc := NewUserService(repo, &tokenManagerStub{})
+	svc.WithEventPublisher(publisher)
+
+	if _, err := svc.UpdateUserStatus(context.Background(), 7, false); err != nil {
+		t.Fatalf("publish failure must not fail the update: %v", err)
+	}
+	if len(publisher.events) != 1 {
+		t.Fatalf("expected one event, got %d", len(publisher.events))
+	}
+	event := publisher.events[0]
+	if event.Type != events.UserEventStatusChanged || event.UserID != 7 || event.IsZero() {
+		t.Fatalf("unexpected event: %+v", event)
+	}
+}
+
+func TestUpdateProfileFailureDoesNotPublish(t *testing.T) {
+	repo := &repoStub{userByID: &entities.User{ID: 7}, updateErr: errors.New("db down")}
+	publisher := &eventPublisherStub{}
+	svc := NewUserService(repo, &tokenManagerStub{})
+	svc.WithEventPublisher(publisher)
+
+	name := "New"
+	if _, err := svc.UpdateProfile(context.Background(), 7, ports.UpdateProfileInput{Name: &name}); err == nil {
+		t.Fatalf("expected error")
+	}
+	if len(publisher.events) != 0 {
+		t.Fatalf("expected no events, got %d", len(publisher.events))
+	}
+}
+
+type revocationStub struct {
+	state          *ports.TokenState
+	stateErr       error
+	revokedTokenID string
+	revokedUserID  int64
+	validAfter     time.Time
+}
+
+func (r *revocationStub) RevokeUserTokens(ctx context.Context, userID int64, validAfter time.Time) error {
+	r.revokedUserID = userID
+	r.validAfter = validAfter
+	return nil
+}
+
+func (r *revocationStub) RevokeToken(ctx context.Context, userID int64, tokenID string, expiresAt time.Time) error {
+	r.revokedUserID = userID
+	r.revokedTokenID = tokenID
+	return nil
+}
+
+func (r *revocationStub) GetTokenState(ctx context.Context, userID int64, tokenID string) (*ports.TokenState, error) {
+	return r.state, r.stateErr
+}
+
+func TestValidateAccessToken(t *testing.T) {
+	issued := time.Date(2024, 12, 10, 9, 0, 0, 0, time.UTC)
+	before := issued.Add(-time.Minute)
+	after := issued.Add(time.Minute)
+
+	tests := []struct {
+		name     string
+		state    *ports.TokenState
+		stateErr error
+		wantErr  error
+	}{
+		{name: "valid", state: &ports.TokenState{UserActive: true}},
+		{name: "revoked before issue", state: &ports.TokenState{UserActive: true, TokensValidAfter: &before}},
+		{name: "revoked after issue", state: &ports.TokenState{UserActive: true, TokensValidAfter: &after}, wantErr: domain.ErrAccessTokenRevoked},
+		{name: "revoked at issue", state: &ports.TokenState{UserActive: true, TokensValidAfter: &issued}, wantErr: domain.ErrAccessTokenRevoked},
+		{name: "token revoked", state: &ports.TokenState{UserActive: true, TokenRevoked: true}, wantErr: domain.ErrAccessTokenRevoked},
+		{name: "user inactive", state: &ports.TokenState{}, wantErr: domain.ErrAccessTokenRevoked},
+		{name: "user deleted", stateErr: domain.ErrUserNotFound, wantErr: domain.ErrAccessTokenRevoked},
+	}
+
+	for _, tt := range tests {
+		tokens := &tokenManagerStub{parseClaims: &ports.TokenClaims{UserID: 7, TokenID: "jti", IssuedAt: issued}}
+		svc := NewUserService(&repoStub{}, tokens)
+		svc.WithTokenRevocations(&revocationStub{state: tt.state, stateErr: tt.stateErr})
+
+		claims, err := svc.ValidateAccessToken(context.Background(), "token")
+		if tt.wantErr != nil {
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("%s: expected %v, got %v", tt.name, tt.wantErr, err)
+			}
+			continue
+		}
+		if err != nil || claims.UserID != 7 {
+			t.Fatalf("%s: unexpected result: %+v, %v", tt.name, claims, err)
+		}
+	}
+}
+
+func TestValidateAccessTokenInvalid(t *testing.T) {
+	svc := NewUserService(&repoStub{}, &tokenManagerStub{parseErr: errors.New("bad signature")})
+
+	if _, err := svc.ValidateAccessToken(context.Background(), "token"); !errors.Is(err, domain.ErrAccessTokenInvalid) {
+		t.Fatalf("expected invalid token, got %v", err)
+	}
+}
+
+func TestLogoutRevokesAccessToken(t *testing.T) {
+	repo := &repoStub{session: &entities.UserSession{RefreshToken: "refresh"}}
+	tokens := &tokenManagerStub{parseClaims: &ports.TokenClaims{UserID: 7, TokenID: "jti", ExpiresAt: time.Now().Add(time.Minute)}}
+	revocations := &revocationStub{}
+	publisher := &eventPublisherStub{}
+	svc := NewUserService(repo, tokens)
+	svc.WithTokenRevocations(revocations)
+	svc.WithEventPublisher(publisher)
+
+	if err := svc.Logout(context.Background(), "refresh", "access"); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if repo.session != nil {
+		t.Fatalf("session not deleted")
+	}
+	if revocations.revokedTokenID != "jti" || revocations.revokedUserID != 7 {
+		t.Fatalf("access token not revoked: %+v", revocations)
+	}
+	if len(publisher.events) != 1 || publisher.events[0].Type != events.UserEventTokensRevoked {
+		t.Fatalf("expected tokens revoked event, got %+v", publisher.events)
+	}
+}
+
+func TestUpdateUserStatusRevokesTokensOnDeactivation(t *testing.T) {
+	now := time.Date(2024, 12, 10, 9, 0, 0, 0, time.UTC)
+	revocations := &revocationStub{}
+	svc := NewUserService(&repoStub{userByID: &entities.User{ID: 7, IsActive: true}}, &tokenManagerStub{})
+	svc.WithNow(func() time.Time { return now })
+	svc.WithTokenRevocations(revocations)
+
+	if _, err := svc.UpdateUserStatus(context.Background(), 7, false); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if revocations.revokedUserID != 7 || !revocations.validAfter.Equal(now) {
+		t.Fatalf("tokens not revoked: %+v", revocations)
+	}
+
+	revocations = &revocationStub{}
+	svc.WithTokenRevocations(revocations)
+	if _, err := svc.UpdateUserStatus(context.Background(), 7, true); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if revocations.revokedUserID != 0 {
+		t.Fatalf("activation must not revoke tokens")
+	}
+}
+
+func TestTokenIssuedAfterReactivationInSameSecond(t *testing.T) {
+	deactivatedAt := time.Date(2024, 12, 10, 9, 0, 0, 400_123_456, time.UTC)
+	revocations := &revocationStub{}
+	tokens := &tokenManagerStub{}
+	svc := NewUserService(&repoStub{userByID: &entities.User{ID: 7, IsActive: true}}, tokens)
+	svc.WithNow(func() time.Time { return deactivatedAt })
+	svc.WithTokenRevocations(revocations)
+
+	if _, err := svc.UpdateUserStatus(context.Background(), 7, false); err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	revocations.state = &ports.TokenState{UserActive: true, TokensValidAfter: &revocations.validAfter}
+
+	// Issue times as the tokens carry them.
+	tests := []struct {
+		name     string
+		issuedAt time.Time
+		wantErr  error
+	}{
+		{name: "issued before deactivation", issuedAt: deactivatedAt.Add(-100 * time.Millisecond).Truncate(ports.TokenTimePrecision), wantErr: domain.ErrAccessTokenRevoked},
+		{name: "issued at deactivation", issuedAt: deactivatedAt.Truncate(ports.TokenTimePrecision), wantErr: domain.ErrAccessTokenRevoked},
+		{name: "issued after reactivation", issuedAt: deactivatedAt.Add(200 * time.Millisecond).Truncate(ports.TokenTimePrecision)},
+	}
+
+	for _, tt := range tests {
+		tokens.parseClaims = &ports.TokenClaims{UserID: 7, TokenID: "jti", IssuedAt: tt.issuedAt}
+		_, err := svc.ValidateAccessToken(context.Background(), "token")
+		if !errors.Is(err, tt.wantErr) {
+			t.Fatalf("%s: expected %v, got %v", tt.name, tt.wantErr, err)
+		}
+	}
+}